@@ -28,9 +28,16 @@ type DatadogAgentInternalStatus struct {
 	// The actual state of the Cluster Checks Runner as a deployment.
 	// +optional
 	ClusterChecksRunner *v2alpha1.DeploymentStatus `json:"clusterChecksRunner,omitempty"`
+	// The actual state of the OTel Agent Gateway as a deployment.
+	// +optional
+	OtelAgentGateway *v2alpha1.DeploymentStatus `json:"otelAgentGateway,omitempty"`
 	// RemoteConfigConfiguration stores the configuration received from RemoteConfig.
 	// +optional
 	RemoteConfigConfiguration *v2alpha1.RemoteConfigConfiguration `json:"remoteConfigConfiguration,omitempty"`
+	// Features contains the status of the enabled and configured features, indexed by feature ID.
+	// The features that are not listed are disabled.
+	// +optional
+	Features map[string]v2alpha1.FeatureStatus `json:"features,omitempty"`
 }
 
 // DatadogAgentInternal is the Schema for the datadogagentinternals API
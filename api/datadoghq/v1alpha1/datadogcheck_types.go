@@ -0,0 +1,96 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DatadogCheckConditionTypeValid means the DatadogCheck has a valid spec
+	DatadogCheckConditionTypeValid = "Valid"
+)
+
+// DatadogCheckSpec defines the desired state of DatadogCheck
+// +k8s:openapi-gen=true
+type DatadogCheckSpec struct {
+	// Name is the name of the integration check, for example: `redisdb`, `http_check`.
+	// +kubebuilder:validation:MaxLength=48
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([a-z0-9_]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// InitConfig is the `init_config` section of the check configuration.
+	// +optional
+	InitConfig *apiextensionsv1.JSON `json:"initConfig,omitempty"`
+
+	// Instances are the `instances` of the check configuration.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	Instances []apiextensionsv1.JSON `json:"instances"`
+
+	// ADIdentifiers are the Autodiscovery identifiers of the containers the check is scheduled on, for example: `redis`.
+	// The check is run once by each node Agent when no identifiers are set.
+	// +optional
+	// +listType=set
+	ADIdentifiers []string `json:"adIdentifiers,omitempty"`
+
+	// ClusterCheck schedules the check once in the cluster through the Cluster Agent instead of on every node Agent.
+	// Requires `features.clusterChecks.enabled`.
+	// Default: false
+	// +optional
+	ClusterCheck *bool `json:"clusterCheck,omitempty"`
+
+	// AgentSelector selects the DatadogAgents that configure the check, using their labels. It must not be empty.
+	// The DatadogAgents of other namespaces must also select the namespace of the check with `features.datadogChecks.namespaceSelector`.
+	// The check is configured in the DatadogAgents of its own namespace when no selector is set.
+	// +optional
+	AgentSelector *metav1.LabelSelector `json:"agentSelector,omitempty"`
+}
+
+// DatadogCheckStatus defines the observed state of DatadogCheck
+// +k8s:openapi-gen=true
+type DatadogCheckStatus struct {
+	// Conditions represents the latest available observations of the state of a DatadogCheck.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Valid shows if the DatadogCheck has a valid spec. Only valid checks are configured in the Agents.
+	// +optional
+	Valid metav1.ConditionStatus `json:"valid,omitempty"`
+}
+
+// DatadogCheck is the Schema for the datadogchecks API
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=datadogchecks,scope=Namespaced,shortName=ddcheck
+// +kubebuilder:printcolumn:name="check",type="string",JSONPath=".spec.name"
+// +kubebuilder:printcolumn:name="cluster check",type="boolean",JSONPath=".spec.clusterCheck"
+// +kubebuilder:printcolumn:name="valid",type="string",JSONPath=".status.valid"
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:openapi-gen=true
+// +genclient
+type DatadogCheck struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DatadogCheckSpec   `json:"spec,omitempty"`
+	Status DatadogCheckStatus `json:"status,omitempty"`
+}
+
+// DatadogCheckList contains a list of DatadogCheck
+// +kubebuilder:object:root=true
+type DatadogCheckList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DatadogCheck `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DatadogCheck{}, &DatadogCheckList{})
+}
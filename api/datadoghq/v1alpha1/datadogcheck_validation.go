@@ -0,0 +1,67 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilserrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+const maxCheckNameLength = 48
+
+var checkNameRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9_]*[a-z0-9])?$`)
+
+// IsValidDatadogCheck is used to check if a DatadogCheckSpec is valid
+func IsValidDatadogCheck(spec *DatadogCheckSpec) error {
+	var errs []error
+	if len(spec.Name) > maxCheckNameLength || !checkNameRegexp.MatchString(spec.Name) {
+		errs = append(errs, fmt.Errorf("spec.name must be at most %d lowercase letters, digits and underscores, starting and ending with a letter or a digit", maxCheckNameLength))
+	}
+
+	if spec.InitConfig != nil && !isObjectOrNull(spec.InitConfig) {
+		errs = append(errs, fmt.Errorf("spec.initConfig must be an object"))
+	}
+
+	if len(spec.Instances) == 0 {
+		errs = append(errs, fmt.Errorf("spec.instances must have at least 1 instance"))
+	}
+	for i := range spec.Instances {
+		if !isObjectOrNull(&spec.Instances[i]) {
+			errs = append(errs, fmt.Errorf("spec.instances[%d] must be an object", i))
+		}
+	}
+
+	for _, identifier := range spec.ADIdentifiers {
+		if identifier == "" {
+			errs = append(errs, fmt.Errorf("spec.adIdentifiers must not contain empty identifiers"))
+			break
+		}
+	}
+
+	if spec.AgentSelector != nil {
+		if len(spec.AgentSelector.MatchLabels) == 0 && len(spec.AgentSelector.MatchExpressions) == 0 {
+			errs = append(errs, fmt.Errorf("spec.agentSelector must not be empty"))
+		} else if _, err := metav1.LabelSelectorAsSelector(spec.AgentSelector); err != nil {
+			errs = append(errs, fmt.Errorf("spec.agentSelector is invalid: %w", err))
+		}
+	}
+
+	return utilserrors.NewAggregate(errs)
+}
+
+// isObjectOrNull returns whether the JSON value is an object or null
+func isObjectOrNull(value *apiextensionsv1.JSON) bool {
+	if len(value.Raw) == 0 {
+		return true
+	}
+	var object map[string]interface{}
+	return json.Unmarshal(value.Raw, &object) == nil
+}
@@ -0,0 +1,113 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_IsValidDatadogCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *DatadogCheckSpec
+		wantErr string
+	}{
+		{
+			name: "valid check",
+			spec: &DatadogCheckSpec{
+				Name:          "redisdb",
+				InitConfig:    &apiextensionsv1.JSON{Raw: []byte(`{}`)},
+				Instances:     []apiextensionsv1.JSON{{Raw: []byte(`{"host": "%%host%%", "port": 6379}`)}},
+				ADIdentifiers: []string{"redis"},
+				AgentSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "web"}},
+			},
+			wantErr: "",
+		},
+		{
+			name: "invalid check name",
+			spec: &DatadogCheckSpec{
+				Name:      "Redis DB",
+				Instances: []apiextensionsv1.JSON{{Raw: []byte(`{}`)}},
+			},
+			wantErr: "spec.name must be at most 48 lowercase letters, digits and underscores, starting and ending with a letter or a digit",
+		},
+		{
+			name: "check name ending with an underscore",
+			spec: &DatadogCheckSpec{
+				Name:      "redisdb_",
+				Instances: []apiextensionsv1.JSON{{Raw: []byte(`{}`)}},
+			},
+			wantErr: "spec.name must be at most 48 lowercase letters, digits and underscores, starting and ending with a letter or a digit",
+		},
+		{
+			name: "no instances",
+			spec: &DatadogCheckSpec{
+				Name: "http_check",
+			},
+			wantErr: "spec.instances must have at least 1 instance",
+		},
+		{
+			name: "instance is not an object",
+			spec: &DatadogCheckSpec{
+				Name:      "http_check",
+				Instances: []apiextensionsv1.JSON{{Raw: []byte(`{"url": "http://example.com"}`)}, {Raw: []byte(`"http://example.com"`)}},
+			},
+			wantErr: "spec.instances[1] must be an object",
+		},
+		{
+			name: "init config is not an object",
+			spec: &DatadogCheckSpec{
+				Name:       "http_check",
+				InitConfig: &apiextensionsv1.JSON{Raw: []byte(`[]`)},
+				Instances:  []apiextensionsv1.JSON{{Raw: []byte(`{"url": "http://example.com"}`)}},
+			},
+			wantErr: "spec.initConfig must be an object",
+		},
+		{
+			name: "empty autodiscovery identifier",
+			spec: &DatadogCheckSpec{
+				Name:          "redisdb",
+				Instances:     []apiextensionsv1.JSON{{Raw: []byte(`{}`)}},
+				ADIdentifiers: []string{"redis", ""},
+			},
+			wantErr: "spec.adIdentifiers must not contain empty identifiers",
+		},
+		{
+			name: "invalid agent selector",
+			spec: &DatadogCheckSpec{
+				Name:      "redisdb",
+				Instances: []apiextensionsv1.JSON{{Raw: []byte(`{}`)}},
+				AgentSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Equals", Values: []string{"web"}}},
+				},
+			},
+			wantErr: `spec.agentSelector is invalid: "Equals" is not a valid label selector operator`,
+		},
+		{
+			name: "empty agent selector",
+			spec: &DatadogCheckSpec{
+				Name:          "redisdb",
+				Instances:     []apiextensionsv1.JSON{{Raw: []byte(`{}`)}},
+				AgentSelector: &metav1.LabelSelector{},
+			},
+			wantErr: "spec.agentSelector must not be empty",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := IsValidDatadogCheck(test.spec)
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -207,11 +208,23 @@ func (in *DatadogAgentInternalStatus) DeepCopyInto(out *DatadogAgentInternalStat
 		*out = new(v2alpha1.DeploymentStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OtelAgentGateway != nil {
+		in, out := &in.OtelAgentGateway, &out.OtelAgentGateway
+		*out = new(v2alpha1.DeploymentStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RemoteConfigConfiguration != nil {
 		in, out := &in.RemoteConfigConfiguration, &out.RemoteConfigConfiguration
 		*out = new(v2alpha1.RemoteConfigConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make(map[string]v2alpha1.FeatureStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogAgentInternalStatus.
@@ -339,6 +352,129 @@ func (in *DatadogAgentProfileStatus) DeepCopy() *DatadogAgentProfileStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogCheck) DeepCopyInto(out *DatadogCheck) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogCheck.
+func (in *DatadogCheck) DeepCopy() *DatadogCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatadogCheck) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogCheckList) DeepCopyInto(out *DatadogCheckList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DatadogCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogCheckList.
+func (in *DatadogCheckList) DeepCopy() *DatadogCheckList {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogCheckList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatadogCheckList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogCheckSpec) DeepCopyInto(out *DatadogCheckSpec) {
+	*out = *in
+	if in.InitConfig != nil {
+		in, out := &in.InitConfig, &out.InitConfig
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]apiextensionsv1.JSON, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ADIdentifiers != nil {
+		in, out := &in.ADIdentifiers, &out.ADIdentifiers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterCheck != nil {
+		in, out := &in.ClusterCheck, &out.ClusterCheck
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AgentSelector != nil {
+		in, out := &in.AgentSelector, &out.AgentSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogCheckSpec.
+func (in *DatadogCheckSpec) DeepCopy() *DatadogCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogCheckStatus) DeepCopyInto(out *DatadogCheckStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogCheckStatus.
+func (in *DatadogCheckStatus) DeepCopy() *DatadogCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatadogDashboard) DeepCopyInto(out *DatadogDashboard) {
 	*out = *in
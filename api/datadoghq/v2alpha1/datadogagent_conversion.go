@@ -0,0 +1,10 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v2alpha1
+
+// Hub marks v2alpha1 as the conversion hub of the DatadogAgent API: the other versions are converted
+// from and to v2alpha1, which is the version the operator reconciles.
+func (*DatadogAgent) Hub() {}
@@ -8,6 +8,7 @@ package v2alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/DataDog/datadog-operator/api/datadoghq/common"
 )
@@ -22,6 +23,8 @@ const (
 	ClusterAgentComponentName ComponentName = "clusterAgent"
 	// ClusterChecksRunnerComponentName is the name of the Cluster Check Runner
 	ClusterChecksRunnerComponentName ComponentName = "clusterChecksRunner"
+	// OtelAgentGatewayComponentName is the name of the OTel Agent Gateway
+	OtelAgentGatewayComponentName ComponentName = "otelAgentGateway"
 )
 
 // DatadogAgentSpec defines the desired state of DatadogAgent
@@ -74,6 +77,8 @@ type DatadogFeatures struct {
 	USM *USMFeatureConfig `json:"usm,omitempty"`
 	// Dogstatsd configuration.
 	Dogstatsd *DogstatsdFeatureConfig `json:"dogstatsd,omitempty"`
+	// DatadogChecks configuration.
+	DatadogChecks *DatadogChecksFeatureConfig `json:"datadogChecks,omitempty"`
 	// OTLP ingest configuration
 	OTLP *OTLPFeatureConfig `json:"otlp,omitempty"`
 	// Remote Configuration configuration.
@@ -105,6 +110,8 @@ type DatadogFeatures struct {
 	PrometheusScrape *PrometheusScrapeFeatureConfig `json:"prometheusScrape,omitempty"`
 	// HelmCheck configuration.
 	HelmCheck *HelmCheckFeatureConfig `json:"helmCheck,omitempty"`
+	// OtelAgentGateway configuration.
+	OtelAgentGateway *OtelAgentGatewayFeatureConfig `json:"otelAgentGateway,omitempty"`
 }
 
 // Configuration structs for each feature in DatadogFeatures. All parameters are optional and have default values when necessary.
@@ -339,8 +346,55 @@ type LogCollectionFeatureConfig struct {
 	// Default: 100
 	// +optional
 	OpenFilesLimit *int32 `json:"openFilesLimit,omitempty"`
+
+	// ProcessingRules are applied by the Agent to all the collected logs, in order.
+	// See also: https://docs.datadoghq.com/agent/logs/advanced_log_collection/#global-processing-rules
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ProcessingRules []LogProcessingRule `json:"processingRules,omitempty"`
+
+	// ContainerInclude selects the containers whose logs are collected, even if they match `containerExclude`.
+	// +optional
+	ContainerInclude *ContainerFilter `json:"containerInclude,omitempty"`
+
+	// ContainerExclude selects the containers whose logs are not collected.
+	// +optional
+	ContainerExclude *ContainerFilter `json:"containerExclude,omitempty"`
 }
 
+// LogProcessingRule is a rule applied by the Agent to the collected logs.
+// +kubebuilder:validation:XValidation:rule="self.type != 'mask_sequences' || has(self.replacePlaceholder)",message="replacePlaceholder is required for mask_sequences rules"
+type LogProcessingRule struct {
+	// Name is the name of the rule.
+	Name string `json:"name"`
+
+	// Type is the type of the rule.
+	Type LogProcessingRuleType `json:"type"`
+
+	// Pattern is the regular expression matched by the rule.
+	// +kubebuilder:validation:MinLength=1
+	Pattern string `json:"pattern"`
+
+	// ReplacePlaceholder replaces the sequences matched by `mask_sequences` rules.
+	// +optional
+	ReplacePlaceholder *string `json:"replacePlaceholder,omitempty"`
+}
+
+// LogProcessingRuleType is the type of a log processing rule.
+// `multi_line` rules only apply to a single log source, they are not supported in the global processing rules.
+// +kubebuilder:validation:Enum=exclude_at_match;include_at_match;mask_sequences
+type LogProcessingRuleType string
+
+const (
+	// LogProcessingRuleExcludeAtMatch drops the logs matching the pattern.
+	LogProcessingRuleExcludeAtMatch LogProcessingRuleType = "exclude_at_match"
+	// LogProcessingRuleIncludeAtMatch only keeps the logs matching the pattern.
+	LogProcessingRuleIncludeAtMatch LogProcessingRuleType = "include_at_match"
+	// LogProcessingRuleMaskSequences replaces the sequences matching the pattern with the placeholder.
+	LogProcessingRuleMaskSequences LogProcessingRuleType = "mask_sequences"
+)
+
 // LiveProcessCollectionFeatureConfig contains Process Collection configuration.
 // Process Collection is run in the Process Agent.
 type LiveProcessCollectionFeatureConfig struct {
@@ -640,6 +694,15 @@ type DogstatsdFeatureConfig struct {
 	NonLocalTraffic *bool `json:"nonLocalTraffic,omitempty"`
 }
 
+// DatadogChecksFeatureConfig contains the configuration of the DatadogChecks configured in the Agents.
+type DatadogChecksFeatureConfig struct {
+	// NamespaceSelector selects the namespaces, other than the namespace of the DatadogAgent,
+	// whose DatadogChecks can be configured in the DatadogAgent when their agent selector matches it.
+	// The DatadogChecks of other namespaces are ignored when no selector is set.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
 // OTLPFeatureConfig contains configuration for OTLP ingest.
 // +k8s:openapi-gen=true
 type OTLPFeatureConfig struct {
@@ -790,8 +853,165 @@ type KubeStateMetricsCoreFeatureConfig struct {
 	// This must point to a ConfigMap containing a valid cluster check configuration.
 	// +optional
 	Conf *CustomConfig `json:"conf,omitempty"`
+
+	// Collectors configures the resources collected by the check.
+	// Ignored when `conf` is set.
+	// +optional
+	Collectors *KubeStateMetricsCoreCollectorsConfig `json:"collectors,omitempty"`
+
+	// LabelsAsTags maps, for each resource, the resource labels to the tags set on its metrics.
+	// For example: `{"pod": {"app": "app"}}`.
+	// Ignored when `conf` is set.
+	// +optional
+	LabelsAsTags map[string]map[string]string `json:"labelsAsTags,omitempty"`
+
+	// AnnotationsAsTags maps, for each resource, the resource annotations to the tags set on its metrics.
+	// Ignored when `conf` is set.
+	// +optional
+	AnnotationsAsTags map[string]map[string]string `json:"annotationsAsTags,omitempty"`
+
+	// Namespaces restricts the collection to the resources of these namespaces.
+	// Ignored when `conf` is set.
+	// Default: all namespaces
+	// +optional
+	// +listType=set
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// CustomResources configures metrics generated from the state of custom resources.
+	// Ignored when `conf` is set.
+	// +optional
+	// +listType=atomic
+	CustomResources []KubeStateMetricsCoreCustomResource `json:"customResources,omitempty"`
+
+	// Sharding splits the check into several cluster check instances.
+	// Ignored when `conf` is set.
+	// +optional
+	Sharding *KubeStateMetricsCoreShardingConfig `json:"sharding,omitempty"`
+}
+
+// KubeStateMetricsCoreCollectorsConfig contains the resources collected by the Kube State Metrics Core check.
+// +k8s:openapi-gen=true
+type KubeStateMetricsCoreCollectorsConfig struct {
+	// Allow replaces the default list of collectors, for example: `pods`, `deployments`.
+	// Default: all the collectors supported by the cluster
+	// +optional
+	// +listType=set
+	Allow []string `json:"allow,omitempty"`
+
+	// Deny removes collectors from the collected ones.
+	// +optional
+	// +listType=set
+	Deny []string `json:"deny,omitempty"`
+}
+
+// KubeStateMetricsCoreCustomResource configures the metrics generated from the state of a custom resource.
+// See also: https://github.com/kubernetes/kube-state-metrics/blob/main/docs/metrics/extend/customresourcestate-metrics.md
+// +k8s:openapi-gen=true
+type KubeStateMetricsCoreCustomResource struct {
+	// Group is the API group of the custom resource.
+	Group string `json:"group"`
+
+	// Version is the API version of the custom resource.
+	Version string `json:"version"`
+
+	// Kind is the kind of the custom resource.
+	Kind string `json:"kind"`
+
+	// Resource is the plural name of the custom resource, used to grant the check access to it.
+	// Default: the lowercase kind followed by `s`
+	// +optional
+	Resource *string `json:"resource,omitempty"`
+
+	// MetricNamePrefix is the prefix of the metric names.
+	// Default: `kube_customresource`
+	// +optional
+	MetricNamePrefix *string `json:"metricNamePrefix,omitempty"`
+
+	// LabelsFromPath adds to all the metrics labels read from the custom resource, indexed by label name.
+	// +optional
+	LabelsFromPath map[string][]string `json:"labelsFromPath,omitempty"`
+
+	// Metrics are the metrics generated from the custom resource.
+	// +listType=map
+	// +listMapKey=name
+	Metrics []KubeStateMetricsCoreCustomResourceMetric `json:"metrics"`
 }
 
+// KubeStateMetricsCoreCustomResourceMetric is a metric generated from the state of a custom resource.
+// +k8s:openapi-gen=true
+type KubeStateMetricsCoreCustomResourceMetric struct {
+	// Name is the name of the metric, appended to the metric name prefix.
+	Name string `json:"name"`
+
+	// Help is the description of the metric.
+	// +optional
+	Help string `json:"help,omitempty"`
+
+	// Type is the type of the metric.
+	// Default: Gauge
+	// +optional
+	Type *KubeStateMetricsCoreMetricType `json:"type,omitempty"`
+
+	// Path is the path of the field of the custom resource holding the metric value.
+	// For example: `["status", "replicas"]`.
+	// +listType=atomic
+	Path []string `json:"path"`
+
+	// ValueFrom is the path of the value, relative to `path`, when `path` points to an object or a list.
+	// +optional
+	// +listType=atomic
+	ValueFrom []string `json:"valueFrom,omitempty"`
+
+	// LabelsFromPath adds to the metric labels read relative to `path`, indexed by label name.
+	// +optional
+	LabelsFromPath map[string][]string `json:"labelsFromPath,omitempty"`
+}
+
+// KubeStateMetricsCoreMetricType is the type of a metric generated from the state of a custom resource.
+// +kubebuilder:validation:Enum=Gauge;Info
+type KubeStateMetricsCoreMetricType string
+
+const (
+	// KubeStateMetricsCoreMetricTypeGauge is a metric with the value of a numeric or boolean field.
+	KubeStateMetricsCoreMetricTypeGauge KubeStateMetricsCoreMetricType = "Gauge"
+	// KubeStateMetricsCoreMetricTypeInfo is a metric with a value of 1, only carrying labels.
+	KubeStateMetricsCoreMetricTypeInfo KubeStateMetricsCoreMetricType = "Info"
+)
+
+// KubeStateMetricsCoreShardingConfig splits the Kube State Metrics Core check into several cluster check instances,
+// so the instances can be dispatched to different Cluster Checks Runners.
+// The check is only split when it runs in the Cluster Checks Runners.
+// +k8s:openapi-gen=true
+type KubeStateMetricsCoreShardingConfig struct {
+	// Enabled enables the sharding of the check.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Shards is the number of instances the check is split into.
+	// Default: the number of replicas of the Cluster Checks Runners
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Shards *int32 `json:"shards,omitempty"`
+
+	// Strategy selects what is split between the instances: the collectors, or the namespaces listed in `namespaces`.
+	// When the namespaces are split, the cluster-scoped resources are collected by the first instance.
+	// Default: Collectors
+	// +optional
+	Strategy *KubeStateMetricsCoreShardingStrategy `json:"strategy,omitempty"`
+}
+
+// KubeStateMetricsCoreShardingStrategy selects what is split between the instances of the Kube State Metrics Core check.
+// +kubebuilder:validation:Enum=Collectors;Namespaces
+type KubeStateMetricsCoreShardingStrategy string
+
+const (
+	// KubeStateMetricsCoreShardingByCollectors splits the collectors between the instances.
+	KubeStateMetricsCoreShardingByCollectors KubeStateMetricsCoreShardingStrategy = "Collectors"
+	// KubeStateMetricsCoreShardingByNamespaces splits the namespaces between the instances.
+	KubeStateMetricsCoreShardingByNamespaces KubeStateMetricsCoreShardingStrategy = "Namespaces"
+)
+
 // OtelCollectorFeatureConfig contains the configuration for the otel-agent.
 // +k8s:openapi-gen=true
 type OtelCollectorFeatureConfig struct {
@@ -818,6 +1038,208 @@ type OtelCollectorFeatureConfig struct {
 	// OTelCollector Config Relevant to the Core agent
 	// +optional
 	CoreConfig *CoreConfig `json:"coreConfig,omitempty"`
+
+	// Receivers configures the receivers of the OTel Collector.
+	// Ignored when `conf` is set.
+	// +optional
+	Receivers *OtelCollectorReceiversConfig `json:"receivers,omitempty"`
+
+	// Processors configures the processors of the OTel Collector.
+	// Ignored when `conf` is set.
+	// +optional
+	Processors *OtelCollectorProcessorsConfig `json:"processors,omitempty"`
+
+	// Exporters configures the exporters of the OTel Collector.
+	// Ignored when `conf` is set.
+	// +optional
+	Exporters *OtelCollectorExportersConfig `json:"exporters,omitempty"`
+
+	// Pipelines replaces the default pipelines of the OTel Collector.
+	// Ignored when `conf` is set.
+	// Default: `traces`, `metrics` and `logs` pipelines using all the enabled components
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Pipelines []OtelCollectorPipeline `json:"pipelines,omitempty"`
+}
+
+// OtelCollectorReceiversConfig contains the receivers of the OTel Collector.
+// +k8s:openapi-gen=true
+type OtelCollectorReceiversConfig struct {
+	// OTLP configures the `otlp` receiver.
+	// +optional
+	OTLP *OtelCollectorOTLPReceiverConfig `json:"otlp,omitempty"`
+
+	// Prometheus configures the `prometheus` receiver, scraping the internal metrics of the OTel Collector.
+	// +optional
+	Prometheus *OtelCollectorPrometheusReceiverConfig `json:"prometheus,omitempty"`
+}
+
+// OtelCollectorOTLPReceiverConfig contains the configuration of the `otlp` receiver.
+// +k8s:openapi-gen=true
+type OtelCollectorOTLPReceiverConfig struct {
+	// GRPC configures the gRPC protocol.
+	// +optional
+	GRPC *OtelCollectorOTLPProtocolConfig `json:"grpc,omitempty"`
+
+	// HTTP configures the HTTP protocol.
+	// +optional
+	HTTP *OtelCollectorOTLPProtocolConfig `json:"http,omitempty"`
+}
+
+// OtelCollectorOTLPProtocolConfig contains the configuration of a protocol of the `otlp` receiver.
+// +k8s:openapi-gen=true
+type OtelCollectorOTLPProtocolConfig struct {
+	// Enabled enables the protocol.
+	// Default: true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Endpoint is the `host:port` address the protocol listens on.
+	// Default: `0.0.0.0` with the port of `otel-grpc` or `otel-http` in `ports`
+	// +optional
+	Endpoint *string `json:"endpoint,omitempty"`
+}
+
+// OtelCollectorPrometheusReceiverConfig contains the configuration of the `prometheus` receiver.
+// +k8s:openapi-gen=true
+type OtelCollectorPrometheusReceiverConfig struct {
+	// Enabled enables the receiver.
+	// Default: true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// ScrapeInterval is the interval between two scrapes.
+	// Default: 60s
+	// +optional
+	ScrapeInterval *metav1.Duration `json:"scrapeInterval,omitempty"`
+}
+
+// OtelCollectorProcessorsConfig contains the processors of the OTel Collector.
+// +k8s:openapi-gen=true
+type OtelCollectorProcessorsConfig struct {
+	// Batch configures the `batch` processor.
+	// +optional
+	Batch *OtelCollectorBatchProcessorConfig `json:"batch,omitempty"`
+
+	// K8sAttributes configures the `k8sattributes` processor, adding Kubernetes metadata to the telemetry.
+	// +optional
+	K8sAttributes *OtelCollectorK8sAttributesProcessorConfig `json:"k8sAttributes,omitempty"`
+
+	// InfraAttributes configures the `infraattributes` processor, adding the Datadog infrastructure tags to the telemetry.
+	// +optional
+	InfraAttributes *OtelCollectorInfraAttributesProcessorConfig `json:"infraAttributes,omitempty"`
+}
+
+// OtelCollectorBatchProcessorConfig contains the configuration of the `batch` processor.
+// +k8s:openapi-gen=true
+type OtelCollectorBatchProcessorConfig struct {
+	// Enabled enables the processor.
+	// Default: true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Timeout is the time after which a batch is sent regardless of its size.
+	// Default: 10s
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// SendBatchSize is the number of items after which a batch is sent regardless of the timeout.
+	// Default: 8192
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	SendBatchSize *int32 `json:"sendBatchSize,omitempty"`
+
+	// SendBatchMaxSize is the maximum number of items of a batch. Larger batches are split.
+	// Default: no limit
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	SendBatchMaxSize *int32 `json:"sendBatchMaxSize,omitempty"`
+}
+
+// OtelCollectorK8sAttributesProcessorConfig contains the configuration of the `k8sattributes` processor.
+// The processor only adds the metadata of the pods running on the same node as the Agent.
+// +k8s:openapi-gen=true
+type OtelCollectorK8sAttributesProcessorConfig struct {
+	// Enabled enables the processor.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Metadata is the list of metadata attributes added to the telemetry, for example: `k8s.pod.name`, `k8s.deployment.name`.
+	// Default: the default attributes of the processor
+	// +optional
+	// +listType=set
+	Metadata []string `json:"metadata,omitempty"`
+
+	// PodLabels maps pod labels to the attributes added to the telemetry.
+	// +optional
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// PodAnnotations maps pod annotations to the attributes added to the telemetry.
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+}
+
+// OtelCollectorInfraAttributesProcessorConfig contains the configuration of the `infraattributes` processor.
+// +k8s:openapi-gen=true
+type OtelCollectorInfraAttributesProcessorConfig struct {
+	// Enabled enables the processor.
+	// Default: true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Cardinality is the cardinality of the tags: 0 for low, 1 for orchestrator and 2 for high.
+	// Default: 2
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=2
+	Cardinality *int32 `json:"cardinality,omitempty"`
+}
+
+// OtelCollectorExportersConfig contains the exporters of the OTel Collector.
+// The `datadog` exporter is always configured, unless the OTel Agent Gateway is enabled:
+// the `loadbalancing` exporter then forwards the telemetry to the gateway, which computes the trace stats.
+// +k8s:openapi-gen=true
+type OtelCollectorExportersConfig struct {
+	// Debug configures the `debug` exporter, writing the telemetry to the logs of the OTel Agent.
+	// +optional
+	Debug *OtelCollectorDebugExporterConfig `json:"debug,omitempty"`
+}
+
+// OtelCollectorDebugExporterConfig contains the configuration of the `debug` exporter.
+// +k8s:openapi-gen=true
+type OtelCollectorDebugExporterConfig struct {
+	// Enabled enables the exporter.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Verbosity is the verbosity of the exporter.
+	// Default: basic
+	// +optional
+	// +kubebuilder:validation:Enum=basic;normal;detailed
+	Verbosity *string `json:"verbosity,omitempty"`
+}
+
+// OtelCollectorPipeline is a pipeline of the OTel Collector.
+// +k8s:openapi-gen=true
+type OtelCollectorPipeline struct {
+	// Name is the name of the pipeline: its type `traces`, `metrics` or `logs`, optionally followed by `/<name>`.
+	Name string `json:"name"`
+
+	// Receivers are the receivers of the pipeline, for example: `otlp`, `prometheus`, `datadog/connector`.
+	// +listType=atomic
+	Receivers []string `json:"receivers"`
+
+	// Processors are the processors of the pipeline, in order, for example: `k8sattributes`, `infraattributes`, `batch`.
+	// +optional
+	// +listType=atomic
+	Processors []string `json:"processors,omitempty"`
+
+	// Exporters are the exporters of the pipeline, for example: `datadog`, `debug`, `datadog/connector`.
+	// +listType=atomic
+	Exporters []string `json:"exporters"`
 }
 
 // CoreConfig exposes the otel collector configs relevant to the core agent.
@@ -892,6 +1314,20 @@ type AdmissionControllerFeatureConfig struct {
 	// CWSInstrumentation holds the CWS Instrumentation endpoint configuration
 	// +optional
 	CWSInstrumentation *CWSInstrumentationConfig `json:"cwsInstrumentation,omitempty"`
+
+	// CertManager configures cert-manager to issue the certificate of the Admission Controller webhooks.
+	// When enabled, the Operator manages the webhook configurations instead of the Cluster Agent,
+	// which then does not need the permissions to create and update them.
+	// Cannot be enabled with operatorIssuedCertificate.
+	// +optional
+	CertManager *AdmissionControllerCertManagerConfig `json:"certManager,omitempty"`
+
+	// OperatorIssuedCertificate configures the Operator to issue the certificate of the Admission Controller webhooks,
+	// without cert-manager. When enabled, the Operator manages the webhook configurations instead of the Cluster Agent,
+	// and renews the certificate before it expires.
+	// Cannot be enabled with certManager.
+	// +optional
+	OperatorIssuedCertificate *AdmissionControllerOperatorIssuedCertificateConfig `json:"operatorIssuedCertificate,omitempty"`
 }
 
 type AdmissionControllerValidationConfig struct {
@@ -908,6 +1344,45 @@ type AdmissionControllerMutationConfig struct {
 	Enabled *bool `json:"enabled,omitempty"`
 }
 
+// AdmissionControllerCertManagerConfig contains the cert-manager configuration of the Admission Controller webhooks certificate.
+type AdmissionControllerCertManagerConfig struct {
+	// Enabled enables the issuance of the Admission Controller webhooks certificate by cert-manager.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// IssuerRef references the cert-manager Issuer or ClusterIssuer signing the certificate.
+	// Default: a self-signed Issuer created by the Operator in the DatadogAgent namespace.
+	// +optional
+	IssuerRef *CertManagerIssuerReference `json:"issuerRef,omitempty"`
+}
+
+// AdmissionControllerOperatorIssuedCertificateConfig contains the configuration of the Admission Controller webhooks
+// certificate issued by the Operator.
+type AdmissionControllerOperatorIssuedCertificateConfig struct {
+	// Enabled enables the issuance of the Admission Controller webhooks certificate by the Operator.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// CertManagerIssuerReference references a cert-manager Issuer or ClusterIssuer.
+type CertManagerIssuerReference struct {
+	// Name is the name of the issuer.
+	Name string `json:"name"`
+
+	// Kind is the kind of the issuer, "Issuer" or "ClusterIssuer".
+	// Default: "Issuer"
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Group is the API group of the issuer, for external issuers.
+	// Default: "cert-manager.io"
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
 type AgentSidecarInjectionConfig struct {
 	// Enabled enables Sidecar injections.
 	// Default: false
@@ -1080,6 +1555,57 @@ type PrometheusScrapeFeatureConfig struct {
 	// Default: 2
 	// +optional
 	Version *int `json:"version,omitempty"`
+
+	// Rules are structured Prometheus check configurations with custom discovery rules.
+	// They are added to the configurations of `additionalConfigs`.
+	// +optional
+	// +listType=atomic
+	Rules []PrometheusScrapeRule `json:"rules,omitempty"`
+}
+
+// PrometheusScrapeRule configures the Prometheus check of the pods and services it selects.
+// +k8s:openapi-gen=true
+type PrometheusScrapeRule struct {
+	// Autodiscovery selects the pods and services scraped with this rule.
+	// Default: the pods and services annotated with `prometheus.io/scrape: "true"`
+	// +optional
+	Autodiscovery *PrometheusScrapeAutodiscovery `json:"autodiscovery,omitempty"`
+
+	// Namespace is the prefix added to the names of the collected metrics.
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+
+	// Metrics are the names of the collected metrics, or regular expressions matching them.
+	// Default: all the metrics
+	// +optional
+	// +listType=set
+	Metrics []string `json:"metrics,omitempty"`
+
+	// ExcludeMetrics are the names of the metrics that are not collected, or regular expressions matching them.
+	// +optional
+	// +listType=set
+	ExcludeMetrics []string `json:"excludeMetrics,omitempty"`
+
+	// RenameMetrics collects metrics under another name, indexed by the name of the scraped metric.
+	// +optional
+	RenameMetrics map[string]string `json:"renameMetrics,omitempty"`
+}
+
+// PrometheusScrapeAutodiscovery selects the pods and services scraped by a Prometheus scrape rule.
+// +k8s:openapi-gen=true
+type PrometheusScrapeAutodiscovery struct {
+	// IncludeAnnotations selects the pods and services having all these annotations.
+	// +optional
+	IncludeAnnotations map[string]string `json:"includeAnnotations,omitempty"`
+
+	// ExcludeAnnotations ignores the pods and services having any of these annotations.
+	// +optional
+	ExcludeAnnotations map[string]string `json:"excludeAnnotations,omitempty"`
+
+	// ContainerNames restricts the check to the containers with these names.
+	// +optional
+	// +listType=set
+	ContainerNames []string `json:"containerNames,omitempty"`
 }
 
 // HelmCheckFeatureConfig allows configuration of the Helm check feature.
@@ -1103,6 +1629,29 @@ type HelmCheckFeatureConfig struct {
 	ValuesAsTags map[string]string `json:"valuesAsTags,omitempty"`
 }
 
+// OtelAgentGatewayFeatureConfig contains the configuration of the OTel Agent Gateway.
+// The OTel Agent Gateway is a Deployment of OTel Agents receiving the telemetry of the node otel-agents
+// and of the applications, for instance to apply tail sampling or aggregations before sending it to Datadog.
+// +k8s:openapi-gen=true
+type OtelAgentGatewayFeatureConfig struct {
+	// Enabled enables the OTel Agent Gateway.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Conf overrides the configuration of the OTel Agent Gateway.
+	// When passing a configmap, file name *must* be otel-gateway-config.yaml.
+	// Default: OTLP receiver exporting to Datadog
+	// +optional
+	Conf *CustomConfig `json:"conf,omitempty"`
+
+	// Ports contains the ports of the OTel Agent Gateway, also exposed by its Service.
+	// Defaults: otel-grpc:4317 / otel-http:4318.
+	// +optional
+	// +listType=atomic
+	Ports []*corev1.ContainerPort `json:"ports,omitempty"`
+}
+
 // Generic support structs
 
 // SecretConfig contains a secret name and an included key.
@@ -1289,6 +1838,78 @@ type DaemonSetStatus struct {
 
 	// DaemonsetName corresponds to the name of the created DaemonSet.
 	DaemonsetName string `json:"daemonsetName,omitempty"`
+
+	// Rollout reports the progress of the staged rollout of the DaemonSet.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+
+	// Rollback reports the state of the automatic rollback of the DaemonSet.
+	// +optional
+	Rollback *RollbackStatus `json:"rollback,omitempty"`
+}
+
+// RolloutState is the state of a staged rollout.
+type RolloutState string
+
+const (
+	// RolloutStateInProgress means that the outdated Agent pods are being replaced.
+	RolloutStateInProgress RolloutState = "InProgress"
+	// RolloutStatePaused means that an updated Agent pod failed the health checks, the rollout is stopped.
+	RolloutStatePaused RolloutState = "Paused"
+	// RolloutStateCompleted means that all the Agent pods are up to date.
+	RolloutStateCompleted RolloutState = "Completed"
+)
+
+// RolloutStatus reports the progress of a staged rollout.
+// +k8s:openapi-gen=true
+type RolloutStatus struct {
+	// State of the rollout.
+	State RolloutState `json:"state,omitempty"`
+
+	// Revision is the DaemonSet revision being rolled out.
+	Revision string `json:"revision,omitempty"`
+
+	// CurrentWave is the name of the wave being rolled out.
+	CurrentWave string `json:"currentWave,omitempty"`
+
+	// CompletedWaves is the number of completed waves.
+	CompletedWaves int32 `json:"completedWaves"`
+
+	// UpdatedPods is the number of up to date Agent pods.
+	UpdatedPods int32 `json:"updatedPods"`
+
+	// TotalPods is the number of Agent pods.
+	TotalPods int32 `json:"totalPods"`
+
+	// LastWaveCompletionTime is the time the last completed wave was completed.
+	// +optional
+	LastWaveCompletionTime *metav1.Time `json:"lastWaveCompletionTime,omitempty"`
+
+	// Message is a human readable description of the rollout state.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// RollbackStatus contains the state of the automatic rollback of a DaemonSet or a Deployment.
+// +k8s:openapi-gen=true
+type RollbackStatus struct {
+	// LastKnownGoodHash is the hash of the last spec whose pods were all up to date and ready.
+	// +optional
+	LastKnownGoodHash string `json:"lastKnownGoodHash,omitempty"`
+
+	// LastKnownGoodRevision identifies the pod template of the last known-good spec:
+	// the `controller-revision-hash` of a DaemonSet or the `pod-template-hash` of a Deployment.
+	// +optional
+	LastKnownGoodRevision string `json:"lastKnownGoodRevision,omitempty"`
+
+	// FailedHash is the hash of the spec that was rolled back because its pods failed readiness.
+	// It is not applied again until the spec changes.
+	// +optional
+	FailedHash string `json:"failedHash,omitempty"`
+
+	// LastRollbackTime is the time of the last rollback.
+	// +optional
+	LastRollbackTime *metav1.Time `json:"lastRollbackTime,omitempty"`
 }
 
 // DeploymentStatus type representing a Deployment status.
@@ -1336,6 +1957,10 @@ type DeploymentStatus struct {
 
 	// DeploymentName corresponds to the name of the Deployment.
 	DeploymentName string `json:"deploymentName,omitempty"`
+
+	// Rollback reports the state of the automatic rollback of the Deployment.
+	// +optional
+	Rollback *RollbackStatus `json:"rollback,omitempty"`
 }
 
 // GlobalConfig is a set of parameters that are used to configure all the components of the Datadog Operator.
@@ -1478,6 +2103,14 @@ type GlobalConfig struct {
 	// +optional
 	ContainerStrategy *ContainerStrategyType `json:"containerStrategy,omitempty"`
 
+	// SecurityProfile restricts the agents to a Pod Security Standard.
+	// With `restricted`, the features requiring privileged containers, host ports or host access, such as CWS, log collection or APM over UDS, are blocked.
+	// The hostPath volumes, host ports and host namespaces are removed and the containers run as non-root with a restricted-compliant security context.
+	// Default: 'default'
+	// +optional
+	// +kubebuilder:validation:Enum=default;restricted
+	SecurityProfile *SecurityProfileType `json:"securityProfile,omitempty"`
+
 	// UseFIPSAgent enables the FIPS flavor of the Agent. If 'true', the FIPS proxy will always be disabled.
 	// Default: 'false'
 	// +optional
@@ -1496,6 +2129,115 @@ type GlobalConfig struct {
 	// Default: 'true'
 	// +optional
 	RunProcessChecksInCoreAgent *bool `json:"runProcessChecksInCoreAgent,omitempty"`
+
+	// NodeSelector restricts the nodes on which the Agent of this DatadogAgent runs.
+	// It is used to run several DatadogAgents in a cluster, each on a disjoint set of nodes.
+	// A node selected by several DatadogAgents is only handled by the oldest one,
+	// and the overlap is reported in the `NodeSelectorOverlap` condition.
+	// Default: all the nodes of the cluster
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// ContainerFilters includes or excludes containers from the metrics, logs and Autodiscovery of the Agent.
+	// +optional
+	ContainerFilters *ContainerFiltersConfig `json:"containerFilters,omitempty"`
+
+	// AutoRollback configures the automatic rollback of the node Agent DaemonSet and of the
+	// Cluster Agent and Cluster Checks Runner Deployments when their updated pods fail readiness.
+	// +optional
+	AutoRollback *AutoRollbackConfig `json:"autoRollback,omitempty"`
+
+	// ResourceRecommendations configures the resource recommendations computed for the containers of the node Agent.
+	// +optional
+	ResourceRecommendations *ResourceRecommendationsConfig `json:"resourceRecommendations,omitempty"`
+}
+
+// ContainerFiltersConfig includes or excludes containers from the Agent data collection.
+// See also: https://docs.datadoghq.com/containers/guide/container-discovery-management
+type ContainerFiltersConfig struct {
+	// Global filters apply to all the data collected from the containers, and to Autodiscovery.
+	// +optional
+	Global *ContainerFilterRules `json:"global,omitempty"`
+
+	// Metrics filters only apply to the container metrics.
+	// +optional
+	Metrics *ContainerFilterRules `json:"metrics,omitempty"`
+
+	// Logs filters only apply to the container logs.
+	// They are extended by `features.logCollection.containerInclude` and `features.logCollection.containerExclude`.
+	// +optional
+	Logs *ContainerFilterRules `json:"logs,omitempty"`
+}
+
+// ContainerFilterRules contains the include and exclude filters of containers.
+type ContainerFilterRules struct {
+	// Include selects the containers whose data is collected, even if they match `exclude`.
+	// +optional
+	Include *ContainerFilter `json:"include,omitempty"`
+
+	// Exclude selects the containers whose data is not collected.
+	// +optional
+	Exclude *ContainerFilter `json:"exclude,omitempty"`
+}
+
+// ContainerFilter selects containers by namespace, image or name.
+// Each value is a regular expression without whitespace; a container matching any of them is selected.
+type ContainerFilter struct {
+	// Namespaces are matched against the namespace of the pod of the container, for example: `^kube-system$`.
+	// +optional
+	// +listType=set
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Images are matched against the image of the container, for example: `^nginx$`.
+	// +optional
+	// +listType=set
+	Images []string `json:"images,omitempty"`
+
+	// Names are matched against the name of the container.
+	// +optional
+	// +listType=set
+	Names []string `json:"names,omitempty"`
+}
+
+// AutoRollbackConfig configures the automatic rollback of the Agent components.
+// When the updated pods of a component fail readiness, the operator reverts the component
+// to the pod template of the last spec whose pods were all up to date and ready.
+// +k8s:openapi-gen=true
+type AutoRollbackConfig struct {
+	// Enabled enables the automatic rollback.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// FailureThreshold is the number of updated pods failing readiness that triggers the rollback.
+	// Value can be an absolute number (ex: 5) or a percentage of the updated pods (ex: 50%).
+	// Default: 50%
+	// +optional
+	FailureThreshold *intstr.IntOrString `json:"failureThreshold,omitempty"`
+
+	// Window is the duration for an updated pod to become ready before it is considered failing.
+	// Default: 5m
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+}
+
+// ResourceRecommendationsConfig configures the resource recommendations of the node Agent containers.
+// The recommendations are computed for each DaemonSet of the node Agent from the usage of its containers,
+// read from the metrics API, or from the kubelets when the metrics API is not available.
+// +k8s:openapi-gen=true
+type ResourceRecommendationsConfig struct {
+	// Enabled enables the computation of the recommendations, reported in `status.resourceRecommendations`.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Apply sets the recommended requests and limits on the containers
+	// which have no resources in `override.nodeAgent.containers`, or in the override of their profile.
+	// The recommendations are applied once the usage has been sampled for 6 hours, and then updated at most every 6 hours,
+	// when a recommended resource differs by more than 20% from the applied one.
+	// Default: false
+	// +optional
+	Apply *bool `json:"apply,omitempty"`
 }
 
 // DatadogCredentials is a generic structure that holds credentials to access Datadog.
@@ -1579,6 +2321,12 @@ const (
 
 	// NetworkPolicyFlavorCilium refers to `cilium.io/v2/CiliumNetworkPolicy`
 	NetworkPolicyFlavorCilium NetworkPolicyFlavor = "cilium"
+
+	// NetworkPolicyFlavorCalico refers to `projectcalico.org/v3/GlobalNetworkPolicy`
+	NetworkPolicyFlavorCalico NetworkPolicyFlavor = "calico"
+
+	// NetworkPolicyFlavorAdminNetworkPolicy refers to `policy.networking.k8s.io/v1alpha1/AdminNetworkPolicy`
+	NetworkPolicyFlavorAdminNetworkPolicy NetworkPolicyFlavor = "adminNetworkPolicy"
 )
 
 // NetworkPolicyConfig provides Network Policy configuration for the agents.
@@ -1596,6 +2344,66 @@ type NetworkPolicyConfig struct {
 	// +optional
 	// +listType=atomic
 	DNSSelectorEndpoints []metav1.LabelSelector `json:"dnsSelectorEndpoints,omitempty"`
+
+	// ExtraIngress defines additional ingress rules per component: `nodeAgent`, `clusterAgent`, `clusterChecksRunner` or `otelAgentGateway`.
+	// They are appended to the rules of the generated `kubernetes` and `cilium` network policies,
+	// and are not supported by the `calico` and `adminNetworkPolicy` flavors.
+	// +optional
+	ExtraIngress map[ComponentName][]NetworkPolicyRule `json:"extraIngress,omitempty"`
+
+	// ExtraEgress defines additional egress rules per component: `nodeAgent`, `clusterAgent`, `clusterChecksRunner` or `otelAgentGateway`.
+	// They are appended to the rules of the generated `kubernetes` and `cilium` network policies,
+	// and are not supported by the `calico` and `adminNetworkPolicy` flavors.
+	// +optional
+	ExtraEgress map[ComponentName][]NetworkPolicyRule `json:"extraEgress,omitempty"`
+}
+
+// NetworkPolicyRule defines a network policy rule allowing the traffic from or to peers on some ports.
+// +k8s:openapi-gen=true
+type NetworkPolicyRule struct {
+	// Ports defines the ports allowed by the rule.
+	// All the ports are allowed if empty.
+	// +optional
+	// +listType=atomic
+	Ports []NetworkPolicyRulePort `json:"ports,omitempty"`
+
+	// Peers defines the sources of an ingress rule, or the destinations of an egress rule.
+	// All the peers are allowed if empty.
+	// +optional
+	// +listType=atomic
+	Peers []NetworkPolicyRulePeer `json:"peers,omitempty"`
+}
+
+// NetworkPolicyRulePort defines a port of a network policy rule.
+// +k8s:openapi-gen=true
+type NetworkPolicyRulePort struct {
+	// Port is the port number.
+	Port int32 `json:"port"`
+
+	// Protocol is the protocol of the port.
+	// Default: TCP
+	// +optional
+	// +kubebuilder:validation:Enum=TCP;UDP;SCTP
+	Protocol *corev1.Protocol `json:"protocol,omitempty"`
+}
+
+// NetworkPolicyRulePeer defines a peer of a network policy rule, either an IP block or a set of pods.
+// +k8s:openapi-gen=true
+type NetworkPolicyRulePeer struct {
+	// CIDR selects an IP block, for example `10.0.0.0/16`.
+	// It cannot be combined with the selectors.
+	// +optional
+	CIDR string `json:"cidr,omitempty"`
+
+	// PodSelector selects pods in the namespaces selected by NamespaceSelector,
+	// or in the namespace of the DatadogAgent when NamespaceSelector is not set.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+
+	// NamespaceSelector selects namespaces. All the pods of the selected namespaces
+	// are selected when PodSelector is not set.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
 }
 
 // LocalService provides the internal traffic policy service configuration.
@@ -1625,6 +2433,15 @@ type SeccompConfig struct {
 	// must include a corev1.KeytoPath that maps the key to the path `system-probe-seccomp.json`.
 	// +optional
 	CustomProfile *CustomConfig `json:"customProfile,omitempty"`
+
+	// SecurityProfilesOperator enables the management of the default Seccomp Profile with the Kubernetes Security Profiles Operator.
+	// The Operator creates a `SeccompProfile` object, the Security Profiles Operator installs it on the nodes,
+	// and the container references it instead of the profile copied by the `seccomp-setup` init container.
+	// It is ignored when CustomProfile is set, or when the `SeccompProfile` API is not served by the cluster.
+	// Only the system-probe default profile is managed: the Agent container keeps its seccomp profile, and AppArmor is not managed.
+	// Default: false
+	// +optional
+	SecurityProfilesOperator *bool `json:"securityProfilesOperator,omitempty"`
 }
 
 // AgentConfigFileName is the list of known Agent config files
@@ -1653,7 +2470,7 @@ type DatadogAgentComponentOverride struct {
 	Replicas *int32 `json:"replicas,omitempty"`
 
 	// Set CreatePodDisruptionBudget to true to create a PodDisruptionBudget for this component.
-	// Not applicable for the Node Agent. A Cluster Agent PDB is set with 1 minimum available pod, and a Cluster Checks Runner PDB is set with 1 maximum unavailable pod.
+	// Not applicable for the Node Agent. A Cluster Agent PDB is set with 1 minimum available pod, and the Cluster Checks Runner and OTel Agent Gateway PDBs are set with 1 maximum unavailable pod.
 	// +optional
 	CreatePodDisruptionBudget *bool `json:"createPodDisruptionBudget,omitempty"`
 
@@ -1760,6 +2577,11 @@ type DatadogAgentComponentOverride struct {
 	// +optional
 	UpdateStrategy *common.UpdateStrategy `json:"updateStrategy,omitempty"`
 
+	// StagedRollout configures the rollout of the node Agent DaemonSet in waves, driven by the operator.
+	// Only applies to the node Agent, when the ExtendedDaemonSet is not used.
+	// +optional
+	StagedRollout *StagedRolloutConfig `json:"stagedRollout,omitempty"`
+
 	// Configure the component tolerations.
 	// +optional
 	// +listType=atomic
@@ -1794,6 +2616,62 @@ type DatadogAgentComponentOverride struct {
 	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 }
 
+// StagedRolloutConfig configures the rollout of the node Agent DaemonSet in waves.
+// The DaemonSet uses the `OnDelete` update strategy, and the operator deletes the outdated Agent pods wave by wave.
+// +k8s:openapi-gen=true
+type StagedRolloutConfig struct {
+	// Enabled enables the staged rollout.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Waves lists the waves of the rollout, in order.
+	// The nodes not selected by any wave are updated in a last wave.
+	// Default: a single wave with all the nodes
+	// +optional
+	// +listType=atomic
+	Waves []RolloutWave `json:"waves,omitempty"`
+
+	// MaxUnavailable is the maximum number of Agent pods that can be unavailable during the rollout.
+	// Value can be an absolute number (ex: 5) or a percentage of the Agent pods (ex: 10%).
+	// Default: 10%
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// MaxRestarts is the maximum number of container restarts of an updated Agent pod.
+	// The rollout is paused when an updated Agent pod exceeds it.
+	// Default: 2
+	// +optional
+	MaxRestarts *int32 `json:"maxRestarts,omitempty"`
+
+	// ProgressDeadline is the maximum duration for an updated Agent pod to become ready.
+	// The rollout is paused when an updated Agent pod exceeds it.
+	// Default: 10m
+	// +optional
+	ProgressDeadline *metav1.Duration `json:"progressDeadline,omitempty"`
+}
+
+// RolloutWave defines the nodes updated in a wave of a staged rollout.
+// +k8s:openapi-gen=true
+type RolloutWave struct {
+	// Name of the wave, reported in the rollout status.
+	Name string `json:"name"`
+
+	// NodeSelector selects the nodes of the wave, among the nodes not selected by the previous waves.
+	// Default: all the nodes not selected by the previous waves
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// MaxNodes limits the number of nodes of the wave.
+	// Value can be an absolute number (ex: 5) or a percentage of the nodes running the Agent (ex: 10%).
+	// +optional
+	MaxNodes *intstr.IntOrString `json:"maxNodes,omitempty"`
+
+	// Pause is the duration to wait once the wave is completed before starting the next one.
+	// +optional
+	Pause *metav1.Duration `json:"pause,omitempty"`
+}
+
 // DatadogAgentGenericContainer is the generic structure describing any container's common configuration.
 // +k8s:openapi-gen=true
 type DatadogAgentGenericContainer struct {
@@ -1884,6 +2762,17 @@ const (
 	SingleContainerStrategy ContainerStrategyType = "single"
 )
 
+// SecurityProfileType is the security profile applied to the agents.
+type SecurityProfileType string
+
+const (
+	// DefaultSecurityProfile enables the features without restriction (default)
+	DefaultSecurityProfile SecurityProfileType = "default"
+	// RestrictedSecurityProfile blocks the features requiring privileged containers or host access and runs the
+	// other containers with a security context compliant with the Pod Security Standard `restricted`
+	RestrictedSecurityProfile SecurityProfileType = "restricted"
+)
+
 // FIPSConfig contains the FIPS configuration.
 // +k8s:openapi-gen=true
 type FIPSConfig struct {
@@ -1948,9 +2837,144 @@ type DatadogAgentStatus struct {
 	// The actual state of the Cluster Checks Runner as a deployment.
 	// +optional
 	ClusterChecksRunner *DeploymentStatus `json:"clusterChecksRunner,omitempty"`
+	// The actual state of the OTel Agent Gateway as a deployment.
+	// +optional
+	OtelAgentGateway *DeploymentStatus `json:"otelAgentGateway,omitempty"`
 	// RemoteConfigConfiguration stores the configuration received from RemoteConfig.
 	// +optional
 	RemoteConfigConfiguration *RemoteConfigConfiguration `json:"remoteConfigConfiguration,omitempty"`
+	// Features contains the status of the enabled and configured features, indexed by feature ID.
+	// The features that are not listed are disabled.
+	// +optional
+	Features map[string]FeatureStatus `json:"features,omitempty"`
+	// Instrumentation reports the namespaces and pods matched by the targets of the APM Single Step Instrumentation.
+	// +optional
+	Instrumentation *InstrumentationStatus `json:"instrumentation,omitempty"`
+	// ResourceRecommendations reports the resource recommendations computed for the containers of the node Agent.
+	// +optional
+	ResourceRecommendations *ResourceRecommendationsStatus `json:"resourceRecommendations,omitempty"`
+}
+
+// FeatureState is the state of a feature.
+// +kubebuilder:validation:Enum=Enabled;Configured;Degraded;Blocked
+type FeatureState string
+
+const (
+	// FeatureStateEnabled is the state of an enabled feature.
+	FeatureStateEnabled FeatureState = "Enabled"
+	// FeatureStateConfigured is the state of a disabled feature that still configures the Agent, for instance to turn off a default Agent behavior.
+	FeatureStateConfigured FeatureState = "Configured"
+	// FeatureStateDegraded is the state of an enabled feature that cannot work as configured.
+	FeatureStateDegraded FeatureState = "Degraded"
+	// FeatureStateBlocked is the state of a feature disabled by the security profile.
+	FeatureStateBlocked FeatureState = "Blocked"
+)
+
+// FeatureStatus is the status of a feature.
+// +k8s:openapi-gen=true
+type FeatureStatus struct {
+	// State is the state of the feature.
+	State FeatureState `json:"state"`
+	// Reason is a CamelCase reason for a degraded or blocked feature.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable explanation of the state of the feature.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// InstrumentationStatus reports the namespaces and pods matched by the targets of the APM Single Step Instrumentation.
+// +k8s:openapi-gen=true
+type InstrumentationStatus struct {
+	// LastUpdate is the last time the targets were evaluated against the namespaces and pods of the cluster.
+	// +optional
+	LastUpdate *metav1.Time `json:"lastUpdate,omitempty"`
+	// ObservedGeneration is the generation of the DatadogAgent whose targets were evaluated.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Targets contains the status of each target of `features.apm.instrumentation.targets`, in the same order.
+	// +optional
+	// +listType=atomic
+	Targets []SSITargetStatus `json:"targets,omitempty"`
+}
+
+// SSITargetStatus reports the namespaces and pods matched by a target of the APM Single Step Instrumentation.
+// +k8s:openapi-gen=true
+type SSITargetStatus struct {
+	// Name is the name of the target, or `targets[<index>]` for a target without a name.
+	Name string `json:"name"`
+	// MatchedNamespaces is the number of namespaces matched by the namespace selector of the target.
+	MatchedNamespaces int32 `json:"matchedNamespaces"`
+	// MatchedPods is the number of pods matched by the target.
+	MatchedPods int32 `json:"matchedPods"`
+	// AppliedPods is the number of matched pods instrumented with this target.
+	// It is lower than MatchedPods when previous targets match the same pods, since the first matching target is used.
+	AppliedPods int32 `json:"appliedPods"`
+	// OverlappingTargets are the previous targets matching some pods of this target.
+	// +optional
+	// +listType=atomic
+	OverlappingTargets []string `json:"overlappingTargets,omitempty"`
+	// InvalidTracerVersions are the keys of `ddTraceVersions` which are not languages supported by the instrumentation.
+	// +optional
+	// +listType=atomic
+	InvalidTracerVersions []string `json:"invalidDDTraceVersions,omitempty"`
+	// Error reports why the target could not be evaluated, for instance an invalid selector.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// ResourceRecommendationsStatus reports the resource recommendations computed for the containers of the node Agent.
+// +k8s:openapi-gen=true
+type ResourceRecommendationsStatus struct {
+	// LastUpdate is the last time the container usage was read.
+	// +optional
+	LastUpdate *metav1.Time `json:"lastUpdate,omitempty"`
+	// Source is the source of the container usage: `metrics-api` or `kubelet`.
+	// +optional
+	Source string `json:"source,omitempty"`
+	// DaemonSets contains the recommendations of each DaemonSet of the node Agent, including the DaemonSets of the profiles.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	DaemonSets []DaemonSetResourceRecommendation `json:"daemonSets,omitempty"`
+}
+
+// DaemonSetResourceRecommendation contains the resource recommendations of the containers of a node Agent DaemonSet.
+// +k8s:openapi-gen=true
+type DaemonSetResourceRecommendation struct {
+	// Name is the name of the DaemonSet or ExtendedDaemonSet.
+	Name string `json:"name"`
+	// Pods is the number of pods whose usage was last read.
+	Pods int32 `json:"pods"`
+	// Containers contains the recommendations of each container of the DaemonSet.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Containers []ContainerResourceRecommendation `json:"containers,omitempty"`
+	// Applied contains the recommendations set on the containers of the DaemonSet when they are applied.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Applied []ContainerResourceRecommendation `json:"applied,omitempty"`
+	// LastApplied is the last time the applied recommendations were updated.
+	// +optional
+	LastApplied *metav1.Time `json:"lastApplied,omitempty"`
+}
+
+// ContainerResourceRecommendation contains the recommended resources of a container.
+// The requests are derived from the 90th percentile of the usage of the container across the pods of its DaemonSet,
+// and the memory limit from the maximum usage, over the usage sampled every 5 minutes in the last 24 hours.
+// No CPU limit is recommended.
+// +k8s:openapi-gen=true
+type ContainerResourceRecommendation struct {
+	// Name is the name of the container.
+	Name string `json:"name"`
+	// Requests are the recommended resource requests.
+	// +optional
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+	// Limits are the recommended resource limits.
+	// +optional
+	Limits corev1.ResourceList `json:"limits,omitempty"`
 }
 
 // DatadogAgent Deployment with the Datadog Operator.
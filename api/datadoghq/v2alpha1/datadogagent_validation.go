@@ -5,7 +5,16 @@
 
 package v2alpha1
 
-import "fmt"
+import (
+	"fmt"
+	"maps"
+	"net"
+	"regexp"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 // ValidateDatadogAgent is used to check if a DatadogAgent is valid
 func ValidateDatadogAgent(dda *DatadogAgent) error {
@@ -14,5 +23,158 @@ func ValidateDatadogAgent(dda *DatadogAgent) error {
 	if dda.Spec.Global == nil || dda.Spec.Global.Credentials == nil {
 		return fmt.Errorf("credentials not configured in the DatadogAgent, can't reconcile")
 	}
+	if dda.Spec.Global.NodeSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(dda.Spec.Global.NodeSelector); err != nil {
+			return fmt.Errorf("invalid global.nodeSelector: %w", err)
+		}
+	}
+	if dda.Spec.Global.NetworkPolicy != nil {
+		if err := validateNetworkPolicyExtraRules(dda.Spec.Global.NetworkPolicy); err != nil {
+			return err
+		}
+	}
+	if filters := dda.Spec.Global.ContainerFilters; filters != nil {
+		if err := validateContainerFilterRules("global.containerFilters.global", filters.Global); err != nil {
+			return err
+		}
+		if err := validateContainerFilterRules("global.containerFilters.metrics", filters.Metrics); err != nil {
+			return err
+		}
+		if err := validateContainerFilterRules("global.containerFilters.logs", filters.Logs); err != nil {
+			return err
+		}
+	}
+	if dda.Spec.Features != nil && dda.Spec.Features.AdmissionController != nil {
+		ac := dda.Spec.Features.AdmissionController
+		if ac.CertManager != nil && ac.CertManager.Enabled != nil && *ac.CertManager.Enabled &&
+			ac.OperatorIssuedCertificate != nil && ac.OperatorIssuedCertificate.Enabled != nil && *ac.OperatorIssuedCertificate.Enabled {
+			return fmt.Errorf("features.admissionController.certManager and features.admissionController.operatorIssuedCertificate cannot be enabled together")
+		}
+	}
+	if dda.Spec.Features != nil && dda.Spec.Features.LogCollection != nil {
+		if err := validateContainerFilter("features.logCollection.containerInclude", dda.Spec.Features.LogCollection.ContainerInclude); err != nil {
+			return err
+		}
+		if err := validateContainerFilter("features.logCollection.containerExclude", dda.Spec.Features.LogCollection.ContainerExclude); err != nil {
+			return err
+		}
+		for i, rule := range dda.Spec.Features.LogCollection.ProcessingRules {
+			switch rule.Type {
+			case LogProcessingRuleExcludeAtMatch, LogProcessingRuleIncludeAtMatch, LogProcessingRuleMaskSequences:
+			default:
+				// The Agent ignores the `multi_line` rules of the global processing rules
+				return fmt.Errorf("invalid features.logCollection.processingRules[%d].type %q: only exclude_at_match, include_at_match and mask_sequences are supported", i, rule.Type)
+			}
+			if rule.Pattern == "" {
+				return fmt.Errorf("invalid features.logCollection.processingRules[%d]: pattern is required", i)
+			}
+			if _, err := regexp.Compile(rule.Pattern); err != nil {
+				return fmt.Errorf("invalid features.logCollection.processingRules[%d].pattern %q: %w", i, rule.Pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// networkPolicyComponentNames are the components with a network policy, which can have extra rules.
+var networkPolicyComponentNames = []ComponentName{
+	NodeAgentComponentName,
+	ClusterAgentComponentName,
+	ClusterChecksRunnerComponentName,
+	OtelAgentGatewayComponentName,
+}
+
+// validateNetworkPolicyExtraRules checks that the extra rules of the network policies can be rendered: they are only
+// supported by the kubernetes and cilium flavors, and each peer is either an IP block or a set of pods.
+func validateNetworkPolicyExtraRules(config *NetworkPolicyConfig) error {
+	if len(config.ExtraIngress) == 0 && len(config.ExtraEgress) == 0 {
+		return nil
+	}
+	if config.Flavor != "" && config.Flavor != NetworkPolicyFlavorKubernetes && config.Flavor != NetworkPolicyFlavorCilium {
+		return fmt.Errorf("global.networkPolicy.extraIngress and global.networkPolicy.extraEgress are not supported by the %s flavor", config.Flavor)
+	}
+	for _, extra := range []struct {
+		path  string
+		rules map[ComponentName][]NetworkPolicyRule
+	}{
+		{path: "global.networkPolicy.extraIngress", rules: config.ExtraIngress},
+		{path: "global.networkPolicy.extraEgress", rules: config.ExtraEgress},
+	} {
+		for _, componentName := range slices.Sorted(maps.Keys(extra.rules)) {
+			if !slices.Contains(networkPolicyComponentNames, componentName) {
+				return fmt.Errorf("invalid %s component %q: must be one of %v", extra.path, componentName, networkPolicyComponentNames)
+			}
+			for i, rule := range extra.rules[componentName] {
+				rulePath := fmt.Sprintf("%s.%s[%d]", extra.path, componentName, i)
+				for _, port := range rule.Ports {
+					if port.Port < 1 || port.Port > 65535 {
+						return fmt.Errorf("invalid %s port %d: must be between 1 and 65535", rulePath, port.Port)
+					}
+				}
+				for j, peer := range rule.Peers {
+					if err := validateNetworkPolicyRulePeer(fmt.Sprintf("%s.peers[%d]", rulePath, j), peer); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func validateNetworkPolicyRulePeer(path string, peer NetworkPolicyRulePeer) error {
+	if peer.CIDR == "" {
+		if peer.PodSelector == nil && peer.NamespaceSelector == nil {
+			return fmt.Errorf("invalid %s: one of cidr, podSelector or namespaceSelector must be set", path)
+		}
+		for _, selector := range []*metav1.LabelSelector{peer.PodSelector, peer.NamespaceSelector} {
+			if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+				return fmt.Errorf("invalid %s selector: %w", path, err)
+			}
+		}
+		return nil
+	}
+	if peer.PodSelector != nil || peer.NamespaceSelector != nil {
+		return fmt.Errorf("invalid %s: cidr cannot be combined with podSelector or namespaceSelector", path)
+	}
+	if _, _, err := net.ParseCIDR(peer.CIDR); err != nil {
+		return fmt.Errorf("invalid %s cidr %q: %w", path, peer.CIDR, err)
+	}
+	return nil
+}
+
+func validateContainerFilterRules(path string, rules *ContainerFilterRules) error {
+	if rules == nil {
+		return nil
+	}
+	if err := validateContainerFilter(path+".include", rules.Include); err != nil {
+		return err
+	}
+	return validateContainerFilter(path+".exclude", rules.Exclude)
+}
+
+// validateContainerFilter checks that the values of a container filter are regular expressions.
+// The values are separated by spaces in the Agent configuration, so they cannot contain whitespace.
+func validateContainerFilter(path string, filter *ContainerFilter) error {
+	if filter == nil {
+		return nil
+	}
+	for _, field := range []struct {
+		name   string
+		values []string
+	}{
+		{name: "namespaces", values: filter.Namespaces},
+		{name: "images", values: filter.Images},
+		{name: "names", values: filter.Names},
+	} {
+		for _, value := range field.values {
+			if value == "" || strings.ContainsAny(value, " \t\n") {
+				return fmt.Errorf("invalid %s.%s value %q: must be a non-empty regular expression without whitespace", path, field.name, value)
+			}
+			if _, err := regexp.Compile(value); err != nil {
+				return fmt.Errorf("invalid %s.%s value %q: %w", path, field.name, value, err)
+			}
+		}
+	}
 	return nil
 }
@@ -14,6 +14,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -146,6 +147,31 @@ func (in *ASMThreatsConfig) DeepCopy() *ASMThreatsConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionControllerCertManagerConfig) DeepCopyInto(out *AdmissionControllerCertManagerConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(CertManagerIssuerReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionControllerCertManagerConfig.
+func (in *AdmissionControllerCertManagerConfig) DeepCopy() *AdmissionControllerCertManagerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionControllerCertManagerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdmissionControllerFeatureConfig) DeepCopyInto(out *AdmissionControllerFeatureConfig) {
 	*out = *in
@@ -209,6 +235,16 @@ func (in *AdmissionControllerFeatureConfig) DeepCopyInto(out *AdmissionControlle
 		*out = new(CWSInstrumentationConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(AdmissionControllerCertManagerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OperatorIssuedCertificate != nil {
+		in, out := &in.OperatorIssuedCertificate, &out.OperatorIssuedCertificate
+		*out = new(AdmissionControllerOperatorIssuedCertificateConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionControllerFeatureConfig.
@@ -241,6 +277,26 @@ func (in *AdmissionControllerMutationConfig) DeepCopy() *AdmissionControllerMuta
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionControllerOperatorIssuedCertificateConfig) DeepCopyInto(out *AdmissionControllerOperatorIssuedCertificateConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionControllerOperatorIssuedCertificateConfig.
+func (in *AdmissionControllerOperatorIssuedCertificateConfig) DeepCopy() *AdmissionControllerOperatorIssuedCertificateConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionControllerOperatorIssuedCertificateConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdmissionControllerValidationConfig) DeepCopyInto(out *AdmissionControllerValidationConfig) {
 	*out = *in
@@ -352,6 +408,36 @@ func (in *AgentSidecarInjectionConfig) DeepCopy() *AgentSidecarInjectionConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoRollbackConfig) DeepCopyInto(out *AutoRollbackConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoRollbackConfig.
+func (in *AutoRollbackConfig) DeepCopy() *AutoRollbackConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoRollbackConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoscalingFeatureConfig) DeepCopyInto(out *AutoscalingFeatureConfig) {
 	*out = *in
@@ -557,6 +643,21 @@ func (in *CWSSecurityProfilesConfig) DeepCopy() *CWSSecurityProfilesConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuerReference) DeepCopyInto(out *CertManagerIssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerReference.
+func (in *CertManagerIssuerReference) DeepCopy() *CertManagerIssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterChecksFeatureConfig) DeepCopyInto(out *ClusterChecksFeatureConfig) {
 	*out = *in
@@ -604,6 +705,120 @@ func (in *ConfigMapConfig) DeepCopy() *ConfigMapConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerFilter) DeepCopyInto(out *ContainerFilter) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerFilter.
+func (in *ContainerFilter) DeepCopy() *ContainerFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerFilterRules) DeepCopyInto(out *ContainerFilterRules) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = new(ContainerFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = new(ContainerFilter)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerFilterRules.
+func (in *ContainerFilterRules) DeepCopy() *ContainerFilterRules {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerFilterRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerFiltersConfig) DeepCopyInto(out *ContainerFiltersConfig) {
+	*out = *in
+	if in.Global != nil {
+		in, out := &in.Global, &out.Global
+		*out = new(ContainerFilterRules)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(ContainerFilterRules)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Logs != nil {
+		in, out := &in.Logs, &out.Logs
+		*out = new(ContainerFilterRules)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerFiltersConfig.
+func (in *ContainerFiltersConfig) DeepCopy() *ContainerFiltersConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerFiltersConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerResourceRecommendation) DeepCopyInto(out *ContainerResourceRecommendation) {
+	*out = *in
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerResourceRecommendation.
+func (in *ContainerResourceRecommendation) DeepCopy() *ContainerResourceRecommendation {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerResourceRecommendation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CoreConfig) DeepCopyInto(out *CoreConfig) {
 	*out = *in
@@ -659,6 +874,39 @@ func (in *CustomConfig) DeepCopy() *CustomConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DaemonSetResourceRecommendation) DeepCopyInto(out *DaemonSetResourceRecommendation) {
+	*out = *in
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]ContainerResourceRecommendation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Applied != nil {
+		in, out := &in.Applied, &out.Applied
+		*out = make([]ContainerResourceRecommendation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastApplied != nil {
+		in, out := &in.LastApplied, &out.LastApplied
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonSetResourceRecommendation.
+func (in *DaemonSetResourceRecommendation) DeepCopy() *DaemonSetResourceRecommendation {
+	if in == nil {
+		return nil
+	}
+	out := new(DaemonSetResourceRecommendation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DaemonSetStatus) DeepCopyInto(out *DaemonSetStatus) {
 	*out = *in
@@ -666,6 +914,16 @@ func (in *DaemonSetStatus) DeepCopyInto(out *DaemonSetStatus) {
 		in, out := &in.LastUpdate, &out.LastUpdate
 		*out = (*in).DeepCopy()
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(RollbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonSetStatus.
@@ -841,6 +1099,11 @@ func (in *DatadogAgentComponentOverride) DeepCopyInto(out *DatadogAgentComponent
 		*out = new(common.UpdateStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.StagedRollout != nil {
+		in, out := &in.StagedRollout, &out.StagedRollout
+		*out = new(StagedRolloutConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
 		*out = make([]corev1.Toleration, len(*in))
@@ -1099,11 +1362,33 @@ func (in *DatadogAgentStatus) DeepCopyInto(out *DatadogAgentStatus) {
 		*out = new(DeploymentStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OtelAgentGateway != nil {
+		in, out := &in.OtelAgentGateway, &out.OtelAgentGateway
+		*out = new(DeploymentStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RemoteConfigConfiguration != nil {
 		in, out := &in.RemoteConfigConfiguration, &out.RemoteConfigConfiguration
 		*out = new(RemoteConfigConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make(map[string]FeatureStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Instrumentation != nil {
+		in, out := &in.Instrumentation, &out.Instrumentation
+		*out = new(InstrumentationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceRecommendations != nil {
+		in, out := &in.ResourceRecommendations, &out.ResourceRecommendations
+		*out = new(ResourceRecommendationsStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogAgentStatus.
@@ -1116,6 +1401,26 @@ func (in *DatadogAgentStatus) DeepCopy() *DatadogAgentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogChecksFeatureConfig) DeepCopyInto(out *DatadogChecksFeatureConfig) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogChecksFeatureConfig.
+func (in *DatadogChecksFeatureConfig) DeepCopy() *DatadogChecksFeatureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogChecksFeatureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatadogCredentials) DeepCopyInto(out *DatadogCredentials) {
 	*out = *in
@@ -1229,6 +1534,11 @@ func (in *DatadogFeatures) DeepCopyInto(out *DatadogFeatures) {
 		*out = new(DogstatsdFeatureConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DatadogChecks != nil {
+		in, out := &in.DatadogChecks, &out.DatadogChecks
+		*out = new(DatadogChecksFeatureConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.OTLP != nil {
 		in, out := &in.OTLP, &out.OTLP
 		*out = new(OTLPFeatureConfig)
@@ -1299,6 +1609,11 @@ func (in *DatadogFeatures) DeepCopyInto(out *DatadogFeatures) {
 		*out = new(HelmCheckFeatureConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OtelAgentGateway != nil {
+		in, out := &in.OtelAgentGateway, &out.OtelAgentGateway
+		*out = new(OtelAgentGatewayFeatureConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogFeatures.
@@ -1318,6 +1633,11 @@ func (in *DeploymentStatus) DeepCopyInto(out *DeploymentStatus) {
 		in, out := &in.LastUpdate, &out.LastUpdate
 		*out = (*in).DeepCopy()
 	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(RollbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatus.
@@ -1592,6 +1912,21 @@ func (in *FIPSConfig) DeepCopy() *FIPSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureStatus) DeepCopyInto(out *FeatureStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureStatus.
+func (in *FeatureStatus) DeepCopy() *FeatureStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GPUFeatureConfig) DeepCopyInto(out *GPUFeatureConfig) {
 	*out = *in
@@ -1788,6 +2123,11 @@ func (in *GlobalConfig) DeepCopyInto(out *GlobalConfig) {
 		*out = new(ContainerStrategyType)
 		**out = **in
 	}
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(SecurityProfileType)
+		**out = **in
+	}
 	if in.UseFIPSAgent != nil {
 		in, out := &in.UseFIPSAgent, &out.UseFIPSAgent
 		*out = new(bool)
@@ -1808,6 +2148,26 @@ func (in *GlobalConfig) DeepCopyInto(out *GlobalConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerFilters != nil {
+		in, out := &in.ContainerFilters, &out.ContainerFilters
+		*out = new(ContainerFiltersConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoRollback != nil {
+		in, out := &in.AutoRollback, &out.AutoRollback
+		*out = new(AutoRollbackConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceRecommendations != nil {
+		in, out := &in.ResourceRecommendations, &out.ResourceRecommendations
+		*out = new(ResourceRecommendationsConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalConfig.
@@ -1893,37 +2253,270 @@ func (in *InjectorConfig) DeepCopy() *InjectorConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeStateMetricsCoreFeatureConfig) DeepCopyInto(out *KubeStateMetricsCoreFeatureConfig) {
+func (in *InstrumentationStatus) DeepCopyInto(out *InstrumentationStatus) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
+	if in.LastUpdate != nil {
+		in, out := &in.LastUpdate, &out.LastUpdate
+		*out = (*in).DeepCopy()
 	}
-	if in.Conf != nil {
-		in, out := &in.Conf, &out.Conf
-		*out = new(CustomConfig)
-		(*in).DeepCopyInto(*out)
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]SSITargetStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeStateMetricsCoreFeatureConfig.
-func (in *KubeStateMetricsCoreFeatureConfig) DeepCopy() *KubeStateMetricsCoreFeatureConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstrumentationStatus.
+func (in *InstrumentationStatus) DeepCopy() *InstrumentationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeStateMetricsCoreFeatureConfig)
+	out := new(InstrumentationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeletConfig) DeepCopyInto(out *KubeletConfig) {
+func (in *KubeStateMetricsCoreCollectorsConfig) DeepCopyInto(out *KubeStateMetricsCoreCollectorsConfig) {
 	*out = *in
-	if in.Host != nil {
-		in, out := &in.Host, &out.Host
-		*out = new(corev1.EnvVarSource)
-		(*in).DeepCopyInto(*out)
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deny != nil {
+		in, out := &in.Deny, &out.Deny
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeStateMetricsCoreCollectorsConfig.
+func (in *KubeStateMetricsCoreCollectorsConfig) DeepCopy() *KubeStateMetricsCoreCollectorsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeStateMetricsCoreCollectorsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeStateMetricsCoreCustomResource) DeepCopyInto(out *KubeStateMetricsCoreCustomResource) {
+	*out = *in
+	if in.Resource != nil {
+		in, out := &in.Resource, &out.Resource
+		*out = new(string)
+		**out = **in
+	}
+	if in.MetricNamePrefix != nil {
+		in, out := &in.MetricNamePrefix, &out.MetricNamePrefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.LabelsFromPath != nil {
+		in, out := &in.LabelsFromPath, &out.LabelsFromPath
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]KubeStateMetricsCoreCustomResourceMetric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeStateMetricsCoreCustomResource.
+func (in *KubeStateMetricsCoreCustomResource) DeepCopy() *KubeStateMetricsCoreCustomResource {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeStateMetricsCoreCustomResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeStateMetricsCoreCustomResourceMetric) DeepCopyInto(out *KubeStateMetricsCoreCustomResourceMetric) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(KubeStateMetricsCoreMetricType)
+		**out = **in
+	}
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelsFromPath != nil {
+		in, out := &in.LabelsFromPath, &out.LabelsFromPath
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeStateMetricsCoreCustomResourceMetric.
+func (in *KubeStateMetricsCoreCustomResourceMetric) DeepCopy() *KubeStateMetricsCoreCustomResourceMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeStateMetricsCoreCustomResourceMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeStateMetricsCoreFeatureConfig) DeepCopyInto(out *KubeStateMetricsCoreFeatureConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Conf != nil {
+		in, out := &in.Conf, &out.Conf
+		*out = new(CustomConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Collectors != nil {
+		in, out := &in.Collectors, &out.Collectors
+		*out = new(KubeStateMetricsCoreCollectorsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LabelsAsTags != nil {
+		in, out := &in.LabelsAsTags, &out.LabelsAsTags
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.AnnotationsAsTags != nil {
+		in, out := &in.AnnotationsAsTags, &out.AnnotationsAsTags
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CustomResources != nil {
+		in, out := &in.CustomResources, &out.CustomResources
+		*out = make([]KubeStateMetricsCoreCustomResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sharding != nil {
+		in, out := &in.Sharding, &out.Sharding
+		*out = new(KubeStateMetricsCoreShardingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeStateMetricsCoreFeatureConfig.
+func (in *KubeStateMetricsCoreFeatureConfig) DeepCopy() *KubeStateMetricsCoreFeatureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeStateMetricsCoreFeatureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeStateMetricsCoreShardingConfig) DeepCopyInto(out *KubeStateMetricsCoreShardingConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Shards != nil {
+		in, out := &in.Shards, &out.Shards
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(KubeStateMetricsCoreShardingStrategy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeStateMetricsCoreShardingConfig.
+func (in *KubeStateMetricsCoreShardingConfig) DeepCopy() *KubeStateMetricsCoreShardingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeStateMetricsCoreShardingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletConfig) DeepCopyInto(out *KubeletConfig) {
+	*out = *in
+	if in.Host != nil {
+		in, out := &in.Host, &out.Host
+		*out = new(corev1.EnvVarSource)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.TLSVerify != nil {
 		in, out := &in.TLSVerify, &out.TLSVerify
@@ -2100,6 +2693,23 @@ func (in *LogCollectionFeatureConfig) DeepCopyInto(out *LogCollectionFeatureConf
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ProcessingRules != nil {
+		in, out := &in.ProcessingRules, &out.ProcessingRules
+		*out = make([]LogProcessingRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ContainerInclude != nil {
+		in, out := &in.ContainerInclude, &out.ContainerInclude
+		*out = new(ContainerFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerExclude != nil {
+		in, out := &in.ContainerExclude, &out.ContainerExclude
+		*out = new(ContainerFilter)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogCollectionFeatureConfig.
@@ -2112,6 +2722,26 @@ func (in *LogCollectionFeatureConfig) DeepCopy() *LogCollectionFeatureConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogProcessingRule) DeepCopyInto(out *LogProcessingRule) {
+	*out = *in
+	if in.ReplacePlaceholder != nil {
+		in, out := &in.ReplacePlaceholder, &out.ReplacePlaceholder
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogProcessingRule.
+func (in *LogProcessingRule) DeepCopy() *LogProcessingRule {
+	if in == nil {
+		return nil
+	}
+	out := new(LogProcessingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultiCustomConfig) DeepCopyInto(out *MultiCustomConfig) {
 	*out = *in
@@ -2218,6 +2848,42 @@ func (in *NetworkPolicyConfig) DeepCopyInto(out *NetworkPolicyConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ExtraIngress != nil {
+		in, out := &in.ExtraIngress, &out.ExtraIngress
+		*out = make(map[ComponentName][]NetworkPolicyRule, len(*in))
+		for key, val := range *in {
+			var outVal []NetworkPolicyRule
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]NetworkPolicyRule, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.ExtraEgress != nil {
+		in, out := &in.ExtraEgress, &out.ExtraEgress
+		*out = make(map[ComponentName][]NetworkPolicyRule, len(*in))
+		for key, val := range *in {
+			var outVal []NetworkPolicyRule
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]NetworkPolicyRule, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyConfig.
@@ -2231,52 +2897,126 @@ func (in *NetworkPolicyConfig) DeepCopy() *NetworkPolicyConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OOMKillFeatureConfig) DeepCopyInto(out *OOMKillFeatureConfig) {
+func (in *NetworkPolicyRule) DeepCopyInto(out *NetworkPolicyRule) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]NetworkPolicyRulePort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]NetworkPolicyRulePeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OOMKillFeatureConfig.
-func (in *OOMKillFeatureConfig) DeepCopy() *OOMKillFeatureConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyRule.
+func (in *NetworkPolicyRule) DeepCopy() *NetworkPolicyRule {
 	if in == nil {
 		return nil
 	}
-	out := new(OOMKillFeatureConfig)
+	out := new(NetworkPolicyRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OTLPFeatureConfig) DeepCopyInto(out *OTLPFeatureConfig) {
+func (in *NetworkPolicyRulePeer) DeepCopyInto(out *NetworkPolicyRulePeer) {
 	*out = *in
-	in.Receiver.DeepCopyInto(&out.Receiver)
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OTLPFeatureConfig.
-func (in *OTLPFeatureConfig) DeepCopy() *OTLPFeatureConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyRulePeer.
+func (in *NetworkPolicyRulePeer) DeepCopy() *NetworkPolicyRulePeer {
 	if in == nil {
 		return nil
 	}
-	out := new(OTLPFeatureConfig)
+	out := new(NetworkPolicyRulePeer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OTLPGRPCConfig) DeepCopyInto(out *OTLPGRPCConfig) {
+func (in *NetworkPolicyRulePort) DeepCopyInto(out *NetworkPolicyRulePort) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(corev1.Protocol)
 		**out = **in
 	}
-	if in.HostPortConfig != nil {
-		in, out := &in.HostPortConfig, &out.HostPortConfig
-		*out = new(HostPortConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyRulePort.
+func (in *NetworkPolicyRulePort) DeepCopy() *NetworkPolicyRulePort {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyRulePort)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OOMKillFeatureConfig) DeepCopyInto(out *OOMKillFeatureConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OOMKillFeatureConfig.
+func (in *OOMKillFeatureConfig) DeepCopy() *OOMKillFeatureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OOMKillFeatureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OTLPFeatureConfig) DeepCopyInto(out *OTLPFeatureConfig) {
+	*out = *in
+	in.Receiver.DeepCopyInto(&out.Receiver)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OTLPFeatureConfig.
+func (in *OTLPFeatureConfig) DeepCopy() *OTLPFeatureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OTLPFeatureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OTLPGRPCConfig) DeepCopyInto(out *OTLPGRPCConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HostPortConfig != nil {
+		in, out := &in.HostPortConfig, &out.HostPortConfig
+		*out = new(HostPortConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Endpoint != nil {
@@ -2390,85 +3130,447 @@ func (in *OrchestratorExplorerFeatureConfig) DeepCopyInto(out *OrchestratorExplo
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.ExtraTags != nil {
-		in, out := &in.ExtraTags, &out.ExtraTags
+	if in.ExtraTags != nil {
+		in, out := &in.ExtraTags, &out.ExtraTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DDUrl != nil {
+		in, out := &in.DDUrl, &out.DDUrl
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrchestratorExplorerFeatureConfig.
+func (in *OrchestratorExplorerFeatureConfig) DeepCopy() *OrchestratorExplorerFeatureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OrchestratorExplorerFeatureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OriginDetectionUnified) DeepCopyInto(out *OriginDetectionUnified) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OriginDetectionUnified.
+func (in *OriginDetectionUnified) DeepCopy() *OriginDetectionUnified {
+	if in == nil {
+		return nil
+	}
+	out := new(OriginDetectionUnified)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OtelAgentGatewayFeatureConfig) DeepCopyInto(out *OtelAgentGatewayFeatureConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Conf != nil {
+		in, out := &in.Conf, &out.Conf
+		*out = new(CustomConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]*corev1.ContainerPort, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(corev1.ContainerPort)
+				**out = **in
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtelAgentGatewayFeatureConfig.
+func (in *OtelAgentGatewayFeatureConfig) DeepCopy() *OtelAgentGatewayFeatureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OtelAgentGatewayFeatureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OtelCollectorBatchProcessorConfig) DeepCopyInto(out *OtelCollectorBatchProcessorConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SendBatchSize != nil {
+		in, out := &in.SendBatchSize, &out.SendBatchSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SendBatchMaxSize != nil {
+		in, out := &in.SendBatchMaxSize, &out.SendBatchMaxSize
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtelCollectorBatchProcessorConfig.
+func (in *OtelCollectorBatchProcessorConfig) DeepCopy() *OtelCollectorBatchProcessorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OtelCollectorBatchProcessorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OtelCollectorDebugExporterConfig) DeepCopyInto(out *OtelCollectorDebugExporterConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Verbosity != nil {
+		in, out := &in.Verbosity, &out.Verbosity
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtelCollectorDebugExporterConfig.
+func (in *OtelCollectorDebugExporterConfig) DeepCopy() *OtelCollectorDebugExporterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OtelCollectorDebugExporterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OtelCollectorExportersConfig) DeepCopyInto(out *OtelCollectorExportersConfig) {
+	*out = *in
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		*out = new(OtelCollectorDebugExporterConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtelCollectorExportersConfig.
+func (in *OtelCollectorExportersConfig) DeepCopy() *OtelCollectorExportersConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OtelCollectorExportersConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OtelCollectorFeatureConfig) DeepCopyInto(out *OtelCollectorFeatureConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Conf != nil {
+		in, out := &in.Conf, &out.Conf
+		*out = new(CustomConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]*corev1.ContainerPort, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(corev1.ContainerPort)
+				**out = **in
+			}
+		}
+	}
+	if in.CoreConfig != nil {
+		in, out := &in.CoreConfig, &out.CoreConfig
+		*out = new(CoreConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Receivers != nil {
+		in, out := &in.Receivers, &out.Receivers
+		*out = new(OtelCollectorReceiversConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Processors != nil {
+		in, out := &in.Processors, &out.Processors
+		*out = new(OtelCollectorProcessorsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Exporters != nil {
+		in, out := &in.Exporters, &out.Exporters
+		*out = new(OtelCollectorExportersConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pipelines != nil {
+		in, out := &in.Pipelines, &out.Pipelines
+		*out = make([]OtelCollectorPipeline, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtelCollectorFeatureConfig.
+func (in *OtelCollectorFeatureConfig) DeepCopy() *OtelCollectorFeatureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OtelCollectorFeatureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OtelCollectorInfraAttributesProcessorConfig) DeepCopyInto(out *OtelCollectorInfraAttributesProcessorConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Cardinality != nil {
+		in, out := &in.Cardinality, &out.Cardinality
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtelCollectorInfraAttributesProcessorConfig.
+func (in *OtelCollectorInfraAttributesProcessorConfig) DeepCopy() *OtelCollectorInfraAttributesProcessorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OtelCollectorInfraAttributesProcessorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OtelCollectorK8sAttributesProcessorConfig) DeepCopyInto(out *OtelCollectorK8sAttributesProcessorConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtelCollectorK8sAttributesProcessorConfig.
+func (in *OtelCollectorK8sAttributesProcessorConfig) DeepCopy() *OtelCollectorK8sAttributesProcessorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OtelCollectorK8sAttributesProcessorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OtelCollectorOTLPProtocolConfig) DeepCopyInto(out *OtelCollectorOTLPProtocolConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtelCollectorOTLPProtocolConfig.
+func (in *OtelCollectorOTLPProtocolConfig) DeepCopy() *OtelCollectorOTLPProtocolConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OtelCollectorOTLPProtocolConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OtelCollectorOTLPReceiverConfig) DeepCopyInto(out *OtelCollectorOTLPReceiverConfig) {
+	*out = *in
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(OtelCollectorOTLPProtocolConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(OtelCollectorOTLPProtocolConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtelCollectorOTLPReceiverConfig.
+func (in *OtelCollectorOTLPReceiverConfig) DeepCopy() *OtelCollectorOTLPReceiverConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OtelCollectorOTLPReceiverConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OtelCollectorPipeline) DeepCopyInto(out *OtelCollectorPipeline) {
+	*out = *in
+	if in.Receivers != nil {
+		in, out := &in.Receivers, &out.Receivers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Processors != nil {
+		in, out := &in.Processors, &out.Processors
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.DDUrl != nil {
-		in, out := &in.DDUrl, &out.DDUrl
-		*out = new(string)
-		**out = **in
+	if in.Exporters != nil {
+		in, out := &in.Exporters, &out.Exporters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrchestratorExplorerFeatureConfig.
-func (in *OrchestratorExplorerFeatureConfig) DeepCopy() *OrchestratorExplorerFeatureConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtelCollectorPipeline.
+func (in *OtelCollectorPipeline) DeepCopy() *OtelCollectorPipeline {
 	if in == nil {
 		return nil
 	}
-	out := new(OrchestratorExplorerFeatureConfig)
+	out := new(OtelCollectorPipeline)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OriginDetectionUnified) DeepCopyInto(out *OriginDetectionUnified) {
+func (in *OtelCollectorProcessorsConfig) DeepCopyInto(out *OtelCollectorProcessorsConfig) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
+	if in.Batch != nil {
+		in, out := &in.Batch, &out.Batch
+		*out = new(OtelCollectorBatchProcessorConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.K8sAttributes != nil {
+		in, out := &in.K8sAttributes, &out.K8sAttributes
+		*out = new(OtelCollectorK8sAttributesProcessorConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InfraAttributes != nil {
+		in, out := &in.InfraAttributes, &out.InfraAttributes
+		*out = new(OtelCollectorInfraAttributesProcessorConfig)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OriginDetectionUnified.
-func (in *OriginDetectionUnified) DeepCopy() *OriginDetectionUnified {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtelCollectorProcessorsConfig.
+func (in *OtelCollectorProcessorsConfig) DeepCopy() *OtelCollectorProcessorsConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(OriginDetectionUnified)
+	out := new(OtelCollectorProcessorsConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OtelCollectorFeatureConfig) DeepCopyInto(out *OtelCollectorFeatureConfig) {
+func (in *OtelCollectorPrometheusReceiverConfig) DeepCopyInto(out *OtelCollectorPrometheusReceiverConfig) {
 	*out = *in
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
-	if in.Conf != nil {
-		in, out := &in.Conf, &out.Conf
-		*out = new(CustomConfig)
-		(*in).DeepCopyInto(*out)
+	if in.ScrapeInterval != nil {
+		in, out := &in.ScrapeInterval, &out.ScrapeInterval
+		*out = new(v1.Duration)
+		**out = **in
 	}
-	if in.Ports != nil {
-		in, out := &in.Ports, &out.Ports
-		*out = make([]*corev1.ContainerPort, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(corev1.ContainerPort)
-				**out = **in
-			}
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtelCollectorPrometheusReceiverConfig.
+func (in *OtelCollectorPrometheusReceiverConfig) DeepCopy() *OtelCollectorPrometheusReceiverConfig {
+	if in == nil {
+		return nil
 	}
-	if in.CoreConfig != nil {
-		in, out := &in.CoreConfig, &out.CoreConfig
-		*out = new(CoreConfig)
+	out := new(OtelCollectorPrometheusReceiverConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OtelCollectorReceiversConfig) DeepCopyInto(out *OtelCollectorReceiversConfig) {
+	*out = *in
+	if in.OTLP != nil {
+		in, out := &in.OTLP, &out.OTLP
+		*out = new(OtelCollectorOTLPReceiverConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(OtelCollectorPrometheusReceiverConfig)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtelCollectorFeatureConfig.
-func (in *OtelCollectorFeatureConfig) DeepCopy() *OtelCollectorFeatureConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtelCollectorReceiversConfig.
+func (in *OtelCollectorReceiversConfig) DeepCopy() *OtelCollectorReceiversConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(OtelCollectorFeatureConfig)
+	out := new(OtelCollectorReceiversConfig)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2525,6 +3627,40 @@ func (in *Profile) DeepCopy() *Profile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusScrapeAutodiscovery) DeepCopyInto(out *PrometheusScrapeAutodiscovery) {
+	*out = *in
+	if in.IncludeAnnotations != nil {
+		in, out := &in.IncludeAnnotations, &out.IncludeAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExcludeAnnotations != nil {
+		in, out := &in.ExcludeAnnotations, &out.ExcludeAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ContainerNames != nil {
+		in, out := &in.ContainerNames, &out.ContainerNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusScrapeAutodiscovery.
+func (in *PrometheusScrapeAutodiscovery) DeepCopy() *PrometheusScrapeAutodiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusScrapeAutodiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrometheusScrapeFeatureConfig) DeepCopyInto(out *PrometheusScrapeFeatureConfig) {
 	*out = *in
@@ -2548,6 +3684,13 @@ func (in *PrometheusScrapeFeatureConfig) DeepCopyInto(out *PrometheusScrapeFeatu
 		*out = new(int)
 		**out = **in
 	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]PrometheusScrapeRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusScrapeFeatureConfig.
@@ -2560,6 +3703,48 @@ func (in *PrometheusScrapeFeatureConfig) DeepCopy() *PrometheusScrapeFeatureConf
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusScrapeRule) DeepCopyInto(out *PrometheusScrapeRule) {
+	*out = *in
+	if in.Autodiscovery != nil {
+		in, out := &in.Autodiscovery, &out.Autodiscovery
+		*out = new(PrometheusScrapeAutodiscovery)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeMetrics != nil {
+		in, out := &in.ExcludeMetrics, &out.ExcludeMetrics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RenameMetrics != nil {
+		in, out := &in.RenameMetrics, &out.RenameMetrics
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusScrapeRule.
+func (in *PrometheusScrapeRule) DeepCopy() *PrometheusScrapeRule {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusScrapeRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoteConfigConfiguration) DeepCopyInto(out *RemoteConfigConfiguration) {
 	*out = *in
@@ -2600,6 +3785,125 @@ func (in *RemoteConfigurationFeatureConfig) DeepCopy() *RemoteConfigurationFeatu
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRecommendationsConfig) DeepCopyInto(out *ResourceRecommendationsConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Apply != nil {
+		in, out := &in.Apply, &out.Apply
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecommendationsConfig.
+func (in *ResourceRecommendationsConfig) DeepCopy() *ResourceRecommendationsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRecommendationsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRecommendationsStatus) DeepCopyInto(out *ResourceRecommendationsStatus) {
+	*out = *in
+	if in.LastUpdate != nil {
+		in, out := &in.LastUpdate, &out.LastUpdate
+		*out = (*in).DeepCopy()
+	}
+	if in.DaemonSets != nil {
+		in, out := &in.DaemonSets, &out.DaemonSets
+		*out = make([]DaemonSetResourceRecommendation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecommendationsStatus.
+func (in *ResourceRecommendationsStatus) DeepCopy() *ResourceRecommendationsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRecommendationsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollbackStatus) DeepCopyInto(out *RollbackStatus) {
+	*out = *in
+	if in.LastRollbackTime != nil {
+		in, out := &in.LastRollbackTime, &out.LastRollbackTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollbackStatus.
+func (in *RollbackStatus) DeepCopy() *RollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	if in.LastWaveCompletionTime != nil {
+		in, out := &in.LastWaveCompletionTime, &out.LastWaveCompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutWave) DeepCopyInto(out *RolloutWave) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxNodes != nil {
+		in, out := &in.MaxNodes, &out.MaxNodes
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Pause != nil {
+		in, out := &in.Pause, &out.Pause
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutWave.
+func (in *RolloutWave) DeepCopy() *RolloutWave {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutWave)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SBOMContainerImageConfig) DeepCopyInto(out *SBOMContainerImageConfig) {
 	*out = *in
@@ -2719,6 +4023,31 @@ func (in *SSITarget) DeepCopy() *SSITarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSITargetStatus) DeepCopyInto(out *SSITargetStatus) {
+	*out = *in
+	if in.OverlappingTargets != nil {
+		in, out := &in.OverlappingTargets, &out.OverlappingTargets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InvalidTracerVersions != nil {
+		in, out := &in.InvalidTracerVersions, &out.InvalidTracerVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSITargetStatus.
+func (in *SSITargetStatus) DeepCopy() *SSITargetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SSITargetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SeccompConfig) DeepCopyInto(out *SeccompConfig) {
 	*out = *in
@@ -2732,6 +4061,11 @@ func (in *SeccompConfig) DeepCopyInto(out *SeccompConfig) {
 		*out = new(CustomConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SecurityProfilesOperator != nil {
+		in, out := &in.SecurityProfilesOperator, &out.SecurityProfilesOperator
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeccompConfig.
@@ -2959,6 +4293,48 @@ func (in *SingleStepInstrumentation) DeepCopy() *SingleStepInstrumentation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StagedRolloutConfig) DeepCopyInto(out *StagedRolloutConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Waves != nil {
+		in, out := &in.Waves, &out.Waves
+		*out = make([]RolloutWave, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxRestarts != nil {
+		in, out := &in.MaxRestarts, &out.MaxRestarts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProgressDeadline != nil {
+		in, out := &in.ProgressDeadline, &out.ProgressDeadline
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StagedRolloutConfig.
+func (in *StagedRolloutConfig) DeepCopy() *StagedRolloutConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(StagedRolloutConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TCPQueueLengthFeatureConfig) DeepCopyInto(out *TCPQueueLengthFeatureConfig) {
 	*out = *in
@@ -0,0 +1,271 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v2beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/api/utils"
+)
+
+const (
+	// ConversionDataAnnotationKey is the annotation storing the v2alpha1 fields that v2beta1 cannot represent,
+	// so that converting a DatadogAgent from v2alpha1 to v2beta1 and back is lossless.
+	ConversionDataAnnotationKey = "datadoghq.com/v2alpha1-conversion-data"
+
+	jmxTagSuffix = "-jmx"
+)
+
+// v2alpha1ConversionData contains the v2alpha1 fields that v2beta1 cannot represent
+type v2alpha1ConversionData struct {
+	UseFIPSAgent *bool `json:"useFIPSAgent,omitempty"`
+	FIPSEnabled  *bool `json:"fipsEnabled,omitempty"`
+	// FIPSUnset is true if `global.fips` was not set
+	FIPSUnset bool `json:"fipsUnset,omitempty"`
+	// Images contains the images using `jmxEnabled`, indexed by their path in the spec
+	Images map[string]v2alpha1ImageData `json:"images,omitempty"`
+}
+
+type v2alpha1ImageData struct {
+	Tag        string `json:"tag,omitempty"`
+	JMXEnabled bool   `json:"jmxEnabled,omitempty"`
+}
+
+// fipsFields contains the v2alpha1 FIPS fields replaced by `global.fips.mode`
+type fipsFields struct {
+	useFIPSAgent *bool
+	hasFIPS      bool
+	fipsEnabled  *bool
+}
+
+var _ conversion.Convertible = &DatadogAgent{}
+
+// ConvertTo converts this DatadogAgent to the hub version (v2alpha1).
+func (src *DatadogAgent) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v2alpha1.DatadogAgent)
+	if !ok {
+		return fmt.Errorf("unsupported conversion hub type %T", dstRaw)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = v2alpha1.DatadogAgentSpec{}
+	if err := convertJSON(&src.Spec, &dst.Spec); err != nil {
+		return fmt.Errorf("unable to convert DatadogAgent spec: %w", err)
+	}
+	dst.Status = v2alpha1.DatadogAgentStatus{}
+	if err := convertJSON(&src.Status, &dst.Status); err != nil {
+		return fmt.Errorf("unable to convert DatadogAgent status: %w", err)
+	}
+
+	if src.Spec.Global != nil {
+		fips := fipsToV2alpha1(src.Spec.Global.FIPS)
+		dst.Spec.Global.UseFIPSAgent = fips.useFIPSAgent
+		if dst.Spec.Global.FIPS != nil {
+			dst.Spec.Global.FIPS.Enabled = fips.fipsEnabled
+		}
+	}
+
+	return restoreV2alpha1Data(src, dst)
+}
+
+// ConvertFrom converts from the hub version (v2alpha1) to this version.
+func (dst *DatadogAgent) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v2alpha1.DatadogAgent)
+	if !ok {
+		return fmt.Errorf("unsupported conversion hub type %T", srcRaw)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = DatadogAgentSpec{}
+	if err := convertJSON(&src.Spec, &dst.Spec); err != nil {
+		return fmt.Errorf("unable to convert DatadogAgent spec: %w", err)
+	}
+	dst.Status = DatadogAgentStatus{}
+	if err := convertJSON(&src.Status, &dst.Status); err != nil {
+		return fmt.Errorf("unable to convert DatadogAgent status: %w", err)
+	}
+
+	data := v2alpha1ConversionData{}
+	if src.Spec.Global != nil {
+		fips := fipsFields{useFIPSAgent: src.Spec.Global.UseFIPSAgent, hasFIPS: src.Spec.Global.FIPS != nil}
+		if src.Spec.Global.FIPS != nil {
+			fips.fipsEnabled = src.Spec.Global.FIPS.Enabled
+		}
+		if mode := fips.mode(); mode != nil {
+			if dst.Spec.Global.FIPS == nil {
+				dst.Spec.Global.FIPS = &FIPSConfig{}
+			}
+			dst.Spec.Global.FIPS.Mode = mode
+		}
+		if !reflect.DeepEqual(fipsToV2alpha1(dst.Spec.Global.FIPS), fips) {
+			data.UseFIPSAgent = fips.useFIPSAgent
+			data.FIPSEnabled = fips.fipsEnabled
+			data.FIPSUnset = !fips.hasFIPS
+		}
+	}
+
+	dstImages := images(&dst.Spec)
+	for path, image := range v2alpha1Images(&src.Spec) {
+		if !image.JMXEnabled {
+			continue
+		}
+		imageData := v2alpha1ImageData{Tag: image.Tag, JMXEnabled: image.JMXEnabled}
+		dstImages[path].Tag = imageData.v2beta1Tag()
+		if data.Images == nil {
+			data.Images = map[string]v2alpha1ImageData{}
+		}
+		data.Images[path] = imageData
+	}
+
+	if reflect.DeepEqual(data, v2alpha1ConversionData{}) {
+		delete(dst.Annotations, ConversionDataAnnotationKey)
+		return nil
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("unable to store the v2alpha1 fields: %w", err)
+	}
+	if dst.Annotations == nil {
+		dst.Annotations = map[string]string{}
+	}
+	dst.Annotations[ConversionDataAnnotationKey] = string(raw)
+	return nil
+}
+
+// restoreV2alpha1Data restores the v2alpha1 fields stored during a previous conversion to v2beta1,
+// if the corresponding v2beta1 fields were not modified since then.
+func restoreV2alpha1Data(src *DatadogAgent, dst *v2alpha1.DatadogAgent) error {
+	raw, found := dst.Annotations[ConversionDataAnnotationKey]
+	if !found {
+		return nil
+	}
+	delete(dst.Annotations, ConversionDataAnnotationKey)
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+
+	data := v2alpha1ConversionData{}
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		return fmt.Errorf("unable to parse the %s annotation: %w", ConversionDataAnnotationKey, err)
+	}
+
+	if dst.Spec.Global != nil && (data.UseFIPSAgent != nil || data.FIPSEnabled != nil || data.FIPSUnset) {
+		stored := fipsFields{useFIPSAgent: data.UseFIPSAgent, hasFIPS: !data.FIPSUnset, fipsEnabled: data.FIPSEnabled}
+		var currentMode *FIPSMode
+		if src.Spec.Global.FIPS != nil {
+			currentMode = src.Spec.Global.FIPS.Mode
+		}
+		if reflect.DeepEqual(stored.mode(), currentMode) {
+			dst.Spec.Global.UseFIPSAgent = data.UseFIPSAgent
+			if dst.Spec.Global.FIPS != nil {
+				dst.Spec.Global.FIPS.Enabled = data.FIPSEnabled
+				if data.FIPSUnset && reflect.DeepEqual(*dst.Spec.Global.FIPS, v2alpha1.FIPSConfig{}) {
+					dst.Spec.Global.FIPS = nil
+				}
+			}
+		}
+	}
+
+	dstImages := v2alpha1Images(&dst.Spec)
+	for path, imageData := range data.Images {
+		image, found := dstImages[path]
+		if !found || image.Tag != imageData.v2beta1Tag() {
+			continue
+		}
+		image.Tag = imageData.Tag
+		image.JMXEnabled = imageData.JMXEnabled
+	}
+	return nil
+}
+
+// mode returns the v2beta1 FIPS mode corresponding to the v2alpha1 FIPS fields.
+// As documented in v2alpha1, the FIPS flavor of the Agent takes precedence over the FIPS proxy.
+func (f fipsFields) mode() *FIPSMode {
+	if utils.BoolValue(f.useFIPSAgent) {
+		return utils.NewPointer(FIPSModeAgent)
+	}
+	if utils.BoolValue(f.fipsEnabled) {
+		return utils.NewPointer(FIPSModeProxy)
+	}
+	return nil
+}
+
+// fipsToV2alpha1 returns the v2alpha1 FIPS fields corresponding to a v2beta1 FIPS configuration
+func fipsToV2alpha1(fips *FIPSConfig) fipsFields {
+	if fips == nil {
+		return fipsFields{}
+	}
+	out := fipsFields{hasFIPS: true}
+	if fips.Mode != nil {
+		switch *fips.Mode {
+		case FIPSModeAgent:
+			out.useFIPSAgent = utils.NewBoolPointer(true)
+		case FIPSModeProxy:
+			out.fipsEnabled = utils.NewBoolPointer(true)
+		}
+	}
+	return out
+}
+
+// v2beta1Tag returns the v2beta1 tag of a v2alpha1 image: the JMX flavor is selected with the tag suffix.
+// An empty tag stays empty, the JMX flavor of the default tag cannot be represented in v2beta1.
+func (image v2alpha1ImageData) v2beta1Tag() string {
+	if !image.JMXEnabled || image.Tag == "" || strings.HasSuffix(image.Tag, jmxTagSuffix) {
+		return image.Tag
+	}
+	return image.Tag + jmxTagSuffix
+}
+
+// v2alpha1Images returns the images configured in a v2alpha1 DatadogAgent spec, indexed by their path
+func v2alpha1Images(spec *v2alpha1.DatadogAgentSpec) map[string]*v2alpha1.AgentImageConfig {
+	out := map[string]*v2alpha1.AgentImageConfig{}
+	if spec.Global != nil && spec.Global.FIPS != nil && spec.Global.FIPS.Image != nil {
+		out["global.fips.image"] = spec.Global.FIPS.Image
+	}
+	if spec.Features != nil && spec.Features.AdmissionController != nil && spec.Features.AdmissionController.AgentSidecarInjection != nil &&
+		spec.Features.AdmissionController.AgentSidecarInjection.Image != nil {
+		out["features.admissionController.agentSidecarInjection.image"] = spec.Features.AdmissionController.AgentSidecarInjection.Image
+	}
+	for name, override := range spec.Override {
+		if override != nil && override.Image != nil {
+			out[fmt.Sprintf("override.%s.image", name)] = override.Image
+		}
+	}
+	return out
+}
+
+// images returns the images configured in a v2beta1 DatadogAgent spec, indexed by their path
+func images(spec *DatadogAgentSpec) map[string]*AgentImageConfig {
+	out := map[string]*AgentImageConfig{}
+	if spec.Global != nil && spec.Global.FIPS != nil && spec.Global.FIPS.Image != nil {
+		out["global.fips.image"] = spec.Global.FIPS.Image
+	}
+	if spec.Features != nil && spec.Features.AdmissionController != nil && spec.Features.AdmissionController.AgentSidecarInjection != nil &&
+		spec.Features.AdmissionController.AgentSidecarInjection.Image != nil {
+		out["features.admissionController.agentSidecarInjection.image"] = spec.Features.AdmissionController.AgentSidecarInjection.Image
+	}
+	for name, override := range spec.Override {
+		if override != nil && override.Image != nil {
+			out[fmt.Sprintf("override.%s.image", name)] = override.Image
+		}
+	}
+	return out
+}
+
+// convertJSON converts between the versions of a type sharing the same JSON representation
+func convertJSON(in, out interface{}) error {
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, out)
+}
@@ -0,0 +1,195 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v2beta1
+
+import (
+	"fmt"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/diff"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/api/utils"
+)
+
+const fuzzIterations = 200
+
+func newFuzzer(seed int64) *fuzz.Fuzzer {
+	return fuzz.NewWithSeed(seed).
+		NilChance(0.3).
+		NumElements(1, 2).
+		MaxDepth(12).
+		Funcs(
+			// The following types don't keep the value generated by the fuzzer when marshalled to JSON
+			func(q *resource.Quantity, c fuzz.Continue) {
+				*q = *resource.NewQuantity(c.Int63n(1000), resource.DecimalSI)
+			},
+			func(t *metav1.Time, c fuzz.Continue) {
+				*t = metav1.Unix(c.Int63n(1000000000), 0)
+			},
+			func(i *intstr.IntOrString, c fuzz.Continue) {
+				if c.RandBool() {
+					*i = intstr.FromInt32(c.Int31())
+				} else {
+					*i = intstr.FromString(c.RandString())
+				}
+			},
+			// A pointer to a nil slice is marshalled to null, which is unmarshalled to a nil pointer
+			func(s *[]corev1.LocalObjectReference, c fuzz.Continue) {
+				*s = make([]corev1.LocalObjectReference, 1+c.Intn(2))
+				for i := range *s {
+					c.Fuzz(&(*s)[i])
+				}
+			},
+			// The FIPS mode is validated by the CRD schema
+			func(m *FIPSMode, c fuzz.Continue) {
+				*m = []FIPSMode{FIPSModeAgent, FIPSModeProxy}[c.Intn(2)]
+			},
+		)
+}
+
+func TestDatadogAgentConversion_HubSpokeHub(t *testing.T) {
+	for i := int64(0); i < fuzzIterations; i++ {
+		t.Run(fmt.Sprintf("seed %d", i), func(t *testing.T) {
+			hub := &v2alpha1.DatadogAgent{}
+			newFuzzer(i).Fuzz(hub)
+			hub.TypeMeta = metav1.TypeMeta{}
+
+			spoke := &DatadogAgent{}
+			require.NoError(t, spoke.ConvertFrom(hub.DeepCopy()))
+			got := &v2alpha1.DatadogAgent{}
+			require.NoError(t, spoke.ConvertTo(got))
+
+			assert.True(t, apiequality.Semantic.DeepEqual(hub, got), "round trip mismatch: %s", diff.ObjectReflectDiff(hub, got))
+		})
+	}
+}
+
+func TestDatadogAgentConversion_SpokeHubSpoke(t *testing.T) {
+	for i := int64(0); i < fuzzIterations; i++ {
+		t.Run(fmt.Sprintf("seed %d", i), func(t *testing.T) {
+			spoke := &DatadogAgent{}
+			newFuzzer(i).Fuzz(spoke)
+			spoke.TypeMeta = metav1.TypeMeta{}
+
+			hub := &v2alpha1.DatadogAgent{}
+			require.NoError(t, spoke.DeepCopy().ConvertTo(hub))
+			got := &DatadogAgent{}
+			require.NoError(t, got.ConvertFrom(hub))
+
+			assert.True(t, apiequality.Semantic.DeepEqual(spoke, got), "round trip mismatch: %s", diff.ObjectReflectDiff(spoke, got))
+		})
+	}
+}
+
+func TestDatadogAgentConversion_FIPS(t *testing.T) {
+	tests := []struct {
+		name            string
+		useFIPSAgent    *bool
+		fips            *v2alpha1.FIPSConfig
+		wantFIPS        *FIPSConfig
+		wantAnnotations bool
+	}{
+		{
+			name: "FIPS disabled",
+		},
+		{
+			name:         "FIPS agent",
+			useFIPSAgent: utils.NewBoolPointer(true),
+			fips:         &v2alpha1.FIPSConfig{},
+			wantFIPS:     &FIPSConfig{Mode: utils.NewPointer(FIPSModeAgent)},
+		},
+		{
+			name:     "FIPS proxy",
+			fips:     &v2alpha1.FIPSConfig{Enabled: utils.NewBoolPointer(true), Port: utils.NewPointer[int32](9000)},
+			wantFIPS: &FIPSConfig{Mode: utils.NewPointer(FIPSModeProxy), Port: utils.NewPointer[int32](9000)},
+		},
+		{
+			name:            "FIPS agent takes precedence over the FIPS proxy",
+			useFIPSAgent:    utils.NewBoolPointer(true),
+			fips:            &v2alpha1.FIPSConfig{Enabled: utils.NewBoolPointer(true)},
+			wantFIPS:        &FIPSConfig{Mode: utils.NewPointer(FIPSModeAgent)},
+			wantAnnotations: true,
+		},
+		{
+			name:            "FIPS explicitly disabled",
+			useFIPSAgent:    utils.NewBoolPointer(false),
+			fips:            &v2alpha1.FIPSConfig{Enabled: utils.NewBoolPointer(false)},
+			wantFIPS:        &FIPSConfig{},
+			wantAnnotations: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := &v2alpha1.DatadogAgent{
+				Spec: v2alpha1.DatadogAgentSpec{
+					Global: &v2alpha1.GlobalConfig{UseFIPSAgent: tt.useFIPSAgent, FIPS: tt.fips},
+				},
+			}
+			spoke := &DatadogAgent{}
+			require.NoError(t, spoke.ConvertFrom(hub))
+
+			assert.Equal(t, tt.wantFIPS, spoke.Spec.Global.FIPS)
+			_, found := spoke.Annotations[ConversionDataAnnotationKey]
+			assert.Equal(t, tt.wantAnnotations, found)
+		})
+	}
+}
+
+func TestDatadogAgentConversion_JMX(t *testing.T) {
+	tests := []struct {
+		name    string
+		image   *v2alpha1.AgentImageConfig
+		wantTag string
+	}{
+		{
+			name:    "JMX disabled",
+			image:   &v2alpha1.AgentImageConfig{Tag: "7.60.0"},
+			wantTag: "7.60.0",
+		},
+		{
+			name:    "JMX enabled",
+			image:   &v2alpha1.AgentImageConfig{Tag: "7.60.0", JMXEnabled: true},
+			wantTag: "7.60.0-jmx",
+		},
+		{
+			name:    "JMX enabled, tag with the JMX suffix",
+			image:   &v2alpha1.AgentImageConfig{Tag: "7.60.0-jmx", JMXEnabled: true},
+			wantTag: "7.60.0-jmx",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := &v2alpha1.DatadogAgent{
+				Spec: v2alpha1.DatadogAgentSpec{
+					Override: map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride{
+						v2alpha1.NodeAgentComponentName: {Image: tt.image},
+					},
+				},
+			}
+			spoke := &DatadogAgent{}
+			require.NoError(t, spoke.ConvertFrom(hub))
+			assert.Equal(t, tt.wantTag, spoke.Spec.Override[v2alpha1.NodeAgentComponentName].Image.Tag)
+
+			// The tag updated in v2beta1 takes precedence over the stored v2alpha1 fields
+			spoke.Spec.Override[v2alpha1.NodeAgentComponentName].Image.Tag = "7.61.0"
+			got := &v2alpha1.DatadogAgent{}
+			require.NoError(t, spoke.ConvertTo(got))
+			assert.Equal(t, &v2alpha1.AgentImageConfig{Tag: "7.61.0"}, got.Spec.Override[v2alpha1.NodeAgentComponentName].Image)
+			assert.Empty(t, got.Annotations)
+		})
+	}
+}
@@ -0,0 +1,1092 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v2beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+// DatadogAgentSpec defines the desired state of DatadogAgent
+type DatadogAgentSpec struct {
+	// Features running on the Agent and Cluster Agent
+	// +optional
+	Features *DatadogFeatures `json:"features,omitempty"`
+
+	// Global settings to configure the agents
+	// +optional
+	Global *GlobalConfig `json:"global,omitempty"`
+
+	// Override the default configurations of the agents
+	// +optional
+	Override map[v2alpha1.ComponentName]*DatadogAgentComponentOverride `json:"override,omitempty"`
+}
+
+// DatadogFeatures are features running on the Agent and Cluster Agent.
+// +k8s:openapi-gen=true
+type DatadogFeatures struct {
+	// Application-level features
+
+	// OtelCollector configuration.
+	OtelCollector *OtelCollectorFeatureConfig `json:"otelCollector,omitempty"`
+	// LogCollection configuration.
+	LogCollection *v2alpha1.LogCollectionFeatureConfig `json:"logCollection,omitempty"`
+	// LiveProcessCollection configuration.
+	LiveProcessCollection *v2alpha1.LiveProcessCollectionFeatureConfig `json:"liveProcessCollection,omitempty"`
+	// LiveContainerCollection configuration.
+	LiveContainerCollection *v2alpha1.LiveContainerCollectionFeatureConfig `json:"liveContainerCollection,omitempty"`
+	// ProcessDiscovery configuration.
+	ProcessDiscovery *v2alpha1.ProcessDiscoveryFeatureConfig `json:"processDiscovery,omitempty"`
+	// OOMKill configuration.
+	OOMKill *v2alpha1.OOMKillFeatureConfig `json:"oomKill,omitempty"`
+	// TCPQueueLength configuration.
+	TCPQueueLength *v2alpha1.TCPQueueLengthFeatureConfig `json:"tcpQueueLength,omitempty"`
+	// EBPFCheck configuration.
+	EBPFCheck *v2alpha1.EBPFCheckFeatureConfig `json:"ebpfCheck,omitempty"`
+	// APM (Application Performance Monitoring) configuration.
+	APM *v2alpha1.APMFeatureConfig `json:"apm,omitempty"`
+	// ASM (Application Security Management) configuration.
+	ASM *v2alpha1.ASMFeatureConfig `json:"asm,omitempty"`
+	// CSPM (Cloud Security Posture Management) configuration.
+	CSPM *CSPMFeatureConfig `json:"cspm,omitempty"`
+	// CWS (Cloud Workload Security) configuration.
+	CWS *CWSFeatureConfig `json:"cws,omitempty"`
+	// NPM (Network Performance Monitoring) configuration.
+	NPM *v2alpha1.NPMFeatureConfig `json:"npm,omitempty"`
+	// USM (Universal Service Monitoring) configuration.
+	USM *v2alpha1.USMFeatureConfig `json:"usm,omitempty"`
+	// Dogstatsd configuration.
+	Dogstatsd *DogstatsdFeatureConfig `json:"dogstatsd,omitempty"`
+	// DatadogChecks configuration.
+	DatadogChecks *v2alpha1.DatadogChecksFeatureConfig `json:"datadogChecks,omitempty"`
+	// OTLP ingest configuration
+	OTLP *v2alpha1.OTLPFeatureConfig `json:"otlp,omitempty"`
+	// Remote Configuration configuration.
+	RemoteConfiguration *v2alpha1.RemoteConfigurationFeatureConfig `json:"remoteConfiguration,omitempty"`
+	// SBOM collection configuration.
+	SBOM *v2alpha1.SBOMFeatureConfig `json:"sbom,omitempty"`
+	// ServiceDiscovery
+	ServiceDiscovery *v2alpha1.ServiceDiscoveryFeatureConfig `json:"serviceDiscovery,omitempty"`
+	// GPU monitoring
+	GPU *v2alpha1.GPUFeatureConfig `json:"gpu,omitempty"`
+
+	// Cluster-level features
+
+	// EventCollection configuration.
+	EventCollection *v2alpha1.EventCollectionFeatureConfig `json:"eventCollection,omitempty"`
+	// OrchestratorExplorer check configuration.
+	OrchestratorExplorer *OrchestratorExplorerFeatureConfig `json:"orchestratorExplorer,omitempty"`
+	// KubeStateMetricsCore check configuration.
+	KubeStateMetricsCore *KubeStateMetricsCoreFeatureConfig `json:"kubeStateMetricsCore,omitempty"`
+	// AdmissionController configuration.
+	AdmissionController *AdmissionControllerFeatureConfig `json:"admissionController,omitempty"`
+	// ExternalMetricsServer configuration.
+	ExternalMetricsServer *v2alpha1.ExternalMetricsServerFeatureConfig `json:"externalMetricsServer,omitempty"`
+	// Autoscaling configuration.
+	Autoscaling *v2alpha1.AutoscalingFeatureConfig `json:"autoscaling,omitempty"`
+	// ClusterChecks configuration.
+	ClusterChecks *v2alpha1.ClusterChecksFeatureConfig `json:"clusterChecks,omitempty"`
+	// PrometheusScrape configuration.
+	PrometheusScrape *v2alpha1.PrometheusScrapeFeatureConfig `json:"prometheusScrape,omitempty"`
+	// HelmCheck configuration.
+	HelmCheck *v2alpha1.HelmCheckFeatureConfig `json:"helmCheck,omitempty"`
+	// OtelAgentGateway configuration.
+	OtelAgentGateway *OtelAgentGatewayFeatureConfig `json:"otelAgentGateway,omitempty"`
+}
+
+// Configuration structs for each feature in DatadogFeatures. All parameters are optional and have default values when necessary.
+// Note: configuration in DatadogAgentSpec.Override takes precedence.
+
+// CSPMFeatureConfig contains CSPM (Cloud Security Posture Management) configuration.
+// CSPM runs in the Security Agent and Cluster Agent.
+type CSPMFeatureConfig struct {
+	// Enabled enables Cloud Security Posture Management.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// CheckInterval defines the check interval.
+	// +optional
+	CheckInterval *metav1.Duration `json:"checkInterval,omitempty"`
+
+	// CustomBenchmarks contains CSPM benchmarks.
+	// The content of the ConfigMap will be merged with the benchmarks bundled with the agent.
+	// Any benchmarks with the same name as those existing in the agent will take precedence.
+	// +optional
+	CustomBenchmarks *CustomConfig `json:"customBenchmarks,omitempty"`
+
+	// HostBenchmarks contains configuration for host benchmarks.
+	// +optional
+	HostBenchmarks *v2alpha1.CSPMHostBenchmarksConfig `json:"hostBenchmarks,omitempty"`
+}
+
+// CWSFeatureConfig contains CWS (Cloud Workload Security) configuration.
+// CWS runs in the Security Agent.
+type CWSFeatureConfig struct {
+	// Enabled enables Cloud Workload Security.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// SyscallMonitorEnabled enables Syscall Monitoring (recommended for troubleshooting only).
+	// Default: false
+	// +optional
+	SyscallMonitorEnabled *bool `json:"syscallMonitorEnabled,omitempty"`
+
+	Network             *v2alpha1.CWSNetworkConfig             `json:"network,omitempty"`
+	SecurityProfiles    *v2alpha1.CWSSecurityProfilesConfig    `json:"securityProfiles,omitempty"`
+	RemoteConfiguration *v2alpha1.CWSRemoteConfigurationConfig `json:"remoteConfiguration,omitempty"`
+
+	// CustomPolicies contains security policies.
+	// The content of the ConfigMap will be merged with the policies bundled with the agent.
+	// Any policies with the same name as those existing in the agent will take precedence.
+	// +optional
+	CustomPolicies *CustomConfig `json:"customPolicies,omitempty"`
+}
+
+// DogstatsdFeatureConfig contains the Dogstatsd configuration parameters.
+// +k8s:openapi-gen=true
+type DogstatsdFeatureConfig struct {
+	// OriginDetectionEnabled enables origin detection for container tagging.
+	// See also: https://docs.datadoghq.com/developers/dogstatsd/unix_socket/#using-origin-detection-for-container-tagging
+	// +optional
+	OriginDetectionEnabled *bool `json:"originDetectionEnabled,omitempty"`
+
+	// TagCardinality configures tag cardinality for the metrics collected using origin detection (`low`, `orchestrator` or `high`).
+	// See also: https://docs.datadoghq.com/getting_started/tagging/assigning_tags/?tab=containerizedenvironments#environment-variables
+	// Cardinality default: low
+	// +optional
+	TagCardinality *string `json:"tagCardinality,omitempty"`
+
+	// HostPortConfig contains host port configuration.
+	// Enabled Default: false
+	// Port Default: 8125
+	// +optional
+	HostPortConfig *v2alpha1.HostPortConfig `json:"hostPortConfig,omitempty"`
+
+	// UnixDomainSocketConfig contains socket configuration.
+	// See also: https://docs.datadoghq.com/agent/kubernetes/apm/?tab=helm#agent-environment-variables
+	// Enabled Default: true
+	// Path Default: `/var/run/datadog/dsd.socket`
+	// +optional
+	UnixDomainSocketConfig *v2alpha1.UnixDomainSocketConfig `json:"unixDomainSocketConfig,omitempty"`
+
+	// Configure the Dogstasd Mapper Profiles.
+	// Can be passed as raw data or via a json encoded string in a config map.
+	// See also: https://docs.datadoghq.com/developers/dogstatsd/dogstatsd_mapper/
+	// +optional
+	MapperProfiles *CustomConfig `json:"mapperProfiles,omitempty"`
+
+	// NonLocalTraffic enables non-local traffic for Dogstatsd.
+	// Default: true
+	// +optional
+	NonLocalTraffic *bool `json:"nonLocalTraffic,omitempty"`
+}
+
+// OrchestratorExplorerFeatureConfig contains the Orchestrator Explorer check feature configuration.
+// The Orchestrator Explorer check runs in the Process and Cluster Agents (or Cluster Check Runners).
+// See also: https://docs.datadoghq.com/infrastructure/livecontainers/#kubernetes-resources
+// +k8s:openapi-gen=true
+type OrchestratorExplorerFeatureConfig struct {
+	// Enabled enables the Orchestrator Explorer.
+	// Default: true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Conf overrides the configuration for the default Orchestrator Explorer check.
+	// This must point to a ConfigMap containing a valid cluster check configuration.
+	// +optional
+	Conf *CustomConfig `json:"conf,omitempty"`
+
+	// ScrubContainers enables scrubbing of sensitive container data (passwords, tokens, etc. ).
+	// Default: true
+	// +optional
+	ScrubContainers *bool `json:"scrubContainers,omitempty"`
+
+	// `CustomResources` defines custom resources for the orchestrator explorer to collect.
+	// Each item should follow the convention `group/version/kind`. For example, `datadoghq.com/v1alpha1/datadogmetrics`.
+	// +optional
+	// +listType=set
+	CustomResources []string `json:"customResources,omitempty"`
+
+	// Additional tags to associate with the collected data in the form of `a b c`.
+	// This is a Cluster Agent option distinct from DD_TAGS that is used in the Orchestrator Explorer.
+	// +optional
+	// +listType=set
+	ExtraTags []string `json:"extraTags,omitempty"`
+
+	// Override the API endpoint for the Orchestrator Explorer.
+	// URL Default: "https://orchestrator.datadoghq.com".
+	// +optional
+	DDUrl *string `json:"ddUrl,omitempty"`
+}
+
+// KubeStateMetricsCoreFeatureConfig contains the Kube State Metrics Core check feature configuration.
+// The Kube State Metrics Core check runs in the Cluster Agent (or Cluster Check Runners).
+// See also: https://docs.datadoghq.com/integrations/kubernetes_state_core
+// +k8s:openapi-gen=true
+type KubeStateMetricsCoreFeatureConfig struct {
+	// Enabled enables Kube State Metrics Core.
+	// Default: true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Conf overrides the configuration for the default Kubernetes State Metrics Core check.
+	// This must point to a ConfigMap containing a valid cluster check configuration.
+	// +optional
+	Conf *CustomConfig `json:"conf,omitempty"`
+
+	// Collectors configures the resources collected by the check.
+	// Ignored when `conf` is set.
+	// +optional
+	Collectors *v2alpha1.KubeStateMetricsCoreCollectorsConfig `json:"collectors,omitempty"`
+
+	// LabelsAsTags maps, for each resource, the resource labels to the tags set on its metrics.
+	// For example: `{"pod": {"app": "app"}}`.
+	// Ignored when `conf` is set.
+	// +optional
+	LabelsAsTags map[string]map[string]string `json:"labelsAsTags,omitempty"`
+
+	// AnnotationsAsTags maps, for each resource, the resource annotations to the tags set on its metrics.
+	// Ignored when `conf` is set.
+	// +optional
+	AnnotationsAsTags map[string]map[string]string `json:"annotationsAsTags,omitempty"`
+
+	// Namespaces restricts the collection to the resources of these namespaces.
+	// Ignored when `conf` is set.
+	// Default: all namespaces
+	// +optional
+	// +listType=set
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// CustomResources configures metrics generated from the state of custom resources.
+	// Ignored when `conf` is set.
+	// +optional
+	// +listType=atomic
+	CustomResources []v2alpha1.KubeStateMetricsCoreCustomResource `json:"customResources,omitempty"`
+
+	// Sharding splits the check into several cluster check instances.
+	// Ignored when `conf` is set.
+	// +optional
+	Sharding *v2alpha1.KubeStateMetricsCoreShardingConfig `json:"sharding,omitempty"`
+}
+
+// OtelCollectorFeatureConfig contains the configuration for the otel-agent.
+// +k8s:openapi-gen=true
+type OtelCollectorFeatureConfig struct {
+	// Enabled enables the OTel Agent.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Conf overrides the configuration for the default Kubernetes State Metrics Core check.
+	// This must point to a ConfigMap containing a valid cluster check configuration.
+	// When passing a configmap, file name *must* be otel-config.yaml.
+	// +optional
+	Conf *CustomConfig `json:"conf,omitempty"`
+
+	// Ports contains the ports for the otel-agent.
+	// Defaults: otel-grpc:4317 / otel-http:4318. Note: setting 4317
+	// or 4318 manually is *only* supported if name match default names (otel-grpc, otel-http).
+	// If not, this will lead to a port conflict.
+	// This limitation will be lifted once annotations support is removed.
+	// +optional
+	// +listType=atomic
+	Ports []*corev1.ContainerPort `json:"ports,omitempty"`
+
+	// OTelCollector Config Relevant to the Core agent
+	// +optional
+	CoreConfig *v2alpha1.CoreConfig `json:"coreConfig,omitempty"`
+
+	// Receivers configures the receivers of the OTel Collector.
+	// Ignored when `conf` is set.
+	// +optional
+	Receivers *v2alpha1.OtelCollectorReceiversConfig `json:"receivers,omitempty"`
+
+	// Processors configures the processors of the OTel Collector.
+	// Ignored when `conf` is set.
+	// +optional
+	Processors *v2alpha1.OtelCollectorProcessorsConfig `json:"processors,omitempty"`
+
+	// Exporters configures the exporters of the OTel Collector.
+	// Ignored when `conf` is set.
+	// +optional
+	Exporters *v2alpha1.OtelCollectorExportersConfig `json:"exporters,omitempty"`
+
+	// Pipelines replaces the default pipelines of the OTel Collector.
+	// Ignored when `conf` is set.
+	// Default: `traces`, `metrics` and `logs` pipelines using all the enabled components
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Pipelines []v2alpha1.OtelCollectorPipeline `json:"pipelines,omitempty"`
+}
+
+// AdmissionControllerFeatureConfig contains the Admission Controller feature configuration.
+// The Admission Controller runs in the Cluster Agent.
+type AdmissionControllerFeatureConfig struct {
+	// Enabled enables the Admission Controller.
+	// Default: true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Validation contains Admission Controller validation configurations.
+	// +optional
+	Validation *v2alpha1.AdmissionControllerValidationConfig `json:"validation,omitempty"`
+
+	// Mutation contains Admission Controller mutation configurations.
+	// +optional
+	Mutation *v2alpha1.AdmissionControllerMutationConfig `json:"mutation,omitempty"`
+
+	// MutateUnlabelled enables config injection without the need of pod label 'admission.datadoghq.com/enabled="true"'.
+	// Default: false
+	// +optional
+	MutateUnlabelled *bool `json:"mutateUnlabelled,omitempty"`
+
+	// ServiceName corresponds to the webhook service name.
+	// +optional
+	ServiceName *string `json:"serviceName,omitempty"`
+
+	// AgentCommunicationMode corresponds to the mode used by the Datadog application libraries to communicate with the Agent.
+	// It can be "hostip", "service", or "socket".
+	// +optional
+	AgentCommunicationMode *string `json:"agentCommunicationMode,omitempty"`
+
+	// FailurePolicy determines how unrecognized and timeout errors are handled.
+	// +optional
+	FailurePolicy *string `json:"failurePolicy,omitempty"`
+
+	// WebhookName is a custom name for the MutatingWebhookConfiguration.
+	// Default: "datadog-webhook"
+	// +optional
+	WebhookName *string `json:"webhookName,omitempty"`
+
+	// AgentSidecarInjection contains Agent sidecar injection configurations.
+	// +optional
+	AgentSidecarInjection *AgentSidecarInjectionConfig `json:"agentSidecarInjection,omitempty"`
+
+	// Registry defines an image registry for the admission controller.
+	// +optional
+	Registry *string `json:"registry,omitempty"`
+
+	// KubernetesAdmissionEvents holds the Kubernetes Admission Events configuration.
+	// +optional
+	KubernetesAdmissionEvents *v2alpha1.KubernetesAdmissionEventsConfig `json:"kubernetesAdmissionEvents,omitempty"`
+
+	// CWSInstrumentation holds the CWS Instrumentation endpoint configuration
+	// +optional
+	CWSInstrumentation *v2alpha1.CWSInstrumentationConfig `json:"cwsInstrumentation,omitempty"`
+
+	// CertManager configures cert-manager to issue the certificate of the Admission Controller webhooks.
+	// When enabled, the Operator manages the webhook configurations instead of the Cluster Agent,
+	// which then does not need the permissions to create and update them.
+	// Cannot be enabled with operatorIssuedCertificate.
+	// +optional
+	CertManager *v2alpha1.AdmissionControllerCertManagerConfig `json:"certManager,omitempty"`
+
+	// OperatorIssuedCertificate configures the Operator to issue the certificate of the Admission Controller webhooks,
+	// without cert-manager. When enabled, the Operator manages the webhook configurations instead of the Cluster Agent,
+	// and renews the certificate before it expires.
+	// Cannot be enabled with certManager.
+	// +optional
+	OperatorIssuedCertificate *v2alpha1.AdmissionControllerOperatorIssuedCertificateConfig `json:"operatorIssuedCertificate,omitempty"`
+}
+
+type AgentSidecarInjectionConfig struct {
+	// Enabled enables Sidecar injections.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled"`
+
+	// ClusterAgentCommunicationEnabled enables communication between Agent sidecars and the Cluster Agent.
+	// Default : true
+	// +optional
+	ClusterAgentCommunicationEnabled *bool `json:"clusterAgentCommunicationEnabled,omitempty"`
+
+	// Provider is used to add infrastructure provider-specific configurations to the Agent sidecar.
+	// Currently only "fargate" is supported.
+	// To use the feature in other environments (including local testing) omit the config.
+	// See also: https://docs.datadoghq.com/integrations/eks_fargate
+	// +optional
+	Provider *string `json:"provider,omitempty"`
+
+	// Registry overrides the default registry for the sidecar Agent.
+	// +optional
+	Registry *string `json:"registry,omitempty"`
+
+	// Image overrides the default Agent image name and tag for the Agent sidecar.
+	// +optional
+	Image *AgentImageConfig `json:"image,omitempty"`
+
+	// Selectors define the pod selector for sidecar injection. Only one rule is supported.
+	// +optional
+	// +listType=atomic
+	Selectors []*v2alpha1.Selector `json:"selectors,omitempty"`
+
+	// Profiles define the sidecar configuration override. Only one profile is supported.
+	// +optional
+	// +listType=atomic
+	Profiles []*v2alpha1.Profile `json:"profiles,omitempty"`
+}
+
+// OtelAgentGatewayFeatureConfig contains the configuration of the OTel Agent Gateway.
+// The OTel Agent Gateway is a Deployment of OTel Agents receiving the telemetry of the node otel-agents
+// and of the applications, for instance to apply tail sampling or aggregations before sending it to Datadog.
+// +k8s:openapi-gen=true
+type OtelAgentGatewayFeatureConfig struct {
+	// Enabled enables the OTel Agent Gateway.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Conf overrides the configuration of the OTel Agent Gateway.
+	// When passing a configmap, file name *must* be otel-gateway-config.yaml.
+	// Default: OTLP receiver exporting to Datadog
+	// +optional
+	Conf *CustomConfig `json:"conf,omitempty"`
+
+	// Ports contains the ports of the OTel Agent Gateway, also exposed by its Service.
+	// Defaults: otel-grpc:4317 / otel-http:4318.
+	// +optional
+	// +listType=atomic
+	Ports []*corev1.ContainerPort `json:"ports,omitempty"`
+}
+
+// Generic support structs
+
+// CustomConfig provides a place for custom configuration of the Agent or Cluster Agent, corresponding to datadog.yaml,
+// system-probe.yaml, security-agent.yaml or datadog-cluster.yaml.
+// The configuration can be provided in the ConfigData field as raw data, or referenced in a ConfigMap.
+// Note: `ConfigData` and `ConfigMap` cannot be set together.
+// +k8s:openapi-gen=true
+// +kubebuilder:validation:XValidation:rule="!(has(self.configData) && has(self.configMap))",message="configData and configMap cannot be set together"
+type CustomConfig struct {
+	// ConfigData corresponds to the configuration file content.
+	ConfigData *string `json:"configData,omitempty"`
+
+	// ConfigMap references an existing ConfigMap with the configuration file content.
+	ConfigMap *v2alpha1.ConfigMapConfig `json:"configMap,omitempty"`
+}
+
+// MultiCustomConfig provides a place for custom configuration of the Agent or Cluster Agent, corresponding to /confd/*.yaml.
+// The configuration can be provided in the ConfigDataMap field as raw data, or referenced in a single ConfigMap.
+// Note: `ConfigDataMap` and `ConfigMap` cannot be set together.
+// +k8s:openapi-gen=true
+// +kubebuilder:validation:XValidation:rule="!(has(self.configDataMap) && has(self.configMap))",message="configDataMap and configMap cannot be set together"
+type MultiCustomConfig struct {
+	// ConfigDataMap corresponds to the content of the configuration files.
+	// The key should be the filename the contents get mounted to; for instance check.py or check.yaml.
+	ConfigDataMap map[string]string `json:"configDataMap,omitempty"`
+
+	// ConfigMap references an existing ConfigMap with the content of the configuration files.
+	ConfigMap *v2alpha1.ConfigMapConfig `json:"configMap,omitempty"`
+}
+
+// AgentImageConfig defines the agent container image config.
+// +kubebuilder:object:generate=true
+type AgentImageConfig struct {
+	// Defines the Agent image name for the pod. You can provide this as:
+	// * `<NAME>` - Use `agent` for the Datadog Agent, `cluster-agent` for the Datadog Cluster Agent, or `dogstatsd`
+	// for DogStatsD. The full image string is derived from `global.registry` and `[key].image.tag`.
+	// * `<NAME>:<TAG>` - For example, `agent:latest`. The registry is derived from `global.registry`. `[key].image.tag`
+	// is ignored.
+	// * `<REGISTRY>/<NAME>:<TAG>` - For example, `gcr.io/datadoghq/agent:latest`. If the full image string is specified
+	//   like this, then `global.registry` and `[key].image.tag` are ignored.
+	Name string `json:"name,omitempty"`
+
+	// Define the image tag to use.
+	// To be used if the `Name` field does not correspond to a full image string.
+	// Use a tag with the `-jmx` suffix, for example `7.64.0-jmx`, to run the Agent image supporting JMX.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// The Kubernetes pull policy:
+	// Use `Always`, `Never`, or `IfNotPresent`.
+	PullPolicy *corev1.PullPolicy `json:"pullPolicy,omitempty"`
+
+	// It is possible to specify Docker registry credentials.
+	// See https://kubernetes.io/docs/concepts/containers/images/#specifying-imagepullsecrets-on-a-pod
+	// +optional
+	PullSecrets *[]corev1.LocalObjectReference `json:"pullSecrets,omitempty"`
+}
+
+// GlobalConfig is a set of parameters that are used to configure all the components of the Datadog Operator.
+type GlobalConfig struct {
+	// Credentials defines the Datadog credentials used to submit data to/query data from Datadog.
+	Credentials *v2alpha1.DatadogCredentials `json:"credentials,omitempty"`
+
+	// ClusterAgentToken is the token for communication between the NodeAgent and ClusterAgent.
+	ClusterAgentToken *string `json:"clusterAgentToken,omitempty"`
+
+	// ClusterAgentTokenSecret is the secret containing the Cluster Agent token.
+	ClusterAgentTokenSecret *v2alpha1.SecretConfig `json:"clusterAgentTokenSecret,omitempty"`
+
+	// ClusterName sets a unique cluster name for the deployment to easily scope monitoring data in the Datadog app.
+	// +optional
+	ClusterName *string `json:"clusterName,omitempty"`
+
+	// Site is the Datadog intake site Agent data are sent to.
+	// Set to 'datadoghq.com' to send data to the US1 site (default).
+	// Set to 'datadoghq.eu' to send data to the EU site.
+	// Set to 'us3.datadoghq.com' to send data to the US3 site.
+	// Set to 'us5.datadoghq.com' to send data to the US5 site.
+	// Set to 'ddog-gov.com' to send data to the US1-FED site.
+	// Set to 'ap1.datadoghq.com' to send data to the AP1 site.
+	// Default: 'datadoghq.com'
+	// +optional
+	Site *string `json:"site,omitempty"`
+
+	// Endpoint is the Datadog intake URL the Agent data are sent to.
+	// Only set this option if you need the Agent to send data to a custom URL.
+	// Overrides the site setting defined in `Site`.
+	// +optional
+	Endpoint *v2alpha1.Endpoint `json:"endpoint,omitempty"`
+
+	// Registry is the image registry to use for all Agent images.
+	// Use 'public.ecr.aws/datadog' for AWS ECR.
+	// Use 'datadoghq.azurecr.io' for Azure Container Registry.
+	// Use 'gcr.io/datadoghq' for Google Container Registry.
+	// Use 'eu.gcr.io/datadoghq' for Google Container Registry in the EU region.
+	// Use 'asia.gcr.io/datadoghq' for Google Container Registry in the Asia region.
+	// Use 'docker.io/datadog' for DockerHub.
+	// Default: 'gcr.io/datadoghq'
+	// +optional
+	Registry *string `json:"registry,omitempty"`
+
+	// LogLevel sets logging verbosity. This can be overridden by container.
+	// Valid log levels are: trace, debug, info, warn, error, critical, and off.
+	// Default: 'info'
+	LogLevel *string `json:"logLevel,omitempty"`
+
+	// Tags contains a list of tags to attach to every metric, event and service check collected.
+	// Learn more about tagging: https://docs.datadoghq.com/tagging/
+	// +optional
+	// +listType=set
+	Tags []string `json:"tags,omitempty"`
+
+	// Env contains a list of environment variables that are set for all Agents.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// ChecksTagCardinality configures tag cardinality for the metrics collected by integrations (`low`, `orchestrator` or `high`).
+	// See also: https://docs.datadoghq.com/getting_started/tagging/assigning_tags/?tab=containerizedenvironments#tags-cardinality.
+	// Not set by default to avoid overriding existing DD_CHECKS_TAG_CARDINALITY configurations, the default value in the Agent is low.
+	// Ref: https://github.com/DataDog/datadog-agent/blob/856cf4a66142ce91fd4f8a278149436eb971184a/pkg/config/setup/config.go#L625.
+	// +optional
+	ChecksTagCardinality *string `json:"checksTagCardinality,omitempty"`
+
+	// OriginDetectionUnified defines the origin detection unified mechanism behavior.
+	// +optional
+	OriginDetectionUnified *v2alpha1.OriginDetectionUnified `json:"originDetectionUnified,omitempty"`
+
+	// Provide a mapping of Kubernetes Labels to Datadog Tags.
+	// <KUBERNETES_LABEL>: <DATADOG_TAG_KEY>
+	// +optional
+	PodLabelsAsTags map[string]string `json:"podLabelsAsTags,omitempty"`
+
+	// Provide a mapping of Kubernetes Annotations to Datadog Tags.
+	// <KUBERNETES_ANNOTATIONS>: <DATADOG_TAG_KEY>
+	// +optional
+	PodAnnotationsAsTags map[string]string `json:"podAnnotationsAsTags,omitempty"`
+
+	// Provide a mapping of Kubernetes Node Labels to Datadog Tags.
+	// <KUBERNETES_NODE_LABEL>: <DATADOG_TAG_KEY>
+	// +optional
+	NodeLabelsAsTags map[string]string `json:"nodeLabelsAsTags,omitempty"`
+
+	// Provide a mapping of Kubernetes Namespace Labels to Datadog Tags.
+	// <KUBERNETES_NAMESPACE_LABEL>: <DATADOG_TAG_KEY>
+	// +optional
+	NamespaceLabelsAsTags map[string]string `json:"namespaceLabelsAsTags,omitempty"`
+
+	// Provide a mapping of Kubernetes Namespace Annotations to Datadog Tags.
+	// <KUBERNETES_LABEL>: <DATADOG_TAG_KEY>
+	// +optional
+	NamespaceAnnotationsAsTags map[string]string `json:"namespaceAnnotationsAsTags,omitempty"`
+
+	// Provide a mapping of Kubernetes Resource Groups to labels mapping to Datadog Tags.
+	// <KUBERNETES_RESOURCE_GROUP>:
+	//		<KUBERNETES_LABEL>: <DATADOG_TAG_KEY>
+	// KUBERNETES_RESOURCE_GROUP should be in the form `{resource}.{group}` or `{resource}` (example: deployments.apps, pods)
+	// +optional
+	KubernetesResourcesLabelsAsTags map[string]map[string]string `json:"kubernetesResourcesLabelsAsTags,omitempty"`
+
+	// Provide a mapping of Kubernetes Resource Groups to annotations mapping to Datadog Tags.
+	// <KUBERNETES_RESOURCE_GROUP>:
+	//		<KUBERNETES_ANNOTATION>: <DATADOG_TAG_KEY>
+	// KUBERNETES_RESOURCE_GROUP should be in the form `{resource}.{group}` or `{resource}` (example: deployments.apps, pods)
+	// +optional
+	KubernetesResourcesAnnotationsAsTags map[string]map[string]string `json:"kubernetesResourcesAnnotationsAsTags,omitempty"`
+
+	// NetworkPolicy contains the network configuration.
+	// +optional
+	NetworkPolicy *v2alpha1.NetworkPolicyConfig `json:"networkPolicy,omitempty"`
+
+	// LocalService contains configuration to customize the internal traffic policy service.
+	// +optional
+	LocalService *v2alpha1.LocalService `json:"localService,omitempty"`
+
+	// Kubelet contains the kubelet configuration parameters.
+	// +optional
+	Kubelet *v2alpha1.KubeletConfig `json:"kubelet,omitempty"`
+
+	// Path to the docker runtime socket.
+	// +optional
+	DockerSocketPath *string `json:"dockerSocketPath,omitempty"`
+
+	// Path to the container runtime socket (if different from Docker).
+	// +optional
+	CriSocketPath *string `json:"criSocketPath,omitempty"`
+
+	// Set DisableNonResourceRules to exclude NonResourceURLs from default ClusterRoles.
+	// Required 'true' for Google Cloud Marketplace.
+	// +optional
+	DisableNonResourceRules *bool `json:"disableNonResourceRules,omitempty"`
+
+	// ContainerStrategy determines whether agents run in a single or multiple containers.
+	// Default: 'optimized'
+	// +optional
+	ContainerStrategy *v2alpha1.ContainerStrategyType `json:"containerStrategy,omitempty"`
+
+	// SecurityProfile restricts the agents to a Pod Security Standard.
+	// With `restricted`, the features requiring privileged containers, host ports or host access, such as CWS, log collection or APM over UDS, are blocked.
+	// The hostPath volumes, host ports and host namespaces are removed and the containers run as non-root with a restricted-compliant security context.
+	// Default: 'default'
+	// +optional
+	// +kubebuilder:validation:Enum=default;restricted
+	SecurityProfile *v2alpha1.SecurityProfileType `json:"securityProfile,omitempty"`
+
+	// FIPS contains the FIPS configuration: the FIPS flavor of the Agent, or the FIPS proxy sidecar.
+	// +optional
+	FIPS *FIPSConfig `json:"fips,omitempty"`
+
+	// Configure the secret backend feature https://docs.datadoghq.com/agent/guide/secrets-management
+	// See also: https://github.com/DataDog/datadog-operator/blob/main/docs/secret_management.md
+	SecretBackend *v2alpha1.SecretBackendConfig `json:"secretBackend,omitempty"`
+
+	// Configure whether the Process Agent or core Agent collects process and/or container information (Linux only).
+	// If no other checks are running, the Process Agent container will not initialize.
+	// (Requires Agent 7.60.0+)
+	// Default: 'true'
+	// +optional
+	RunProcessChecksInCoreAgent *bool `json:"runProcessChecksInCoreAgent,omitempty"`
+
+	// NodeSelector restricts the nodes on which the Agent of this DatadogAgent runs.
+	// It is used to run several DatadogAgents in a cluster, each on a disjoint set of nodes.
+	// A node selected by several DatadogAgents is only handled by the oldest one,
+	// and the overlap is reported in the `NodeSelectorOverlap` condition.
+	// Default: all the nodes of the cluster
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// ContainerFilters includes or excludes containers from the metrics, logs and Autodiscovery of the Agent.
+	// +optional
+	ContainerFilters *v2alpha1.ContainerFiltersConfig `json:"containerFilters,omitempty"`
+
+	// AutoRollback configures the automatic rollback of the node Agent DaemonSet and of the
+	// Cluster Agent and Cluster Checks Runner Deployments when their updated pods fail readiness.
+	// +optional
+	AutoRollback *v2alpha1.AutoRollbackConfig `json:"autoRollback,omitempty"`
+
+	// ResourceRecommendations configures the resource recommendations computed for the containers of the node Agent.
+	// +optional
+	ResourceRecommendations *v2alpha1.ResourceRecommendationsConfig `json:"resourceRecommendations,omitempty"`
+}
+
+// SeccompConfig is used to override default values for Seccomp Profile configurations.
+// +k8s:openapi-gen=true
+type SeccompConfig struct {
+	// CustomRootPath specifies a custom Seccomp Profile root location.
+	// +optional
+	CustomRootPath *string `json:"customRootPath,omitempty"`
+
+	// CustomProfile specifies a ConfigMap containing a custom Seccomp Profile.
+	// ConfigMap data must either have the key `system-probe-seccomp.json` or CustomProfile.Items
+	// must include a corev1.KeytoPath that maps the key to the path `system-probe-seccomp.json`.
+	// +optional
+	CustomProfile *CustomConfig `json:"customProfile,omitempty"`
+
+	// SecurityProfilesOperator enables the management of the default Seccomp Profile with the Kubernetes Security Profiles Operator.
+	// The Operator creates a `SeccompProfile` object, the Security Profiles Operator installs it on the nodes,
+	// and the container references it instead of the profile copied by the `seccomp-setup` init container.
+	// It is ignored when CustomProfile is set, or when the `SeccompProfile` API is not served by the cluster.
+	// Only the system-probe default profile is managed: the Agent container keeps its seccomp profile, and AppArmor is not managed.
+	// Default: false
+	// +optional
+	SecurityProfilesOperator *bool `json:"securityProfilesOperator,omitempty"`
+}
+
+// DatadogAgentComponentOverride is the generic description equivalent to a subset of the PodTemplate for a component.
+type DatadogAgentComponentOverride struct {
+	// Name overrides the default name for the resource
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Number of the replicas.
+	// Not applicable for a DaemonSet/ExtendedDaemonSet deployment
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Set CreatePodDisruptionBudget to true to create a PodDisruptionBudget for this component.
+	// Not applicable for the Node Agent. A Cluster Agent PDB is set with 1 minimum available pod, and the Cluster Checks Runner and OTel Agent Gateway PDBs are set with 1 maximum unavailable pod.
+	// +optional
+	CreatePodDisruptionBudget *bool `json:"createPodDisruptionBudget,omitempty"`
+
+	// Set CreateRbac to false to prevent automatic creation of Role/ClusterRole for this component
+	// +optional
+	CreateRbac *bool `json:"createRbac,omitempty"`
+
+	// Sets the ServiceAccount used by this component.
+	// Ignored if the field CreateRbac is true.
+	// +optional
+	ServiceAccountName *string `json:"serviceAccountName,omitempty"`
+
+	// Sets the ServiceAccountAnnotations used by this component.
+	// +optional
+	ServiceAccountAnnotations map[string]string `json:"serviceAccountAnnotations,omitempty"`
+
+	// The container image of the different components (Datadog Agent, Cluster Agent, Cluster Check Runner).
+	// +optional
+	Image *AgentImageConfig `json:"image,omitempty"`
+
+	// Specify additional environment variables for all containers in this component
+	// Priority is Container > Component.
+	// See also: https://docs.datadoghq.com/agent/kubernetes/?tab=helm#environment-variables
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Env []corev1.EnvVar `json:"env,omitempty" patchMergeKey:"name" patchStrategy:"merge"`
+
+	// EnvFrom specifies the ConfigMaps and Secrets to expose as environment variables.
+	// Priority is env > envFrom.
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+
+	// CustomConfiguration allows to specify custom configuration files for `datadog.yaml`, `datadog-cluster.yaml`, `security-agent.yaml`, and `system-probe.yaml`.
+	// The content is merged with configuration generated by the Datadog Operator, with priority given to custom configuration.
+	// WARNING: It is possible to override values set in the `DatadogAgent`.
+	// +optional
+	CustomConfigurations map[v2alpha1.AgentConfigFileName]CustomConfig `json:"customConfigurations,omitempty"`
+
+	// Confd configuration allowing to specify config files for custom checks placed under /etc/datadog-agent/conf.d/.
+	// See https://docs.datadoghq.com/agent/guide/agent-configuration-files/?tab=agentv6 for more details.
+	// +optional
+	ExtraConfd *MultiCustomConfig `json:"extraConfd,omitempty"`
+
+	// Checksd configuration allowing to specify custom checks placed under /etc/datadog-agent/checks.d/
+	// See https://docs.datadoghq.com/agent/guide/agent-configuration-files/?tab=agentv6 for more details.
+	// +optional
+	ExtraChecksd *MultiCustomConfig `json:"extraChecksd,omitempty"`
+
+	// Configure the basic configurations for each Agent container. Valid Agent container names are:
+	// `agent`, `cluster-agent`, `init-config`, `init-volume`, `process-agent`, `seccomp-setup`,
+	// `security-agent`, `system-probe`, and `trace-agent`.
+	// +optional
+	Containers map[common.AgentContainerName]*DatadogAgentGenericContainer `json:"containers,omitempty"`
+
+	// Specify additional volumes in the different components (Datadog Agent, Cluster Agent, Cluster Check Runner).
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// Pod-level SecurityContext.
+	// +optional
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+
+	// If specified, indicates the pod's priority. "system-node-critical" and "system-cluster-critical"
+	// are two special keywords which indicate the highest priorities with the former being the highest priority.
+	// Any other name must be defined by creating a PriorityClass object with that name. If not specified,
+	// the pod priority is default, or zero if there is no default.
+	PriorityClassName *string `json:"priorityClassName,omitempty"`
+
+	// If specified, indicates the pod's RuntimeClass kubelet should use to run the pod.
+	// If the named RuntimeClass does not exist, or the CRI cannot run the corresponding handler, the pod enters the Failed terminal phase.
+	// If no runtimeClassName is specified, the default RuntimeHandler is used, which is equivalent to the behavior when the RuntimeClass feature is disabled.
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// If specified, the pod's scheduling constraints.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// Set DNS policy for the pod.
+	// Defaults to "ClusterFirst".
+	// Valid values are 'ClusterFirstWithHostNet', 'ClusterFirst', 'Default' or 'None'.
+	// DNS parameters given in DNSConfig will be merged with the policy selected with DNSPolicy.
+	// To have DNS options set along with hostNetwork, you have to specify DNS policy
+	// explicitly to 'ClusterFirstWithHostNet'.
+	// +optional
+	DNSPolicy *corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// Specifies the DNS parameters of a pod.
+	// Parameters specified here will be merged to the generated DNS
+	// configuration based on DNSPolicy.
+	// +optional
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// A map of key-value pairs. For this pod to run on a specific node, the node must have these key-value pairs as labels.
+	// See https://kubernetes.io/docs/concepts/configuration/assign-pod-node/
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// The deployment strategy to use to replace existing pods with new ones.
+	// +optional
+	UpdateStrategy *common.UpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// StagedRollout configures the rollout of the node Agent DaemonSet in waves, driven by the operator.
+	// Only applies to the node Agent, when the ExtendedDaemonSet is not used.
+	// +optional
+	StagedRollout *v2alpha1.StagedRolloutConfig `json:"stagedRollout,omitempty"`
+
+	// Configure the component tolerations.
+	// +optional
+	// +listType=atomic
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Annotations provide annotations that are added to the different component (Datadog Agent, Cluster Agent, Cluster Check Runner) pods.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// AdditionalLabels provide labels that are added to the different component (Datadog Agent, Cluster Agent, Cluster Check Runner) pods.
+	//+mapType=granular
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Host networking requested for this pod. Use the host's network namespace.
+	// +optional
+	HostNetwork *bool `json:"hostNetwork,omitempty"`
+
+	// Use the host's PID namespace.
+	// +optional
+	HostPID *bool `json:"hostPID,omitempty"`
+
+	// Disabled force disables a component.
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
+
+	// TopologySpreadConstraints describes how a group of pods ought to spread across topology
+	// domains. Scheduler will schedule pods in a way which abides by the constraints.
+	// All topologySpreadConstraints are ANDed.
+	// +optional
+	// +listType=map
+	// +listMapKey=topologyKey
+	// +listMapKey=whenUnsatisfiable
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+}
+
+// DatadogAgentGenericContainer is the generic structure describing any container's common configuration.
+// +k8s:openapi-gen=true
+type DatadogAgentGenericContainer struct {
+	// Name of the container that is overridden
+	//+optional
+	Name *string `json:"name,omitempty"`
+
+	// LogLevel sets logging verbosity (overrides global setting).
+	// Valid log levels are: trace, debug, info, warn, error, critical, and off.
+	// Default: 'info'
+	// +optional
+	LogLevel *string `json:"logLevel,omitempty"`
+
+	// Specify additional ports to be exposed by the container. Not specifying a port here
+	// DOES NOT prevent that port from being exposed.
+	// See https://pkg.go.dev/k8s.io/api/core/v1#Container documentation for more details.
+	// +optional
+	// +listType=atomic
+	Ports []corev1.ContainerPort `json:"ports,omitempty"`
+
+	// Specify additional environment variables in the container.
+	// See also: https://docs.datadoghq.com/agent/kubernetes/?tab=helm#environment-variables
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Specify additional volume mounts in the container.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +listMapKey=mountPath
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// Specify the Request and Limits of the pods
+	// To get guaranteed QoS class, specify requests and limits equal.
+	// See also: http://kubernetes.io/docs/user-guide/compute-resources/
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Command allows the specification of a custom entrypoint for container
+	// +listType=atomic
+	Command []string `json:"command,omitempty"`
+
+	// Args allows the specification of extra args to the `Command` parameter
+	// +listType=atomic
+	Args []string `json:"args,omitempty"`
+
+	// HealthPort of the container for the internal liveness probe.
+	// Must be the same as the Liveness/Readiness probes.
+	// +optional
+	HealthPort *int32 `json:"healthPort,omitempty"`
+
+	// Configure the Readiness Probe of the container
+	// +optional
+	ReadinessProbe *corev1.Probe `json:"readinessProbe,omitempty"`
+
+	// Configure the Liveness Probe of the container
+	// +optional
+	LivenessProbe *corev1.Probe `json:"livenessProbe,omitempty"`
+
+	// Configure the Startup Probe of the container
+	// +optional
+	StartupProbe *corev1.Probe `json:"startupProbe,omitempty"`
+
+	// Container-level SecurityContext.
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// Seccomp configurations to override Operator actions. For all other Seccomp Profile manipulation,
+	// use SecurityContext.
+	// +optional
+	SeccompConfig *SeccompConfig `json:"seccompConfig,omitempty"`
+
+	// AppArmorProfileName specifies an apparmor profile.
+	// +optional
+	AppArmorProfileName *string `json:"appArmorProfileName,omitempty"`
+}
+
+// FIPSMode selects how the Agent complies with FIPS.
+// +kubebuilder:validation:Enum=agent;proxy
+type FIPSMode string
+
+const (
+	// FIPSModeAgent runs the FIPS flavor of the Agent images
+	FIPSModeAgent FIPSMode = "agent"
+	// FIPSModeProxy runs the FIPS proxy sidecar next to the Agent containers
+	FIPSModeProxy FIPSMode = "proxy"
+)
+
+// FIPSConfig contains the FIPS configuration.
+// +k8s:openapi-gen=true
+type FIPSConfig struct {
+	// Mode selects how the Agent complies with FIPS:
+	// `agent` runs the FIPS flavor of the Agent images, `proxy` runs the FIPS proxy sidecar.
+	// The other fields configure the FIPS proxy sidecar and are only used in `proxy` mode.
+	// FIPS is disabled if not set.
+	// +optional
+	Mode *FIPSMode `json:"mode,omitempty"`
+
+	// The container image of the FIPS sidecar.
+	// +optional
+	Image *AgentImageConfig `json:"image,omitempty"`
+	// Set the local IP address.
+	// Default: `127.0.0.1`
+	// +optional
+	LocalAddress *string `json:"localAddress,omitempty"`
+	// Port specifies which port is used by the containers to communicate to the FIPS sidecar.
+	// Default: 9803
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+	// PortRange specifies the number of ports used.
+	// Default: 15
+	// +optional
+	PortRange *int32 `json:"portRange,omitempty"`
+	// Resources is the requests and limits for the FIPS sidecar container.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// UseHTTPS enables HTTPS.
+	// Default: false
+	// +optional
+	UseHTTPS *bool `json:"useHTTPS,omitempty"`
+	// CustomFIPSConfig configures a custom configMap to provide the FIPS configuration.
+	// Specify custom contents for the FIPS proxy sidecar container config
+	// (/etc/datadog-fips-proxy/datadog-fips-proxy.cfg). If empty, the default FIPS
+	// proxy sidecar container config is used.
+	// +optional
+	CustomFIPSConfig *CustomConfig `json:"customFIPSConfig,omitempty"`
+}
+
+// RemoteConfigConfiguration stores the configuration received from RemoteConfig.
+// +k8s:openapi-gen=true
+type RemoteConfigConfiguration struct {
+	Features *DatadogFeatures `json:"features,omitempty"`
+}
+
+// DatadogAgentStatus defines the observed state of DatadogAgent.
+// +k8s:openapi-gen=true
+type DatadogAgentStatus struct {
+	// Conditions Represents the latest available observations of a DatadogAgent's current state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions"`
+	// The actual state of the Agent as a daemonset or an extended daemonset.
+	// +optional
+	// +listType=atomic
+	AgentList []*v2alpha1.DaemonSetStatus `json:"agentList,omitempty"`
+	// The combined actual state of all Agents as daemonsets or extended daemonsets.
+	// +optional
+	Agent *v2alpha1.DaemonSetStatus `json:"agent,omitempty"`
+	// The actual state of the Cluster Agent as a deployment.
+	// +optional
+	ClusterAgent *v2alpha1.DeploymentStatus `json:"clusterAgent,omitempty"`
+	// The actual state of the Cluster Checks Runner as a deployment.
+	// +optional
+	ClusterChecksRunner *v2alpha1.DeploymentStatus `json:"clusterChecksRunner,omitempty"`
+	// The actual state of the OTel Agent Gateway as a deployment.
+	// +optional
+	OtelAgentGateway *v2alpha1.DeploymentStatus `json:"otelAgentGateway,omitempty"`
+	// RemoteConfigConfiguration stores the configuration received from RemoteConfig.
+	// +optional
+	RemoteConfigConfiguration *RemoteConfigConfiguration `json:"remoteConfigConfiguration,omitempty"`
+	// Features contains the status of the enabled and configured features, indexed by feature ID.
+	// The features that are not listed are disabled.
+	// +optional
+	Features map[string]v2alpha1.FeatureStatus `json:"features,omitempty"`
+	// Instrumentation reports the namespaces and pods matched by the targets of the APM Single Step Instrumentation.
+	// +optional
+	Instrumentation *v2alpha1.InstrumentationStatus `json:"instrumentation,omitempty"`
+	// ResourceRecommendations reports the resource recommendations computed for the containers of the node Agent.
+	// +optional
+	ResourceRecommendations *v2alpha1.ResourceRecommendationsStatus `json:"resourceRecommendations,omitempty"`
+}
+
+// DatadogAgent Deployment with the Datadog Operator.
+// v2alpha1 stays the storage version: the API server converts the v2beta1 resources with the conversion webhook.
+// v2beta1 is not served by default, as the API server would prune its fields without the conversion webhook.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:unservedversion
+// +kubebuilder:resource:path=datadogagents,shortName=dd
+// +kubebuilder:printcolumn:name="agent",type="string",JSONPath=".status.agent.status"
+// +kubebuilder:printcolumn:name="cluster-agent",type="string",JSONPath=".status.clusterAgent.status"
+// +kubebuilder:printcolumn:name="cluster-checks-runner",type="string",JSONPath=".status.clusterChecksRunner.status"
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:openapi-gen=true
+// +genclient
+type DatadogAgent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DatadogAgentSpec   `json:"spec,omitempty"`
+	Status DatadogAgentStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DatadogAgentList contains a list of DatadogAgent.
+type DatadogAgentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DatadogAgent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DatadogAgent{}, &DatadogAgentList{})
+}
@@ -12,9 +12,12 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
 
 	"github.com/DataDog/datadog-operator/pkg/plugin/common"
 )
@@ -25,6 +28,8 @@ type Options struct {
 	common.Options
 	crdName        string
 	storedVersions []string
+	migrate        bool
+	storageVersion string
 }
 
 // NewOptions provides an instance of Options with default values.
@@ -45,7 +50,7 @@ func NewCmdPatch(streams genericclioptions.IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:          "patch-crd <crd-name>",
 		Short:        "patch the CRD status to update storedVersions list",
-		Example:      "./check-operator patch-crd datadogagents.datadoghq.com",
+		Example:      "./check-operator patch-crd datadogagents.datadoghq.com --storedVersions v2alpha1\n./check-operator patch-crd datadogagents.datadoghq.com --migrate\n./check-operator patch-crd datadogagents.datadoghq.com --migrate --storageVersion v2beta1",
 		SilenceUsage: true,
 		RunE: func(c *cobra.Command, args []string) error {
 			if err := o.Complete(c, args); err != nil {
@@ -60,6 +65,8 @@ func NewCmdPatch(streams genericclioptions.IOStreams) *cobra.Command {
 	}
 
 	cmd.Flags().StringArrayVar(&o.storedVersions, "storedVersions", []string{}, "used to define the status.storedVersion field in the CRD")
+	cmd.Flags().BoolVar(&o.migrate, "migrate", false, "rewrite all the objects in the CRD storage version, then set it as the only status.storedVersion")
+	cmd.Flags().StringVar(&o.storageVersion, "storageVersion", "", "used with migrate to change the storage version of the CRD before rewriting the objects")
 
 	o.ConfigFlags.AddFlags(cmd.Flags())
 
@@ -81,7 +88,15 @@ func (o *Options) Validate() error {
 		return fmt.Errorf("the CRD name is required")
 	}
 
-	if len(o.storedVersions) == 0 {
+	if o.migrate && len(o.storedVersions) > 0 {
+		return fmt.Errorf("storedVersions cannot be provided with migrate, the storage version of the CRD is used")
+	}
+
+	if o.storageVersion != "" && !o.migrate {
+		return fmt.Errorf("storageVersion can only be provided with migrate")
+	}
+
+	if !o.migrate && len(o.storedVersions) == 0 {
 		return fmt.Errorf("at least one storedVersion needs to be provided")
 	}
 
@@ -100,7 +115,20 @@ func (o *Options) Run() error {
 	}
 
 	o.printOutf("Current storedVersions value: %s,", strings.Join(crd.Status.StoredVersions, ","))
-	crd.Status.StoredVersions = o.storedVersions
+	storedVersions := o.storedVersions
+	if o.migrate && o.storageVersion != "" {
+		if crd, err = o.updateStorageVersion(crd); err != nil {
+			return err
+		}
+	}
+	if o.migrate {
+		storageVersion, err := o.migrateObjects(crd)
+		if err != nil {
+			return err
+		}
+		storedVersions = []string{storageVersion}
+	}
+	crd.Status.StoredVersions = storedVersions
 
 	crd, err = o.APIExtClient.ApiextensionsV1().CustomResourceDefinitions().UpdateStatus(context.Background(), crd, v1.UpdateOptions{})
 	if err != nil {
@@ -111,6 +139,93 @@ func (o *Options) Run() error {
 
 }
 
+// updateStorageVersion sets the storage version of the CRD to the version provided with --storageVersion
+func (o *Options) updateStorageVersion(crd *apiextensionsv1.CustomResourceDefinition) (*apiextensionsv1.CustomResourceDefinition, error) {
+	changed, err := setStorageVersion(crd, o.storageVersion)
+	if err != nil || !changed {
+		return crd, err
+	}
+
+	crd, err = o.APIExtClient.ApiextensionsV1().CustomResourceDefinitions().Update(context.Background(), crd, v1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to update the storage version, retry later, err: %w", err)
+	}
+	o.printOutf("New storage version: %s", o.storageVersion)
+	return crd, nil
+}
+
+// setStorageVersion sets the storage version of a CRD. It returns false if it already is the storage version.
+// The version must be served, and the CRD must use a conversion webhook: without conversion, the API server
+// would prune the fields of the objects that do not exist in the new storage version.
+func setStorageVersion(crd *apiextensionsv1.CustomResourceDefinition, storageVersion string) (bool, error) {
+	found := false
+	for _, version := range crd.Spec.Versions {
+		if version.Name != storageVersion {
+			continue
+		}
+		if !version.Served {
+			return false, fmt.Errorf("the version %s of the %s CRD is not served", storageVersion, crd.Name)
+		}
+		if version.Storage {
+			return false, nil
+		}
+		found = true
+	}
+	if !found {
+		return false, fmt.Errorf("the %s CRD has no version %s", crd.Name, storageVersion)
+	}
+	if crd.Spec.Conversion == nil || crd.Spec.Conversion.Strategy != apiextensionsv1.WebhookConverter {
+		return false, fmt.Errorf("the %s CRD must use a conversion webhook to change its storage version", crd.Name)
+	}
+
+	for i := range crd.Spec.Versions {
+		crd.Spec.Versions[i].Storage = crd.Spec.Versions[i].Name == storageVersion
+	}
+	return true, nil
+}
+
+// migrateObjects rewrites all the objects of the CRD so that the API server stores them in the storage version.
+// It returns the storage version.
+func (o *Options) migrateObjects(crd *apiextensionsv1.CustomResourceDefinition) (string, error) {
+	storageVersion := ""
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			storageVersion = version.Name
+		}
+	}
+	if storageVersion == "" {
+		return "", fmt.Errorf("unable to find the storage version of the %s CRD", o.crdName)
+	}
+
+	restConfig, err := o.GetClientConfig().ClientConfig()
+	if err != nil {
+		return "", fmt.Errorf("unable to get the rest config, err: %w", err)
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return "", fmt.Errorf("unable to instantiate the dynamic client, err: %w", err)
+	}
+
+	gvr := schema.GroupVersionResource{Group: crd.Spec.Group, Version: storageVersion, Resource: crd.Spec.Names.Plural}
+	list, err := dynamicClient.Resource(gvr).Namespace(v1.NamespaceAll).List(context.Background(), v1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("unable to list %s, err: %w", crd.Spec.Names.Plural, err)
+	}
+
+	o.printOutf("Migrating %d objects to %s", len(list.Items), storageVersion)
+	for i := range list.Items {
+		obj := &list.Items[i]
+		// A no-op update makes the API server write the object in the storage version.
+		// On conflict, the object was updated in the meantime, so it is already stored in the storage version.
+		_, err = dynamicClient.Resource(gvr).Namespace(obj.GetNamespace()).Update(context.Background(), obj, v1.UpdateOptions{})
+		if err != nil && !errors.IsConflict(err) && !errors.IsNotFound(err) {
+			return "", fmt.Errorf("unable to migrate %s/%s, retry later, err: %w", obj.GetNamespace(), obj.GetName(), err)
+		}
+	}
+
+	return storageVersion, nil
+}
+
 func (o *Options) printOutf(format string, a ...interface{}) {
 	args := []interface{}{time.Now().UTC().Format("2006-01-02T15:04:05.999Z"), o.crdName}
 	args = append(args, a...)
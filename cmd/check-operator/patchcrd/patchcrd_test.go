@@ -0,0 +1,81 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package patchcrd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func Test_setStorageVersion(t *testing.T) {
+	newCRD := func(strategy apiextensionsv1.ConversionStrategyType, v2beta1Served bool) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Conversion: &apiextensionsv1.CustomResourceConversion{Strategy: strategy},
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+					{Name: "v2alpha1", Served: true, Storage: true},
+					{Name: "v2beta1", Served: v2beta1Served},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		crd         *apiextensionsv1.CustomResourceDefinition
+		version     string
+		wantChanged bool
+		wantErr     string
+	}{
+		{
+			name:        "switch to v2beta1",
+			crd:         newCRD(apiextensionsv1.WebhookConverter, true),
+			version:     "v2beta1",
+			wantChanged: true,
+		},
+		{
+			name:    "already the storage version",
+			crd:     newCRD(apiextensionsv1.WebhookConverter, true),
+			version: "v2alpha1",
+		},
+		{
+			name:    "unknown version",
+			crd:     newCRD(apiextensionsv1.WebhookConverter, true),
+			version: "v3",
+			wantErr: "has no version v3",
+		},
+		{
+			name:    "version not served",
+			crd:     newCRD(apiextensionsv1.WebhookConverter, false),
+			version: "v2beta1",
+			wantErr: "is not served",
+		},
+		{
+			name:    "no conversion webhook",
+			crd:     newCRD(apiextensionsv1.NoneConverter, true),
+			version: "v2beta1",
+			wantErr: "must use a conversion webhook",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed, err := setStorageVersion(tt.crd, tt.version)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantChanged, changed)
+			for _, version := range tt.crd.Spec.Versions {
+				assert.Equal(t, version.Name == tt.version, version.Storage, version.Name)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/agent/check"
 	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/agent/find"
+	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/agent/resources"
 	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/agent/upgrade"
 )
 
@@ -37,6 +38,7 @@ func New(streams genericclioptions.IOStreams) *cobra.Command {
 	cmd.AddCommand(upgrade.New(streams))
 	cmd.AddCommand(check.New(streams))
 	cmd.AddCommand(find.New(streams))
+	cmd.AddCommand(resources.New(streams))
 
 	o := newOptions(streams)
 	o.configFlags.AddFlags(cmd.Flags())
@@ -0,0 +1,164 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/pkg/plugin/common"
+)
+
+var resourcesExample = `
+  # view the resources recommended for the node Agent containers of all DatadogAgent in the current namespace
+  %[1]s agent resources
+
+  # view the resources recommended for the node Agent containers of DatadogAgent foo
+  %[1]s agent resources foo
+`
+
+// options provides information required by agent resources command.
+type options struct {
+	genericclioptions.IOStreams
+	common.Options
+	args                 []string
+	userDatadogAgentName string
+}
+
+// newOptions provides an instance of options with default values.
+func newOptions(streams genericclioptions.IOStreams) *options {
+	o := &options{
+		IOStreams: streams,
+	}
+	o.SetConfigFlags()
+	return o
+}
+
+// New provides a cobra command wrapping options for "resources" sub command.
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+	cmd := &cobra.Command{
+		Use:          "resources [DatadogAgent name]",
+		Short:        "View the requests and limits recommended for the node Agent containers of each DaemonSet",
+		Example:      fmt.Sprintf(resourcesExample, "kubectl datadog"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+			return o.run()
+		},
+	}
+
+	o.ConfigFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// complete sets all information required for processing the command.
+func (o *options) complete(cmd *cobra.Command, args []string) error {
+	o.args = args
+	if len(args) > 0 {
+		o.userDatadogAgentName = args[0]
+	}
+	return o.Init(cmd)
+}
+
+// validate ensures that all required arguments and flag values are provided.
+func (o *options) validate() error {
+	if len(o.args) > 1 {
+		return errors.New("either one or no arguments are allowed")
+	}
+	return nil
+}
+
+// run runs the resources command.
+func (o *options) run() error {
+	ddList := &v2alpha1.DatadogAgentList{}
+	if o.userDatadogAgentName == "" {
+		if err := o.Client.List(context.TODO(), ddList, &client.ListOptions{Namespace: o.UserNamespace}); err != nil {
+			return fmt.Errorf("unable to list DatadogAgent: %w", err)
+		}
+	} else {
+		dd := &v2alpha1.DatadogAgent{}
+		err := o.Client.Get(context.TODO(), client.ObjectKey{Namespace: o.UserNamespace, Name: o.userDatadogAgentName}, dd)
+		if err != nil && apierrors.IsNotFound(err) {
+			return fmt.Errorf("DatadogAgent %s/%s not found", o.UserNamespace, o.userDatadogAgentName)
+		} else if err != nil {
+			return fmt.Errorf("unable to get DatadogAgent: %w", err)
+		}
+		ddList.Items = append(ddList.Items, *dd)
+	}
+
+	table := newTable(o.Out)
+	for i := range ddList.Items {
+		dda := &ddList.Items[i]
+		status := dda.Status.ResourceRecommendations
+		if status == nil {
+			continue
+		}
+		lastUpdate := ""
+		if status.LastUpdate != nil {
+			lastUpdate = status.LastUpdate.UTC().Format("2006-01-02T15:04:05Z")
+		}
+		for _, ds := range status.DaemonSets {
+			for _, container := range ds.Containers {
+				table.Append([]string{
+					dda.Name,
+					ds.Name,
+					strconv.Itoa(int(ds.Pods)),
+					container.Name,
+					quantity(container.Requests, corev1.ResourceCPU),
+					quantity(container.Requests, corev1.ResourceMemory),
+					quantity(container.Limits, corev1.ResourceMemory),
+					status.Source,
+					lastUpdate,
+				})
+			}
+		}
+	}
+	if table.NumLines() == 0 {
+		fmt.Fprintln(o.Out, "No resource recommendations found, they are computed when `global.resourceRecommendations.enabled` is true")
+		return nil
+	}
+	table.Render()
+	return nil
+}
+
+func quantity(resources corev1.ResourceList, name corev1.ResourceName) string {
+	if value, ok := resources[name]; ok {
+		return value.String()
+	}
+	return ""
+}
+
+func newTable(out io.Writer) *tablewriter.Table {
+	table := tablewriter.NewWriter(out)
+	table.SetHeader([]string{"DatadogAgent", "DaemonSet", "Pods", "Container", "CPU-Request", "Memory-Request", "Memory-Limit", "Source", "Last-Update"})
+	table.SetBorders(tablewriter.Border{Left: false, Top: false, Right: false, Bottom: false})
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeaderLine(false)
+	return table
+}
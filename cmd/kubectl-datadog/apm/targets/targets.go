@@ -0,0 +1,156 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package targets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/apm"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+	"github.com/DataDog/datadog-operator/pkg/plugin/common"
+)
+
+var targetsExample = `
+  # view the namespaces and pods matched by the instrumentation targets of all DatadogAgent in the current namespace
+  %[1]s apm targets
+
+  # view the namespaces and pods matched by the instrumentation targets of DatadogAgent foo
+  %[1]s apm targets foo
+`
+
+// options provides information required by Datadog apm targets command.
+type options struct {
+	genericclioptions.IOStreams
+	common.Options
+	args                 []string
+	userDatadogAgentName string
+}
+
+// newOptions provides an instance of options with default values.
+func newOptions(streams genericclioptions.IOStreams) *options {
+	o := &options{
+		IOStreams: streams,
+	}
+	o.SetConfigFlags()
+	return o
+}
+
+// New provides a cobra command wrapping options for "targets" sub command.
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+	cmd := &cobra.Command{
+		Use:          "targets [DatadogAgent name]",
+		Short:        "Evaluate the APM Single Step Instrumentation targets against the namespaces and pods of the cluster",
+		Example:      fmt.Sprintf(targetsExample, "kubectl datadog"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+			return o.run()
+		},
+	}
+
+	o.ConfigFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// complete sets all information required for processing the command.
+func (o *options) complete(cmd *cobra.Command, args []string) error {
+	o.args = args
+	if len(args) > 0 {
+		o.userDatadogAgentName = args[0]
+	}
+	return o.Init(cmd)
+}
+
+// validate ensures that all required arguments and flag values are provided.
+func (o *options) validate() error {
+	if len(o.args) > 1 {
+		return errors.New("either one or no arguments are allowed")
+	}
+	return nil
+}
+
+// run runs the targets command.
+func (o *options) run() error {
+	ddList := &v2alpha1.DatadogAgentList{}
+	if o.userDatadogAgentName == "" {
+		if err := o.Client.List(context.TODO(), ddList, &client.ListOptions{Namespace: o.UserNamespace}); err != nil {
+			return fmt.Errorf("unable to list DatadogAgent: %w", err)
+		}
+	} else {
+		dd := &v2alpha1.DatadogAgent{}
+		err := o.Client.Get(context.TODO(), client.ObjectKey{Namespace: o.UserNamespace, Name: o.userDatadogAgentName}, dd)
+		if err != nil && apierrors.IsNotFound(err) {
+			return fmt.Errorf("DatadogAgent %s/%s not found", o.UserNamespace, o.userDatadogAgentName)
+		} else if err != nil {
+			return fmt.Errorf("unable to get DatadogAgent: %w", err)
+		}
+		ddList.Items = append(ddList.Items, *dd)
+	}
+
+	table := newTable(o.Out)
+	for i := range ddList.Items {
+		dda := &ddList.Items[i]
+		targets := apm.GetInstrumentationTargets(&dda.Spec)
+		if len(targets) == 0 {
+			continue
+		}
+		statuses, err := apm.GetTargetsStatus(context.TODO(), o.Client, targets, constants.GetInstrumentationNamespaces(&dda.Spec, dda.Namespace))
+		if err != nil {
+			return fmt.Errorf("unable to evaluate the instrumentation targets of DatadogAgent %s/%s: %w", dda.Namespace, dda.Name, err)
+		}
+		for _, status := range statuses {
+			table.Append([]string{
+				dda.Name,
+				status.Name,
+				strconv.Itoa(int(status.MatchedNamespaces)),
+				strconv.Itoa(int(status.MatchedPods)),
+				strconv.Itoa(int(status.AppliedPods)),
+				strings.Join(status.OverlappingTargets, ","),
+				strings.Join(status.InvalidTracerVersions, ","),
+				status.Error,
+			})
+		}
+	}
+	if table.NumLines() == 0 {
+		fmt.Fprintln(o.Out, "No APM Single Step Instrumentation targets found")
+		return nil
+	}
+	table.Render()
+	return nil
+}
+
+func newTable(out io.Writer) *tablewriter.Table {
+	table := tablewriter.NewWriter(out)
+	table.SetHeader([]string{"DatadogAgent", "Target", "Namespaces", "Matched-Pods", "Applied-Pods", "Overlapping-Targets", "Invalid-DDTraceVersions", "Error"})
+	table.SetBorders(tablewriter.Border{Left: false, Top: false, Right: false, Bottom: false})
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeaderLine(false)
+	return table
+}
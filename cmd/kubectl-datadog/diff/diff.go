@@ -0,0 +1,189 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package diff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent"
+	componentagent "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/agent"
+	"github.com/DataDog/datadog-operator/pkg/plugin/common"
+)
+
+var diffExample = `
+  # show the changes the operator would apply if the DatadogAgent in dda.yaml was deployed
+  %[1]s diff -f dda.yaml
+
+  # only list the objects that would change
+  %[1]s diff -f dda.yaml --summary
+
+  # read the options of the operator from the Deployment "datadog-operator" of the namespace "datadog-operator"
+  %[1]s diff -f dda.yaml --operator-namespace datadog-operator
+`
+
+// options provides information required by Datadog diff command
+type options struct {
+	genericclioptions.IOStreams
+	common.Options
+	args                     []string
+	filename                 string
+	summary                  bool
+	operatorDeployment       string
+	operatorNamespace        string
+	supportCilium            bool
+	supportExtendedDaemonset bool
+}
+
+// newOptions provides an instance of options with default values
+func newOptions(streams genericclioptions.IOStreams) *options {
+	o := &options{
+		IOStreams: streams,
+	}
+	o.SetConfigFlags()
+	return o
+}
+
+// New provides a cobra command wrapping options for "diff" sub command
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+	cmd := &cobra.Command{
+		Use:          "diff -f [DatadogAgent manifest] [flags]",
+		Short:        "Show the changes the operator would apply to the cluster for a DatadogAgent",
+		Example:      fmt.Sprintf(diffExample, "kubectl datadog"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+			return o.run()
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.filename, "filename", "f", "", "The DatadogAgent manifest to compare with the cluster")
+	cmd.Flags().BoolVarP(&o.summary, "summary", "", false, "Only list the objects to create, update and delete")
+	cmd.Flags().StringVarP(&o.operatorDeployment, "operator-deployment", "", "datadog-operator", "The operator Deployment, whose arguments set the options of the comparison")
+	cmd.Flags().StringVarP(&o.operatorNamespace, "operator-namespace", "", "", "The namespace of the operator Deployment, the namespace of the DatadogAgent if not set")
+	cmd.Flags().BoolVarP(&o.supportCilium, "support-cilium", "", false, "Compare as if the operator runs with Cilium network policies support, if the operator Deployment is not found")
+	cmd.Flags().BoolVarP(&o.supportExtendedDaemonset, "support-extendeddaemonset", "", false, "Compare as if the operator runs with ExtendedDaemonset support, if the operator Deployment is not found")
+
+	o.ConfigFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// complete sets all information required for processing the command
+func (o *options) complete(cmd *cobra.Command, args []string) error {
+	o.args = args
+	return o.Init(cmd)
+}
+
+// validate ensures that all required arguments and flag values are provided
+func (o *options) validate() error {
+	if o.filename == "" {
+		return errors.New("a DatadogAgent manifest is required, use --filename")
+	}
+	if len(o.args) > 0 {
+		return fmt.Errorf("no argument is allowed, got %d", len(o.args))
+	}
+	return nil
+}
+
+// run runs the diff command
+func (o *options) run() error {
+	dda, err := common.ReadDatadogAgentManifest(o.filename, o.UserNamespace)
+	if err != nil {
+		return err
+	}
+
+	platformInfo, err := common.NewPlatformInfo(o.DiscoveryClient)
+	if err != nil {
+		return err
+	}
+
+	reconcilerOptions, err := o.reconcilerOptions(dda.Namespace)
+	if err != nil {
+		return err
+	}
+
+	diffs, err := datadogagent.Diff(context.TODO(), o.Client, dda, datadogagent.RenderOptions{
+		ReconcilerOptions: reconcilerOptions,
+		PlatformInfo:      &platformInfo,
+	})
+	if err != nil {
+		return err
+	}
+
+	o.printDiffs(diffs)
+	return nil
+}
+
+// reconcilerOptions returns the options of the operator Deployment, or the options set by the flags if it is not found
+func (o *options) reconcilerOptions(ddaNamespace string) (datadogagent.ReconcilerOptions, error) {
+	namespace := o.operatorNamespace
+	if namespace == "" {
+		namespace = ddaNamespace
+	}
+	reconcilerOptions, err := getOperatorReconcilerOptions(context.TODO(), o.Client, namespace, o.operatorDeployment)
+	if err == nil {
+		return reconcilerOptions, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return reconcilerOptions, fmt.Errorf("unable to read the options of the operator: %w", err)
+	}
+
+	fmt.Fprintf(o.ErrOut, "The operator Deployment %s/%s is not found, the comparison uses the options set by the flags\n", namespace, o.operatorDeployment)
+	return datadogagent.ReconcilerOptions{
+		ExtendedDaemonsetOptions: componentagent.ExtendedDaemonsetOptions{
+			Enabled: o.supportExtendedDaemonset,
+		},
+		SupportCilium: o.supportCilium,
+	}, nil
+}
+
+func (o *options) printDiffs(diffs []datadogagent.ObjectDiff) {
+	var toCreate, toUpdate, toRestart, toDelete int
+	for _, d := range diffs {
+		id := fmt.Sprintf("%s %s", d.Kind, d.Object.GetName())
+		if d.Object.GetNamespace() != "" {
+			id = fmt.Sprintf("%s %s/%s", d.Kind, d.Object.GetNamespace(), d.Object.GetName())
+		}
+
+		switch d.Action {
+		case datadogagent.DiffActionCreate:
+			toCreate++
+			fmt.Fprintf(o.Out, "+ %s will be created\n", id)
+		case datadogagent.DiffActionDelete:
+			toDelete++
+			fmt.Fprintf(o.Out, "- %s will be deleted\n", id)
+		case datadogagent.DiffActionUpdate:
+			toUpdate++
+			if d.RestartsPods {
+				toRestart++
+				fmt.Fprintf(o.Out, "~ %s will be updated, its pods will be restarted\n", id)
+			} else {
+				fmt.Fprintf(o.Out, "~ %s will be updated\n", id)
+			}
+			if !o.summary {
+				fmt.Fprintln(o.Out, d.Diff)
+			}
+		}
+	}
+
+	if len(diffs) == 0 {
+		fmt.Fprintln(o.Out, "No changes")
+		return
+	}
+	fmt.Fprintf(o.Out, "\n%d to create, %d to update (%d restarting pods), %d to delete\n", toCreate, toUpdate, toRestart, toDelete)
+}
@@ -0,0 +1,100 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package diff
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent"
+)
+
+// getOperatorReconcilerOptions returns the reconciler options of the operator Deployment, read from the arguments
+// of its first container
+func getOperatorReconcilerOptions(ctx context.Context, c client.Reader, namespace, name string) (datadogagent.ReconcilerOptions, error) {
+	deployment := &appsv1.Deployment{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, deployment); err != nil {
+		return datadogagent.ReconcilerOptions{}, err
+	}
+	containers := deployment.Spec.Template.Spec.Containers
+	if len(containers) == 0 {
+		return datadogagent.ReconcilerOptions{}, fmt.Errorf("the operator Deployment %s/%s has no container", namespace, name)
+	}
+	return reconcilerOptionsFromArgs(containers[0].Args)
+}
+
+// reconcilerOptionsFromArgs parses the operator flags setting the DatadogAgent reconciler options,
+// with the same names and defaults as the operator. The other flags are ignored.
+func reconcilerOptionsFromArgs(args []string) (datadogagent.ReconcilerOptions, error) {
+	opts := datadogagent.ReconcilerOptions{}
+	eds := &opts.ExtendedDaemonsetOptions
+	var canaryAutoPauseMaxRestarts, canaryAutoFailMaxRestarts int
+
+	fs := flag.NewFlagSet("datadog-operator", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.BoolVar(&opts.SupportCilium, "supportCilium", false, "")
+	fs.BoolVar(&opts.OperatorMetricsEnabled, "operatorMetricsEnabled", true, "")
+	fs.BoolVar(&opts.IntrospectionEnabled, "introspectionEnabled", false, "")
+	fs.BoolVar(&opts.DatadogAgentProfileEnabled, "datadogAgentProfileEnabled", false, "")
+	fs.BoolVar(&opts.AgentRolloutEnabled, "agentRolloutEnabled", false, "")
+	fs.BoolVar(&opts.DatadogAgentInternalEnabled, "datadogAgentInternalEnabled", false, "")
+	fs.BoolVar(&opts.DatadogCheckEnabled, "datadogCheckEnabled", false, "")
+	fs.BoolVar(&eds.Enabled, "supportExtendedDaemonset", false, "")
+	fs.StringVar(&eds.MaxPodUnavailable, "edsMaxPodUnavailable", "", "")
+	fs.StringVar(&eds.SlowStartAdditiveIncrease, "edsSlowStartAdditiveIncrease", "", "")
+	fs.StringVar(&eds.MaxPodSchedulerFailure, "edsMaxPodSchedulerFailure", "", "")
+	fs.DurationVar(&eds.CanaryDuration, "edsCanaryDuration", 10*time.Minute, "")
+	fs.StringVar(&eds.CanaryReplicas, "edsCanaryReplicas", "", "")
+	fs.BoolVar(&eds.CanaryAutoPauseEnabled, "edsCanaryAutoPauseEnabled", true, "")
+	fs.IntVar(&canaryAutoPauseMaxRestarts, "edsCanaryAutoPauseMaxRestarts", 0, "")
+	fs.BoolVar(&eds.CanaryAutoFailEnabled, "edsCanaryAutoFailEnabled", true, "")
+	fs.IntVar(&canaryAutoFailMaxRestarts, "edsCanaryAutoFailMaxRestarts", 0, "")
+	fs.DurationVar(&eds.CanaryAutoPauseMaxSlowStartDuration, "edsCanaryAutoPauseMaxSlowStartDuration", 0, "")
+
+	if err := fs.Parse(knownFlagArgs(fs, args)); err != nil {
+		return opts, fmt.Errorf("unable to parse the operator arguments: %w", err)
+	}
+	eds.CanaryAutoPauseMaxRestarts = int32(canaryAutoPauseMaxRestarts)
+	eds.CanaryAutoFailMaxRestarts = int32(canaryAutoFailMaxRestarts)
+	return opts, nil
+}
+
+// knownFlagArgs returns the arguments of the flags defined in fs, with their values
+func knownFlagArgs(fs *flag.FlagSet, args []string) []string {
+	var known []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		// A flag value is either inlined with "=", or the next argument for non-boolean flags
+		takesNext := !hasValue && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-")
+		f := fs.Lookup(name)
+		if f == nil {
+			if takesNext {
+				i++
+			}
+			continue
+		}
+		known = append(known, arg)
+		if boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && boolFlag.IsBoolFlag() {
+			continue
+		}
+		if takesNext {
+			known = append(known, args[i+1])
+			i++
+		}
+	}
+	return known
+}
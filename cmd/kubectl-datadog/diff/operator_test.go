@@ -0,0 +1,43 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package diff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_reconcilerOptionsFromArgs(t *testing.T) {
+	opts, err := reconcilerOptionsFromArgs([]string{
+		"--enable-leader-election",
+		"-logEncoder", "console",
+		"-supportCilium=true",
+		"--datadogCheckEnabled",
+		"-agentRolloutEnabled",
+		"-operatorMetricsEnabled=false",
+		"-edsMaxPodUnavailable", "10%",
+		"--edsCanaryDuration=5m",
+		"-edsCanaryAutoFailMaxRestarts=3",
+	})
+	require.NoError(t, err)
+
+	assert.True(t, opts.SupportCilium)
+	assert.True(t, opts.DatadogCheckEnabled)
+	assert.True(t, opts.AgentRolloutEnabled)
+	assert.False(t, opts.OperatorMetricsEnabled)
+	assert.False(t, opts.ExtendedDaemonsetOptions.Enabled)
+	assert.Equal(t, "10%", opts.ExtendedDaemonsetOptions.MaxPodUnavailable)
+	assert.Equal(t, 5*time.Minute, opts.ExtendedDaemonsetOptions.CanaryDuration)
+	assert.Equal(t, int32(3), opts.ExtendedDaemonsetOptions.CanaryAutoFailMaxRestarts)
+	// Defaults of the operator
+	assert.True(t, opts.ExtendedDaemonsetOptions.CanaryAutoPauseEnabled)
+
+	_, err = reconcilerOptionsFromArgs([]string{"-supportCilium=maybe"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,129 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package render
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent"
+	componentagent "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/agent"
+	"github.com/DataDog/datadog-operator/pkg/plugin/common"
+)
+
+var renderExample = `
+  # render the resources the operator creates for the DatadogAgent in dda.yaml
+  %[1]s render -f dda.yaml
+
+  # render them for a cluster supporting Cilium network policies and running Kubernetes v1.29
+  %[1]s render -f dda.yaml --support-cilium --kubernetes-version v1.29.4
+`
+
+// options provides information required by Datadog render command
+type options struct {
+	genericclioptions.IOStreams
+	common.Options
+	args                     []string
+	filename                 string
+	kubernetesVersion        string
+	supportCilium            bool
+	supportExtendedDaemonset bool
+	namespace                string
+}
+
+// newOptions provides an instance of options with default values
+func newOptions(streams genericclioptions.IOStreams) *options {
+	o := &options{
+		IOStreams: streams,
+	}
+	o.SetConfigFlags()
+	return o
+}
+
+// New provides a cobra command wrapping options for "render" sub command
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+	cmd := &cobra.Command{
+		Use:          "render -f [DatadogAgent manifest] [flags]",
+		Short:        "Render the resources the operator creates for a DatadogAgent, without a cluster",
+		Example:      fmt.Sprintf(renderExample, "kubectl datadog"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+			return o.run()
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.filename, "filename", "f", "", "The DatadogAgent manifest to render")
+	cmd.Flags().StringVarP(&o.kubernetesVersion, "kubernetes-version", "", datadogagent.DefaultRenderKubernetesVersion, "The Kubernetes version of the targeted cluster")
+	cmd.Flags().BoolVarP(&o.supportCilium, "support-cilium", "", false, "Render as if the operator runs with Cilium network policies support")
+	cmd.Flags().BoolVarP(&o.supportExtendedDaemonset, "support-extendeddaemonset", "", false, "Render as if the operator runs with ExtendedDaemonset support")
+
+	o.ConfigFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// complete sets all information required for processing the command
+func (o *options) complete(cmd *cobra.Command, args []string) error {
+	o.args = args
+	// Rendering happens offline, only the namespace is read from the kubeconfig
+	nsFlag, err := cmd.Flags().GetString("namespace")
+	if err != nil {
+		return err
+	}
+	o.namespace = nsFlag
+	if o.namespace == "" {
+		if o.namespace, _, err = o.GetClientConfig().Namespace(); err != nil {
+			o.namespace = "default"
+		}
+	}
+	return nil
+}
+
+// validate ensures that all required arguments and flag values are provided
+func (o *options) validate() error {
+	if o.filename == "" {
+		return errors.New("a DatadogAgent manifest is required, use --filename")
+	}
+	if len(o.args) > 0 {
+		return fmt.Errorf("no argument is allowed, got %d", len(o.args))
+	}
+	return nil
+}
+
+// run runs the render command
+func (o *options) run() error {
+	dda, err := common.ReadDatadogAgentManifest(o.filename, o.namespace)
+	if err != nil {
+		return err
+	}
+
+	platformInfo := datadogagent.NewRenderPlatformInfo(o.kubernetesVersion)
+	objs, err := datadogagent.Render(context.TODO(), dda, datadogagent.RenderOptions{
+		ReconcilerOptions: datadogagent.ReconcilerOptions{
+			ExtendedDaemonsetOptions: componentagent.ExtendedDaemonsetOptions{
+				Enabled: o.supportExtendedDaemonset,
+			},
+			SupportCilium: o.supportCilium,
+		},
+		PlatformInfo: &platformInfo,
+	})
+	if err != nil {
+		return err
+	}
+
+	return common.PrintObjectsYAML(o.Out, objs)
+}
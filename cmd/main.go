@@ -34,8 +34,10 @@ import (
 
 	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
 	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	datadoghqv2beta1 "github.com/DataDog/datadog-operator/api/datadoghq/v2beta1"
 	"github.com/DataDog/datadog-operator/internal/controller"
 	"github.com/DataDog/datadog-operator/internal/controller/metrics"
+	"github.com/DataDog/datadog-operator/internal/webhook"
 	"github.com/DataDog/datadog-operator/pkg/config"
 	"github.com/DataDog/datadog-operator/pkg/controller/debug"
 	"github.com/DataDog/datadog-operator/pkg/remoteconfig"
@@ -126,9 +128,16 @@ type options struct {
 	maximumGoroutines                      int
 	introspectionEnabled                   bool
 	datadogAgentProfileEnabled             bool
+	agentRolloutEnabled                    bool
 	remoteConfigEnabled                    bool
 	datadogDashboardEnabled                bool
 	datadogGenericResourceEnabled          bool
+	datadogCheckEnabled                    bool
+
+	// Webhook options
+	validatingWebhookEnabled bool
+	mutatingWebhookEnabled   bool
+	conversionWebhookEnabled bool
 
 	// Secret Backend options
 	secretBackendCommand string
@@ -161,9 +170,16 @@ func (opts *options) Parse() {
 	flag.IntVar(&opts.maximumGoroutines, "maximumGoroutines", defaultMaximumGoroutines, "Override health check threshold for maximum number of goroutines.")
 	flag.BoolVar(&opts.introspectionEnabled, "introspectionEnabled", false, "Enable introspection (beta)")
 	flag.BoolVar(&opts.datadogAgentProfileEnabled, "datadogAgentProfileEnabled", false, "Enable DatadogAgentProfile controller (beta)")
+	flag.BoolVar(&opts.agentRolloutEnabled, "agentRolloutEnabled", false, "Enable the staged rollout and the automatic rollback of the DatadogAgent components, which cache and watch their pods (beta)")
 	flag.BoolVar(&opts.remoteConfigEnabled, "remoteConfigEnabled", false, "Enable RemoteConfig capabilities in the Operator (beta)")
 	flag.BoolVar(&opts.datadogDashboardEnabled, "datadogDashboardEnabled", false, "Enable the DatadogDashboard controller")
 	flag.BoolVar(&opts.datadogGenericResourceEnabled, "datadogGenericResourceEnabled", false, "Enable the DatadogGenericResource controller")
+	flag.BoolVar(&opts.datadogCheckEnabled, "datadogCheckEnabled", false, "Enable the DatadogCheck controller")
+
+	// Webhook
+	flag.BoolVar(&opts.validatingWebhookEnabled, "validatingWebhookEnabled", false, "Enable the validating admission webhook for DatadogAgent resources")
+	flag.BoolVar(&opts.mutatingWebhookEnabled, "mutatingWebhookEnabled", false, "Enable the mutating admission webhook writing the defaults in DatadogAgent resources")
+	flag.BoolVar(&opts.conversionWebhookEnabled, "conversionWebhookEnabled", false, "Enable the conversion webhook between the DatadogAgent API versions")
 
 	// DatadogAgentInternal
 	flag.BoolVar(&opts.datadogAgentInternalEnabled, "datadogAgentInternalEnabled", false, "Enable the DatadogAgentInternal controller")
@@ -250,6 +266,11 @@ func run(opts *options) error {
 		metricsServerOptions.FilterProvider = filters.WithAuthenticationAndAuthorization
 	}
 
+	// v2beta1 is only served by the CRD along with the conversion webhook
+	if opts.conversionWebhookEnabled {
+		utilruntime.Must(datadoghqv2beta1.AddToScheme(scheme))
+	}
+
 	restConfig := ctrl.GetConfigOrDie()
 	restConfig.UserAgent = "datadog-operator"
 	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
@@ -269,8 +290,10 @@ func run(opts *options) error {
 			DatadogSLOEnabled:             opts.datadogSLOEnabled,
 			DatadogAgentProfileEnabled:    opts.datadogAgentProfileEnabled,
 			IntrospectionEnabled:          opts.introspectionEnabled,
+			AgentRolloutEnabled:           opts.agentRolloutEnabled,
 			DatadogDashboardEnabled:       opts.datadogDashboardEnabled,
 			DatadogGenericResourceEnabled: opts.datadogGenericResourceEnabled,
+			DatadogCheckEnabled:           opts.datadogCheckEnabled,
 		}),
 	})
 	if err != nil {
@@ -324,14 +347,27 @@ func run(opts *options) error {
 		V2APIEnabled:                  true,
 		IntrospectionEnabled:          opts.introspectionEnabled,
 		DatadogAgentProfileEnabled:    opts.datadogAgentProfileEnabled,
+		AgentRolloutEnabled:           opts.agentRolloutEnabled,
 		DatadogDashboardEnabled:       opts.datadogDashboardEnabled,
 		DatadogGenericResourceEnabled: opts.datadogGenericResourceEnabled,
+		DatadogCheckEnabled:           opts.datadogCheckEnabled,
 	}
 
 	if err = controller.SetupControllers(setupLog, mgr, options); err != nil {
 		return setupErrorf(setupLog, err, "Unable to start controllers")
 	}
 
+	webhookOptions := webhook.Options{
+		ValidationEnabled: opts.validatingWebhookEnabled,
+		DefaultingEnabled: opts.mutatingWebhookEnabled,
+		ConversionEnabled: opts.conversionWebhookEnabled,
+
+		DatadogAgentInternalEnabled: opts.datadogAgentInternalEnabled,
+	}
+	if err = webhook.SetupWebhooks(ctrl.Log.WithName("webhooks"), mgr, webhookOptions); err != nil {
+		return setupErrorf(setupLog, err, "Unable to setup webhooks")
+	}
+
 	// +kubebuilder:scaffold:builder
 
 	setupLog.Info("starting manager")
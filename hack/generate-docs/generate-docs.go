@@ -70,6 +70,8 @@ func generateDoc(header []byte, crd apiextensions.CustomResourceDefinitionVersio
 	// Write prop content
 	var generator = map[string]func(*os.File, apiextensions.CustomResourceDefinitionVersion){
 		"v2alpha1": generateContent_v2alpha1,
+		// v2beta1 has the same layout as v2alpha1
+		"v2beta1": generateContent_v2alpha1,
 	}
 	generator[version](f, crd)
 
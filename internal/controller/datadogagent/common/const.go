@@ -39,6 +39,9 @@ const (
 
 	SystemProbeAppArmorAnnotationKey   = "container.apparmor.security.beta.kubernetes.io/system-probe"
 	SystemProbeAppArmorAnnotationValue = "unconfined"
+
+	// SeccompProfileAgentVersionAnnotationKey is set on the generated SeccompProfile with the Agent version the profile was written for
+	SeccompProfileAgentVersionAnnotationKey = "agent.datadoghq.com/seccomp-profile-agent-version"
 )
 
 // Condition types
@@ -49,10 +52,22 @@ const (
 	AgentReconcileConditionType = "AgentReconcile"
 	// ClusterChecksRunnerReconcileConditionType ReconcileConditionType for Cluster Checks Runner component
 	ClusterChecksRunnerReconcileConditionType = "ClusterChecksRunnerReconcile"
+	// OtelAgentGatewayReconcileConditionType ReconcileConditionType for OTel Agent Gateway component
+	OtelAgentGatewayReconcileConditionType = "OtelAgentGatewayReconcile"
 	// OverrideReconcileConflictConditionType ReconcileConditionType for override conflict
 	OverrideReconcileConflictConditionType = "OverrideReconcileConflict"
 	// DatadogAgentReconcileErrorConditionType ReconcileConditionType for DatadogAgent reconcile error
 	DatadogAgentReconcileErrorConditionType = "DatadogAgentReconcileError"
+	// NodeSelectorOverlapConditionType ReconcileConditionType for nodes selected by several DatadogAgents
+	NodeSelectorOverlapConditionType = "NodeSelectorOverlap"
+	// RolledBackConditionType ReconcileConditionType for components rolled back by the automatic rollback
+	RolledBackConditionType = "RolledBack"
+	// AutoRollbackUnsupportedConditionType ReconcileConditionType for components the automatic rollback cannot revert
+	AutoRollbackUnsupportedConditionType = "AutoRollbackUnsupported"
+	// ReconcilePausedConditionType ReconcileConditionType for components whose updates are paused
+	ReconcilePausedConditionType = "ReconcilePaused"
+	// SeccompProfileDriftConditionType ReconcileConditionType for a generated SeccompProfile not matching the Agent version
+	SeccompProfileDriftConditionType = "SeccompProfileDrift"
 )
 
 const (
@@ -62,6 +77,10 @@ const (
 	DefaultClusterAgentServicePort = 5005
 	// DefaultDogstatsdPort default dogstatsd port
 	DefaultDogstatsdPort = 8125
+	// DefaultOtelGRPCPort default OTLP gRPC receiver port of the otel agent
+	DefaultOtelGRPCPort = 4317
+	// DefaultOtelHTTPPort default OTLP HTTP receiver port of the otel agent
+	DefaultOtelHTTPPort = 4318
 	// DefaultSystemProbeSocketPath default System Probe socket path
 	DefaultSystemProbeSocketPath string = "/var/run/sysprobe/sysprobe.sock"
 )
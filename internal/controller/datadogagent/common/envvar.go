@@ -15,6 +15,12 @@ const (
 	DDClusterAgentKubeServiceName       = "DD_CLUSTER_AGENT_KUBERNETES_SERVICE_NAME"
 	DDClusterAgentTokenName             = "DD_CLUSTER_AGENT_TOKEN_NAME"
 	DDContainerCollectionEnabled        = "DD_PROCESS_CONFIG_CONTAINER_COLLECTION_ENABLED"
+	DDContainerExclude                  = "DD_CONTAINER_EXCLUDE"
+	DDContainerExcludeLogs              = "DD_CONTAINER_EXCLUDE_LOGS"
+	DDContainerExcludeMetrics           = "DD_CONTAINER_EXCLUDE_METRICS"
+	DDContainerInclude                  = "DD_CONTAINER_INCLUDE"
+	DDContainerIncludeLogs              = "DD_CONTAINER_INCLUDE_LOGS"
+	DDContainerIncludeMetrics           = "DD_CONTAINER_INCLUDE_METRICS"
 	DDDogstatsdEnabled                  = "DD_USE_DOGSTATSD"
 	DDHealthPort                        = "DD_HEALTH_PORT"
 	DDHostRootEnvVar                    = "HOST_ROOT"
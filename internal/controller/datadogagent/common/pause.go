@@ -0,0 +1,91 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package common
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+const (
+	// PauseReconcileAnnotationKey lists the components whose workload is not updated by the operator, separated by commas.
+	// For example: `nodeAgent,clusterAgent`.
+	PauseReconcileAnnotationKey = "agent.datadoghq.com/pause-reconcile"
+	// PauseReconcileUntilAnnotationKey is the optional end of the pause, in RFC 3339 format.
+	// For example: `2024-01-01T12:00:00Z`.
+	PauseReconcileUntilAnnotationKey = "agent.datadoghq.com/pause-reconcile-until"
+)
+
+// ReconcilePause contains the components paused by the pause-reconcile annotations
+type ReconcilePause struct {
+	// Components are the paused components
+	Components []v2alpha1.ComponentName
+	// Until is the end of the pause, nil if the pause does not expire
+	Until *time.Time
+}
+
+// ParseReconcilePause parses the pause-reconcile annotations. It returns nil when no component is paused,
+// or when the pause has expired.
+func ParseReconcilePause(annotations map[string]string, now time.Time) (*ReconcilePause, error) {
+	value := strings.TrimSpace(annotations[PauseReconcileAnnotationKey])
+	if value == "" {
+		return nil, nil
+	}
+
+	pause := &ReconcilePause{}
+	for _, name := range strings.Split(value, ",") {
+		component := v2alpha1.ComponentName(strings.TrimSpace(name))
+		switch component {
+		case v2alpha1.NodeAgentComponentName, v2alpha1.ClusterAgentComponentName, v2alpha1.ClusterChecksRunnerComponentName,
+			v2alpha1.OtelAgentGatewayComponentName:
+			pause.Components = append(pause.Components, component)
+		default:
+			return nil, fmt.Errorf("unknown component %q in annotation %s", component, PauseReconcileAnnotationKey)
+		}
+	}
+
+	if until, found := annotations[PauseReconcileUntilAnnotationKey]; found {
+		deadline, err := time.Parse(time.RFC3339, until)
+		if err != nil {
+			return nil, fmt.Errorf("invalid annotation %s: %w", PauseReconcileUntilAnnotationKey, err)
+		}
+		if !now.Before(deadline) {
+			return nil, nil
+		}
+		pause.Until = &deadline
+	}
+
+	return pause, nil
+}
+
+// IsPaused returns true if the updates of the component are paused
+func (p *ReconcilePause) IsPaused(component v2alpha1.ComponentName) bool {
+	if p == nil {
+		return false
+	}
+	for _, paused := range p.Components {
+		if paused == component {
+			return true
+		}
+	}
+	return false
+}
+
+// IsReconcilePaused returns true if the updates of the component are paused by the pause-reconcile annotations.
+// Invalid annotations are logged and ignored.
+func IsReconcilePaused(logger logr.Logger, annotations map[string]string, component v2alpha1.ComponentName) bool {
+	pause, err := ParseReconcilePause(annotations, time.Now())
+	if err != nil {
+		logger.Error(err, "Ignoring the pause-reconcile annotations")
+		return false
+	}
+	return pause.IsPaused(component)
+}
@@ -0,0 +1,91 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+func TestParseReconcilePause(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	until := now.Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        *ReconcilePause
+		wantErr     bool
+	}{
+		{
+			name: "no annotation",
+		},
+		{
+			name:        "paused components",
+			annotations: map[string]string{PauseReconcileAnnotationKey: "nodeAgent, clusterAgent"},
+			want:        &ReconcilePause{Components: []v2alpha1.ComponentName{v2alpha1.NodeAgentComponentName, v2alpha1.ClusterAgentComponentName}},
+		},
+		{
+			name: "pause with a deadline",
+			annotations: map[string]string{
+				PauseReconcileAnnotationKey:      "clusterChecksRunner",
+				PauseReconcileUntilAnnotationKey: until.Format(time.RFC3339),
+			},
+			want: &ReconcilePause{Components: []v2alpha1.ComponentName{v2alpha1.ClusterChecksRunnerComponentName}, Until: &until},
+		},
+		{
+			name:        "paused otel agent gateway",
+			annotations: map[string]string{PauseReconcileAnnotationKey: "otelAgentGateway"},
+			want:        &ReconcilePause{Components: []v2alpha1.ComponentName{v2alpha1.OtelAgentGatewayComponentName}},
+		},
+		{
+			name: "expired pause",
+			annotations: map[string]string{
+				PauseReconcileAnnotationKey:      "nodeAgent",
+				PauseReconcileUntilAnnotationKey: now.Add(-time.Minute).Format(time.RFC3339),
+			},
+		},
+		{
+			name:        "unknown component",
+			annotations: map[string]string{PauseReconcileAnnotationKey: "agent"},
+			wantErr:     true,
+		},
+		{
+			name: "invalid deadline",
+			annotations: map[string]string{
+				PauseReconcileAnnotationKey:      "nodeAgent",
+				PauseReconcileUntilAnnotationKey: "1h",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pause, err := ParseReconcilePause(tt.annotations, now)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, pause)
+		})
+	}
+}
+
+func TestReconcilePause_IsPaused(t *testing.T) {
+	pause := &ReconcilePause{Components: []v2alpha1.ComponentName{v2alpha1.NodeAgentComponentName}}
+	assert.True(t, pause.IsPaused(v2alpha1.NodeAgentComponentName))
+	assert.False(t, pause.IsPaused(v2alpha1.ClusterAgentComponentName))
+
+	var noPause *ReconcilePause
+	assert.False(t, noPause.IsPaused(v2alpha1.NodeAgentComponentName))
+}
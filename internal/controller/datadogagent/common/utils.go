@@ -80,6 +80,11 @@ func GetDefaultSeccompConfigMapName(dda metav1.Object) string {
 	return fmt.Sprintf("%s-%s", dda.GetName(), SystemProbeAgentSecurityConfigMapSuffixName)
 }
 
+// GetDefaultSeccompProfileName returns the name of the SeccompProfile generated for the Security Profiles Operator
+func GetDefaultSeccompProfileName(dda metav1.Object) string {
+	return fmt.Sprintf("%s-%s", dda.GetName(), SystemProbeAgentSecurityConfigMapSuffixName)
+}
+
 // GetAgentVersionFromImage returns the Agent version based on the AgentImageConfig
 func GetAgentVersionFromImage(imageConfig v2alpha1.AgentImageConfig) string {
 	version := ""
@@ -133,3 +138,24 @@ func ShouldCreateAgentLocalService(versionInfo *version.Info, forceEnableLocalSe
 	// Service Internal Traffic Policy is enabled by default since 1.22
 	return utils.IsAboveMinVersion(versionInfo.GitVersion, localServiceDefaultMinimumVersion) || forceEnableLocalService
 }
+
+// GetContainerFilter returns the Agent container filter selecting the containers matched by the filters,
+// for example: `kube_namespace:^kube-system$ image:^nginx$`
+func GetContainerFilter(filters ...*v2alpha1.ContainerFilter) string {
+	var selectors []string
+	for _, filter := range filters {
+		if filter == nil {
+			continue
+		}
+		for _, namespace := range filter.Namespaces {
+			selectors = append(selectors, "kube_namespace:"+namespace)
+		}
+		for _, image := range filter.Images {
+			selectors = append(selectors, "image:"+image)
+		}
+		for _, name := range filter.Names {
+			selectors = append(selectors, "name:"+name)
+		}
+	}
+	return strings.Join(selectors, " ")
+}
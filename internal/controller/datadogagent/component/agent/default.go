@@ -6,6 +6,7 @@
 package agent
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/DataDog/datadog-operator/pkg/constants"
 	"github.com/DataDog/datadog-operator/pkg/images"
 	"github.com/DataDog/datadog-operator/pkg/secrets"
+	securityprofilesoperator "github.com/DataDog/datadog-operator/pkg/securityprofilesoperator/v1beta1"
 )
 
 // NewDefaultAgentDaemonset return a new default agent DaemonSet
@@ -320,6 +322,16 @@ func DefaultSeccompConfigDataForSystemProbe() map[string]string {
 	}
 }
 
+// DefaultSeccompProfileSpecForSystemProbe returns the default System Probe seccomp profile
+// as a Security Profiles Operator SeccompProfile spec
+func DefaultSeccompProfileSpecForSystemProbe() (securityprofilesoperator.SeccompProfileSpec, error) {
+	profileSpec := securityprofilesoperator.SeccompProfileSpec{}
+	if err := json.Unmarshal([]byte(DefaultSeccompConfigDataForSystemProbe()[common.SystemProbeSeccompKey]), &profileSpec); err != nil {
+		return profileSpec, fmt.Errorf("unable to parse the default system-probe seccomp profile: %w", err)
+	}
+	return profileSpec, nil
+}
+
 // GetAgentRoleName returns the name of the role for the Agent
 func GetAgentRoleName(dda metav1.Object) string {
 	return fmt.Sprintf("%s-%s", dda.GetName(), constants.DefaultAgentResourceSuffix)
@@ -18,6 +18,7 @@ import (
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/component"
 	componentccr "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/clusterchecksrunner"
+	componentotelgateway "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/otelagentgateway"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
 	cilium "github.com/DataDog/datadog-operator/pkg/cilium/v1"
 	"github.com/DataDog/datadog-operator/pkg/kubernetes"
@@ -124,6 +125,20 @@ func BuildKubernetesNetworkPolicy(dda metav1.Object, componentName v2alpha1.Comp
 			},
 		}
 		ingress = []netv1.NetworkPolicyIngressRule{}
+	case v2alpha1.OtelAgentGatewayComponentName:
+		// The gateway sends the telemetry to the Datadog intake and queries the
+		// Kubernetes API server for the k8sattributes processor.
+		egress = []netv1.NetworkPolicyEgressRule{
+			{
+				Ports: append([]netv1.NetworkPolicyPort{}, ddIntakePort()),
+			},
+		}
+		// The node agents and the applications send their telemetry to the gateway
+		ingress = []netv1.NetworkPolicyIngressRule{
+			{
+				Ports: otlpPorts(),
+			},
+		}
 	}
 
 	return policyName, ddaNamespace, podSelector, policyTypes, ingress, egress
@@ -138,6 +153,8 @@ func GetNetworkPolicyMetadata(dda metav1.Object, componentName v2alpha1.Componen
 		policyName = component.GetClusterAgentName(dda)
 	case v2alpha1.ClusterChecksRunnerComponentName:
 		policyName = componentccr.GetClusterChecksRunnerName(dda)
+	case v2alpha1.OtelAgentGatewayComponentName:
+		policyName = componentotelgateway.GetOtelAgentGatewayName(dda)
 	}
 	podSelector = metav1.LabelSelector{
 		MatchLabels: map[string]string{
@@ -168,7 +185,25 @@ func dcaServicePort() netv1.NetworkPolicyPort {
 	}
 }
 
-// BuildCiliumPolicy creates the base node agent, DCA, or CCR cilium network policy
+// default OTLP gRPC and HTTP ports of the otel agent gateway
+func otlpPorts() []netv1.NetworkPolicyPort {
+	return []netv1.NetworkPolicyPort{
+		{
+			Port: &intstr.IntOrString{
+				Type:   intstr.Int,
+				IntVal: common.DefaultOtelGRPCPort,
+			},
+		},
+		{
+			Port: &intstr.IntOrString{
+				Type:   intstr.Int,
+				IntVal: common.DefaultOtelHTTPPort,
+			},
+		},
+	}
+}
+
+// BuildCiliumPolicy creates the base node agent, DCA, CCR, or otel agent gateway cilium network policy
 func BuildCiliumPolicy(dda metav1.Object, site string, ddURL string, hostNetwork bool, dnsSelectorEndpoints []metav1.LabelSelector, componentName v2alpha1.ComponentName) (string, string, []cilium.NetworkPolicySpec) {
 	policyName, podSelector := GetNetworkPolicyMetadata(dda, componentName)
 	var policySpecs []cilium.NetworkPolicySpec
@@ -204,6 +239,13 @@ func BuildCiliumPolicy(dda metav1.Object, site string, ddURL string, hostNetwork
 			egressCCRToDCA(podSelector, dda),
 			egressChecks(podSelector),
 		}
+	case v2alpha1.OtelAgentGatewayComponentName:
+		policySpecs = []cilium.NetworkPolicySpec{
+			egressDNS(podSelector, dnsSelectorEndpoints),
+			egressCCRDatadogIntake(podSelector, site, ddURL),
+			egressKubeAPIServer(podSelector),
+			ingressOTLP(podSelector),
+		}
 	}
 	return policyName, dda.GetNamespace(), policySpecs
 }
@@ -410,6 +452,35 @@ func ingressDogstatsd(podSelector metav1.LabelSelector) cilium.NetworkPolicySpec
 	}
 }
 
+// cilium ingress for the OTLP receivers of the otel agent gateway
+func ingressOTLP(podSelector metav1.LabelSelector) cilium.NetworkPolicySpec {
+	return cilium.NetworkPolicySpec{
+		Description:      "Ingress for OTLP",
+		EndpointSelector: podSelector,
+		Ingress: []cilium.IngressRule{
+			{
+				FromEndpoints: []metav1.LabelSelector{
+					{},
+				},
+				ToPorts: []cilium.PortRule{
+					{
+						Ports: []cilium.PortProtocol{
+							{
+								Port:     strconv.Itoa(common.DefaultOtelGRPCPort),
+								Protocol: cilium.ProtocolTCP,
+							},
+							{
+								Port:     strconv.Itoa(common.DefaultOtelHTTPPort),
+								Protocol: cilium.ProtocolTCP,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 // cilium egress to metadata server for cloud providers
 func egressMetadataServerRule(podSelector metav1.LabelSelector) cilium.NetworkPolicySpec {
 	return cilium.NetworkPolicySpec{
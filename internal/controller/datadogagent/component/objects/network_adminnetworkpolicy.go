@@ -0,0 +1,199 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package objects
+
+import (
+	"fmt"
+	"net/url"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	adminnetworkpolicy "github.com/DataDog/datadog-operator/pkg/adminnetworkpolicy/v1alpha1"
+)
+
+// AdminNetworkPolicyPriority is the priority of the admin network policies created for the agents.
+// A lower value has a higher precedence, the policies only contain Allow rules.
+const AdminNetworkPolicyPriority int32 = 50
+
+// BuildAdminNetworkPolicy creates the base node agent, DCA, CCR, or otel agent gateway admin network policy.
+// Admin network policies are evaluated before the NetworkPolicies and don't deny the traffic that isn't matched,
+// they ensure that the agent traffic is allowed regardless of the policies of the cluster.
+func BuildAdminNetworkPolicy(dda metav1.Object, site string, ddURL string, hostNetwork bool, componentName v2alpha1.ComponentName) (string, adminnetworkpolicy.AdminNetworkPolicySpec) {
+	_, podSelector := GetNetworkPolicyMetadata(dda, componentName)
+	policySpec := adminnetworkpolicy.AdminNetworkPolicySpec{
+		Priority: AdminNetworkPolicyPriority,
+		Subject: adminnetworkpolicy.Subject{
+			Pods: anpNamespacedPod(dda.GetNamespace(), podSelector),
+		},
+	}
+
+	switch componentName {
+	case v2alpha1.NodeAgentComponentName:
+		policySpec.Egress = []adminnetworkpolicy.EgressRule{
+			anpEgressDNS(),
+			anpEgressRule("egress-ntp", adminnetworkpolicy.EgressPeer{DomainNames: []string{"*.datadog.pool.ntp.org"}}, anpPorts(corev1.ProtocolUDP, 123)),
+			anpEgressMetadataServer(),
+			anpEgressECSPorts(),
+			anpEgressRule("egress-datadog-intake", adminnetworkpolicy.EgressPeer{DomainNames: append(anpDefaultDDDomainNames(site, ddURL),
+				fmt.Sprintf("api.%s", site),
+				fmt.Sprintf("agent-intake.logs.%s", site),
+				fmt.Sprintf("agent-http-intake.logs.%s", site),
+				fmt.Sprintf("process.%s", site),
+				fmt.Sprintf("orchestrator.%s", site),
+			)}, anpPorts(corev1.ProtocolTCP, 443, 10516)),
+			anpEgressRule("egress-kubelet", adminnetworkpolicy.EgressPeer{Nodes: &metav1.LabelSelector{}}, anpPorts(corev1.ProtocolTCP, 10250)),
+			anpEgressRule("egress-checks", adminnetworkpolicy.EgressPeer{Namespaces: &metav1.LabelSelector{}}, nil),
+		}
+		policySpec.Ingress = []adminnetworkpolicy.IngressRule{
+			anpIngressRule("ingress-dogstatsd", nil, anpPorts(corev1.ProtocolUDP, common.DefaultDogstatsdPort)),
+		}
+	case v2alpha1.ClusterAgentComponentName:
+		_, nodeAgentPodSelector := GetNetworkPolicyMetadata(dda, v2alpha1.NodeAgentComponentName)
+		policySpec.Egress = []adminnetworkpolicy.EgressRule{
+			anpEgressDNS(),
+			anpEgressMetadataServer(),
+			anpEgressRule("egress-datadog-intake", adminnetworkpolicy.EgressPeer{DomainNames: append(anpDefaultDDDomainNames(site, ddURL),
+				fmt.Sprintf("orchestrator.%s", site),
+			)}, anpPorts(corev1.ProtocolTCP, 443)),
+			anpEgressKubeAPIServer(),
+			anpEgressRule("egress-cluster-agent", adminnetworkpolicy.EgressPeer{Pods: anpNamespacedPod(dda.GetNamespace(), podSelector)}, anpPorts(corev1.ProtocolTCP, common.DefaultClusterAgentServicePort)),
+		}
+		policySpec.Ingress = []adminnetworkpolicy.IngressRule{
+			anpIngressRule("ingress-cluster-agent", anpNamespacedPod(dda.GetNamespace(), podSelector), anpPorts(corev1.ProtocolTCP, common.DefaultClusterAgentServicePort)),
+		}
+		// Admin network policies can only select pods as ingress peers, the node agents using the IP of
+		// their node can't be selected.
+		if !hostNetwork {
+			policySpec.Ingress = append(policySpec.Ingress,
+				anpIngressRule("ingress-agent", anpNamespacedPod(dda.GetNamespace(), nodeAgentPodSelector), anpPorts(corev1.ProtocolTCP, 5000, common.DefaultClusterAgentServicePort)),
+			)
+		}
+	case v2alpha1.ClusterChecksRunnerComponentName:
+		_, dcaPodSelector := GetNetworkPolicyMetadata(dda, v2alpha1.ClusterAgentComponentName)
+		policySpec.Egress = []adminnetworkpolicy.EgressRule{
+			anpEgressDNS(),
+			anpEgressMetadataServer(),
+			anpEgressRule("egress-datadog-intake", adminnetworkpolicy.EgressPeer{DomainNames: anpDefaultDDDomainNames(site, ddURL)}, anpPorts(corev1.ProtocolTCP, 443)),
+			anpEgressRule("egress-cluster-agent", adminnetworkpolicy.EgressPeer{Pods: anpNamespacedPod(dda.GetNamespace(), dcaPodSelector)}, anpPorts(corev1.ProtocolTCP, common.DefaultClusterAgentServicePort)),
+			anpEgressRule("egress-checks", adminnetworkpolicy.EgressPeer{Namespaces: &metav1.LabelSelector{}}, nil),
+		}
+	case v2alpha1.OtelAgentGatewayComponentName:
+		policySpec.Egress = []adminnetworkpolicy.EgressRule{
+			anpEgressDNS(),
+			anpEgressRule("egress-datadog-intake", adminnetworkpolicy.EgressPeer{DomainNames: anpDefaultDDDomainNames(site, ddURL)}, anpPorts(corev1.ProtocolTCP, 443)),
+			anpEgressKubeAPIServer(),
+		}
+		policySpec.Ingress = []adminnetworkpolicy.IngressRule{
+			anpIngressRule("ingress-otlp", nil, anpPorts(corev1.ProtocolTCP, common.DefaultOtelGRPCPort, common.DefaultOtelHTTPPort)),
+		}
+	}
+
+	return GetClusterNetworkPolicyName(dda, componentName), policySpec
+}
+
+// BuildAdminNetworkPolicyIngress creates the admin network policy spec allowing the ingress of a feature to a
+// component, from any pod or from the pods matching sourcePodSelector in the namespace of the DatadogAgent.
+func BuildAdminNetworkPolicyIngress(dda metav1.Object, componentName v2alpha1.ComponentName, ruleName string, sourcePodSelector *metav1.LabelSelector, ports ...int32) (string, adminnetworkpolicy.AdminNetworkPolicySpec) {
+	var from *adminnetworkpolicy.NamespacedPod
+	if sourcePodSelector != nil {
+		from = anpNamespacedPod(dda.GetNamespace(), *sourcePodSelector)
+	}
+
+	return GetClusterNetworkPolicyName(dda, componentName), adminnetworkpolicy.AdminNetworkPolicySpec{
+		Ingress: []adminnetworkpolicy.IngressRule{
+			anpIngressRule(ruleName, from, anpPorts(corev1.ProtocolTCP, ports...)),
+		},
+	}
+}
+
+func anpNamespacedPod(namespace string, podSelector metav1.LabelSelector) *adminnetworkpolicy.NamespacedPod {
+	return &adminnetworkpolicy.NamespacedPod{
+		NamespaceSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				corev1.LabelMetadataName: namespace,
+			},
+		},
+		PodSelector: podSelector,
+	}
+}
+
+func anpPorts(protocol corev1.Protocol, ports ...int32) []adminnetworkpolicy.Port {
+	anpPorts := make([]adminnetworkpolicy.Port, 0, len(ports))
+	for _, port := range ports {
+		anpPorts = append(anpPorts, adminnetworkpolicy.Port{
+			PortNumber: &adminnetworkpolicy.PortNumber{
+				Protocol: protocol,
+				Port:     port,
+			},
+		})
+	}
+	return anpPorts
+}
+
+func anpEgressRule(name string, to adminnetworkpolicy.EgressPeer, ports []adminnetworkpolicy.Port) adminnetworkpolicy.EgressRule {
+	return adminnetworkpolicy.EgressRule{
+		Name:   name,
+		Action: adminnetworkpolicy.RuleActionAllow,
+		To:     []adminnetworkpolicy.EgressPeer{to},
+		Ports:  ports,
+	}
+}
+
+// anpIngressRule allows the ingress from the source pods, or from any pod if source is nil.
+func anpIngressRule(name string, source *adminnetworkpolicy.NamespacedPod, ports []adminnetworkpolicy.Port) adminnetworkpolicy.IngressRule {
+	from := adminnetworkpolicy.IngressPeer{
+		Namespaces: &metav1.LabelSelector{},
+	}
+	if source != nil {
+		from = adminnetworkpolicy.IngressPeer{
+			Pods: source,
+		}
+	}
+
+	return adminnetworkpolicy.IngressRule{
+		Name:   name,
+		Action: adminnetworkpolicy.RuleActionAllow,
+		From:   []adminnetworkpolicy.IngressPeer{from},
+		Ports:  ports,
+	}
+}
+
+// admin network policy egress ports for ECS, a peer can only have one kind of selector
+func anpEgressECSPorts() adminnetworkpolicy.EgressRule {
+	rule := anpEgressRule("egress-ecs-agent", adminnetworkpolicy.EgressPeer{Nodes: &metav1.LabelSelector{}}, anpPorts(corev1.ProtocolTCP, 51678))
+	rule.To = append(rule.To, adminnetworkpolicy.EgressPeer{Networks: []string{"169.254.0.0/16"}})
+	return rule
+}
+
+// admin network policy egress to dns endpoints
+func anpEgressDNS() adminnetworkpolicy.EgressRule {
+	return anpEgressRule("egress-dns", adminnetworkpolicy.EgressPeer{Networks: []string{"0.0.0.0/0", "::/0"}},
+		append(anpPorts(corev1.ProtocolUDP, 53), anpPorts(corev1.ProtocolTCP, 53)...))
+}
+
+// admin network policy egress to metadata server for cloud providers
+func anpEgressMetadataServer() adminnetworkpolicy.EgressRule {
+	return anpEgressRule("egress-metadata-server", adminnetworkpolicy.EgressPeer{Networks: []string{"169.254.169.254/32"}}, anpPorts(corev1.ProtocolTCP, 80))
+}
+
+// admin network policy egress to kube api server, running on the control plane nodes
+func anpEgressKubeAPIServer() adminnetworkpolicy.EgressRule {
+	return anpEgressRule("egress-kube-apiserver", adminnetworkpolicy.EgressPeer{Nodes: &metav1.LabelSelector{}}, anpPorts(corev1.ProtocolTCP, 443, 6443))
+}
+
+// anpDefaultDDDomainNames mirrors defaultDDFQDNs, admin network policies only support a wildcard as the
+// first label of a domain name. Only the host of ddURL is a domain name, an unparsable ddURL is skipped.
+func anpDefaultDDDomainNames(site, ddURL string) []string {
+	domainNames := []string{}
+	if u, err := url.Parse(ddURL); err == nil && u.Hostname() != "" {
+		domainNames = append(domainNames, u.Hostname())
+	}
+
+	return append(domainNames, fmt.Sprintf("*.agent.%s", site))
+}
@@ -0,0 +1,56 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package objects
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_anpDefaultDDDomainNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		ddURL string
+		want  []string
+	}{
+		{
+			name: "no url",
+			want: []string{"*.agent.datadoghq.com"},
+		},
+		{
+			name:  "https url",
+			ddURL: "https://app.datadoghq.com",
+			want:  []string{"app.datadoghq.com", "*.agent.datadoghq.com"},
+		},
+		{
+			name:  "http url",
+			ddURL: "http://intake.example.com",
+			want:  []string{"intake.example.com", "*.agent.datadoghq.com"},
+		},
+		{
+			name:  "url with a port",
+			ddURL: "https://intake.example.com:8443",
+			want:  []string{"intake.example.com", "*.agent.datadoghq.com"},
+		},
+		{
+			name:  "url with a path",
+			ddURL: "https://intake.example.com/api/v1/",
+			want:  []string{"intake.example.com", "*.agent.datadoghq.com"},
+		},
+		{
+			name:  "unparsable url",
+			ddURL: "https://intake example.com:port",
+			want:  []string{"*.agent.datadoghq.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, anpDefaultDDDomainNames("datadoghq.com", tt.ddURL))
+		})
+	}
+}
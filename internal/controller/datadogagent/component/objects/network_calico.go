@@ -0,0 +1,185 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package objects
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	calico "github.com/DataDog/datadog-operator/pkg/calico/v3"
+)
+
+// GetClusterNetworkPolicyName returns the name of the cluster scoped network policy of a component.
+// The namespace is part of the name, as the component names are only unique within a namespace.
+func GetClusterNetworkPolicyName(dda metav1.Object, componentName v2alpha1.ComponentName) string {
+	policyName, _ := GetNetworkPolicyMetadata(dda, componentName)
+	return fmt.Sprintf("%s-%s", dda.GetNamespace(), policyName)
+}
+
+// GetCalicoSelector converts a pod selector to a calico selector restricted to a namespace
+func GetCalicoSelector(namespace string, podSelector metav1.LabelSelector) string {
+	keys := make([]string, 0, len(podSelector.MatchLabels))
+	for key := range podSelector.MatchLabels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	selectors := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		selectors = append(selectors, fmt.Sprintf("%s == '%s'", key, podSelector.MatchLabels[key]))
+	}
+	selectors = append(selectors, fmt.Sprintf("%s == '%s'", calico.NamespaceLabelKey, namespace))
+
+	return strings.Join(selectors, " && ")
+}
+
+// BuildCalicoPolicy creates the base node agent, DCA, CCR, or otel agent gateway calico global network policy.
+// Calico only supports domain names in policies with Calico Enterprise, so the egress to the Datadog intake is
+// allowed by port.
+func BuildCalicoPolicy(dda metav1.Object, hostNetwork bool, componentName v2alpha1.ComponentName) (string, calico.GlobalNetworkPolicySpec) {
+	_, podSelector := GetNetworkPolicyMetadata(dda, componentName)
+	policySpec := calico.GlobalNetworkPolicySpec{
+		Selector: GetCalicoSelector(dda.GetNamespace(), podSelector),
+		Types: []calico.PolicyType{
+			calico.PolicyTypeIngress,
+			calico.PolicyTypeEgress,
+		},
+	}
+
+	switch componentName {
+	case v2alpha1.NodeAgentComponentName:
+		policySpec.Egress = append(calicoEgressDNS(),
+			calicoEgressRule(calico.ProtocolUDP, calico.EntityRule{Ports: []int32{123}}),
+			calicoEgressMetadataServer(),
+			calicoEgressRule(calico.ProtocolTCP, calico.EntityRule{Ports: []int32{51678}}),
+			calicoEgressRule(calico.ProtocolTCP, calico.EntityRule{Ports: []int32{443, 10516}}),
+			calicoEgressRule(calico.ProtocolTCP, calico.EntityRule{Ports: []int32{10250}}),
+			calicoEgressChecks(),
+		)
+		policySpec.Ingress = []calico.Rule{
+			calicoIngressRule(calico.ProtocolUDP, nil, common.DefaultDogstatsdPort),
+		}
+	case v2alpha1.ClusterAgentComponentName:
+		_, nodeAgentPodSelector := GetNetworkPolicyMetadata(dda, v2alpha1.NodeAgentComponentName)
+		selector := GetCalicoSelector(dda.GetNamespace(), podSelector)
+		nodeAgentSelector := GetCalicoSelector(dda.GetNamespace(), nodeAgentPodSelector)
+		policySpec.Egress = append(calicoEgressDNS(),
+			calicoEgressMetadataServer(),
+			calicoEgressRule(calico.ProtocolTCP, calico.EntityRule{Ports: []int32{443}}),
+			calicoEgressKubeAPIServer(),
+			calicoEgressRule(calico.ProtocolTCP, calico.EntityRule{Selector: selector, Ports: []int32{common.DefaultClusterAgentServicePort}}),
+		)
+		if hostNetwork {
+			// The node agents use the IP of their node, they can't be selected
+			policySpec.Ingress = []calico.Rule{
+				calicoIngressRule(calico.ProtocolTCP, nil, 5000, common.DefaultClusterAgentServicePort),
+			}
+		} else {
+			policySpec.Ingress = []calico.Rule{
+				calicoIngressRule(calico.ProtocolTCP, &nodeAgentSelector, 5000, common.DefaultClusterAgentServicePort),
+			}
+		}
+		policySpec.Ingress = append(policySpec.Ingress, calicoIngressRule(calico.ProtocolTCP, &selector, common.DefaultClusterAgentServicePort))
+	case v2alpha1.ClusterChecksRunnerComponentName:
+		_, dcaPodSelector := GetNetworkPolicyMetadata(dda, v2alpha1.ClusterAgentComponentName)
+		policySpec.Egress = append(calicoEgressDNS(),
+			calicoEgressMetadataServer(),
+			calicoEgressRule(calico.ProtocolTCP, calico.EntityRule{Ports: []int32{443}}),
+			calicoEgressRule(calico.ProtocolTCP, calico.EntityRule{Selector: GetCalicoSelector(dda.GetNamespace(), dcaPodSelector), Ports: []int32{common.DefaultClusterAgentServicePort}}),
+			calicoEgressChecks(),
+		)
+	case v2alpha1.OtelAgentGatewayComponentName:
+		policySpec.Egress = append(calicoEgressDNS(),
+			calicoEgressRule(calico.ProtocolTCP, calico.EntityRule{Ports: []int32{443}}),
+			calicoEgressKubeAPIServer(),
+		)
+		policySpec.Ingress = []calico.Rule{
+			calicoIngressRule(calico.ProtocolTCP, nil, common.DefaultOtelGRPCPort, common.DefaultOtelHTTPPort),
+		}
+	}
+
+	return GetClusterNetworkPolicyName(dda, componentName), policySpec
+}
+
+// BuildCalicoIngressPolicy creates the calico global network policy spec allowing the ingress of a feature to a
+// component, from any source or from the pods matching sourcePodSelector in the namespace of the DatadogAgent.
+func BuildCalicoIngressPolicy(dda metav1.Object, componentName v2alpha1.ComponentName, sourcePodSelector *metav1.LabelSelector, ports ...int32) (string, calico.GlobalNetworkPolicySpec) {
+	var sourceSelector *string
+	if sourcePodSelector != nil {
+		selector := GetCalicoSelector(dda.GetNamespace(), *sourcePodSelector)
+		sourceSelector = &selector
+	}
+
+	return GetClusterNetworkPolicyName(dda, componentName), calico.GlobalNetworkPolicySpec{
+		Ingress: []calico.Rule{
+			calicoIngressRule(calico.ProtocolTCP, sourceSelector, ports...),
+		},
+	}
+}
+
+func calicoEgressRule(protocol calico.Protocol, destination calico.EntityRule) calico.Rule {
+	return calico.Rule{
+		Action:      calico.ActionAllow,
+		Protocol:    &protocol,
+		Destination: destination,
+	}
+}
+
+func calicoIngressRule(protocol calico.Protocol, sourceSelector *string, ports ...int32) calico.Rule {
+	rule := calico.Rule{
+		Action:   calico.ActionAllow,
+		Protocol: &protocol,
+		Destination: calico.EntityRule{
+			Ports: ports,
+		},
+	}
+	if sourceSelector != nil {
+		rule.Source.Selector = *sourceSelector
+	}
+	return rule
+}
+
+// calico egress to dns endpoints
+func calicoEgressDNS() []calico.Rule {
+	return []calico.Rule{
+		calicoEgressRule(calico.ProtocolUDP, calico.EntityRule{Ports: []int32{53}}),
+		calicoEgressRule(calico.ProtocolTCP, calico.EntityRule{Ports: []int32{53}}),
+	}
+}
+
+// calico egress to metadata server for cloud providers
+func calicoEgressMetadataServer() calico.Rule {
+	return calicoEgressRule(calico.ProtocolTCP, calico.EntityRule{
+		Nets:  []string{"169.254.169.254/32"},
+		Ports: []int32{80},
+	})
+}
+
+// calico egress to kube api server
+func calicoEgressKubeAPIServer() calico.Rule {
+	return calicoEgressRule(calico.ProtocolTCP, calico.EntityRule{
+		Services: &calico.ServiceMatch{
+			Name:      "kubernetes",
+			Namespace: "default",
+		},
+	})
+}
+
+// The agents are susceptible to connect to any pod that would be annotated
+// with auto-discovery annotations, they must be allowed to probe any pod.
+func calicoEgressChecks() calico.Rule {
+	return calico.Rule{
+		Action: calico.ActionAllow,
+		Destination: calico.EntityRule{
+			Selector: "all()",
+		},
+	}
+}
@@ -0,0 +1,204 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package objects
+
+import (
+	"strconv"
+
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	cilium "github.com/DataDog/datadog-operator/pkg/cilium/v1"
+)
+
+const (
+	// ciliumNamespaceLabelPrefix is the prefix of the namespace labels in the cilium endpoint selectors
+	ciliumNamespaceLabelPrefix = "k8s:io.cilium.k8s.namespace.labels."
+	// ciliumNamespaceKey is the label holding the namespace of a pod in the cilium endpoint selectors
+	ciliumNamespaceKey = "k8s:io.kubernetes.pod.namespace"
+)
+
+// BuildKubernetesNetworkPolicyExtraRules converts the user defined extra rules of a component
+// to kubernetes network policy rules
+func BuildKubernetesNetworkPolicyExtraRules(extraIngress, extraEgress []v2alpha1.NetworkPolicyRule) ([]netv1.NetworkPolicyIngressRule, []netv1.NetworkPolicyEgressRule) {
+	var ingress []netv1.NetworkPolicyIngressRule
+	for _, rule := range extraIngress {
+		ingress = append(ingress, netv1.NetworkPolicyIngressRule{
+			Ports: kubernetesExtraPorts(rule.Ports),
+			From:  kubernetesExtraPeers(rule.Peers),
+		})
+	}
+
+	var egress []netv1.NetworkPolicyEgressRule
+	for _, rule := range extraEgress {
+		egress = append(egress, netv1.NetworkPolicyEgressRule{
+			Ports: kubernetesExtraPorts(rule.Ports),
+			To:    kubernetesExtraPeers(rule.Peers),
+		})
+	}
+
+	return ingress, egress
+}
+
+// BuildCiliumPolicyExtraRules converts the user defined extra rules of a component to cilium network policy specs
+func BuildCiliumPolicyExtraRules(dda metav1.Object, componentName v2alpha1.ComponentName, extraIngress, extraEgress []v2alpha1.NetworkPolicyRule) []cilium.NetworkPolicySpec {
+	_, podSelector := GetNetworkPolicyMetadata(dda, componentName)
+	var policySpecs []cilium.NetworkPolicySpec
+
+	for _, rule := range extraIngress {
+		ports := ciliumExtraPorts(rule.Ports)
+		cidrs, endpoints, entities := ciliumExtraPeers(rule.Peers)
+		var ingress []cilium.IngressRule
+		if len(entities) > 0 {
+			ingress = append(ingress, cilium.IngressRule{FromEntities: entities, ToPorts: ports})
+		}
+		if len(cidrs) > 0 {
+			ingress = append(ingress, cilium.IngressRule{FromCIDR: cidrs, ToPorts: ports})
+		}
+		if len(endpoints) > 0 {
+			ingress = append(ingress, cilium.IngressRule{FromEndpoints: endpoints, ToPorts: ports})
+		}
+		policySpecs = append(policySpecs, cilium.NetworkPolicySpec{
+			Description:      "Extra ingress rule",
+			EndpointSelector: podSelector,
+			Ingress:          ingress,
+		})
+	}
+
+	for _, rule := range extraEgress {
+		ports := ciliumExtraPorts(rule.Ports)
+		cidrs, endpoints, entities := ciliumExtraPeers(rule.Peers)
+		var egress []cilium.EgressRule
+		if len(entities) > 0 {
+			egress = append(egress, cilium.EgressRule{ToEntities: entities, ToPorts: ports})
+		}
+		if len(cidrs) > 0 {
+			egress = append(egress, cilium.EgressRule{ToCIDR: cidrs, ToPorts: ports})
+		}
+		if len(endpoints) > 0 {
+			egress = append(egress, cilium.EgressRule{ToEndpoints: endpoints, ToPorts: ports})
+		}
+		policySpecs = append(policySpecs, cilium.NetworkPolicySpec{
+			Description:      "Extra egress rule",
+			EndpointSelector: podSelector,
+			Egress:           egress,
+		})
+	}
+
+	return policySpecs
+}
+
+func kubernetesExtraPorts(ports []v2alpha1.NetworkPolicyRulePort) []netv1.NetworkPolicyPort {
+	var policyPorts []netv1.NetworkPolicyPort
+	for _, port := range ports {
+		policyPort := netv1.NetworkPolicyPort{
+			Port: &intstr.IntOrString{
+				Type:   intstr.Int,
+				IntVal: port.Port,
+			},
+		}
+		if port.Protocol != nil {
+			protocol := *port.Protocol
+			policyPort.Protocol = &protocol
+		}
+		policyPorts = append(policyPorts, policyPort)
+	}
+	return policyPorts
+}
+
+// kubernetesExtraPeers converts the peers, validated to be either an IP block or selectors, to network policy peers.
+func kubernetesExtraPeers(peers []v2alpha1.NetworkPolicyRulePeer) []netv1.NetworkPolicyPeer {
+	var policyPeers []netv1.NetworkPolicyPeer
+	for _, peer := range peers {
+		if peer.CIDR != "" {
+			policyPeers = append(policyPeers, netv1.NetworkPolicyPeer{
+				IPBlock: &netv1.IPBlock{
+					CIDR: peer.CIDR,
+				},
+			})
+			continue
+		}
+		policyPeers = append(policyPeers, netv1.NetworkPolicyPeer{
+			PodSelector:       peer.PodSelector.DeepCopy(),
+			NamespaceSelector: peer.NamespaceSelector.DeepCopy(),
+		})
+	}
+	return policyPeers
+}
+
+func ciliumExtraPorts(ports []v2alpha1.NetworkPolicyRulePort) []cilium.PortRule {
+	if len(ports) == 0 {
+		return nil
+	}
+
+	portProtocols := make([]cilium.PortProtocol, 0, len(ports))
+	for _, port := range ports {
+		protocol := cilium.ProtocolTCP
+		if port.Protocol != nil {
+			protocol = cilium.Protocol(*port.Protocol)
+		}
+		portProtocols = append(portProtocols, cilium.PortProtocol{
+			Port:     strconv.Itoa(int(port.Port)),
+			Protocol: protocol,
+		})
+	}
+	return []cilium.PortRule{
+		{
+			Ports: portProtocols,
+		},
+	}
+}
+
+// ciliumExtraPeers splits the peers into CIDRs and endpoint selectors, a rule without peers matches all the entities.
+// Cilium doesn't support combining different kinds of peers in the same rule.
+func ciliumExtraPeers(peers []v2alpha1.NetworkPolicyRulePeer) ([]string, []metav1.LabelSelector, []cilium.Entity) {
+	if len(peers) == 0 {
+		return nil, nil, []cilium.Entity{cilium.EntityAll}
+	}
+
+	var cidrs []string
+	var endpoints []metav1.LabelSelector
+	for _, peer := range peers {
+		if peer.CIDR != "" {
+			cidrs = append(cidrs, peer.CIDR)
+			continue
+		}
+		endpoints = append(endpoints, ciliumEndpointSelector(peer.PodSelector, peer.NamespaceSelector))
+	}
+	return cidrs, endpoints, nil
+}
+
+// ciliumEndpointSelector converts a pod selector and a namespace selector to a cilium endpoint selector.
+// Without namespace selector, cilium restricts the endpoint selector to the namespace of the policy.
+func ciliumEndpointSelector(podSelector, namespaceSelector *metav1.LabelSelector) metav1.LabelSelector {
+	selector := metav1.LabelSelector{}
+	if podSelector != nil {
+		podSelector.DeepCopyInto(&selector)
+	}
+	if namespaceSelector == nil {
+		return selector
+	}
+
+	for key, value := range namespaceSelector.MatchLabels {
+		if selector.MatchLabels == nil {
+			selector.MatchLabels = map[string]string{}
+		}
+		selector.MatchLabels[ciliumNamespaceLabelPrefix+key] = value
+	}
+	for _, expression := range namespaceSelector.MatchExpressions {
+		expression = *expression.DeepCopy()
+		expression.Key = ciliumNamespaceLabelPrefix + expression.Key
+		selector.MatchExpressions = append(selector.MatchExpressions, expression)
+	}
+	// Selecting the namespace label lifts the restriction to the namespace of the policy
+	selector.MatchExpressions = append(selector.MatchExpressions, metav1.LabelSelectorRequirement{
+		Key:      ciliumNamespaceKey,
+		Operator: metav1.LabelSelectorOpExists,
+	})
+	return selector
+}
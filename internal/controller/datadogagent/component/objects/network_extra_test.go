@@ -0,0 +1,119 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package objects
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	cilium "github.com/DataDog/datadog-operator/pkg/cilium/v1"
+)
+
+func TestBuildKubernetesNetworkPolicyExtraRules(t *testing.T) {
+	udp := corev1.ProtocolUDP
+	podSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}}
+
+	ingress, egress := BuildKubernetesNetworkPolicyExtraRules(
+		[]v2alpha1.NetworkPolicyRule{
+			{
+				Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 8125, Protocol: &udp}},
+				Peers: []v2alpha1.NetworkPolicyRulePeer{{PodSelector: podSelector}},
+			},
+		},
+		[]v2alpha1.NetworkPolicyRule{
+			{
+				Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 443}},
+				Peers: []v2alpha1.NetworkPolicyRulePeer{{CIDR: "10.0.0.0/16"}},
+			},
+		},
+	)
+
+	assert.Equal(t, []netv1.NetworkPolicyIngressRule{
+		{
+			Ports: []netv1.NetworkPolicyPort{{Port: &intstr.IntOrString{Type: intstr.Int, IntVal: 8125}, Protocol: &udp}},
+			From:  []netv1.NetworkPolicyPeer{{PodSelector: podSelector}},
+		},
+	}, ingress)
+	assert.Equal(t, []netv1.NetworkPolicyEgressRule{
+		{
+			Ports: []netv1.NetworkPolicyPort{{Port: &intstr.IntOrString{Type: intstr.Int, IntVal: 443}}},
+			To:    []netv1.NetworkPolicyPeer{{IPBlock: &netv1.IPBlock{CIDR: "10.0.0.0/16"}}},
+		},
+	}, egress)
+}
+
+func TestBuildCiliumPolicyExtraRules(t *testing.T) {
+	dda := &metav1.ObjectMeta{Name: "foo", Namespace: "bar"}
+	_, podSelector := GetNetworkPolicyMetadata(dda, v2alpha1.NodeAgentComponentName)
+
+	tests := []struct {
+		name         string
+		extraIngress []v2alpha1.NetworkPolicyRule
+		extraEgress  []v2alpha1.NetworkPolicyRule
+		want         []cilium.NetworkPolicySpec
+	}{
+		{
+			name:         "no peers allows all the entities",
+			extraIngress: []v2alpha1.NetworkPolicyRule{{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 8080}}}},
+			want: []cilium.NetworkPolicySpec{
+				{
+					Description:      "Extra ingress rule",
+					EndpointSelector: podSelector,
+					Ingress: []cilium.IngressRule{
+						{
+							FromEntities: []cilium.Entity{cilium.EntityAll},
+							ToPorts:      []cilium.PortRule{{Ports: []cilium.PortProtocol{{Port: "8080", Protocol: cilium.ProtocolTCP}}}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "cidr and namespace selector are split",
+			extraEgress: []v2alpha1.NetworkPolicyRule{
+				{
+					Peers: []v2alpha1.NetworkPolicyRulePeer{
+						{CIDR: "10.0.0.0/16"},
+						{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "foo"}}},
+					},
+				},
+			},
+			want: []cilium.NetworkPolicySpec{
+				{
+					Description:      "Extra egress rule",
+					EndpointSelector: podSelector,
+					Egress: []cilium.EgressRule{
+						{
+							ToCIDR: []string{"10.0.0.0/16"},
+						},
+						{
+							ToEndpoints: []metav1.LabelSelector{
+								{
+									MatchLabels: map[string]string{"k8s:io.cilium.k8s.namespace.labels.team": "foo"},
+									MatchExpressions: []metav1.LabelSelectorRequirement{
+										{Key: "k8s:io.kubernetes.pod.namespace", Operator: metav1.LabelSelectorOpExists},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildCiliumPolicyExtraRules(dda, v2alpha1.NodeAgentComponentName, tt.extraIngress, tt.extraEgress)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
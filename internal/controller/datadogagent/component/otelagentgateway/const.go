@@ -0,0 +1,17 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package otelagentgateway
+
+const (
+	pdbMaxUnavailableInstances = 1
+	// defaultOtelAgentGatewayReplicas default otel agent gateway deployment replicas
+	defaultOtelAgentGatewayReplicas = 1
+
+	// OtelGRPCPortName is the name of the OTLP gRPC port of the OTel Agent Gateway
+	OtelGRPCPortName = "otel-grpc"
+	// OtelHTTPPortName is the name of the OTLP HTTP port of the OTel Agent Gateway
+	OtelHTTPPortName = "otel-http"
+)
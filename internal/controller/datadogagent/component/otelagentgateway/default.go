@@ -0,0 +1,208 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package otelagentgateway
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+	"github.com/DataDog/datadog-operator/pkg/images"
+)
+
+// GetOtelAgentGatewayName return the OTel Agent Gateway name based on the DatadogAgent name
+func GetOtelAgentGatewayName(dda metav1.Object) string {
+	return fmt.Sprintf("%s-%s", dda.GetName(), constants.DefaultOtelAgentGatewayResourceSuffix)
+}
+
+// GetOtelAgentGatewayServiceName return the OTel Agent Gateway service name based on the DatadogAgent name
+func GetOtelAgentGatewayServiceName(dda metav1.Object) string {
+	return fmt.Sprintf("%s-%s", dda.GetName(), constants.DefaultOtelAgentGatewayResourceSuffix)
+}
+
+// GetOtelAgentGatewayHeadlessServiceName return the OTel Agent Gateway headless service name based on the DatadogAgent name
+func GetOtelAgentGatewayHeadlessServiceName(dda metav1.Object) string {
+	return fmt.Sprintf("%s-%s-headless", dda.GetName(), constants.DefaultOtelAgentGatewayResourceSuffix)
+}
+
+// GetOtelAgentGatewayHeadlessServiceHostname returns the DNS name of the OTel Agent Gateway headless Service,
+// resolved by the node otel-agents to load balance the telemetry across the gateway pods
+func GetOtelAgentGatewayHeadlessServiceHostname(dda metav1.Object) string {
+	return fmt.Sprintf("%s.%s.svc", GetOtelAgentGatewayHeadlessServiceName(dda), dda.GetNamespace())
+}
+
+// GetOtelAgentGatewayGRPCPort returns the OTLP gRPC port of the OTel Agent Gateway
+func GetOtelAgentGatewayGRPCPort(config *v2alpha1.OtelAgentGatewayFeatureConfig) int32 {
+	port := int32(common.DefaultOtelGRPCPort)
+	if config != nil {
+		for _, p := range config.Ports {
+			if p != nil && p.Name == OtelGRPCPortName {
+				port = p.ContainerPort
+			}
+		}
+	}
+	return port
+}
+
+// GetOtelAgentGatewayRbacResourcesName returns the OTel Agent Gateway RBAC resource name
+func GetOtelAgentGatewayRbacResourcesName(dda metav1.Object) string {
+	return fmt.Sprintf("%s-%s", dda.GetName(), constants.DefaultOtelAgentGatewayResourceSuffix)
+}
+
+// GetOtelAgentGatewayServiceSelector returns the labels selecting the OTel Agent Gateway pods
+func GetOtelAgentGatewayServiceSelector(dda metav1.Object) map[string]string {
+	return map[string]string{
+		apicommon.AgentDeploymentNameLabelKey:      dda.GetName(),
+		apicommon.AgentDeploymentComponentLabelKey: constants.DefaultOtelAgentGatewayResourceSuffix,
+	}
+}
+
+// NewDefaultOtelAgentGatewayDeployment return a new default otel-agent-gateway deployment
+func NewDefaultOtelAgentGatewayDeployment(dda metav1.Object) *appsv1.Deployment {
+	deployment := common.NewDeployment(dda, constants.DefaultOtelAgentGatewayResourceSuffix, GetOtelAgentGatewayName(dda), common.GetAgentVersion(dda), nil)
+
+	podTemplate := NewDefaultOtelAgentGatewayPodTemplateSpec(dda)
+	for key, val := range deployment.GetLabels() {
+		podTemplate.Labels[key] = val
+	}
+
+	for key, val := range deployment.GetAnnotations() {
+		podTemplate.Annotations[key] = val
+	}
+
+	deployment.Spec.Template = *podTemplate
+	deployment.Spec.Replicas = apiutils.NewInt32Pointer(defaultOtelAgentGatewayReplicas)
+
+	return deployment
+}
+
+// NewDefaultOtelAgentGatewayPodTemplateSpec returns a default pod template for the otel-agent-gateway deployment
+func NewDefaultOtelAgentGatewayPodTemplateSpec(dda metav1.Object) *corev1.PodTemplateSpec {
+	volumes := []corev1.Volume{
+		common.GetVolumeInstallInfo(dda),
+		common.GetVolumeForLogs(),
+		common.GetVolumeForTmp(),
+	}
+
+	volumeMounts := []corev1.VolumeMount{
+		common.GetVolumeMountForInstallInfo(),
+		common.GetVolumeMountForLogs(),
+		common.GetVolumeMountForTmp(),
+	}
+
+	template := &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      make(map[string]string),
+			Annotations: make(map[string]string),
+		},
+		Spec: defaultPodSpec(dda, volumes, volumeMounts),
+	}
+
+	return template
+}
+
+func GetOtelAgentGatewayPodDisruptionBudgetName(dda metav1.Object) string {
+	return fmt.Sprintf("%s-%s-pdb", dda.GetName(), constants.DefaultOtelAgentGatewayResourceSuffix)
+}
+
+func GetOtelAgentGatewayPodDisruptionBudget(dda metav1.Object, useV1BetaPDB bool) client.Object {
+	maxUnavailableStr := intstr.FromInt(pdbMaxUnavailableInstances)
+	matchLabels := GetOtelAgentGatewayServiceSelector(dda)
+	if useV1BetaPDB {
+		return &policyv1beta1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      GetOtelAgentGatewayPodDisruptionBudgetName(dda),
+				Namespace: dda.GetNamespace(),
+			},
+			Spec: policyv1beta1.PodDisruptionBudgetSpec{
+				MaxUnavailable: &maxUnavailableStr,
+				Selector: &metav1.LabelSelector{
+					MatchLabels: matchLabels,
+				},
+			},
+		}
+	}
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetOtelAgentGatewayPodDisruptionBudgetName(dda),
+			Namespace: dda.GetNamespace(),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailableStr,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: matchLabels,
+			},
+		},
+	}
+}
+
+// getDefaultServiceAccountName return the default OTel Agent Gateway ServiceAccountName
+func getDefaultServiceAccountName(dda metav1.Object) string {
+	return fmt.Sprintf("%s-%s", dda.GetName(), constants.DefaultOtelAgentGatewayResourceSuffix)
+}
+
+// otelAgentGatewayImage returns the -full agent image, which embeds the otel-agent
+func otelAgentGatewayImage() string {
+	return images.GetLatestAgentImageWithSuffix(false, false, true)
+}
+
+func defaultPodSpec(dda metav1.Object, volumes []corev1.Volume, volumeMounts []corev1.VolumeMount) corev1.PodSpec {
+	podSpec := corev1.PodSpec{
+		ServiceAccountName: getDefaultServiceAccountName(dda),
+		Containers: []corev1.Container{
+			{
+				Name:  string(apicommon.OtelAgent),
+				Image: otelAgentGatewayImage(),
+				Command: []string{
+					"otel-agent",
+				},
+				Env:          []corev1.EnvVar{},
+				VolumeMounts: volumeMounts,
+				SecurityContext: &corev1.SecurityContext{
+					ReadOnlyRootFilesystem:   apiutils.NewBoolPointer(true),
+					AllowPrivilegeEscalation: apiutils.NewBoolPointer(false),
+				},
+			},
+		},
+		Affinity: DefaultAffinity(),
+		Volumes:  volumes,
+	}
+	return podSpec
+}
+
+// DefaultAffinity returns the pod anti affinity of the otel agent gateway
+// The default anti affinity prefers scheduling the gateway pods on different nodes if possible
+// so that a node failure does not take down the whole gateway.
+func DefaultAffinity() *corev1.Affinity {
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: 50,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								apicommon.AgentDeploymentComponentLabelKey: constants.DefaultOtelAgentGatewayResourceSuffix,
+							},
+						},
+						TopologyKey: "kubernetes.io/hostname",
+					},
+				},
+			},
+		},
+	}
+}
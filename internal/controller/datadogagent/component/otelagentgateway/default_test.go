@@ -0,0 +1,58 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package otelagentgateway
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+func Test_getDefaultServiceAccountName(t *testing.T) {
+	dda := v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-datadog-agent",
+			Namespace: "some-namespace",
+		},
+	}
+
+	assert.Equal(t, "my-datadog-agent-otel-agent-gateway", getDefaultServiceAccountName(&dda))
+}
+
+func Test_getPodDisruptionBudget(t *testing.T) {
+	dda := v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-datadog-agent",
+			Namespace: "some-namespace",
+		},
+	}
+	testpdb := GetOtelAgentGatewayPodDisruptionBudget(&dda, false).(*policyv1.PodDisruptionBudget)
+	assert.Equal(t, "my-datadog-agent-otel-agent-gateway-pdb", testpdb.Name)
+	assert.Equal(t, intstr.FromInt(pdbMaxUnavailableInstances), *testpdb.Spec.MaxUnavailable)
+	assert.Nil(t, testpdb.Spec.MinAvailable)
+}
+
+func Test_NewDefaultOtelAgentGatewayDeployment(t *testing.T) {
+	dda := v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-datadog-agent",
+			Namespace: "some-namespace",
+		},
+	}
+
+	deployment := NewDefaultOtelAgentGatewayDeployment(&dda)
+	assert.Equal(t, "my-datadog-agent-otel-agent-gateway", deployment.Name)
+	assert.Equal(t, GetOtelAgentGatewayServiceSelector(&dda), deployment.Spec.Selector.MatchLabels)
+	assert.Len(t, deployment.Spec.Template.Spec.Containers, 1)
+	assert.Equal(t, string(apicommon.OtelAgent), deployment.Spec.Template.Spec.Containers[0].Name)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Image, "-full")
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/managedfields"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -19,6 +20,7 @@ import (
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	componentagent "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/agent"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/recommendation"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
 	"github.com/DataDog/datadog-operator/pkg/kubernetes"
 
@@ -30,6 +32,7 @@ import (
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/clusterchecks"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/cspm"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/cws"
+	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/datadogcheck"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/dogstatsd"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/dummy"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/ebpfcheck"
@@ -45,6 +48,7 @@ import (
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/npm"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/oomkill"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/orchestratorexplorer"
+	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/otelagentgateway"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/otelcollector"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/otlp"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/processdiscovery"
@@ -67,34 +71,50 @@ type ReconcilerOptions struct {
 	OperatorMetricsEnabled      bool
 	IntrospectionEnabled        bool
 	DatadogAgentProfileEnabled  bool
+	AgentRolloutEnabled         bool
 	DatadogAgentInternalEnabled bool
+	DatadogCheckEnabled         bool
 }
 
 // Reconciler is the internal reconciler for Datadog Agent
 type Reconciler struct {
 	options      ReconcilerOptions
 	client       client.Client
+	apiReader    client.Reader
+	restClient   rest.Interface
 	platformInfo kubernetes.PlatformInfo
 	scheme       *runtime.Scheme
 	log          logr.Logger
 	recorder     record.EventRecorder
 	forwarders   datadog.MetricsForwardersManager
 	fieldManager *managedfields.FieldManager
+
+	recommendations *recommendation.Collector
 }
 
 // NewReconciler returns a reconciler for DatadogAgent
-func NewReconciler(options ReconcilerOptions, client client.Client, platformInfo kubernetes.PlatformInfo,
+func NewReconciler(options ReconcilerOptions, client client.Client, apiReader client.Reader, restClient rest.Interface, platformInfo kubernetes.PlatformInfo,
 	scheme *runtime.Scheme, log logr.Logger, recorder record.EventRecorder, metricForwardersMgr datadog.MetricsForwardersManager,
 ) (*Reconciler, error) {
-	return &Reconciler{
+	r := &Reconciler{
 		options:      options,
 		client:       client,
+		apiReader:    apiReader,
+		restClient:   restClient,
 		platformInfo: platformInfo,
 		scheme:       scheme,
 		log:          log,
 		recorder:     recorder,
 		forwarders:   metricForwardersMgr,
-	}, nil
+	}
+	r.recommendations = recommendation.NewCollector(client, r.uncachedReader(), restClient, &r.platformInfo, log.WithName("resource-recommendations"))
+	return r, nil
+}
+
+// ResourceRecommendationsCollector returns the collector sampling the usage of the node Agent containers,
+// which must be run by the controller-runtime manager.
+func (r *Reconciler) ResourceRecommendationsCollector() *recommendation.Collector {
+	return r.recommendations
 }
 
 // Reconcile is similar to reconciler.Reconcile interface, but taking a context
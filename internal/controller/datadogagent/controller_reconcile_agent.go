@@ -32,6 +32,7 @@ import (
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/override"
 	"github.com/DataDog/datadog-operator/pkg/agentprofile"
+	"github.com/DataDog/datadog-operator/pkg/agentrollout"
 	"github.com/DataDog/datadog-operator/pkg/condition"
 	"github.com/DataDog/datadog-operator/pkg/constants"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
@@ -102,15 +103,23 @@ func (r *Reconciler) reconcileV2Agent(logger logr.Logger, requiredComponents fea
 			eds.Labels[constants.MD5AgentDeploymentProviderLabelKey] = kubernetes.LegacyProvider
 		}
 
+		if recommendationsOverride := resourceRecommendationsOverride(dda, newStatus, eds.Name, componentOverrides); recommendationsOverride != nil {
+			// Set the recommended resources on the containers whose resources are not overridden
+			componentOverrides = append([]*datadoghqv2alpha1.DatadogAgentComponentOverride{recommendationsOverride}, componentOverrides...)
+		}
+
 		for _, componentOverride := range componentOverrides {
 			if apiutils.BoolValue(componentOverride.Disabled) {
 				disabledByOverride = true
 			}
-			override.PodTemplateSpec(logger, podManagers, componentOverride, datadoghqv2alpha1.NodeAgentComponentName, dda.Name)
+			if err := override.PodTemplateSpec(logger, podManagers, componentOverride, datadoghqv2alpha1.NodeAgentComponentName, dda.Name); err != nil {
+				return reconcile.Result{}, err
+			}
 			override.ExtendedDaemonSet(eds, componentOverride)
 		}
 
 		experimental.ApplyExperimentalOverrides(logger, dda, podManagers)
+		global.ApplySecurityProfile(podManagers, &dda.Spec)
 
 		if disabledByOverride {
 			if agentEnabled {
@@ -179,15 +188,30 @@ func (r *Reconciler) reconcileV2Agent(logger logr.Logger, requiredComponents fea
 		daemonset.Labels[constants.MD5AgentDeploymentProviderLabelKey] = kubernetes.LegacyProvider
 	}
 
+	if recommendationsOverride := resourceRecommendationsOverride(dda, newStatus, daemonset.Name, componentOverrides); recommendationsOverride != nil {
+		// Set the recommended resources on the containers whose resources are not overridden
+		componentOverrides = append([]*datadoghqv2alpha1.DatadogAgentComponentOverride{recommendationsOverride}, componentOverrides...)
+	}
+
 	for _, componentOverride := range componentOverrides {
 		if apiutils.BoolValue(componentOverride.Disabled) {
 			disabledByOverride = true
 		}
-		override.PodTemplateSpec(logger, podManagers, componentOverride, datadoghqv2alpha1.NodeAgentComponentName, dda.Name)
-		override.DaemonSet(daemonset, componentOverride)
+		if err := override.PodTemplateSpec(logger, podManagers, componentOverride, datadoghqv2alpha1.NodeAgentComponentName, dda.Name); err != nil {
+			return reconcile.Result{}, err
+		}
+		daemonSetOverride := componentOverride
+		// Without the agentRolloutEnabled option, the operator does not delete the outdated pods
+		// and the DaemonSet keeps its update strategy
+		if !r.options.AgentRolloutEnabled && componentOverride.StagedRollout != nil {
+			daemonSetOverride = componentOverride.DeepCopy()
+			daemonSetOverride.StagedRollout = nil
+		}
+		override.DaemonSet(daemonset, daemonSetOverride)
 	}
 
 	experimental.ApplyExperimentalOverrides(logger, dda, podManagers)
+	global.ApplySecurityProfile(podManagers, &dda.Spec)
 
 	if disabledByOverride {
 		if agentEnabled {
@@ -209,7 +233,22 @@ func (r *Reconciler) reconcileV2Agent(logger logr.Logger, requiredComponents fea
 		return reconcile.Result{}, nil
 	}
 
-	return r.createOrUpdateDaemonset(daemonsetLogger, dda, daemonset, newStatus, updateDSStatusV2WithAgent, profile)
+	result, err := r.createOrUpdateDaemonset(daemonsetLogger, dda, daemonset, newStatus, updateDSStatusV2WithAgent, profile)
+	if err != nil {
+		return result, err
+	}
+
+	if nodeAgentOverride, ok := dda.Spec.Override[datadoghqv2alpha1.NodeAgentComponentName]; ok && agentrollout.IsEnabled(nodeAgentOverride.StagedRollout) {
+		if !r.options.AgentRolloutEnabled {
+			daemonsetLogger.Info("The staged rollout is ignored, it requires the agentRolloutEnabled operator option")
+		} else if err = r.reconcileStagedRollout(context.TODO(), daemonsetLogger, dda, daemonset.Name, nodeAgentOverride.StagedRollout, newStatus); err != nil {
+			return result, err
+		}
+	}
+	if err = r.reconcileDaemonSetRollback(context.TODO(), daemonsetLogger, dda, daemonset.Name, daemonset.Annotations[constants.MD5AgentDeploymentAnnotationKey], newStatus); err != nil {
+		return result, err
+	}
+	return result, nil
 }
 
 func updateDSStatusV2WithAgent(dsName string, ds *appsv1.DaemonSet, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string) {
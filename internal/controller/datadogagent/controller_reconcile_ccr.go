@@ -82,13 +82,21 @@ func (r *Reconciler) reconcileV2ClusterChecksRunner(logger logr.Logger, required
 			// Delete CCR
 			return r.cleanupV2ClusterChecksRunner(deploymentLogger, dda, deployment, newStatus)
 		}
-		override.PodTemplateSpec(logger, podManagers, componentOverride, datadoghqv2alpha1.ClusterChecksRunnerComponentName, dda.Name)
+		if err := override.PodTemplateSpec(logger, podManagers, componentOverride, datadoghqv2alpha1.ClusterChecksRunnerComponentName, dda.Name); err != nil {
+			return reconcile.Result{}, err
+		}
 		override.Deployment(deployment, componentOverride)
 	} else if !ccrEnabled {
 		return r.cleanupV2ClusterChecksRunner(deploymentLogger, dda, deployment, newStatus)
 	}
 
-	return r.createOrUpdateDeployment(deploymentLogger, dda, deployment, newStatus, updateStatusV2WithClusterChecksRunner)
+	global.ApplySecurityProfile(podManagers, &dda.Spec)
+
+	result, err := r.createOrUpdateDeployment(deploymentLogger, dda, datadoghqv2alpha1.ClusterChecksRunnerComponentName, deployment, newStatus, updateStatusV2WithClusterChecksRunner)
+	if err != nil {
+		return result, err
+	}
+	return result, r.reconcileDeploymentRollback(context.TODO(), deploymentLogger, dda, datadoghqv2alpha1.ClusterChecksRunnerComponentName, deployment, newStatus.ClusterChecksRunner)
 }
 
 func updateStatusV2WithClusterChecksRunner(deployment *appsv1.Deployment, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string) {
@@ -81,7 +81,9 @@ func (r *Reconciler) reconcileV2ClusterAgent(logger logr.Logger, requiredCompone
 			deleteStatusV2WithClusterAgent(newStatus)
 			return r.cleanupV2ClusterAgent(deploymentLogger, dda, deployment, resourcesManager, newStatus)
 		}
-		override.PodTemplateSpec(logger, podManagers, componentOverride, datadoghqv2alpha1.ClusterAgentComponentName, dda.Name)
+		if err := override.PodTemplateSpec(logger, podManagers, componentOverride, datadoghqv2alpha1.ClusterAgentComponentName, dda.Name); err != nil {
+			return reconcile.Result{}, err
+		}
 		override.Deployment(deployment, componentOverride)
 	} else if !dcaEnabled {
 		// If the override is not defined, then disable based on dcaEnabled value
@@ -89,7 +91,13 @@ func (r *Reconciler) reconcileV2ClusterAgent(logger logr.Logger, requiredCompone
 		return r.cleanupV2ClusterAgent(deploymentLogger, dda, deployment, resourcesManager, newStatus)
 	}
 
-	return r.createOrUpdateDeployment(deploymentLogger, dda, deployment, newStatus, updateStatusV2WithClusterAgent)
+	global.ApplySecurityProfile(podManagers, &dda.Spec)
+
+	result, err := r.createOrUpdateDeployment(deploymentLogger, dda, datadoghqv2alpha1.ClusterAgentComponentName, deployment, newStatus, updateStatusV2WithClusterAgent)
+	if err != nil {
+		return result, err
+	}
+	return result, r.reconcileDeploymentRollback(context.TODO(), deploymentLogger, dda, datadoghqv2alpha1.ClusterAgentComponentName, deployment, newStatus.ClusterAgent)
 }
 
 func updateStatusV2WithClusterAgent(dca *appsv1.Deployment, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string) {
@@ -0,0 +1,161 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	componentotelgateway "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/otelagentgateway"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/global"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/override"
+	"github.com/DataDog/datadog-operator/pkg/condition"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+func (r *Reconciler) reconcileV2OtelAgentGateway(logger logr.Logger, requiredComponents feature.RequiredComponents, features []feature.Feature, dda *datadoghqv2alpha1.DatadogAgent, resourcesManager feature.ResourceManagers, newStatus *datadoghqv2alpha1.DatadogAgentStatus) (reconcile.Result, error) {
+	var result reconcile.Result
+
+	// Start by creating the Default OTel Agent Gateway deployment
+	deployment := componentotelgateway.NewDefaultOtelAgentGatewayDeployment(dda)
+	podManagers := feature.NewPodTemplateManagers(&deployment.Spec.Template)
+
+	// Set Global setting on the default deployment
+	global.ApplyGlobalSettingsOtelAgentGateway(logger, podManagers, dda.GetObjectMeta(), &dda.Spec, resourcesManager, requiredComponents)
+
+	// Apply features changes on the Deployment.Spec.Template
+	for _, feat := range features {
+		if errFeat := feat.ManageOtelAgentGateway(podManagers); errFeat != nil {
+			return result, errFeat
+		}
+	}
+
+	deploymentLogger := logger.WithValues("component", common.OtelAgentGatewayReconcileConditionType)
+
+	// The requiredComponents can change depending on if updates to features result in disabled components
+	gatewayEnabled := requiredComponents.OtelAgentGateway.IsEnabled()
+
+	// If Override is defined for the OTel Agent Gateway component, apply the override on the PodTemplateSpec, it will cascade to container.
+	if componentOverride, ok := dda.Spec.Override[datadoghqv2alpha1.OtelAgentGatewayComponentName]; ok {
+		if apiutils.BoolValue(componentOverride.Disabled) {
+			if gatewayEnabled {
+				// The override supersedes what's set in requiredComponents; update status to reflect the conflict
+				condition.UpdateDatadogAgentStatusConditions(
+					newStatus,
+					metav1.NewTime(time.Now()),
+					common.OverrideReconcileConflictConditionType,
+					metav1.ConditionTrue,
+					"OverrideConflict",
+					"OTel Agent Gateway component is set to disabled",
+					true,
+				)
+			}
+			// Delete OTel Agent Gateway
+			return r.cleanupV2OtelAgentGateway(deploymentLogger, dda, deployment, newStatus)
+		}
+		if err := override.PodTemplateSpec(logger, podManagers, componentOverride, datadoghqv2alpha1.OtelAgentGatewayComponentName, dda.Name); err != nil {
+			return reconcile.Result{}, err
+		}
+		override.Deployment(deployment, componentOverride)
+	} else if !gatewayEnabled {
+		return r.cleanupV2OtelAgentGateway(deploymentLogger, dda, deployment, newStatus)
+	}
+
+	global.ApplySecurityProfile(podManagers, &dda.Spec)
+
+	result, err := r.createOrUpdateDeployment(deploymentLogger, dda, datadoghqv2alpha1.OtelAgentGatewayComponentName, deployment, newStatus, updateStatusV2WithOtelAgentGateway)
+	if err != nil {
+		return result, err
+	}
+	return result, r.reconcileDeploymentRollback(context.TODO(), deploymentLogger, dda, datadoghqv2alpha1.OtelAgentGatewayComponentName, deployment, newStatus.OtelAgentGateway)
+}
+
+func updateStatusV2WithOtelAgentGateway(deployment *appsv1.Deployment, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string) {
+	newStatus.OtelAgentGateway = condition.UpdateDeploymentStatus(deployment, newStatus.OtelAgentGateway, &updateTime)
+	condition.UpdateDatadogAgentStatusConditions(newStatus, updateTime, common.OtelAgentGatewayReconcileConditionType, status, reason, message, true)
+}
+
+func (r *Reconciler) cleanupV2OtelAgentGateway(logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, deployment *appsv1.Deployment, newStatus *datadoghqv2alpha1.DatadogAgentStatus) (reconcile.Result, error) {
+	nsName := types.NamespacedName{
+		Name:      deployment.GetName(),
+		Namespace: deployment.GetNamespace(),
+	}
+
+	// OTel Agent Gateway Deployment attached to this instance
+	gatewayDeployment := &appsv1.Deployment{}
+	if err := r.client.Get(context.TODO(), nsName, gatewayDeployment); err != nil {
+		if !errors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+	} else {
+		logger.Info("Deleting OTel Agent Gateway Deployment", "deployment.Namespace", gatewayDeployment.Namespace, "deployment.Name", gatewayDeployment.Name)
+		event := buildEventInfo(gatewayDeployment.Name, gatewayDeployment.Namespace, kubernetes.DeploymentKind, datadog.DeletionEvent)
+		r.recordEvent(dda, event)
+		if err := r.client.Delete(context.TODO(), gatewayDeployment); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	deleteStatusWithOtelAgentGateway(newStatus)
+
+	return reconcile.Result{}, nil
+}
+
+func deleteStatusWithOtelAgentGateway(newStatus *datadoghqv2alpha1.DatadogAgentStatus) {
+	newStatus.OtelAgentGateway = nil
+	condition.DeleteDatadogAgentStatusCondition(newStatus, common.OtelAgentGatewayReconcileConditionType)
+}
+
+// cleanupOldOtelAgentGatewayDeployments deletes OTel Agent Gateway deployments when the Deployment's name is changed using otelAgentGateway name override
+func (r *Reconciler) cleanupOldOtelAgentGatewayDeployments(ctx context.Context, logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus) error {
+	matchLabels := client.MatchingLabels{
+		apicommon.AgentDeploymentComponentLabelKey: constants.DefaultOtelAgentGatewayResourceSuffix,
+		kubernetes.AppKubernetesManageByLabelKey:   "datadog-operator",
+		kubernetes.AppKubernetesPartOfLabelKey:     object.NewPartOfLabelValue(dda).String(),
+	}
+	deploymentName := getDeploymentNameFromOtelAgentGateway(dda)
+	deploymentList := appsv1.DeploymentList{}
+	if err := r.client.List(ctx, &deploymentList, matchLabels); err != nil {
+		return err
+	}
+	for _, deployment := range deploymentList.Items {
+		if deploymentName != deployment.Name {
+			if _, err := r.cleanupV2OtelAgentGateway(logger, dda, &deployment, newStatus); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// getDeploymentNameFromOtelAgentGateway returns the expected OTel Agent Gateway deployment name based on
+// the DDA name and otelAgentGateway name override
+func getDeploymentNameFromOtelAgentGateway(dda *datadoghqv2alpha1.DatadogAgent) string {
+	deploymentName := componentotelgateway.GetOtelAgentGatewayName(dda)
+	if componentOverride, ok := dda.Spec.Override[datadoghqv2alpha1.OtelAgentGatewayComponentName]; ok {
+		if componentOverride.Name != nil && *componentOverride.Name != "" {
+			deploymentName = *componentOverride.Name
+		}
+	}
+	return deploymentName
+}
@@ -0,0 +1,216 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	agenttestutils "github.com/DataDog/datadog-operator/internal/controller/datadogagent/testutils"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+)
+
+func Test_createOrUpdateDeployment_paused(t *testing.T) {
+	newDeployment := func(image string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "dda-cluster-agent"},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "cluster-agent", Image: image}}},
+				},
+			},
+		}
+	}
+	current := newDeployment("cluster-agent:hand-edited")
+	current.Annotations = map[string]string{constants.MD5AgentDeploymentAnnotationKey: "hand-edited"}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantImage   string
+		wantReason  string
+	}{
+		{
+			name:       "not paused",
+			wantImage:  "cluster-agent:rendered",
+			wantReason: updateSucceeded,
+		},
+		{
+			name:        "paused",
+			annotations: map[string]string{common.PauseReconcileAnnotationKey: "clusterAgent"},
+			wantImage:   "cluster-agent:hand-edited",
+			wantReason:  "DeploymentUpdatePaused",
+		},
+		{
+			name: "pause of another component",
+			annotations: map[string]string{
+				common.PauseReconcileAnnotationKey: "nodeAgent,clusterChecksRunner",
+			},
+			wantImage: "cluster-agent:rendered",
+		},
+		{
+			name: "expired pause",
+			annotations: map[string]string{
+				common.PauseReconcileAnnotationKey:      "clusterAgent",
+				common.PauseReconcileUntilAnnotationKey: time.Now().Add(-time.Minute).Format(time.RFC3339),
+			},
+			wantImage: "cluster-agent:rendered",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dda := &v2alpha1.DatadogAgent{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "dda", Annotations: tt.annotations},
+			}
+			r := &Reconciler{
+				client:   fake.NewClientBuilder().WithScheme(agenttestutils.TestScheme()).WithObjects(current.DeepCopy()).Build(),
+				scheme:   agenttestutils.TestScheme(),
+				recorder: record.NewFakeRecorder(10),
+			}
+			newStatus := &v2alpha1.DatadogAgentStatus{}
+
+			_, err := r.createOrUpdateDeployment(logr.Discard(), dda, v2alpha1.ClusterAgentComponentName, newDeployment("cluster-agent:rendered"), newStatus, updateStatusV2WithClusterAgent)
+			require.NoError(t, err)
+
+			deployment := &appsv1.Deployment{}
+			require.NoError(t, r.client.Get(context.TODO(), types.NamespacedName{Namespace: "ns", Name: "dda-cluster-agent"}, deployment))
+			assert.Equal(t, tt.wantImage, deployment.Spec.Template.Spec.Containers[0].Image)
+			// The status is reported even when the updates are paused
+			assert.NotNil(t, newStatus.ClusterAgent)
+			if tt.wantReason != "" {
+				reconcileCondition := meta.FindStatusCondition(newStatus.Conditions, common.ClusterAgentReconcileConditionType)
+				require.NotNil(t, reconcileCondition)
+				assert.Equal(t, tt.wantReason, reconcileCondition.Reason)
+			}
+		})
+	}
+}
+
+func Test_createOrUpdateDaemonset_paused(t *testing.T) {
+	newDaemonSet := func(image string) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "dda-agent"},
+			Spec: appsv1.DaemonSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "agent", Image: image}}},
+				},
+			},
+		}
+	}
+	current := newDaemonSet("agent:hand-edited")
+	current.Annotations = map[string]string{constants.MD5AgentDeploymentAnnotationKey: "hand-edited"}
+
+	dda := &v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "ns",
+			Name:        "dda",
+			Annotations: map[string]string{common.PauseReconcileAnnotationKey: "nodeAgent"},
+		},
+	}
+	r := &Reconciler{
+		client:   fake.NewClientBuilder().WithScheme(agenttestutils.TestScheme()).WithObjects(current).Build(),
+		scheme:   agenttestutils.TestScheme(),
+		recorder: record.NewFakeRecorder(10),
+	}
+	newStatus := &v2alpha1.DatadogAgentStatus{}
+
+	_, err := r.createOrUpdateDaemonset(logr.Discard(), dda, newDaemonSet("agent:rendered"), newStatus, updateDSStatusV2WithAgent, &v1alpha1.DatadogAgentProfile{})
+	require.NoError(t, err)
+
+	daemonset := &appsv1.DaemonSet{}
+	require.NoError(t, r.client.Get(context.TODO(), types.NamespacedName{Namespace: "ns", Name: "dda-agent"}, daemonset))
+	assert.Equal(t, "agent:hand-edited", daemonset.Spec.Template.Spec.Containers[0].Image)
+	assert.NotNil(t, newStatus.Agent)
+	reconcileCondition := meta.FindStatusCondition(newStatus.Conditions, common.AgentReconcileConditionType)
+	require.NotNil(t, reconcileCondition)
+	assert.Equal(t, "DaemonSetUpdatePaused", reconcileCondition.Reason)
+}
+
+func Test_updateReconcilePausedCondition(t *testing.T) {
+	now := metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	dda := &v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				common.PauseReconcileAnnotationKey:      "nodeAgent",
+				common.PauseReconcileUntilAnnotationKey: "2024-01-01T13:00:00Z",
+			},
+		},
+	}
+	newStatus := &v2alpha1.DatadogAgentStatus{}
+
+	pauseLeft := updateReconcilePausedCondition(logr.Discard(), dda, newStatus, now)
+	assert.Equal(t, time.Hour, pauseLeft)
+	pausedCondition := meta.FindStatusCondition(newStatus.Conditions, common.ReconcilePausedConditionType)
+	require.NotNil(t, pausedCondition)
+	assert.Equal(t, metav1.ConditionTrue, pausedCondition.Status)
+	assert.Equal(t, "updates of nodeAgent are paused until 2024-01-01T13:00:00Z", pausedCondition.Message)
+
+	// The pause expires
+	pauseLeft = updateReconcilePausedCondition(logr.Discard(), dda, newStatus, metav1.NewTime(now.Add(2*time.Hour)))
+	assert.Zero(t, pauseLeft)
+	pausedCondition = meta.FindStatusCondition(newStatus.Conditions, common.ReconcilePausedConditionType)
+	require.NotNil(t, pausedCondition)
+	assert.Equal(t, metav1.ConditionFalse, pausedCondition.Status)
+
+	// The annotations are removed
+	pauseLeft = updateReconcilePausedCondition(logr.Discard(), &v2alpha1.DatadogAgent{}, newStatus, now)
+	assert.Zero(t, pauseLeft)
+	assert.Nil(t, meta.FindStatusCondition(newStatus.Conditions, common.ReconcilePausedConditionType))
+}
+
+func Test_requeueAtPauseEnd(t *testing.T) {
+	tests := []struct {
+		name      string
+		result    reconcile.Result
+		pauseLeft time.Duration
+		want      time.Duration
+	}{
+		{
+			name:   "not paused",
+			result: reconcile.Result{RequeueAfter: defaultRequeuePeriod},
+			want:   defaultRequeuePeriod,
+		},
+		{
+			name:      "pause ends before the next requeue",
+			result:    reconcile.Result{RequeueAfter: defaultRequeuePeriod},
+			pauseLeft: 5 * time.Second,
+			want:      5 * time.Second,
+		},
+		{
+			name:      "pause ends after the next requeue",
+			result:    reconcile.Result{RequeueAfter: defaultRequeuePeriod},
+			pauseLeft: time.Hour,
+			want:      defaultRequeuePeriod,
+		},
+		{
+			name:      "no requeue scheduled",
+			pauseLeft: time.Hour,
+			want:      time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, requeueAtPauseEnd(tt.result, tt.pauseLeft).RequeueAfter)
+		})
+	}
+}
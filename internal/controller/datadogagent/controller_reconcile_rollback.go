@@ -0,0 +1,417 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	"github.com/DataDog/datadog-operator/pkg/agentrollout"
+	"github.com/DataDog/datadog-operator/pkg/condition"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+)
+
+const (
+	rolledBackReason    = "RolledBack"
+	notRolledBackReason = "NotRolledBack"
+	// autoRollbackUnsupportedReason is the reason of the AutoRollbackUnsupported condition
+	autoRollbackUnsupportedReason = "Unsupported"
+
+	// deploymentRevisionAnnotation is set by the Deployment controller on the ReplicaSets of a Deployment
+	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+)
+
+// rollbackTarget describes the current state of a DaemonSet or a Deployment
+type rollbackTarget struct {
+	// currentHash is the hash of the spec applied to the object
+	currentHash string
+	// revision identifies the pod template of the object
+	revision string
+	// healthy is true when all the pods are up to date and ready
+	healthy bool
+	// updatedPods are the pods running the current pod template
+	updatedPods []corev1.Pod
+}
+
+// reconcileDaemonSetRollback records the last known-good revision of a DaemonSet and reverts the DaemonSet
+// to it when the updated pods fail readiness. desiredHash is the hash of the DaemonSet rendered from the spec.
+func (r *Reconciler) reconcileDaemonSetRollback(ctx context.Context, logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, dsName, desiredHash string, newStatus *datadoghqv2alpha1.DatadogAgentStatus) error {
+	var dsStatus *datadoghqv2alpha1.DaemonSetStatus
+	for _, status := range newStatus.AgentList {
+		if status.DaemonsetName == dsName {
+			dsStatus = status
+		}
+	}
+	if dsStatus == nil {
+		return nil
+	}
+	config := getAutoRollbackConfig(dda)
+	if !r.options.AgentRolloutEnabled || !agentrollout.IsRollbackEnabled(config) {
+		dsStatus.Rollback = nil
+		return nil
+	}
+	// The DaemonSet is not modified while its updates are paused
+	if common.IsReconcilePaused(logger, dda.Annotations, datadoghqv2alpha1.NodeAgentComponentName) {
+		dsStatus.Rollback = getDaemonSetRollbackStatus(dda.Status.AgentList, dsName)
+		return nil
+	}
+
+	ds := &appsv1.DaemonSet{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: dda.Namespace, Name: dsName}, ds); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	target := rollbackTarget{
+		currentHash: ds.Annotations[constants.MD5AgentDeploymentAnnotationKey],
+		healthy: ds.Status.ObservedGeneration >= ds.Generation && ds.Status.DesiredNumberScheduled > 0 &&
+			ds.Status.UpdatedNumberScheduled == ds.Status.DesiredNumberScheduled && ds.Status.NumberReady == ds.Status.DesiredNumberScheduled,
+	}
+	var err error
+	if target.revision, err = r.getDaemonSetUpdateRevision(ctx, ds); err != nil {
+		return err
+	}
+	pods, err := r.getDaemonSetPods(ctx, ds)
+	if err != nil {
+		return err
+	}
+	target.updatedPods = filterPodsByLabel(pods, appsv1.DefaultDaemonSetUniqueLabelKey, target.revision)
+
+	status, rollback, failing, err := nextRollbackStatus(config, getDaemonSetRollbackStatus(dda.Status.AgentList, dsName), desiredHash, target, time.Now())
+	if err != nil {
+		return err
+	}
+	dsStatus.Rollback = status
+	if !rollback {
+		return nil
+	}
+
+	revisions, err := r.getDaemonSetRevisions(ctx, ds)
+	if err != nil {
+		return err
+	}
+	var template *corev1.PodTemplateSpec
+	for _, revision := range revisions {
+		if revision.Labels[appsv1.DefaultDaemonSetUniqueLabelKey] != status.LastKnownGoodRevision {
+			continue
+		}
+		// The data of a DaemonSet ControllerRevision is a patch containing the pod template
+		data := struct {
+			Spec struct {
+				Template corev1.PodTemplateSpec `json:"template"`
+			} `json:"spec"`
+		}{}
+		if err = json.Unmarshal(revision.Data.Raw, &data); err != nil {
+			return fmt.Errorf("unable to decode the ControllerRevision %s: %w", revision.Name, err)
+		}
+		template = &data.Spec.Template
+	}
+	if template == nil {
+		logger.Info("Unable to roll back the DaemonSet, the last known-good revision is not found", "revision", status.LastKnownGoodRevision)
+		abortRollback(status, getDaemonSetRollbackStatus(dda.Status.AgentList, dsName))
+		return nil
+	}
+
+	patch := client.MergeFrom(ds.DeepCopy())
+	ds.Spec.Template = *template
+	ds.Annotations[constants.MD5AgentDeploymentAnnotationKey] = status.LastKnownGoodHash
+	if err = r.client.Patch(ctx, ds, patch); err != nil {
+		return fmt.Errorf("unable to roll back the DaemonSet %s: %w", ds.Name, err)
+	}
+	r.recordRollbackEvent(logger, dda, "DaemonSet", ds.Name, status, failing, config)
+	return nil
+}
+
+// reconcileDeploymentRollback records the last known-good revision of a Deployment and reverts the Deployment
+// to it when the updated pods fail readiness. The deployment is the one rendered from the spec.
+func (r *Reconciler) reconcileDeploymentRollback(ctx context.Context, logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, component datadoghqv2alpha1.ComponentName, deployment *appsv1.Deployment, depStatus *datadoghqv2alpha1.DeploymentStatus) error {
+	if depStatus == nil {
+		return nil
+	}
+	config := getAutoRollbackConfig(dda)
+	if !r.options.AgentRolloutEnabled || !agentrollout.IsRollbackEnabled(config) {
+		depStatus.Rollback = nil
+		return nil
+	}
+	// The Deployment is not modified while its updates are paused
+	if common.IsReconcilePaused(logger, dda.Annotations, component) {
+		depStatus.Rollback = getDeploymentRollbackStatus(&dda.Status, deployment.Name)
+		return nil
+	}
+
+	current := &appsv1.Deployment{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: deployment.Namespace, Name: deployment.Name}, current); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	replicaSets, err := r.getDeploymentReplicaSets(ctx, current)
+	if err != nil {
+		return err
+	}
+	var latest *appsv1.ReplicaSet
+	for i := range replicaSets {
+		if latest == nil || replicaSetRevision(&replicaSets[i]) > replicaSetRevision(latest) {
+			latest = &replicaSets[i]
+		}
+	}
+
+	replicas := int32(1)
+	if current.Spec.Replicas != nil {
+		replicas = *current.Spec.Replicas
+	}
+	target := rollbackTarget{
+		currentHash: current.Annotations[constants.MD5AgentDeploymentAnnotationKey],
+		healthy: current.Status.ObservedGeneration >= current.Generation && replicas > 0 && current.Status.Replicas == replicas &&
+			current.Status.UpdatedReplicas == replicas && current.Status.AvailableReplicas == replicas,
+	}
+	if latest != nil {
+		target.revision = latest.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+	}
+	pods, err := r.getSelectedPods(ctx, current.Namespace, current.Spec.Selector)
+	if err != nil {
+		return err
+	}
+	target.updatedPods = filterPodsByLabel(pods, appsv1.DefaultDeploymentUniqueLabelKey, target.revision)
+
+	previous := depStatus.Rollback
+	status, rollback, failing, err := nextRollbackStatus(config, previous, deployment.Annotations[constants.MD5AgentDeploymentAnnotationKey], target, time.Now())
+	if err != nil {
+		return err
+	}
+	depStatus.Rollback = status
+	if !rollback {
+		return nil
+	}
+
+	var template *corev1.PodTemplateSpec
+	for i := range replicaSets {
+		if replicaSets[i].Labels[appsv1.DefaultDeploymentUniqueLabelKey] == status.LastKnownGoodRevision {
+			template = replicaSets[i].Spec.Template.DeepCopy()
+		}
+	}
+	if template == nil {
+		logger.Info("Unable to roll back the Deployment, the last known-good revision is not found", "revision", status.LastKnownGoodRevision)
+		abortRollback(status, previous)
+		return nil
+	}
+	// The Deployment controller adds the pod template hash to the pod template of its ReplicaSets
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+	patch := client.MergeFrom(current.DeepCopy())
+	current.Spec.Template = *template
+	current.Annotations[constants.MD5AgentDeploymentAnnotationKey] = status.LastKnownGoodHash
+	if err = r.client.Patch(ctx, current, patch); err != nil {
+		return fmt.Errorf("unable to roll back the Deployment %s: %w", current.Name, err)
+	}
+	r.recordRollbackEvent(logger, dda, "Deployment", current.Name, status, failing, config)
+	return nil
+}
+
+// nextRollbackStatus computes the rollback status of a DaemonSet or a Deployment. It returns true when
+// the updated pods fail readiness and the object must be reverted to the last known-good revision,
+// with the number of failing pods.
+func nextRollbackStatus(config *datadoghqv2alpha1.AutoRollbackConfig, previous *datadoghqv2alpha1.RollbackStatus, desiredHash string, target rollbackTarget, now time.Time) (*datadoghqv2alpha1.RollbackStatus, bool, int, error) {
+	status := &datadoghqv2alpha1.RollbackStatus{}
+	if previous != nil {
+		status = previous.DeepCopy()
+	}
+	// The spec changed since the rollback, the new spec is applied
+	if status.FailedHash != "" && status.FailedHash != desiredHash {
+		status.FailedHash = ""
+	}
+	if target.revision == "" {
+		return status, false, 0, nil
+	}
+
+	if target.healthy {
+		status.LastKnownGoodHash = target.currentHash
+		status.LastKnownGoodRevision = target.revision
+		return status, false, 0, nil
+	}
+	if status.LastKnownGoodRevision == "" || status.LastKnownGoodRevision == target.revision || status.LastKnownGoodHash == target.currentHash {
+		// There is nothing to roll back to
+		return status, false, 0, nil
+	}
+
+	rollback, failing, err := agentrollout.ShouldRollback(config, target.updatedPods, now)
+	if err != nil || !rollback {
+		return status, false, failing, err
+	}
+	status.FailedHash = target.currentHash
+	status.LastRollbackTime = &metav1.Time{Time: now}
+	return status, true, failing, nil
+}
+
+// abortRollback resets a rollback status when the last known-good revision is not found
+func abortRollback(status, previous *datadoghqv2alpha1.RollbackStatus) {
+	status.FailedHash = ""
+	status.LastKnownGoodRevision = ""
+	status.LastRollbackTime = nil
+	if previous != nil {
+		status.LastRollbackTime = previous.LastRollbackTime
+	}
+}
+
+func (r *Reconciler) recordRollbackEvent(logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, kind, name string, status *datadoghqv2alpha1.RollbackStatus, failing int, config *datadoghqv2alpha1.AutoRollbackConfig) {
+	window := agentrollout.DefaultRollbackWindow
+	if config.Window != nil {
+		window = config.Window.Duration
+	}
+	message := fmt.Sprintf("%s %s rolled back to spec hash %s: %d updated pod(s) not ready after %s with spec hash %s", kind, name, status.LastKnownGoodHash, failing, window, status.FailedHash)
+	logger.Info("Rolled back", "kind", kind, "name", name, "failedHash", status.FailedHash, "lastKnownGoodHash", status.LastKnownGoodHash)
+	r.recorder.Event(dda, corev1.EventTypeWarning, rolledBackReason, message)
+}
+
+// updateRolledBackCondition reports the components whose spec is rolled back in the RolledBack condition.
+// The condition is removed when the automatic rollback is not enabled.
+func (r *Reconciler) updateRolledBackCondition(dda *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus, now metav1.Time) {
+	if !r.options.AgentRolloutEnabled || !agentrollout.IsRollbackEnabled(getAutoRollbackConfig(dda)) {
+		condition.DeleteDatadogAgentStatusCondition(newStatus, common.RolledBackConditionType)
+		return
+	}
+
+	var messages []string
+	for _, dsStatus := range newStatus.AgentList {
+		if dsStatus.Rollback != nil && dsStatus.Rollback.FailedHash != "" {
+			messages = append(messages, fmt.Sprintf("DaemonSet %s rolled back, failing spec hash %s", dsStatus.DaemonsetName, dsStatus.Rollback.FailedHash))
+		}
+	}
+	for _, depStatus := range []*datadoghqv2alpha1.DeploymentStatus{newStatus.ClusterAgent, newStatus.ClusterChecksRunner, newStatus.OtelAgentGateway} {
+		if depStatus != nil && depStatus.Rollback != nil && depStatus.Rollback.FailedHash != "" {
+			messages = append(messages, fmt.Sprintf("Deployment %s rolled back, failing spec hash %s", depStatus.DeploymentName, depStatus.Rollback.FailedHash))
+		}
+	}
+
+	if len(messages) == 0 {
+		condition.UpdateDatadogAgentStatusConditions(newStatus, now, common.RolledBackConditionType, metav1.ConditionFalse, notRolledBackReason, "no component is rolled back", false)
+		return
+	}
+	condition.UpdateDatadogAgentStatusConditions(newStatus, now, common.RolledBackConditionType, metav1.ConditionTrue, rolledBackReason, strings.Join(messages, "; "), false)
+}
+
+// updateAutoRollbackUnsupportedCondition reports in the AutoRollbackUnsupported condition the components that the
+// automatic rollback cannot revert with the current operator options: the rollback requires the agentRolloutEnabled
+// option and only handles the DaemonSets and Deployments reconciled directly from the DatadogAgent.
+func (r *Reconciler) updateAutoRollbackUnsupportedCondition(dda *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus, now metav1.Time) {
+	var message string
+	switch {
+	case !agentrollout.IsRollbackEnabled(getAutoRollbackConfig(dda)):
+	case r.options.DatadogAgentInternalEnabled:
+		message = "automatic rollback is not supported when the DatadogAgentInternal controller is enabled"
+	case r.options.ExtendedDaemonsetOptions.Enabled:
+		message = "automatic rollback of the node Agent is not supported with ExtendedDaemonSets"
+	case !r.options.AgentRolloutEnabled:
+		message = "automatic rollback requires the agentRolloutEnabled operator option"
+	}
+
+	if message == "" {
+		condition.DeleteDatadogAgentStatusCondition(newStatus, common.AutoRollbackUnsupportedConditionType)
+		return
+	}
+	condition.UpdateDatadogAgentStatusConditions(newStatus, now, common.AutoRollbackUnsupportedConditionType, metav1.ConditionTrue, autoRollbackUnsupportedReason, message, false)
+}
+
+// isRolledBackSpec returns true if the hash is the hash of a spec rolled back by the automatic rollback.
+// Such a spec is not applied again until it changes.
+func isRolledBackSpec(dda *datadoghqv2alpha1.DatadogAgent, status *datadoghqv2alpha1.RollbackStatus, hash string) bool {
+	return agentrollout.IsRollbackEnabled(getAutoRollbackConfig(dda)) && status != nil && status.FailedHash != "" && status.FailedHash == hash
+}
+
+func getAutoRollbackConfig(dda *datadoghqv2alpha1.DatadogAgent) *datadoghqv2alpha1.AutoRollbackConfig {
+	if dda.Spec.Global == nil {
+		return nil
+	}
+	return dda.Spec.Global.AutoRollback
+}
+
+func getDaemonSetRollbackStatus(agentList []*datadoghqv2alpha1.DaemonSetStatus, dsName string) *datadoghqv2alpha1.RollbackStatus {
+	for _, status := range agentList {
+		if status.DaemonsetName == dsName {
+			return status.Rollback
+		}
+	}
+	return nil
+}
+
+func getDeploymentRollbackStatus(ddaStatus *datadoghqv2alpha1.DatadogAgentStatus, deploymentName string) *datadoghqv2alpha1.RollbackStatus {
+	for _, status := range []*datadoghqv2alpha1.DeploymentStatus{ddaStatus.ClusterAgent, ddaStatus.ClusterChecksRunner, ddaStatus.OtelAgentGateway} {
+		if status != nil && status.DeploymentName == deploymentName {
+			return status.Rollback
+		}
+	}
+	return nil
+}
+
+// getDeploymentReplicaSets returns the ReplicaSets controlled by a Deployment
+func (r *Reconciler) getDeploymentReplicaSets(ctx context.Context, deployment *appsv1.Deployment) ([]appsv1.ReplicaSet, error) {
+	replicaSetList := appsv1.ReplicaSetList{}
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	// The ReplicaSets are not cached
+	if err = r.uncachedReader().List(ctx, &replicaSetList, client.InNamespace(deployment.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+
+	replicaSets := make([]appsv1.ReplicaSet, 0, len(replicaSetList.Items))
+	for _, replicaSet := range replicaSetList.Items {
+		if metav1.IsControlledBy(&replicaSet, deployment) {
+			replicaSets = append(replicaSets, replicaSet)
+		}
+	}
+	return replicaSets, nil
+}
+
+// getSelectedPods returns the pods of a namespace matching a label selector
+func (r *Reconciler) getSelectedPods(ctx context.Context, namespace string, labelSelector *metav1.LabelSelector) ([]corev1.Pod, error) {
+	podList := corev1.PodList{}
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.client.List(ctx, &podList, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	return podList.Items, nil
+}
+
+func replicaSetRevision(replicaSet *appsv1.ReplicaSet) int64 {
+	revision, err := strconv.ParseInt(replicaSet.Annotations[deploymentRevisionAnnotation], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return revision
+}
+
+func filterPodsByLabel(pods []corev1.Pod, key, value string) []corev1.Pod {
+	var filtered []corev1.Pod
+	for _, pod := range pods {
+		if value != "" && pod.Labels[key] == value {
+			filtered = append(filtered, pod)
+		}
+	}
+	return filtered
+}
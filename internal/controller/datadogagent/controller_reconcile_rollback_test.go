@@ -0,0 +1,324 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	componentagent "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/agent"
+	"github.com/DataDog/datadog-operator/pkg/condition"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+)
+
+func newRollbackTestPod(name, revision string, ready bool, age time.Duration, now time.Time) corev1.Pod {
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Labels:            map[string]string{appsv1.DefaultDaemonSetUniqueLabelKey: revision},
+			CreationTimestamp: metav1.NewTime(now.Add(-age)),
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+		},
+	}
+}
+
+func Test_nextRollbackStatus(t *testing.T) {
+	now := time.Now()
+	config := &v2alpha1.AutoRollbackConfig{Enabled: apiutils.NewBoolPointer(true)}
+	knownGood := &v2alpha1.RollbackStatus{LastKnownGoodHash: "good", LastKnownGoodRevision: "good-rev"}
+
+	tests := []struct {
+		name         string
+		previous     *v2alpha1.RollbackStatus
+		desiredHash  string
+		target       rollbackTarget
+		wantStatus   *v2alpha1.RollbackStatus
+		wantRollback bool
+	}{
+		{
+			name:        "healthy component is recorded as known-good",
+			desiredHash: "good",
+			target:      rollbackTarget{currentHash: "good", revision: "good-rev", healthy: true},
+			wantStatus:  knownGood,
+		},
+		{
+			name:        "no known-good revision to roll back to",
+			desiredHash: "bad",
+			target: rollbackTarget{
+				currentHash: "bad",
+				revision:    "bad-rev",
+				updatedPods: []corev1.Pod{newRollbackTestPod("pod1", "bad-rev", false, time.Hour, now)},
+			},
+			wantStatus: &v2alpha1.RollbackStatus{},
+		},
+		{
+			name:        "update in progress",
+			previous:    knownGood,
+			desiredHash: "new",
+			target: rollbackTarget{
+				currentHash: "new",
+				revision:    "new-rev",
+				updatedPods: []corev1.Pod{newRollbackTestPod("pod1", "new-rev", false, time.Minute, now)},
+			},
+			wantStatus: knownGood,
+		},
+		{
+			name:        "updated pods fail readiness",
+			previous:    knownGood,
+			desiredHash: "bad",
+			target: rollbackTarget{
+				currentHash: "bad",
+				revision:    "bad-rev",
+				updatedPods: []corev1.Pod{newRollbackTestPod("pod1", "bad-rev", false, time.Hour, now)},
+			},
+			wantStatus: &v2alpha1.RollbackStatus{
+				LastKnownGoodHash:     "good",
+				LastKnownGoodRevision: "good-rev",
+				FailedHash:            "bad",
+				LastRollbackTime:      &metav1.Time{Time: now},
+			},
+			wantRollback: true,
+		},
+		{
+			name: "rolled back spec is kept",
+			previous: &v2alpha1.RollbackStatus{
+				LastKnownGoodHash:     "good",
+				LastKnownGoodRevision: "good-rev",
+				FailedHash:            "bad",
+			},
+			desiredHash: "bad",
+			target:      rollbackTarget{currentHash: "good", revision: "good-rev", healthy: true},
+			wantStatus: &v2alpha1.RollbackStatus{
+				LastKnownGoodHash:     "good",
+				LastKnownGoodRevision: "good-rev",
+				FailedHash:            "bad",
+			},
+		},
+		{
+			name: "spec changed after the rollback",
+			previous: &v2alpha1.RollbackStatus{
+				LastKnownGoodHash:     "good",
+				LastKnownGoodRevision: "good-rev",
+				FailedHash:            "bad",
+			},
+			desiredHash: "fixed",
+			target:      rollbackTarget{currentHash: "fixed", revision: "fixed-rev"},
+			wantStatus:  knownGood,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, rollback, _, err := nextRollbackStatus(config, tt.previous, tt.desiredHash, tt.target, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRollback, rollback)
+			assert.Equal(t, tt.wantStatus, status)
+		})
+	}
+}
+
+func Test_reconcileDeploymentRollback(t *testing.T) {
+	now := time.Now()
+	labels := map[string]string{"app": "cluster-agent"}
+	dda := &v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "dda"},
+		Spec: v2alpha1.DatadogAgentSpec{
+			Global: &v2alpha1.GlobalConfig{
+				AutoRollback: &v2alpha1.AutoRollbackConfig{Enabled: apiutils.NewBoolPointer(true)},
+			},
+		},
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "ns",
+			Name:        "dda-cluster-agent",
+			UID:         types.UID("deployment-uid"),
+			Annotations: map[string]string{constants.MD5AgentDeploymentAnnotationKey: "bad"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: apiutils.NewInt32Pointer(1),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "cluster-agent", Image: "cluster-agent:bad"}}},
+			},
+		},
+		Status: appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 1, AvailableReplicas: 1},
+	}
+	newReplicaSet := func(revision, hash, image string) *appsv1.ReplicaSet {
+		rsLabels := map[string]string{"app": "cluster-agent", appsv1.DefaultDeploymentUniqueLabelKey: hash}
+		return &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       "ns",
+				Name:            "dda-cluster-agent-" + hash,
+				Labels:          rsLabels,
+				Annotations:     map[string]string{deploymentRevisionAnnotation: revision},
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: deployment.Name, UID: deployment.UID, Controller: apiutils.NewBoolPointer(true)}},
+			},
+			Spec: appsv1.ReplicaSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: rsLabels},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: rsLabels},
+					Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "cluster-agent", Image: image}}},
+				},
+			},
+		}
+	}
+	failingPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "ns",
+			Name:              "dda-cluster-agent-bad-rev-abcde",
+			Labels:            map[string]string{"app": "cluster-agent", appsv1.DefaultDeploymentUniqueLabelKey: "bad-rev"},
+			CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+		},
+		Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}}},
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{
+		client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+			deployment.DeepCopy(),
+			newReplicaSet("1", "good-rev", "cluster-agent:good"),
+			newReplicaSet("2", "bad-rev", "cluster-agent:bad"),
+			failingPod,
+		).Build(),
+		recorder: recorder,
+		options:  ReconcilerOptions{AgentRolloutEnabled: true},
+	}
+	newStatus := &v2alpha1.DatadogAgentStatus{
+		ClusterAgent: &v2alpha1.DeploymentStatus{
+			DeploymentName: deployment.Name,
+			Rollback:       &v2alpha1.RollbackStatus{LastKnownGoodHash: "good", LastKnownGoodRevision: "good-rev"},
+		},
+	}
+
+	err := r.reconcileDeploymentRollback(context.TODO(), logr.Discard(), dda, v2alpha1.ClusterAgentComponentName, deployment, newStatus.ClusterAgent)
+	require.NoError(t, err)
+
+	assert.Equal(t, "bad", newStatus.ClusterAgent.Rollback.FailedHash)
+	assert.NotNil(t, newStatus.ClusterAgent.Rollback.LastRollbackTime)
+
+	rolledBack := &appsv1.Deployment{}
+	require.NoError(t, r.client.Get(context.TODO(), types.NamespacedName{Namespace: "ns", Name: deployment.Name}, rolledBack))
+	assert.Equal(t, "cluster-agent:good", rolledBack.Spec.Template.Spec.Containers[0].Image)
+	assert.NotContains(t, rolledBack.Spec.Template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	assert.Equal(t, "good", rolledBack.Annotations[constants.MD5AgentDeploymentAnnotationKey])
+
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "Warning RolledBack Deployment dda-cluster-agent rolled back to spec hash good")
+
+	r.updateRolledBackCondition(dda, newStatus, metav1.NewTime(now))
+	rolledBackCondition := meta.FindStatusCondition(newStatus.Conditions, common.RolledBackConditionType)
+	require.NotNil(t, rolledBackCondition)
+	assert.Equal(t, metav1.ConditionTrue, rolledBackCondition.Status)
+	assert.Contains(t, rolledBackCondition.Message, "failing spec hash bad")
+
+	// The condition is removed when the automatic rollback is disabled
+	disabledDDA := dda.DeepCopy()
+	disabledDDA.Spec.Global.AutoRollback = nil
+	disabledStatus := newStatus.DeepCopy()
+	r.updateRolledBackCondition(disabledDDA, disabledStatus, metav1.NewTime(now))
+	assert.Nil(t, meta.FindStatusCondition(disabledStatus.Conditions, common.RolledBackConditionType))
+
+	// The rolled back spec is not applied again
+	assert.True(t, isRolledBackSpec(dda, newStatus.ClusterAgent.Rollback, "bad"))
+	assert.False(t, isRolledBackSpec(dda, newStatus.ClusterAgent.Rollback, "fixed"))
+
+	// While the Deployment is paused, the previous rollback status is kept
+	pausedDDA := dda.DeepCopy()
+	pausedDDA.Annotations = map[string]string{common.PauseReconcileAnnotationKey: "clusterAgent"}
+	pausedDDA.Status.ClusterAgent = newStatus.ClusterAgent.DeepCopy()
+	pausedStatus := &v2alpha1.DeploymentStatus{DeploymentName: deployment.Name}
+	require.NoError(t, r.reconcileDeploymentRollback(context.TODO(), logr.Discard(), pausedDDA, v2alpha1.ClusterAgentComponentName, deployment, pausedStatus))
+	assert.Equal(t, newStatus.ClusterAgent.Rollback, pausedStatus.Rollback)
+	assert.Empty(t, recorder.Events)
+}
+
+func Test_updateAutoRollbackUnsupportedCondition(t *testing.T) {
+	now := metav1.NewTime(time.Now())
+	dda := &v2alpha1.DatadogAgent{
+		Spec: v2alpha1.DatadogAgentSpec{
+			Global: &v2alpha1.GlobalConfig{
+				AutoRollback: &v2alpha1.AutoRollbackConfig{Enabled: apiutils.NewBoolPointer(true)},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		options     ReconcilerOptions
+		enabled     bool
+		wantMessage string
+	}{
+		{
+			name:    "supported",
+			options: ReconcilerOptions{AgentRolloutEnabled: true},
+			enabled: true,
+		},
+		{
+			name:        "agent rollout disabled",
+			enabled:     true,
+			wantMessage: "requires the agentRolloutEnabled operator option",
+		},
+		{
+			name:        "DatadogAgentInternal",
+			options:     ReconcilerOptions{DatadogAgentInternalEnabled: true},
+			enabled:     true,
+			wantMessage: "not supported when the DatadogAgentInternal controller is enabled",
+		},
+		{
+			name:        "ExtendedDaemonSet",
+			options:     ReconcilerOptions{ExtendedDaemonsetOptions: componentagent.ExtendedDaemonsetOptions{Enabled: true}},
+			enabled:     true,
+			wantMessage: "not supported with ExtendedDaemonSets",
+		},
+		{
+			name:    "rollback disabled",
+			options: ReconcilerOptions{DatadogAgentInternalEnabled: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dda.Spec.Global.AutoRollback.Enabled = apiutils.NewBoolPointer(tt.enabled)
+			r := &Reconciler{options: tt.options}
+			// A condition set by a previous reconcile is removed once the rollback is supported
+			newStatus := &v2alpha1.DatadogAgentStatus{}
+			condition.UpdateDatadogAgentStatusConditions(newStatus, now, common.AutoRollbackUnsupportedConditionType, metav1.ConditionTrue, autoRollbackUnsupportedReason, "stale", false)
+
+			r.updateAutoRollbackUnsupportedCondition(dda, newStatus, now)
+			unsupported := meta.FindStatusCondition(newStatus.Conditions, common.AutoRollbackUnsupportedConditionType)
+			if tt.wantMessage == "" {
+				assert.Nil(t, unsupported)
+				return
+			}
+			require.NotNil(t, unsupported)
+			assert.Equal(t, metav1.ConditionTrue, unsupported.Status)
+			assert.Contains(t, unsupported.Message, tt.wantMessage)
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	"github.com/DataDog/datadog-operator/pkg/agentrollout"
+	"github.com/DataDog/datadog-operator/pkg/condition"
+)
+
+// reconcileStagedRollout deletes the outdated pods of the node Agent DaemonSet wave by wave,
+// and reports the progress of the rollout in the DaemonSet status.
+func (r *Reconciler) reconcileStagedRollout(ctx context.Context, logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, dsName string, config *datadoghqv2alpha1.StagedRolloutConfig, newStatus *datadoghqv2alpha1.DatadogAgentStatus) error {
+	// No pod is deleted while the DaemonSet updates are paused
+	if common.IsReconcilePaused(logger, dda.Annotations, datadoghqv2alpha1.NodeAgentComponentName) {
+		for _, status := range newStatus.AgentList {
+			if status.DaemonsetName == dsName {
+				status.Rollout = getRolloutStatus(dda.Status.AgentList, dsName)
+			}
+		}
+		return nil
+	}
+
+	ds := &appsv1.DaemonSet{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: dda.Namespace, Name: dsName}, ds); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	revision, err := r.getDaemonSetUpdateRevision(ctx, ds)
+	if err != nil || revision == "" {
+		// The DaemonSet controller has not created the revision yet
+		return err
+	}
+
+	pods, err := r.getDaemonSetPods(ctx, ds)
+	if err != nil {
+		return err
+	}
+
+	nodeList, err := r.getNodeList(ctx)
+	if err != nil {
+		return err
+	}
+	nodes := make(map[string]*corev1.Node, len(nodeList))
+	for i := range nodeList {
+		nodes[nodeList[i].Name] = &nodeList[i]
+	}
+
+	previous := getRolloutStatus(dda.Status.AgentList, ds.Name)
+	plan, err := agentrollout.ComputePlan(config, pods, nodes, revision, previous, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for i := range plan.PodsToDelete {
+		pod := &plan.PodsToDelete[i]
+		logger.Info("Deleting outdated Agent pod", "pod", pod.Name, "node", pod.Spec.NodeName, "wave", plan.Status.CurrentWave)
+		if err = r.client.Delete(ctx, pod, client.Preconditions{UID: &pod.UID}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("unable to delete the outdated Agent pod %s: %w", pod.Name, err)
+		}
+	}
+
+	if previous == nil || previous.State != plan.Status.State {
+		logger.Info("Staged rollout state changed", "state", plan.Status.State, "message", plan.Status.Message)
+	}
+
+	now := metav1.NewTime(time.Now())
+	newStatus.AgentList = condition.UpdateDaemonSetStatus(ds.Name, ds, newStatus.AgentList, &now)
+	for _, status := range newStatus.AgentList {
+		if status.DaemonsetName == ds.Name {
+			status.Rollout = &plan.Status
+		}
+	}
+	newStatus.Agent = condition.UpdateCombinedDaemonSetStatus(newStatus.AgentList)
+	return nil
+}
+
+// getDaemonSetUpdateRevision returns the `controller-revision-hash` of the latest revision of a DaemonSet
+func (r *Reconciler) getDaemonSetUpdateRevision(ctx context.Context, ds *appsv1.DaemonSet) (string, error) {
+	revisions, err := r.getDaemonSetRevisions(ctx, ds)
+	if err != nil {
+		return "", err
+	}
+
+	var latest *appsv1.ControllerRevision
+	for i := range revisions {
+		if latest == nil || revisions[i].Revision > latest.Revision {
+			latest = &revisions[i]
+		}
+	}
+	if latest == nil {
+		return "", nil
+	}
+	return latest.Labels[appsv1.DefaultDaemonSetUniqueLabelKey], nil
+}
+
+// getDaemonSetRevisions returns the ControllerRevisions controlled by a DaemonSet
+func (r *Reconciler) getDaemonSetRevisions(ctx context.Context, ds *appsv1.DaemonSet) ([]appsv1.ControllerRevision, error) {
+	revisionList := appsv1.ControllerRevisionList{}
+	selector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	// The ControllerRevisions are not cached
+	if err = r.uncachedReader().List(ctx, &revisionList, client.InNamespace(ds.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+
+	revisions := make([]appsv1.ControllerRevision, 0, len(revisionList.Items))
+	for _, revision := range revisionList.Items {
+		if metav1.IsControlledBy(&revision, ds) {
+			revisions = append(revisions, revision)
+		}
+	}
+	return revisions, nil
+}
+
+// getDaemonSetPods returns the pods controlled by a DaemonSet
+func (r *Reconciler) getDaemonSetPods(ctx context.Context, ds *appsv1.DaemonSet) ([]corev1.Pod, error) {
+	podList := corev1.PodList{}
+	selector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.client.List(ctx, &podList, client.InNamespace(ds.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+
+	pods := make([]corev1.Pod, 0, len(podList.Items))
+	for _, pod := range podList.Items {
+		if metav1.IsControlledBy(&pod, ds) {
+			pods = append(pods, pod)
+		}
+	}
+	return pods, nil
+}
+
+func getRolloutStatus(agentList []*datadoghqv2alpha1.DaemonSetStatus, dsName string) *datadoghqv2alpha1.RolloutStatus {
+	for _, status := range agentList {
+		if status.DaemonsetName == dsName {
+			return status.Rollout
+		}
+	}
+	return nil
+}
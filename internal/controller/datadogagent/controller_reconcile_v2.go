@@ -22,6 +22,7 @@ import (
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/defaults"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogcheck"
 	"github.com/DataDog/datadog-operator/pkg/agentprofile"
 	"github.com/DataDog/datadog-operator/pkg/condition"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils"
@@ -70,6 +71,16 @@ func (r *Reconciler) reconcileInstanceV3(ctx context.Context, logger logr.Logger
 	ddaStatusCopy := instance.Status.DeepCopy()
 	newDDAStatus := generateNewStatusFromDDA(ddaStatusCopy)
 
+	// Restrict the node Agent to the nodes handled by this DatadogAgent
+	if err := r.applyNodePartition(ctx, instance, newDDAStatus, now); err != nil {
+		return r.updateStatusIfNeededV2(logger, instance, ddaStatusCopy, result, err, now)
+	}
+	pauseLeft := updateReconcilePausedCondition(logger, instance, newDDAStatus, now)
+	r.updateAutoRollbackUnsupportedCondition(instance, newDDAStatus, now)
+	r.updateInstrumentationStatus(ctx, logger, instance, newDDAStatus, now)
+	r.updateSeccompProfileDriftCondition(ctx, logger, instance, newDDAStatus, now)
+	r.updateResourceRecommendationsStatus(logger, instance, newDDAStatus, now)
+
 	// Manage dependencies
 	if err := r.manageDDADependenciesWithDDAI(ctx, logger, instance, newDDAStatus); err != nil {
 		return r.updateStatusIfNeededV2(logger, instance, ddaStatusCopy, result, err, now)
@@ -93,6 +104,9 @@ func (r *Reconciler) reconcileInstanceV3(ctx context.Context, logger logr.Logger
 		ddais = profileDDAIs
 	}
 
+	// Apply the recommended resources through the node Agent override of each DDAI
+	applyResourceRecommendationsToDDAIs(instance, newDDAStatus, ddais)
+
 	// Create or update the DDAI object in k8s
 	for _, ddai := range ddais {
 		if e := r.createOrUpdateDDAI(ddai); e != nil {
@@ -117,6 +131,7 @@ func (r *Reconciler) reconcileInstanceV3(ctx context.Context, logger logr.Logger
 
 	// Prevent the reconcile loop from stopping by requeueing the DDAI object after a period of time
 	result.RequeueAfter = defaultRequeuePeriod
+	result = requeueAtPauseEnd(result, pauseLeft)
 	return r.updateStatusIfNeededV2(logger, instance, newDDAStatus, result, err, now)
 }
 
@@ -125,7 +140,26 @@ func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger
 	newStatus := instance.Status.DeepCopy()
 	now := metav1.NewTime(time.Now())
 
-	configuredFeatures, enabledFeatures, requiredComponents := feature.BuildFeatures(instance, &instance.Spec, instance.Status.RemoteConfigConfiguration, reconcilerOptionsToFeatureOptions(&r.options, r.log))
+	// Restrict the node Agent to the nodes handled by this DatadogAgent
+	if err := r.applyNodePartition(ctx, instance, newStatus, now); err != nil {
+		return r.updateStatusIfNeededV2(logger, instance, newStatus, result, err, now)
+	}
+	pauseLeft := updateReconcilePausedCondition(logger, instance, newStatus, now)
+	r.updateInstrumentationStatus(ctx, logger, instance, newStatus, now)
+	r.updateSeccompProfileDriftCondition(ctx, logger, instance, newStatus, now)
+	r.updateResourceRecommendationsStatus(logger, instance, newStatus, now)
+
+	featureOptions := reconcilerOptionsToFeatureOptions(&r.options, r.log)
+	if r.options.DatadogCheckEnabled {
+		checks, err := datadogcheck.ListValidChecks(ctx, r.client, instance, &instance.Spec)
+		if err != nil {
+			return r.updateStatusIfNeededV2(logger, instance, newStatus, result, err, now)
+		}
+		featureOptions.DatadogChecks = checks
+	}
+
+	configuredFeatures, enabledFeatures, requiredComponents, blockedFeatures := feature.BuildFeatures(instance, &instance.Spec, instance.Status.RemoteConfigConfiguration, featureOptions)
+	newStatus.Features = feature.NewStatuses(configuredFeatures, enabledFeatures, blockedFeatures)
 	// update list of enabled features for metrics forwarder
 	r.updateMetricsForwardersFeatures(instance, enabledFeatures)
 
@@ -136,7 +170,9 @@ func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger
 	if err = r.manageGlobalDependencies(logger, instance, resourceManagers, requiredComponents); err != nil {
 		return r.updateStatusIfNeededV2(logger, instance, newStatus, reconcile.Result{}, err, now)
 	}
-	if err = r.manageFeatureDependencies(logger, enabledFeatures, resourceManagers); err != nil {
+	err = r.manageFeatureDependencies(logger, enabledFeatures, resourceManagers, newStatus.Features)
+	feature.UpdateDegradedStatuses(ctx, r.uncachedReader(), enabledFeatures, newStatus.Features)
+	if err != nil {
 		return r.updateStatusIfNeededV2(logger, instance, newStatus, reconcile.Result{}, err, now)
 	}
 	if err = r.overrideDependencies(logger, resourceManagers, instance); err != nil {
@@ -168,6 +204,18 @@ func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger
 	// Update the status to set ClusterChecksRunnerReconcileConditionType to successful
 	condition.UpdateDatadogAgentStatusConditions(newStatus, now, common.ClusterChecksRunnerReconcileConditionType, metav1.ConditionTrue, "reconcile_succeed", "reconcile succeed", false)
 
+	// 2.d. OTel Agent Gateway
+	result, err = r.reconcileV2OtelAgentGateway(logger, requiredComponents, append(configuredFeatures, enabledFeatures...), instance, resourceManagers, newStatus)
+	if utils.ShouldReturn(result, err) {
+		return r.updateStatusIfNeededV2(logger, instance, newStatus, result, err, now)
+	}
+	// Update the status to set OtelAgentGatewayReconcileConditionType to successful
+	condition.UpdateDatadogAgentStatusConditions(newStatus, now, common.OtelAgentGatewayReconcileConditionType, metav1.ConditionTrue, "reconcile_succeed", "reconcile succeed", false)
+
+	// Report the components rolled back by the automatic rollback
+	r.updateRolledBackCondition(instance, newStatus, now)
+	r.updateAutoRollbackUnsupportedCondition(instance, newStatus, now)
+
 	// TODO: this feels like it should be moved somewhere else
 	userSpecifiedClusterAgentToken := instance.Spec.Global.ClusterAgentToken != nil || instance.Spec.Global.ClusterAgentTokenSecret != nil
 	if !userSpecifiedClusterAgentToken {
@@ -189,6 +237,7 @@ func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger
 	if !result.Requeue && result.RequeueAfter == 0 {
 		result.RequeueAfter = defaultRequeuePeriod
 	}
+	result = requeueAtPauseEnd(result, pauseLeft)
 	return r.updateStatusIfNeededV2(logger, instance, newStatus, result, err, now)
 }
 
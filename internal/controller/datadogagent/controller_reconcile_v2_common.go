@@ -25,6 +25,7 @@ import (
 	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
 	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
 	"github.com/DataDog/datadog-operator/pkg/agentprofile"
 	"github.com/DataDog/datadog-operator/pkg/condition"
 	"github.com/DataDog/datadog-operator/pkg/constants"
@@ -45,7 +46,7 @@ type updateDepStatusComponentFunc func(deployment *appsv1.Deployment, newStatus
 type updateDSStatusComponentFunc func(daemonsetName string, daemonset *appsv1.DaemonSet, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string)
 type updateEDSStatusComponentFunc func(eds *edsv1alpha1.ExtendedDaemonSet, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string)
 
-func (r *Reconciler) createOrUpdateDeployment(parentLogger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, deployment *appsv1.Deployment, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateStatusFunc updateDepStatusComponentFunc) (reconcile.Result, error) {
+func (r *Reconciler) createOrUpdateDeployment(parentLogger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, component datadoghqv2alpha1.ComponentName, deployment *appsv1.Deployment, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateStatusFunc updateDepStatusComponentFunc) (reconcile.Result, error) {
 	logger := parentLogger.WithValues("deployment.Namespace", deployment.Namespace, "deployment.Name", deployment.Name)
 
 	var result reconcile.Result
@@ -56,13 +57,6 @@ func (r *Reconciler) createOrUpdateDeployment(parentLogger logr.Logger, dda *dat
 		return reconcile.Result{}, err
 	}
 
-	// From here the PodTemplateSpec should be ready, we can generate the hash that will be used to compare this deployment with the current one (if it exists).
-	var hash string
-	hash, err = comparison.SetMD5DatadogAgentGenerationAnnotation(&deployment.ObjectMeta, deployment.Spec)
-	if err != nil {
-		return result, err
-	}
-
 	// Get the current deployment and compare
 	nsName := types.NamespacedName{
 		Name:      deployment.GetName(),
@@ -103,8 +97,23 @@ func (r *Reconciler) createOrUpdateDeployment(parentLogger logr.Logger, dda *dat
 			logger.Info("Deployment owner reference patched")
 		}
 
-		// check if same hash
-		needUpdate := !comparison.IsSameSpecMD5Hash(hash, currentDeployment.GetAnnotations())
+		var hash string
+		var needUpdate bool
+		var updateDeployment *appsv1.Deployment
+		hash, needUpdate, updateDeployment, err = deploymentUpdate(logger, deployment, currentDeployment)
+		if err != nil {
+			return result, err
+		}
+		if needUpdate && isRolledBackSpec(dda, getDeploymentRollbackStatus(&dda.Status, currentDeployment.Name), hash) {
+			logger.Info("Deployment spec was rolled back, waiting for a spec change", "failedHash", hash)
+			needUpdate = false
+		}
+		if needUpdate && common.IsReconcilePaused(logger, dda.Annotations, component) {
+			logger.Info("Deployment updates are paused", "annotation", common.PauseReconcileAnnotationKey)
+			now := metav1.NewTime(time.Now())
+			updateStatusFunc(currentDeployment, newStatus, now, metav1.ConditionTrue, "DeploymentUpdatePaused", "Deployment updates are paused")
+			return reconcile.Result{}, nil
+		}
 		if !needUpdate {
 			// no need to update hasn't changed
 			now := metav1.NewTime(time.Now())
@@ -114,17 +123,6 @@ func (r *Reconciler) createOrUpdateDeployment(parentLogger logr.Logger, dda *dat
 
 		logger.Info("Updating Deployment")
 
-		// TODO: these parameters can be added to the override.PodTemplateSpec. (It exists in v1alpha1)
-		keepAnnotationsFilter := ""
-		keepLabelsFilter := ""
-
-		// Copy possibly changed fields
-		updateDeployment := deployment.DeepCopy()
-		updateDeployment.Spec = *deployment.Spec.DeepCopy()
-		updateDeployment.Spec.Replicas = getReplicas(currentDeployment.Spec.Replicas, updateDeployment.Spec.Replicas)
-		updateDeployment.Annotations = mergeAnnotationsLabels(logger, currentDeployment.GetAnnotations(), deployment.GetAnnotations(), keepAnnotationsFilter)
-		updateDeployment.Labels = mergeAnnotationsLabels(logger, currentDeployment.GetLabels(), deployment.GetLabels(), keepLabelsFilter)
-
 		now := metav1.NewTime(time.Now())
 		err = kubernetes.UpdateFromObject(context.TODO(), r.client, updateDeployment, currentDeployment.ObjectMeta)
 		if err != nil {
@@ -135,6 +133,12 @@ func (r *Reconciler) createOrUpdateDeployment(parentLogger logr.Logger, dda *dat
 		r.recordEvent(dda, event)
 		updateStatusFunc(updateDeployment, newStatus, now, metav1.ConditionTrue, updateSucceeded, "Deployment updated")
 	} else {
+		// From here the PodTemplateSpec should be ready, we can generate the hash that will be added to this deployment.
+		_, err = comparison.SetMD5DatadogAgentGenerationAnnotation(&deployment.ObjectMeta, deployment.Spec)
+		if err != nil {
+			return result, err
+		}
+
 		now := metav1.NewTime(time.Now())
 
 		err = r.client.Create(context.TODO(), deployment)
@@ -222,45 +226,22 @@ func (r *Reconciler) createOrUpdateDaemonset(parentLogger logr.Logger, dda *data
 			r.updateDAPStatus(logger, profile)
 		}
 
-		// When overriding node labels in <1.7.0, the hash could be updated
-		// without updating the pod template spec in <1.7.0 since pod template
-		// labels were copied over directly from the existing daemonset.
-		// With operator <1.7.0, it would look like:
-		// 1. Set override node label `abc: def`
-		//    a. Daemonset annotation: `agentspechash: 12345`
-		// 2. Change label to `abc: xyz`
-		//    a. Daemonset annotation: `agentspechash: 67890`
-		//    b. Pod template spec still has `abc: def` (set in step 1)
-		// To ensure the pod template label updates, we compare the existing
-		// daemonset's pod template labels with the new daemonset's pod
-		// template labels.
-		var currentDaemonsetPodTemplateLabelHash string
-		currentDaemonsetPodTemplateLabelHash, err = comparison.GenerateMD5ForSpec(currentDaemonset.Spec.Template.Labels)
+		var hash string
+		var needUpdate bool
+		var updateDaemonset *appsv1.DaemonSet
+		hash, needUpdate, updateDaemonset, err = daemonSetUpdate(logger, daemonset, currentDaemonset)
 		if err != nil {
 			return result, err
 		}
-
-		// TODO: remove in 1.8.0 when v1alpha1 is removed
-		// Spec.Selector is an immutable field and changing it leads to an error.
-		// Template.Labels must include Spec.Selector.
-		// See https://kubernetes.io/docs/concepts/workloads/controllers/daemonset/#pod-selector
-		daemonset.Spec.Selector = currentDaemonset.Spec.Selector
-		daemonset.Spec.Template.Labels = ensureSelectorInPodTemplateLabels(logger, daemonset.Spec.Selector, daemonset.Spec.Template.Labels)
-
-		// From here the PodTemplateSpec should be ready, we can generate the hash that will be used to compare this daemonset with the current one (if it exists).
-		var hash, daemonsetPodTemplateLabelHash string
-		hash, err = comparison.SetMD5DatadogAgentGenerationAnnotation(&daemonset.ObjectMeta, daemonset.Spec)
-		if err != nil {
-			return result, err
+		if needUpdate && isRolledBackSpec(dda, getDaemonSetRollbackStatus(dda.Status.AgentList, currentDaemonset.Name), hash) {
+			logger.Info("Daemonset spec was rolled back, waiting for a spec change", "failedHash", hash)
+			needUpdate = false
 		}
-		// create a separate hash to compare pod template labels
-		daemonsetPodTemplateLabelHash, err = comparison.GenerateMD5ForSpec(daemonset.Spec.Template.Labels)
-		if err != nil {
-			return result, err
+		if needUpdate && common.IsReconcilePaused(logger, dda.Annotations, datadoghqv2alpha1.NodeAgentComponentName) {
+			logger.Info("Daemonset updates are paused", "annotation", common.PauseReconcileAnnotationKey)
+			updateStatusFunc(currentDaemonset.Name, currentDaemonset, newStatus, now, metav1.ConditionTrue, "DaemonSetUpdatePaused", "Daemonset updates are paused")
+			return reconcile.Result{}, nil
 		}
-
-		// check if same hash
-		needUpdate := !comparison.IsSameSpecMD5Hash(hash, currentDaemonset.GetAnnotations()) || currentDaemonsetPodTemplateLabelHash != daemonsetPodTemplateLabelHash
 		if !needUpdate {
 			// Even if the DaemonSet is still the same, its status might have
 			// changed (for example, the number of pods ready). This call is
@@ -272,19 +253,6 @@ func (r *Reconciler) createOrUpdateDaemonset(parentLogger logr.Logger, dda *data
 			return reconcile.Result{}, nil
 		}
 
-		// TODO: these parameters can be added to the override.PodTemplateSpec. (It exists in v1alpha1)
-		keepAnnotationsFilter := ""
-		keepLabelsFilter := ""
-
-		// Copy possibly changed fields
-		updateDaemonset := daemonset.DeepCopy()
-		updateDaemonset.Spec = *daemonset.Spec.DeepCopy()
-		updateDaemonset.Annotations = mergeAnnotationsLabels(logger, currentDaemonset.GetAnnotations(), daemonset.GetAnnotations(), keepAnnotationsFilter)
-		updateDaemonset.Labels = mergeAnnotationsLabels(logger, currentDaemonset.GetLabels(), daemonset.GetLabels(), keepLabelsFilter)
-		// manually remove the old profile label because mergeAnnotationsLabels
-		// won't filter labels with "datadoghq.com" in the key
-		delete(updateDaemonset.Labels, agentprofile.OldProfileLabelKey)
-
 		updateProfileDS := true
 		if shouldProfileWaitForCanary(logger, dda.Annotations) {
 			ddaLastSpecUpdate := getDDALastUpdatedTime(dda.ManagedFields, dda.CreationTimestamp)
@@ -340,13 +308,6 @@ func (r *Reconciler) createOrUpdateExtendedDaemonset(parentLogger logr.Logger, d
 		return reconcile.Result{}, err
 	}
 
-	// From here the PodTemplateSpec should be ready, we can generate the hash that will be used to compare this extendeddaemonset with the current one (if it exists).
-	var hash string
-	hash, err = comparison.SetMD5DatadogAgentGenerationAnnotation(&eds.ObjectMeta, eds.Spec)
-	if err != nil {
-		return result, err
-	}
-
 	// Get the current extendeddaemonset and compare
 	nsName := types.NamespacedName{
 		Name:      eds.GetName(),
@@ -386,8 +347,18 @@ func (r *Reconciler) createOrUpdateExtendedDaemonset(parentLogger logr.Logger, d
 			}
 			logger.Info("ExtendedDaemonSet owner reference patched")
 		}
-		// check if same hash
-		needUpdate := !comparison.IsSameSpecMD5Hash(hash, currentEDS.GetAnnotations())
+		var needUpdate bool
+		var updateEDS *edsv1alpha1.ExtendedDaemonSet
+		_, needUpdate, updateEDS, err = extendedDaemonSetUpdate(logger, eds, currentEDS)
+		if err != nil {
+			return result, err
+		}
+		if needUpdate && common.IsReconcilePaused(logger, dda.Annotations, datadoghqv2alpha1.NodeAgentComponentName) {
+			logger.Info("ExtendedDaemonSet updates are paused", "annotation", common.PauseReconcileAnnotationKey)
+			now := metav1.NewTime(time.Now())
+			updateStatusFunc(currentEDS, newStatus, now, metav1.ConditionTrue, "ExtendedDaemonSetUpdatePaused", "ExtendedDaemonSet updates are paused")
+			return reconcile.Result{}, nil
+		}
 		if !needUpdate {
 			// Even if the EDS is still the same, its status might have
 			// changed (for example, the number of pods ready). This call is
@@ -402,16 +373,6 @@ func (r *Reconciler) createOrUpdateExtendedDaemonset(parentLogger logr.Logger, d
 
 		logger.Info("Updating ExtendedDaemonSet")
 
-		// TODO: these parameters can be added to the override.PodTemplateSpec. (It exists in v1alpha1)
-		keepAnnotationsFilter := ""
-		keepLabelsFilter := ""
-
-		// Copy possibly changed fields
-		updateEDS := eds.DeepCopy()
-		updateEDS.Spec = *eds.Spec.DeepCopy()
-		updateEDS.Annotations = mergeAnnotationsLabels(logger, currentEDS.GetAnnotations(), eds.GetAnnotations(), keepAnnotationsFilter)
-		updateEDS.Labels = mergeAnnotationsLabels(logger, currentEDS.GetLabels(), eds.GetLabels(), keepLabelsFilter)
-
 		now := metav1.NewTime(time.Now())
 		err = kubernetes.UpdateFromObject(context.TODO(), r.client, updateEDS, currentEDS.ObjectMeta)
 		if err != nil {
@@ -422,6 +383,12 @@ func (r *Reconciler) createOrUpdateExtendedDaemonset(parentLogger logr.Logger, d
 		r.recordEvent(dda, event)
 		updateStatusFunc(updateEDS, newStatus, now, metav1.ConditionTrue, updateSucceeded, "ExtendedDaemonSet updated")
 	} else {
+		// From here the PodTemplateSpec should be ready, we can generate the hash that will be added to this extendeddaemonset.
+		_, err = comparison.SetMD5DatadogAgentGenerationAnnotation(&eds.ObjectMeta, eds.Spec)
+		if err != nil {
+			return result, err
+		}
+
 		now := metav1.NewTime(time.Now())
 
 		err = r.client.Create(context.TODO(), eds)
@@ -439,6 +406,104 @@ func (r *Reconciler) createOrUpdateExtendedDaemonset(parentLogger logr.Logger, d
 	return result, err
 }
 
+// deploymentUpdate sets the spec hash of deployment, and returns it with whether the current Deployment needs to be
+// updated, and the Deployment to update it with. It is used by the reconcile loop and the diff preview.
+func deploymentUpdate(logger logr.Logger, deployment, current *appsv1.Deployment) (string, bool, *appsv1.Deployment, error) {
+	// From here the PodTemplateSpec should be ready, we can generate the hash that will be used to compare this deployment with the current one.
+	hash, err := comparison.SetMD5DatadogAgentGenerationAnnotation(&deployment.ObjectMeta, deployment.Spec)
+	if err != nil {
+		return "", false, nil, err
+	}
+	needUpdate := !comparison.IsSameSpecMD5Hash(hash, current.GetAnnotations())
+
+	// TODO: these parameters can be added to the override.PodTemplateSpec. (It exists in v1alpha1)
+	keepAnnotationsFilter := ""
+	keepLabelsFilter := ""
+
+	// Copy possibly changed fields
+	updated := deployment.DeepCopy()
+	updated.Spec.Replicas = getReplicas(current.Spec.Replicas, updated.Spec.Replicas)
+	updated.Annotations = mergeAnnotationsLabels(logger, current.GetAnnotations(), deployment.GetAnnotations(), keepAnnotationsFilter)
+	updated.Labels = mergeAnnotationsLabels(logger, current.GetLabels(), deployment.GetLabels(), keepLabelsFilter)
+	return hash, needUpdate, updated, nil
+}
+
+// daemonSetUpdate keeps the selector of the current DaemonSet in daemonset and sets its spec hash, and returns it with
+// whether the current DaemonSet needs to be updated, and the DaemonSet to update it with. It is used by the reconcile
+// loop and the diff preview.
+func daemonSetUpdate(logger logr.Logger, daemonset, current *appsv1.DaemonSet) (string, bool, *appsv1.DaemonSet, error) {
+	// When overriding node labels in <1.7.0, the hash could be updated
+	// without updating the pod template spec in <1.7.0 since pod template
+	// labels were copied over directly from the existing daemonset.
+	// With operator <1.7.0, it would look like:
+	// 1. Set override node label `abc: def`
+	//    a. Daemonset annotation: `agentspechash: 12345`
+	// 2. Change label to `abc: xyz`
+	//    a. Daemonset annotation: `agentspechash: 67890`
+	//    b. Pod template spec still has `abc: def` (set in step 1)
+	// To ensure the pod template label updates, we compare the existing
+	// daemonset's pod template labels with the new daemonset's pod
+	// template labels.
+	currentPodTemplateLabelHash, err := comparison.GenerateMD5ForSpec(current.Spec.Template.Labels)
+	if err != nil {
+		return "", false, nil, err
+	}
+
+	// TODO: remove in 1.8.0 when v1alpha1 is removed
+	// Spec.Selector is an immutable field and changing it leads to an error.
+	// Template.Labels must include Spec.Selector.
+	// See https://kubernetes.io/docs/concepts/workloads/controllers/daemonset/#pod-selector
+	daemonset.Spec.Selector = current.Spec.Selector
+	daemonset.Spec.Template.Labels = ensureSelectorInPodTemplateLabels(logger, daemonset.Spec.Selector, daemonset.Spec.Template.Labels)
+
+	// From here the PodTemplateSpec should be ready, we can generate the hash that will be used to compare this daemonset with the current one.
+	hash, err := comparison.SetMD5DatadogAgentGenerationAnnotation(&daemonset.ObjectMeta, daemonset.Spec)
+	if err != nil {
+		return "", false, nil, err
+	}
+	// create a separate hash to compare pod template labels
+	podTemplateLabelHash, err := comparison.GenerateMD5ForSpec(daemonset.Spec.Template.Labels)
+	if err != nil {
+		return "", false, nil, err
+	}
+
+	needUpdate := !comparison.IsSameSpecMD5Hash(hash, current.GetAnnotations()) || currentPodTemplateLabelHash != podTemplateLabelHash
+
+	// TODO: these parameters can be added to the override.PodTemplateSpec. (It exists in v1alpha1)
+	keepAnnotationsFilter := ""
+	keepLabelsFilter := ""
+
+	// Copy possibly changed fields
+	updated := daemonset.DeepCopy()
+	updated.Annotations = mergeAnnotationsLabels(logger, current.GetAnnotations(), daemonset.GetAnnotations(), keepAnnotationsFilter)
+	updated.Labels = mergeAnnotationsLabels(logger, current.GetLabels(), daemonset.GetLabels(), keepLabelsFilter)
+	// manually remove the old profile label because mergeAnnotationsLabels
+	// won't filter labels with "datadoghq.com" in the key
+	delete(updated.Labels, agentprofile.OldProfileLabelKey)
+	return hash, needUpdate, updated, nil
+}
+
+// extendedDaemonSetUpdate sets the spec hash of eds, and returns it with whether the current ExtendedDaemonSet needs
+// to be updated, and the ExtendedDaemonSet to update it with. It is used by the reconcile loop and the diff preview.
+func extendedDaemonSetUpdate(logger logr.Logger, eds, current *edsv1alpha1.ExtendedDaemonSet) (string, bool, *edsv1alpha1.ExtendedDaemonSet, error) {
+	// From here the PodTemplateSpec should be ready, we can generate the hash that will be used to compare this extendeddaemonset with the current one.
+	hash, err := comparison.SetMD5DatadogAgentGenerationAnnotation(&eds.ObjectMeta, eds.Spec)
+	if err != nil {
+		return "", false, nil, err
+	}
+	needUpdate := !comparison.IsSameSpecMD5Hash(hash, current.GetAnnotations())
+
+	// TODO: these parameters can be added to the override.PodTemplateSpec. (It exists in v1alpha1)
+	keepAnnotationsFilter := ""
+	keepLabelsFilter := ""
+
+	// Copy possibly changed fields
+	updated := eds.DeepCopy()
+	updated.Annotations = mergeAnnotationsLabels(logger, current.GetAnnotations(), eds.GetAnnotations(), keepAnnotationsFilter)
+	updated.Labels = mergeAnnotationsLabels(logger, current.GetLabels(), eds.GetLabels(), keepLabelsFilter)
+	return hash, needUpdate, updated, nil
+}
+
 // TODO: remove in 1.8.0 when v1alpha1 is removed
 // ensureSelectorInPodTemplateLabels checks that a label selector's MatchLabels
 // are present in the pod template labels. If the label is missing, it adds it
@@ -644,6 +709,11 @@ func (r *Reconciler) addDDAIStatusToDDAStatus(status *datadoghqv2alpha1.DatadogA
 	status.Agent = condition.CombineDaemonSetStatus(status.Agent, currentDDAI.Status.Agent)
 	status.ClusterAgent = condition.CombineDeploymentStatus(status.ClusterAgent, currentDDAI.Status.ClusterAgent)
 	status.ClusterChecksRunner = condition.CombineDeploymentStatus(status.ClusterChecksRunner, currentDDAI.Status.ClusterChecksRunner)
+	status.OtelAgentGateway = condition.CombineDeploymentStatus(status.OtelAgentGateway, currentDDAI.Status.OtelAgentGateway)
+	// Feature dependencies are only managed by the default DDAI, profile DDAIs only change the Agent pods
+	if currentDDAI.Labels[agentprofile.ProfileLabelKey] == "" {
+		status.Features = currentDDAI.Status.Features
+	}
 
 	// TODO: Add and/or merge conditions once DDAI reconcile PR is merged
 
@@ -937,6 +937,9 @@ func Test_addDDAIStatusToDDAStatus(t *testing.T) {
 							},
 						},
 					},
+					Features: map[string]v2alpha1.FeatureStatus{
+						"cws": {State: v2alpha1.FeatureStateEnabled},
+					},
 				},
 			},
 			expectedStatus: v2alpha1.DatadogAgentStatus{
@@ -952,6 +955,9 @@ func Test_addDDAIStatusToDDAStatus(t *testing.T) {
 				ClusterChecksRunner: &v2alpha1.DeploymentStatus{
 					CurrentHash: "foo",
 				},
+				Features: map[string]v2alpha1.FeatureStatus{
+					"cws": {State: v2alpha1.FeatureStateEnabled},
+				},
 			},
 		},
 	}
@@ -2,6 +2,8 @@ package datadogagent
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -56,6 +58,9 @@ func (r *Reconciler) manageGlobalDependencies(logger logr.Logger, dda *datadoghq
 	if err := global.ApplyGlobalComponentDependencies(logger, dda.GetObjectMeta(), &dda.Spec, &dda.Status, resourceManagers, datadoghqv2alpha1.ClusterChecksRunnerComponentName, requiredComponents.ClusterChecksRunner, false); len(err) > 0 {
 		errs = append(errs, err...)
 	}
+	if err := global.ApplyGlobalComponentDependencies(logger, dda.GetObjectMeta(), &dda.Spec, &dda.Status, resourceManagers, datadoghqv2alpha1.OtelAgentGatewayComponentName, requiredComponents.OtelAgentGateway, false); len(err) > 0 {
+		errs = append(errs, err...)
+	}
 
 	if len(errs) > 0 {
 		return errors.NewAggregate(errs)
@@ -64,11 +69,13 @@ func (r *Reconciler) manageGlobalDependencies(logger logr.Logger, dda *datadoghq
 }
 
 // manageFeatureDependencies iterates over features to set up dependencies.
-func (r *Reconciler) manageFeatureDependencies(logger logr.Logger, features []feature.Feature, resourceManagers feature.ResourceManagers) error {
+// The features failing to manage their dependencies are marked as degraded in featureStatuses.
+func (r *Reconciler) manageFeatureDependencies(logger logr.Logger, features []feature.Feature, resourceManagers feature.ResourceManagers, featureStatuses map[string]datadoghqv2alpha1.FeatureStatus) error {
 	var errs []error
 	for _, feat := range features {
 		logger.V(1).Info("Managing dependencies", "featureID", feat.ID())
 		if err := feat.ManageDependencies(resourceManagers); err != nil {
+			feature.SetDegraded(featureStatuses, feat.ID(), feature.DependenciesErrorReason, err.Error())
 			errs = append(errs, err)
 		}
 	}
@@ -97,7 +104,7 @@ func (r *Reconciler) reconcileAgentProfiles(ctx context.Context, logger logr.Log
 
 	// If profiles or introspection is enabled, get the node list and update providers.
 	if r.options.DatadogAgentProfileEnabled || r.options.IntrospectionEnabled {
-		nodeList, err := r.getNodeList(ctx)
+		nodeList, err := r.getDatadogAgentNodeList(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}, instance.Spec.Global.NodeSelector)
 		if err != nil {
 			return reconcile.Result{}, err
 		}
@@ -145,7 +152,7 @@ func (r *Reconciler) reconcileAgentProfiles(ctx context.Context, logger logr.Log
 // cleanupExtraneousResources groups the cleanup calls for old components.
 func (r *Reconciler) cleanupExtraneousResources(ctx context.Context, logger logr.Logger, instance *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus, resourceManagers feature.ResourceManagers) error {
 	var errs []error
-	// Cleanup old DaemonSets, DCA and CCR deployments.
+	// Cleanup old DaemonSets, DCA, CCR and OTel Agent Gateway deployments.
 	now := metav1.NewTime(time.Now())
 	providerList := map[string]struct{}{kubernetes.LegacyProvider: {}}
 	profiles := []datadoghqv1alpha1.DatadogAgentProfile{{}}
@@ -153,7 +160,7 @@ func (r *Reconciler) cleanupExtraneousResources(ctx context.Context, logger logr
 	// Repeat of the code from reconcileAgentProfiles, but this will be removed in DDAI controller since this logic will be from DDA to DDAI.
 	if r.options.DatadogAgentProfileEnabled || r.options.IntrospectionEnabled {
 		// Get a node list for profiles and introspection
-		nodeList, e := r.getNodeList(ctx)
+		nodeList, e := r.getDatadogAgentNodeList(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}, instance.Spec.Global.NodeSelector)
 		if e != nil {
 			return e
 		}
@@ -187,6 +194,10 @@ func (r *Reconciler) cleanupExtraneousResources(ctx context.Context, logger logr
 		errs = append(errs, err)
 		logger.Error(err, "Error cleaning up old CCR Deployments")
 	}
+	if err := r.cleanupOldOtelAgentGatewayDeployments(ctx, logger, instance, newStatus); err != nil {
+		errs = append(errs, err)
+		logger.Error(err, "Error cleaning up old OTel Agent Gateway Deployments")
+	}
 	if len(errs) > 0 {
 		return errors.NewAggregate(errs)
 	}
@@ -228,3 +239,47 @@ func generateNewStatusFromDDA(ddaStatus *datadoghqv2alpha1.DatadogAgentStatus) *
 	}
 	return status
 }
+
+// updateReconcilePausedCondition reports the components whose updates are paused by the pause-reconcile annotations.
+// The condition is removed when the DatadogAgent has no pause-reconcile annotation.
+// It returns the time left before the pause expires, or zero when the pause has no deadline.
+func updateReconcilePausedCondition(logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus, now metav1.Time) time.Duration {
+	if _, found := dda.Annotations[common.PauseReconcileAnnotationKey]; !found {
+		condition.DeleteDatadogAgentStatusCondition(newStatus, common.ReconcilePausedConditionType)
+		return 0
+	}
+
+	pause, err := common.ParseReconcilePause(dda.Annotations, now.Time)
+	if err != nil {
+		logger.Error(err, "Ignoring the pause-reconcile annotations")
+		condition.UpdateDatadogAgentStatusConditions(newStatus, now, common.ReconcilePausedConditionType, metav1.ConditionFalse, "InvalidPauseAnnotation", err.Error(), false)
+		return 0
+	}
+	if pause == nil {
+		condition.UpdateDatadogAgentStatusConditions(newStatus, now, common.ReconcilePausedConditionType, metav1.ConditionFalse, "ReconcileNotPaused", "no component is paused", false)
+		return 0
+	}
+
+	components := make([]string, len(pause.Components))
+	for i, component := range pause.Components {
+		components[i] = string(component)
+	}
+	message := fmt.Sprintf("updates of %s are paused", strings.Join(components, ", "))
+	if pause.Until != nil {
+		message = fmt.Sprintf("%s until %s", message, pause.Until.UTC().Format(time.RFC3339))
+	}
+	condition.UpdateDatadogAgentStatusConditions(newStatus, now, common.ReconcilePausedConditionType, metav1.ConditionTrue, "ReconcilePaused", message, false)
+	if pause.Until == nil {
+		return 0
+	}
+	return pause.Until.Sub(now.Time)
+}
+
+// requeueAtPauseEnd schedules the next reconcile no later than the end of the pause,
+// so that the paused components are updated as soon as the pause expires.
+func requeueAtPauseEnd(result reconcile.Result, pauseLeft time.Duration) reconcile.Result {
+	if pauseLeft > 0 && (result.RequeueAfter == 0 || pauseLeft < result.RequeueAfter) {
+		result.RequeueAfter = pauseLeft
+	}
+	return result
+}
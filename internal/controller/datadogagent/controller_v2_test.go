@@ -9,7 +9,9 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -390,6 +392,64 @@ func TestReconcileDatadogAgentV2_Reconcile(t *testing.T) {
 				return verifyDaemonsetContainers(c, resourcesNamespace, dsName, expectedContainers)
 			},
 		},
+		{
+			name: "DatadogAgent with the restricted security profile, create a Daemonset compliant with the Pod Security Standard restricted",
+			fields: fields{
+				client:   fake.NewClientBuilder().WithStatusSubresource(&appsv1.DaemonSet{}, &v2alpha1.DatadogAgent{}).Build(),
+				scheme:   s,
+				recorder: recorder,
+			},
+			loadFunc: func(c client.Client) *v2alpha1.DatadogAgent {
+				dda := testutils.NewInitializedDatadogAgentBuilder(resourcesNamespace, resourcesName).
+					WithSecurityProfile(v2alpha1.RestrictedSecurityProfile).
+					WithAPMEnabled(true).
+					WithAPMHostPortEnabled(true, apiutils.NewInt32Pointer(8126)).
+					WithLiveContainerCollectionEnabled(true).
+					WithLogCollectionEnabled(true).
+					WithCWSEnabled(true).
+					WithSingleContainerStrategy(false).
+					Build()
+				_ = c.Create(context.TODO(), dda)
+				return dda
+			},
+			want:    reconcile.Result{RequeueAfter: defaultRequeueDuration},
+			wantErr: false,
+			wantFunc: func(c client.Client) error {
+				return verifyDaemonsetPodSecurityRestricted(c, resourcesNamespace, dsName)
+			},
+		},
+		{
+			name: "DatadogAgent with the restricted security profile, the overrides cannot add privileges to the Daemonset",
+			fields: fields{
+				client:   fake.NewClientBuilder().WithStatusSubresource(&appsv1.DaemonSet{}, &v2alpha1.DatadogAgent{}).Build(),
+				scheme:   s,
+				recorder: recorder,
+			},
+			loadFunc: func(c client.Client) *v2alpha1.DatadogAgent {
+				dda := testutils.NewInitializedDatadogAgentBuilder(resourcesNamespace, resourcesName).
+					WithSecurityProfile(v2alpha1.RestrictedSecurityProfile).
+					WithComponentOverride(v2alpha1.NodeAgentComponentName, v2alpha1.DatadogAgentComponentOverride{
+						HostNetwork: apiutils.NewBoolPointer(true),
+						Volumes: []corev1.Volume{{
+							Name:         "host-root",
+							VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/"}},
+						}},
+						Containers: map[apicommon.AgentContainerName]*v2alpha1.DatadogAgentGenericContainer{
+							apicommon.CoreAgentContainerName: {
+								SecurityContext: &corev1.SecurityContext{Privileged: apiutils.NewBoolPointer(true)},
+							},
+						},
+					}).
+					Build()
+				_ = c.Create(context.TODO(), dda)
+				return dda
+			},
+			want:    reconcile.Result{RequeueAfter: defaultRequeueDuration},
+			wantErr: false,
+			wantFunc: func(c client.Client) error {
+				return verifyDaemonsetPodSecurityRestricted(c, resourcesNamespace, dsName)
+			},
+		},
 		{
 			name: "DatadogAgent with override.nodeAgent.disabled true",
 			fields: fields{
@@ -598,6 +658,91 @@ func verifyDaemonsetContainers(c client.Client, resourcesNamespace, dsName strin
 	}
 }
 
+// verifyDaemonsetPodSecurityRestricted checks the pod template of the Daemonset against the controls of the Pod Security Standard `restricted`.
+func verifyDaemonsetPodSecurityRestricted(c client.Client, resourcesNamespace, dsName string) error {
+	ds := &appsv1.DaemonSet{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: resourcesNamespace, Name: dsName}, ds); err != nil {
+		return err
+	}
+	podSpec := ds.Spec.Template.Spec
+	if podSpec.HostNetwork || podSpec.HostPID || podSpec.HostIPC {
+		return fmt.Errorf("host namespaces are used")
+	}
+	for _, volume := range podSpec.Volumes {
+		if volume.ConfigMap == nil && volume.CSI == nil && volume.DownwardAPI == nil && volume.EmptyDir == nil &&
+			volume.Ephemeral == nil && volume.PersistentVolumeClaim == nil && volume.Projected == nil && volume.Secret == nil {
+			return fmt.Errorf("volume %s has a restricted type", volume.Name)
+		}
+	}
+	podSecurityContext := podSpec.SecurityContext
+	if podSecurityContext == nil {
+		podSecurityContext = &corev1.PodSecurityContext{}
+	}
+	if podSecurityContext.RunAsUser != nil && *podSecurityContext.RunAsUser == 0 {
+		return fmt.Errorf("the pod runs as root")
+	}
+	if podSecurityContext.AppArmorProfile != nil && podSecurityContext.AppArmorProfile.Type == corev1.AppArmorProfileTypeUnconfined {
+		return fmt.Errorf("the pod AppArmor profile is unconfined")
+	}
+	for key, value := range ds.Spec.Template.Annotations {
+		if strings.HasPrefix(key, "container.apparmor.security.beta.kubernetes.io/") && value == "unconfined" {
+			return fmt.Errorf("the AppArmor annotation %s is unconfined", key)
+		}
+	}
+	podSeccompProfile := podSecurityContext.SeccompProfile
+	if podSeccompProfile != nil && podSeccompProfile.Type == corev1.SeccompProfileTypeUnconfined {
+		return fmt.Errorf("the pod seccomp profile is unconfined")
+	}
+
+	containers := append(append([]corev1.Container{}, podSpec.InitContainers...), podSpec.Containers...)
+	for _, container := range containers {
+		for _, port := range container.Ports {
+			if port.HostPort != 0 {
+				return fmt.Errorf("container %s uses the host port %d", container.Name, port.HostPort)
+			}
+		}
+		securityContext := container.SecurityContext
+		if securityContext == nil {
+			return fmt.Errorf("container %s has no security context", container.Name)
+		}
+		if apiutils.BoolValue(securityContext.Privileged) {
+			return fmt.Errorf("container %s is privileged", container.Name)
+		}
+		if securityContext.AllowPrivilegeEscalation == nil || *securityContext.AllowPrivilegeEscalation {
+			return fmt.Errorf("container %s allows privilege escalation", container.Name)
+		}
+		if !apiutils.BoolValue(securityContext.RunAsNonRoot) && !apiutils.BoolValue(podSecurityContext.RunAsNonRoot) {
+			return fmt.Errorf("container %s does not run as non-root", container.Name)
+		}
+		if securityContext.RunAsUser != nil && *securityContext.RunAsUser == 0 {
+			return fmt.Errorf("container %s runs as root", container.Name)
+		}
+		seccompProfile := securityContext.SeccompProfile
+		if seccompProfile == nil {
+			seccompProfile = podSeccompProfile
+		}
+		if seccompProfile == nil || (seccompProfile.Type != corev1.SeccompProfileTypeRuntimeDefault && seccompProfile.Type != corev1.SeccompProfileTypeLocalhost) {
+			return fmt.Errorf("container %s has no RuntimeDefault or Localhost seccomp profile", container.Name)
+		}
+		if securityContext.AppArmorProfile != nil && securityContext.AppArmorProfile.Type == corev1.AppArmorProfileTypeUnconfined {
+			return fmt.Errorf("container %s AppArmor profile is unconfined", container.Name)
+		}
+		if securityContext.ProcMount != nil && *securityContext.ProcMount != corev1.DefaultProcMount {
+			return fmt.Errorf("container %s uses a non-default proc mount", container.Name)
+		}
+		capabilities := securityContext.Capabilities
+		if capabilities == nil || !slices.Contains(capabilities.Drop, "ALL") {
+			return fmt.Errorf("container %s does not drop all capabilities", container.Name)
+		}
+		for _, capability := range capabilities.Add {
+			if capability != "NET_BIND_SERVICE" {
+				return fmt.Errorf("container %s adds the capability %s", container.Name, capability)
+			}
+		}
+	}
+	return nil
+}
+
 func verifyDaemonsetNames(t *testing.T, c client.Client, resourcesNamespace, dsName string, expectedDSNames []string) error {
 	daemonSetList := appsv1.DaemonSetList{}
 	if err := c.List(context.TODO(), &daemonSetList, client.HasLabels{constants.MD5AgentDeploymentProviderLabelKey}); err != nil {
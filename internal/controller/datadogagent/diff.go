@@ -0,0 +1,370 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	edsdatadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/pmezard/go-difflib/difflib"
+	appsv1 "k8s.io/api/apps/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+// DiffAction is the action the operator would take on an object.
+type DiffAction string
+
+const (
+	// DiffActionCreate is used for objects the operator would create
+	DiffActionCreate DiffAction = "create"
+	// DiffActionUpdate is used for objects the operator would update
+	DiffActionUpdate DiffAction = "update"
+	// DiffActionDelete is used for objects the operator would delete
+	DiffActionDelete DiffAction = "delete"
+)
+
+// ObjectDiff describes a change the operator would make in the api-server.
+type ObjectDiff struct {
+	Action DiffAction
+	// Kind is the Kubernetes kind of the object, for instance "DaemonSet"
+	Kind string
+	// Object is the rendered object for creations and updates, and the live object for deletions
+	Object client.Object
+	// Diff is a unified diff between the live and the updated object, set for updates only.
+	// Secret values are redacted.
+	Diff string
+	// RestartsPods is true when an update changes the pod template of a workload
+	RestartsPods bool
+}
+
+// Diff renders a DatadogAgent and compares the result with the objects in the api-server.
+// It returns the objects the operator would create, update and delete, using the same comparison
+// as the reconcile loop: spec hashes for the workloads, and the dependencies store for the other objects.
+// The workloads whose updates are paused, or whose spec was rolled back, are not updated.
+// The cluster objects read by the rendering are read with c, the certificates the operator would issue are not issued,
+// and updates are sent to the api-server in dry-run mode to compute the field-level diff: nothing is modified.
+// If the DatadogAgent already exists, its UID, creation timestamp and status are used for the rendering.
+func Diff(ctx context.Context, c client.Client, dda *v2alpha1.DatadogAgent, opts RenderOptions) ([]ObjectDiff, error) {
+	instance := dda.DeepCopy()
+	current := &v2alpha1.DatadogAgent{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(dda), current); err == nil {
+		instance.UID = current.UID
+		instance.CreationTimestamp = current.CreationTimestamp
+		instance.Status = current.Status
+	} else if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("unable to get DatadogAgent %s/%s: %w", dda.Namespace, dda.Name, err)
+	}
+
+	opts.Reader = c
+	res, err := render(ctx, instance, opts)
+	if err != nil {
+		return nil, err
+	}
+	logger := logr.Discard()
+	if opts.Logger != nil {
+		logger = *opts.Logger
+	}
+
+	var diffs []ObjectDiff
+	var errs []error
+	for _, obj := range res.workloads {
+		d, err := diffWorkload(ctx, c, logger, instance, obj)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if d != nil {
+			diffs = append(diffs, *d)
+		}
+	}
+	workloadDeletions, err := workloadsToDelete(ctx, c, res)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	diffs = append(diffs, workloadDeletions...)
+
+	storeDiffs, storeErrs := diffDependencies(ctx, c, logger, res)
+	diffs = append(diffs, storeDiffs...)
+	errs = append(errs, storeErrs...)
+
+	sort.SliceStable(diffs, func(i, j int) bool {
+		if diffs[i].Kind != diffs[j].Kind {
+			return diffs[i].Kind < diffs[j].Kind
+		}
+		if diffs[i].Object.GetNamespace() != diffs[j].Object.GetNamespace() {
+			return diffs[i].Object.GetNamespace() < diffs[j].Object.GetNamespace()
+		}
+		return diffs[i].Object.GetName() < diffs[j].Object.GetName()
+	})
+	return diffs, utilerrors.NewAggregate(errs)
+}
+
+// diffWorkload computes the update of a DaemonSet, Deployment or ExtendedDaemonSet like the createOrUpdate functions
+// of the reconcile loop. It returns nil if the workload doesn't need to be updated.
+// The annotations and the status of dda tell whether the updates are paused or the spec was rolled back.
+func diffWorkload(ctx context.Context, c client.Client, logger logr.Logger, dda *v2alpha1.DatadogAgent, obj client.Object) (*ObjectDiff, error) {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	current, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("unable to copy %s %s/%s", gvk.Kind, obj.GetNamespace(), obj.GetName())
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+		if apierrors.IsNotFound(err) {
+			return &ObjectDiff{Action: DiffActionCreate, Kind: gvk.Kind, Object: obj}, nil
+		}
+		return nil, err
+	}
+
+	var hash string
+	var needUpdate bool
+	var updated client.Object
+	var err error
+	var component v2alpha1.ComponentName
+	var rollbackStatus *v2alpha1.RollbackStatus
+	switch desired := obj.DeepCopyObject().(type) {
+	case *appsv1.DaemonSet:
+		hash, needUpdate, updated, err = daemonSetUpdate(logger, desired, current.(*appsv1.DaemonSet))
+		component = v2alpha1.NodeAgentComponentName
+		rollbackStatus = getDaemonSetRollbackStatus(dda.Status.AgentList, current.GetName())
+	case *appsv1.Deployment:
+		hash, needUpdate, updated, err = deploymentUpdate(logger, desired, current.(*appsv1.Deployment))
+		component = deploymentComponents[desired.Labels[apicommon.AgentDeploymentComponentLabelKey]]
+		rollbackStatus = getDeploymentRollbackStatus(&dda.Status, current.GetName())
+	case *edsdatadoghqv1alpha1.ExtendedDaemonSet:
+		_, needUpdate, updated, err = extendedDaemonSetUpdate(logger, desired, current.(*edsdatadoghqv1alpha1.ExtendedDaemonSet))
+		component = v2alpha1.NodeAgentComponentName
+	default:
+		return nil, fmt.Errorf("unsupported workload %s %s/%s", gvk.Kind, obj.GetNamespace(), obj.GetName())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !needUpdate || isRolledBackSpec(dda, rollbackStatus, hash) || common.IsReconcilePaused(logger, dda.Annotations, component) {
+		return nil, nil
+	}
+
+	return diffUpdate(ctx, c, gvk, updated, current)
+}
+
+// deploymentComponents are the components of the Deployments, indexed by the value of their component label
+var deploymentComponents = map[string]v2alpha1.ComponentName{
+	constants.DefaultClusterAgentResourceSuffix:        v2alpha1.ClusterAgentComponentName,
+	constants.DefaultClusterChecksRunnerResourceSuffix: v2alpha1.ClusterChecksRunnerComponentName,
+	constants.DefaultOtelAgentGatewayResourceSuffix:    v2alpha1.OtelAgentGatewayComponentName,
+}
+
+// workloadsToDelete returns the workloads of the DatadogAgent that are not rendered anymore
+func workloadsToDelete(ctx context.Context, c client.Client, res *renderResult) ([]ObjectDiff, error) {
+	rendered := map[string]bool{}
+	for _, obj := range res.workloads {
+		rendered[obj.GetObjectKind().GroupVersionKind().Kind+"/"+obj.GetName()] = true
+	}
+
+	objectLists := []client.ObjectList{
+		&appsv1.DaemonSetList{},
+		&appsv1.DeploymentList{},
+	}
+	if res.reconcilerOptions.ExtendedDaemonsetOptions.Enabled {
+		objectLists = append(objectLists, &edsdatadoghqv1alpha1.ExtendedDaemonSetList{})
+	}
+
+	var diffs []ObjectDiff
+	for _, objList := range objectLists {
+		if err := c.List(ctx, objList, client.InNamespace(res.dda.Namespace), client.MatchingLabels{
+			kubernetes.AppKubernetesPartOfLabelKey: object.NewPartOfLabelValue(res.dda).String(),
+		}); err != nil {
+			return nil, err
+		}
+		objs, err := extractObjects(res.scheme, objList)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objs {
+			kind := obj.GetObjectKind().GroupVersionKind().Kind
+			if !rendered[kind+"/"+obj.GetName()] {
+				diffs = append(diffs, ObjectDiff{Action: DiffActionDelete, Kind: kind, Object: obj})
+			}
+		}
+	}
+	return diffs, nil
+}
+
+// diffDependencies loads the rendered dependencies in a store, and returns the changes the store would apply
+func diffDependencies(ctx context.Context, c client.Client, logger logr.Logger, res *renderResult) ([]ObjectDiff, []error) {
+	depsStore := store.NewStore(res.dda, &store.StoreOptions{
+		SupportCilium: res.reconcilerOptions.SupportCilium,
+		PlatformInfo:  res.platformInfo,
+		Scheme:        res.scheme,
+		Logger:        logger,
+	})
+	for kind, objs := range res.dependencies {
+		for _, obj := range objs {
+			if err := depsStore.AddOrUpdate(kind, obj); err != nil {
+				return nil, []error{err}
+			}
+		}
+	}
+
+	errs := depsStore.PreviewCertificates(ctx, c)
+	changes, changesErrs := depsStore.Changes(ctx, c)
+	errs = append(errs, changesErrs...)
+	var diffs []ObjectDiff
+	for _, change := range changes.ToCreate {
+		diffs = append(diffs, ObjectDiff{Action: DiffActionCreate, Kind: change.Object.GetObjectKind().GroupVersionKind().Kind, Object: change.Object})
+	}
+	for _, change := range changes.ToUpdate {
+		gvk := change.Object.GetObjectKind().GroupVersionKind()
+		d, err := diffUpdate(ctx, c, gvk, change.Object, change.Current)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		diffs = append(diffs, *d)
+	}
+	for _, change := range changes.ToDelete {
+		obj := change.Current
+		if obj == nil {
+			obj = change.Object
+		}
+		diffs = append(diffs, ObjectDiff{Action: DiffActionDelete, Kind: change.Object.GetObjectKind().GroupVersionKind().Kind, Object: obj})
+	}
+	return diffs, errs
+}
+
+// diffUpdate sends the update in dry-run mode, so that the api-server applies its defaults,
+// and returns the diff between the current object and the dry-run result
+func diffUpdate(ctx context.Context, c client.Client, gvk schema.GroupVersionKind, updated, current client.Object) (*ObjectDiff, error) {
+	dryRun, ok := updated.DeepCopyObject().(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("unable to copy %s %s/%s", gvk.Kind, updated.GetNamespace(), updated.GetName())
+	}
+	dryRun.SetResourceVersion(current.GetResourceVersion())
+	if err := c.Update(ctx, dryRun, client.DryRunAll); err != nil {
+		return nil, fmt.Errorf("unable to update %s %s/%s in dry-run mode: %w", gvk.Kind, updated.GetNamespace(), updated.GetName(), err)
+	}
+
+	currentContent, err := diffableContent(current)
+	if err != nil {
+		return nil, err
+	}
+	updatedContent, err := diffableContent(dryRun)
+	if err != nil {
+		return nil, err
+	}
+	if gvk.Kind == "Secret" {
+		redactSecretData(currentContent, updatedContent)
+	}
+	text, err := unifiedDiff(currentContent, updatedContent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ObjectDiff{
+		Action:       DiffActionUpdate,
+		Kind:         gvk.Kind,
+		Object:       updated,
+		Diff:         text,
+		RestartsPods: podTemplateChanged(current, dryRun),
+	}, nil
+}
+
+// podTemplateChanged returns true if the pod template of a workload differs between two versions
+func podTemplateChanged(current, updated client.Object) bool {
+	switch currentObj := current.(type) {
+	case *appsv1.DaemonSet:
+		return !apiequality.Semantic.DeepEqual(currentObj.Spec.Template, updated.(*appsv1.DaemonSet).Spec.Template)
+	case *appsv1.Deployment:
+		return !apiequality.Semantic.DeepEqual(currentObj.Spec.Template, updated.(*appsv1.Deployment).Spec.Template)
+	case *edsdatadoghqv1alpha1.ExtendedDaemonSet:
+		return !apiequality.Semantic.DeepEqual(currentObj.Spec.Template, updated.(*edsdatadoghqv1alpha1.ExtendedDaemonSet).Spec.Template)
+	}
+	return false
+}
+
+// diffableContent returns the object content without the fields managed by the api-server
+func diffableContent(obj client.Object) (map[string]interface{}, error) {
+	var content map[string]interface{}
+	if u, ok := obj.(runtime.Unstructured); ok {
+		content = runtime.DeepCopyJSON(u.UnstructuredContent())
+	} else {
+		var err error
+		if content, err = runtime.DefaultUnstructuredConverter.ToUnstructured(obj); err != nil {
+			return nil, err
+		}
+	}
+
+	delete(content, "apiVersion")
+	delete(content, "kind")
+	delete(content, "status")
+	if metadata, ok := content["metadata"].(map[string]interface{}); ok {
+		for _, field := range []string{"creationTimestamp", "generation", "managedFields", "resourceVersion", "uid"} {
+			delete(metadata, field)
+		}
+	}
+	return content, nil
+}
+
+// redactSecretData replaces the Secret values, and only shows whether they changed
+func redactSecretData(current, updated map[string]interface{}) {
+	for _, field := range []string{"data", "stringData"} {
+		currentData, _ := current[field].(map[string]interface{})
+		updatedData, _ := updated[field].(map[string]interface{})
+		for key, currentVal := range currentData {
+			updatedVal, found := updatedData[key]
+			if !found {
+				currentData[key] = "***"
+				continue
+			}
+			if currentVal == updatedVal {
+				currentData[key] = "***"
+				updatedData[key] = "***"
+				continue
+			}
+			currentData[key] = "*** (before)"
+			updatedData[key] = "*** (after)"
+		}
+		for key := range updatedData {
+			if _, found := currentData[key]; !found {
+				updatedData[key] = "***"
+			}
+		}
+	}
+}
+
+func unifiedDiff(current, updated map[string]interface{}) (string, error) {
+	currentYAML, err := yaml.Marshal(current)
+	if err != nil {
+		return "", err
+	}
+	updatedYAML, err := yaml.Marshal(updated)
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(currentYAML)),
+		B:        difflib.SplitLines(string(updatedYAML)),
+		FromFile: "live",
+		ToFile:   "rendered",
+		Context:  3,
+	})
+}
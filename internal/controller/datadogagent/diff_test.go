@@ -0,0 +1,198 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	"github.com/DataDog/datadog-operator/pkg/testutils"
+)
+
+func diffKeys(diffs []ObjectDiff, action DiffAction) []string {
+	var keys []string
+	for _, d := range diffs {
+		if d.Action == action {
+			keys = append(keys, d.Kind+"/"+d.Object.GetName())
+		}
+	}
+	return keys
+}
+
+func TestDiff(t *testing.T) {
+	deployed := testutils.NewInitializedDatadogAgentBuilder("bar", "foo").
+		WithClusterChecksEnabled(true).
+		WithClusterChecksUseCLCEnabled(true).
+		Build()
+	deployed.UID = "foo-uid"
+	withNodeAgentEnv := func(annotations map[string]string) *v2alpha1.DatadogAgent {
+		dda := testutils.NewInitializedDatadogAgentBuilder("bar", "foo").
+			WithClusterChecksEnabled(true).
+			WithClusterChecksUseCLCEnabled(true).
+			WithComponentOverride(v2alpha1.NodeAgentComponentName, v2alpha1.DatadogAgentComponentOverride{
+				Env: []corev1.EnvVar{{Name: "DD_FOO", Value: "bar"}},
+			}).
+			Build()
+		dda.Annotations = annotations
+		return dda
+	}
+	otherDDA := testutils.NewInitializedDatadogAgentBuilder("bar", "baz").Build()
+	otherDDA.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+
+	tests := []struct {
+		name          string
+		liveDDA       *v2alpha1.DatadogAgent
+		dda           *v2alpha1.DatadogAgent
+		clusterObjs   []client.Object
+		wantCreate    []string
+		wantUpdate    []string
+		wantDelete    []string
+		wantRestart   []string
+		wantDiffLines []string
+	}{
+		{
+			name: "nothing deployed",
+			dda:  deployed,
+			wantCreate: []string{
+				"DaemonSet/foo-agent",
+				"Deployment/foo-cluster-agent",
+				"Deployment/foo-cluster-checks-runner",
+				"ServiceAccount/foo-agent",
+			},
+		},
+		{
+			name:    "no change",
+			liveDDA: deployed,
+			dda:     deployed,
+		},
+		{
+			name:          "node agent env var added",
+			liveDDA:       deployed,
+			dda:           withNodeAgentEnv(nil),
+			wantUpdate:    []string{"DaemonSet/foo-agent"},
+			wantRestart:   []string{"DaemonSet/foo-agent"},
+			wantDiffLines: []string{"+        - name: DD_FOO", "+          value: bar"},
+		},
+		{
+			name:    "node agent updates paused",
+			liveDDA: deployed,
+			dda:     withNodeAgentEnv(map[string]string{common.PauseReconcileAnnotationKey: "nodeAgent"}),
+		},
+		{
+			name:    "no change with the node partition of another DatadogAgent",
+			liveDDA: deployed,
+			dda:     deployed,
+			clusterObjs: []client.Object{
+				otherDDA,
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+			},
+		},
+		{
+			name:    "cluster checks runner disabled",
+			liveDDA: deployed,
+			dda: testutils.NewInitializedDatadogAgentBuilder("bar", "foo").
+				WithClusterChecksEnabled(true).
+				WithClusterChecksUseCLCEnabled(false).
+				Build(),
+			// The Cluster Agent runs the cluster checks, and takes over the KSM and orchestrator checks
+			wantCreate: []string{
+				"ClusterRole/bar-foo-ksm-core-dca",
+				"ClusterRoleBinding/bar-foo-ksm-core-dca",
+			},
+			wantUpdate: []string{
+				"ConfigMap/foo-kube-state-metrics-core-config",
+				"ConfigMap/foo-orchestrator-explorer-config",
+				"DaemonSet/foo-agent",
+				"Deployment/foo-cluster-agent",
+			},
+			wantDelete: []string{
+				"ClusterRole/bar-foo-ksm-core-ccr",
+				"ClusterRole/bar-foo-orch-exp-ccr",
+				"ClusterRole/foo-cluster-checks-runner",
+				"ClusterRoleBinding/bar-foo-ksm-core-ccr",
+				"ClusterRoleBinding/bar-foo-orch-exp-ccr",
+				"ClusterRoleBinding/foo-cluster-checks-runner",
+				"Deployment/foo-cluster-checks-runner",
+				"ServiceAccount/foo-cluster-checks-runner",
+			},
+			wantRestart: []string{
+				"DaemonSet/foo-agent",
+				"Deployment/foo-cluster-agent",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := renderScheme()
+			require.NoError(t, err)
+			builder := fake.NewClientBuilder().WithScheme(s).WithObjects(tt.clusterObjs...)
+			if tt.liveDDA != nil {
+				clusterReader := fake.NewClientBuilder().WithScheme(s).WithObjects(tt.clusterObjs...).Build()
+				objs, err := Render(context.TODO(), tt.liveDDA, RenderOptions{Reader: clusterReader})
+				require.NoError(t, err)
+				builder = builder.WithObjects(objs...).WithObjects(tt.liveDDA.DeepCopy())
+			}
+			c := builder.Build()
+
+			diffs, err := Diff(context.TODO(), c, tt.dda, RenderOptions{})
+			require.NoError(t, err)
+
+			created := diffKeys(diffs, DiffActionCreate)
+			for _, want := range tt.wantCreate {
+				assert.Contains(t, created, want)
+			}
+			if len(tt.wantCreate) == 0 {
+				assert.Empty(t, created)
+			}
+			assert.ElementsMatch(t, tt.wantUpdate, diffKeys(diffs, DiffActionUpdate))
+			assert.ElementsMatch(t, tt.wantDelete, diffKeys(diffs, DiffActionDelete))
+
+			var restarted []string
+			for _, d := range diffs {
+				if d.RestartsPods {
+					restarted = append(restarted, d.Kind+"/"+d.Object.GetName())
+				}
+				if d.Action == DiffActionUpdate {
+					for _, line := range tt.wantDiffLines {
+						assert.Contains(t, d.Diff, line)
+					}
+				}
+			}
+			assert.ElementsMatch(t, tt.wantRestart, restarted)
+
+			// Diff must not modify the cluster
+			secrets := &corev1.SecretList{}
+			require.NoError(t, c.List(context.TODO(), secrets))
+			if tt.liveDDA == nil {
+				assert.Empty(t, secrets.Items)
+			}
+		})
+	}
+}
+
+func TestRedactSecretData(t *testing.T) {
+	current := map[string]interface{}{
+		"data": map[string]interface{}{"same": "YQ==", "changed": "Yg==", "removed": "Yw=="},
+	}
+	updated := map[string]interface{}{
+		"data": map[string]interface{}{"same": "YQ==", "changed": "ZA==", "added": "ZQ=="},
+	}
+	redactSecretData(current, updated)
+
+	assert.Equal(t, map[string]interface{}{"same": "***", "changed": "*** (before)", "removed": "***"}, current["data"])
+	assert.Equal(t, map[string]interface{}{"same": "***", "changed": "*** (after)", "added": "***"}, updated["data"])
+}
@@ -15,4 +15,16 @@ const (
 	defaultAdmissionControllerTargetPort = 8000
 	// DefaultAdmissionControllerWebhookName default admission controller webhook name
 	defaultAdmissionControllerWebhookName string = "datadog-webhook"
+	// defaultAdmissionControllerWebhookTimeoutSeconds default timeout of the webhooks managed by the operator
+	defaultAdmissionControllerWebhookTimeoutSeconds = 10
+
+	// admissionControllerEnabledLabelKey is the label enabling the mutation of a pod when mutateUnlabelled is not set
+	admissionControllerEnabledLabelKey = "admission.datadoghq.com/enabled"
+
+	admissionControllerCertificateSuffix          = "admission-controller"
+	admissionControllerCertificateSecretSuffix    = "cert"
+	admissionControllerCertManagerSecretSuffix    = "tls"
+	admissionControllerSelfSignedIssuerSuffix     = "selfsigned"
+	admissionControllerCertManagerCertificateDays = 90
+	admissionControllerCertManagerRenewBeforeDays = 45
 )
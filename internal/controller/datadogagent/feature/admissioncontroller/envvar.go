@@ -14,6 +14,7 @@ const (
 	DDAdmissionControllerAgentSidecarImageTag             = "DD_ADMISSION_CONTROLLER_AGENT_SIDECAR_IMAGE_TAG"
 	DDAdmissionControllerAgentSidecarSelectors            = "DD_ADMISSION_CONTROLLER_AGENT_SIDECAR_SELECTORS"
 	DDAdmissionControllerAgentSidecarProfiles             = "DD_ADMISSION_CONTROLLER_AGENT_SIDECAR_PROFILES"
+	DDAdmissionControllerCertificateSecretName            = "DD_ADMISSION_CONTROLLER_CERTIFICATE_SECRET_NAME"
 	DDAdmissionControllerEnabled                          = "DD_ADMISSION_CONTROLLER_ENABLED"
 	DDAdmissionControllerValidationEnabled                = "DD_ADMISSION_CONTROLLER_VALIDATION_ENABLED"
 	DDAdmissionControllerMutationEnabled                  = "DD_ADMISSION_CONTROLLER_MUTATION_ENABLED"
@@ -25,6 +26,7 @@ const (
 	DDAdmissionControllerServiceName                      = "DD_ADMISSION_CONTROLLER_SERVICE_NAME"
 	DDAdmissionControllerFailurePolicy                    = "DD_ADMISSION_CONTROLLER_FAILURE_POLICY"
 	DDAdmissionControllerWebhookName                      = "DD_ADMISSION_CONTROLLER_WEBHOOK_NAME"
+	DDAdmissionControllerWebhookControllerEnabled         = "DD_ADMISSION_CONTROLLER_WEBHOOK_CONTROLLER_ENABLED"
 	DDAdmissionControllerRegistryName                     = "DD_ADMISSION_CONTROLLER_CONTAINER_REGISTRY"
 	DDAdmissionControllerCWSInstrumentationEnabled        = "DD_ADMISSION_CONTROLLER_CWS_INSTRUMENTATION_ENABLED"
 	DDAdmissionControllerCWSInstrumentationMode           = "DD_ADMISSION_CONTROLLER_CWS_INSTRUMENTATION_MODE"
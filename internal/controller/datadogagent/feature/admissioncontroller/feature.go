@@ -7,6 +7,7 @@ package admissioncontroller
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
@@ -20,6 +21,7 @@ import (
 	componentdca "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/clusteragent"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/objects"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	certmanager "github.com/DataDog/datadog-operator/pkg/certmanager/v1"
 	cilium "github.com/DataDog/datadog-operator/pkg/cilium/v1"
 	"github.com/DataDog/datadog-operator/pkg/constants"
 	"github.com/DataDog/datadog-operator/pkg/images"
@@ -51,6 +53,13 @@ type admissionControllerFeature struct {
 	cwsInstrumentationMode    string
 
 	kubernetesAdmissionEvents *KubernetesAdmissionEventConfig
+
+	certManagerConfig         *CertManagerConfig
+	operatorIssuedCertificate bool
+
+	// instrumentationNamespaces is set when the Single Step Instrumentation is enabled
+	instrumentationNamespaces  *constants.InstrumentationNamespaces
+	workloadAutoscalingEnabled bool
 }
 
 type ValidationConfig struct {
@@ -76,6 +85,10 @@ type KubernetesAdmissionEventConfig struct {
 	enabled bool
 }
 
+type CertManagerConfig struct {
+	issuerRef *certmanager.ObjectReference
+}
+
 func buildAdmissionControllerFeature(options *feature.Options) feature.Feature {
 	return &admissionControllerFeature{}
 }
@@ -154,6 +167,38 @@ func (f *admissionControllerFeature) Configure(dda metav1.Object, ddaSpec *v2alp
 			f.kubernetesAdmissionEvents = &KubernetesAdmissionEventConfig{enabled: true}
 		}
 
+		// the operator manages the webhook configurations when cert-manager or the operator issues their certificate
+		if ac.CertManager != nil && apiutils.BoolValue(ac.CertManager.Enabled) {
+			f.certManagerConfig = &CertManagerConfig{}
+			if ac.CertManager.IssuerRef != nil {
+				f.certManagerConfig.issuerRef = &certmanager.ObjectReference{
+					Name:  ac.CertManager.IssuerRef.Name,
+					Kind:  ac.CertManager.IssuerRef.Kind,
+					Group: ac.CertManager.IssuerRef.Group,
+				}
+				if f.certManagerConfig.issuerRef.Kind == "" {
+					f.certManagerConfig.issuerRef.Kind = certmanager.IssuerKind
+				}
+				if f.certManagerConfig.issuerRef.Group == "" {
+					f.certManagerConfig.issuerRef.Group = certmanager.GroupName
+				}
+			}
+		}
+
+		if ac.OperatorIssuedCertificate != nil && apiutils.BoolValue(ac.OperatorIssuedCertificate.Enabled) {
+			f.operatorIssuedCertificate = true
+		}
+
+		// the webhooks registered by the Cluster Agent depend on the other features
+		if apmConf := ddaSpec.Features.APM; apmConf != nil && apiutils.BoolValue(apmConf.Enabled) &&
+			apmConf.SingleStepInstrumentation != nil && apiutils.BoolValue(apmConf.SingleStepInstrumentation.Enabled) {
+			namespaces := constants.GetInstrumentationNamespaces(ddaSpec, dda.GetNamespace())
+			f.instrumentationNamespaces = &namespaces
+		}
+		if autoscaling := ddaSpec.Features.Autoscaling; autoscaling != nil && autoscaling.Workload != nil {
+			f.workloadAutoscalingEnabled = apiutils.BoolValue(autoscaling.Workload.Enabled)
+		}
+
 		_, f.networkPolicy = constants.IsNetworkPolicyEnabled(ddaSpec)
 
 		sidecarConfig := ddaSpec.Features.AdmissionController.AgentSidecarInjection
@@ -240,6 +285,19 @@ func (f *admissionControllerFeature) Configure(dda metav1.Object, ddaSpec *v2alp
 	return reqComp
 }
 
+// operatorManagesWebhooks returns true when the operator, instead of the Cluster Agent, manages the webhook configurations.
+func (f *admissionControllerFeature) operatorManagesWebhooks() bool {
+	return f.certManagerConfig != nil || f.operatorIssuedCertificate
+}
+
+// Validate checks that the webhook configurations can be rendered by the operator.
+func (f *admissionControllerFeature) Validate() error {
+	if f.operatorManagesWebhooks() && f.agentSidecarConfig != nil && len(f.agentSidecarConfig.selectors) > 1 {
+		return fmt.Errorf("only one agentSidecarInjection selector is supported when the operator manages the webhook configurations")
+	}
+	return nil
+}
+
 func (f *admissionControllerFeature) ManageDependencies(managers feature.ResourceManagers) error {
 	ns := f.owner.GetNamespace()
 	rbacName := componentdca.GetClusterAgentRbacResourcesName(f.owner)
@@ -262,13 +320,20 @@ func (f *admissionControllerFeature) ManageDependencies(managers feature.Resourc
 	}
 
 	// rbac
-	if err := managers.RBACManager().AddClusterPolicyRules(ns, rbacName, f.serviceAccountName, getRBACClusterPolicyRules(f.webhookName, f.cwsInstrumentationEnabled, f.cwsInstrumentationMode)); err != nil {
+	if err := managers.RBACManager().AddClusterPolicyRules(ns, rbacName, f.serviceAccountName, getRBACClusterPolicyRules(f.webhookName, !f.operatorManagesWebhooks(), f.cwsInstrumentationEnabled, f.cwsInstrumentationMode)); err != nil {
 		return err
 	}
 	if err := managers.RBACManager().AddPolicyRules(ns, rbacName, f.serviceAccountName, getRBACPolicyRules()); err != nil {
 		return err
 	}
 
+	// certificate and webhook configurations
+	if f.operatorManagesWebhooks() {
+		if err := f.manageWebhookConfigurations(managers); err != nil {
+			return err
+		}
+	}
+
 	if f.networkPolicy != "" {
 		policyName, podSelector := objects.GetNetworkPolicyMetadata(f.owner, v2alpha1.ClusterAgentComponentName)
 		switch f.networkPolicy {
@@ -318,6 +383,12 @@ func (f *admissionControllerFeature) ManageDependencies(managers feature.Resourc
 				},
 			}
 			return managers.CiliumPolicyManager().AddCiliumPolicy(policyName, f.owner.GetNamespace(), policySpecs)
+		case v2alpha1.NetworkPolicyFlavorCalico:
+			return managers.CalicoPolicyManager().AddCalicoGlobalNetworkPolicy(
+				objects.BuildCalicoIngressPolicy(f.owner, v2alpha1.ClusterAgentComponentName, nil, defaultAdmissionControllerTargetPort),
+			)
+		case v2alpha1.NetworkPolicyFlavorAdminNetworkPolicy:
+			// Admin network policies can only select pods as ingress peers, not the API server
 		}
 	}
 	return nil
@@ -405,6 +476,17 @@ func (f *admissionControllerFeature) ManageClusterAgent(managers feature.PodTemp
 		Value: f.webhookName,
 	})
 
+	if f.operatorManagesWebhooks() {
+		managers.EnvVar().AddEnvVarToContainer(apicommon.ClusterAgentContainerName, &corev1.EnvVar{
+			Name:  DDAdmissionControllerCertificateSecretName,
+			Value: getCertificateSecretName(f.owner),
+		})
+		managers.EnvVar().AddEnvVarToContainer(apicommon.ClusterAgentContainerName, &corev1.EnvVar{
+			Name:  DDAdmissionControllerWebhookControllerEnabled,
+			Value: "false",
+		})
+	}
+
 	if f.agentSidecarConfig != nil {
 		managers.EnvVar().AddEnvVarToContainer(apicommon.ClusterAgentContainerName, &corev1.EnvVar{
 			Name:  DDAdmissionControllerAgentSidecarEnabled,
@@ -482,3 +564,7 @@ func (f *admissionControllerFeature) ManageNodeAgent(managers feature.PodTemplat
 func (f *admissionControllerFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+func (f *admissionControllerFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
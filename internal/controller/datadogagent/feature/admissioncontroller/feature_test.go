@@ -6,6 +6,7 @@ package admissioncontroller
 
 import (
 	"testing"
+	"time"
 
 	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
@@ -13,12 +14,21 @@ import (
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/fake"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/test"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	"github.com/DataDog/datadog-operator/pkg/certificate"
+	certmanager "github.com/DataDog/datadog-operator/pkg/certmanager/v1"
 	"github.com/DataDog/datadog-operator/pkg/images"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
 	"github.com/DataDog/datadog-operator/pkg/testutils"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func Test_admissionControllerFeature_Configure(t *testing.T) {
@@ -429,3 +439,230 @@ func sidecarInjectionWantFunc(acm, acRegistry, sidecarRegstry, imageName, imageT
 		)
 	}
 }
+
+func Test_admissionControllerFeature_CertManager(t *testing.T) {
+	certManagerPlatformInfo := kubernetes.NewPlatformInfoFromVersionMaps(nil, map[string]string{
+		"Certificate": "cert-manager.io/v1",
+		"Issuer":      "cert-manager.io/v1",
+	}, map[string]string{})
+
+	tests := test.FeatureTestSuite{
+		{
+			Name: "cert-manager with a self-signed issuer",
+			DDA: testutils.NewInitializedDatadogAgentBuilder("bar", "foo").
+				WithAdmissionControllerEnabled(true).
+				WithAdmissionControllerServiceName("datadog-admission-controller").
+				WithAdmissionControllerCertManager(nil).
+				Build(),
+			StoreOption:   &store.StoreOptions{PlatformInfo: certManagerPlatformInfo},
+			WantConfigure: true,
+			WantDependenciesFunc: func(t testing.TB, store store.StoreClient) {
+				_, found := store.Get(kubernetes.CertManagerIssuersKind, "bar", "foo-admission-controller-selfsigned")
+				assert.True(t, found, "Issuer not found")
+
+				cert := getCertificate(t, store)
+				assert.Equal(t, certmanager.CertificateSpec{
+					DNSNames: []string{
+						"datadog-admission-controller.bar.svc",
+						"datadog-admission-controller.bar.svc.cluster.local",
+					},
+					SecretName: "foo-admission-controller-tls",
+					IssuerRef: certmanager.ObjectReference{
+						Name:  "foo-admission-controller-selfsigned",
+						Kind:  "Issuer",
+						Group: "cert-manager.io",
+					},
+					Duration:    &metav1.Duration{Duration: 90 * 24 * time.Hour},
+					RenewBefore: &metav1.Duration{Duration: 45 * 24 * time.Hour},
+				}, cert.Spec)
+
+				obj, found := store.Get(kubernetes.SecretsKind, "bar", "foo-admission-controller-cert")
+				assert.True(t, found, "Secret not found")
+				assert.Equal(t, "foo-admission-controller-tls", obj.GetAnnotations()[certificate.CopyFromAnnotationKey])
+
+				obj, found = store.Get(kubernetes.MutatingWebhookConfigurationsKind, "", "datadog-webhook")
+				assert.True(t, found, "MutatingWebhookConfiguration not found")
+				webhookConfig := obj.(*admissionregistrationv1.MutatingWebhookConfiguration)
+				assert.Equal(t, "bar/foo-admission-controller", webhookConfig.Annotations[certmanager.InjectCAFromAnnotationKey])
+				webhookNames := []string{}
+				for _, webhook := range webhookConfig.Webhooks {
+					webhookNames = append(webhookNames, webhook.Name)
+					assert.Equal(t, "datadog-admission-controller", webhook.ClientConfig.Service.Name)
+				}
+				assert.Equal(t, []string{"datadog.webhook.agent.config", "datadog.webhook.standard.tags", "datadog.webhook.lib.injection"}, webhookNames)
+
+				_, found = store.Get(kubernetes.ValidatingWebhookConfigurationsKind, "", "datadog-webhook")
+				assert.False(t, found, "ValidatingWebhookConfiguration should not be created without admission events")
+
+				obj, found = store.Get(kubernetes.RolesKind, "bar", "foo-cluster-agent")
+				assert.True(t, found, "Role not found")
+				assert.ElementsMatch(t, []string{"get", "list", "watch", "create", "update"}, obj.(*rbacv1.Role).Rules[0].Verbs)
+			},
+			ClusterAgent: test.NewDefaultComponentTest().WithWantFunc(operatorManagedWebhooksWantFunc),
+		},
+		{
+			Name: "cert-manager with a cluster issuer",
+			DDA: testutils.NewInitializedDatadogAgentBuilder("bar", "foo").
+				WithAdmissionControllerEnabled(true).
+				WithAdmissionControllerServiceName("datadog-admission-controller").
+				WithAdmissionControllerCertManager(&v2alpha1.CertManagerIssuerReference{Name: "ca-issuer", Kind: "ClusterIssuer"}).
+				Build(),
+			StoreOption:   &store.StoreOptions{PlatformInfo: certManagerPlatformInfo},
+			WantConfigure: true,
+			WantDependenciesFunc: func(t testing.TB, store store.StoreClient) {
+				_, found := store.Get(kubernetes.CertManagerIssuersKind, "bar", "foo-admission-controller-selfsigned")
+				assert.False(t, found, "Issuer should not be created")
+
+				cert := getCertificate(t, store)
+				assert.Equal(t, certmanager.ObjectReference{Name: "ca-issuer", Kind: "ClusterIssuer", Group: "cert-manager.io"}, cert.Spec.IssuerRef)
+			},
+		},
+		{
+			Name: "operator-issued certificate",
+			DDA: testutils.NewInitializedDatadogAgentBuilder("bar", "foo").
+				WithAdmissionControllerEnabled(true).
+				WithAdmissionControllerServiceName("datadog-admission-controller").
+				WithAdmissionControllerOperatorIssuedCertificate().
+				Build(),
+			WantConfigure: true,
+			WantDependenciesFunc: func(t testing.TB, store store.StoreClient) {
+				_, found := store.Get(kubernetes.CertManagerCertificatesKind, "bar", "foo-admission-controller")
+				assert.False(t, found, "Certificate should not be created")
+
+				obj, found := store.Get(kubernetes.SecretsKind, "bar", "foo-admission-controller-cert")
+				assert.True(t, found, "Secret not found")
+				assert.Equal(t, "datadog-admission-controller.bar.svc,datadog-admission-controller.bar.svc.cluster.local", obj.GetAnnotations()[certificate.IssueForAnnotationKey])
+
+				obj, found = store.Get(kubernetes.MutatingWebhookConfigurationsKind, "", "datadog-webhook")
+				assert.True(t, found, "MutatingWebhookConfiguration not found")
+				assert.Equal(t, map[string]string{certificate.InjectCAFromSecretAnnotationKey: "bar/foo-admission-controller-cert"}, obj.GetAnnotations())
+			},
+			ClusterAgent: test.NewDefaultComponentTest().WithWantFunc(operatorManagedWebhooksWantFunc),
+		},
+		{
+			Name: "operator-issued certificate with the Single Step Instrumentation and workload autoscaling",
+			DDA: testutils.NewInitializedDatadogAgentBuilder("bar", "foo").
+				WithAdmissionControllerEnabled(true).
+				WithAdmissionControllerServiceName("datadog-admission-controller").
+				WithAdmissionControllerOperatorIssuedCertificate().
+				WithAPMEnabled(true).
+				WithAPMSingleStepInstrumentationEnabled(true, nil, []string{"monitoring"}, nil, false, "", nil).
+				WithWorkloadAutoscalerEnabled(true).
+				Build(),
+			WantConfigure: true,
+			WantDependenciesFunc: func(t testing.TB, store store.StoreClient) {
+				obj, found := store.Get(kubernetes.MutatingWebhookConfigurationsKind, "", "datadog-webhook")
+				assert.True(t, found, "MutatingWebhookConfiguration not found")
+				webhooks := map[string]admissionregistrationv1.MutatingWebhook{}
+				for _, webhook := range obj.(*admissionregistrationv1.MutatingWebhookConfiguration).Webhooks {
+					webhooks[webhook.Name] = webhook
+				}
+				assert.Len(t, webhooks, 4)
+
+				libInjection, found := webhooks["datadog.webhook.lib.injection"]
+				assert.True(t, found, "library injection webhook not found")
+				assert.Equal(t, &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "kubernetes.io/metadata.name", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"kube-system", "bar", "monitoring"}},
+					},
+				}, libInjection.NamespaceSelector)
+				assert.Equal(t, &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "admission.datadoghq.com/enabled", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"false"}},
+					},
+				}, libInjection.ObjectSelector)
+
+				_, found = webhooks["datadog.webhook.autoscaling"]
+				assert.True(t, found, "autoscaling webhook not found")
+			},
+		},
+		{
+			Name: "cert-manager not installed",
+			DDA: testutils.NewInitializedDatadogAgentBuilder("bar", "foo").
+				WithAdmissionControllerEnabled(true).
+				WithAdmissionControllerServiceName("datadog-admission-controller").
+				WithAdmissionControllerCertManager(nil).
+				Build(),
+			WantConfigure:             true,
+			WantManageDependenciesErr: true,
+		},
+	}
+
+	tests.Run(t, buildAdmissionControllerFeature)
+}
+
+func operatorManagedWebhooksWantFunc(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+	mgr := mgrInterface.(*fake.PodTemplateManagers)
+	dcaEnvVars := mgr.EnvVarMgr.EnvVarsByC[apicommon.ClusterAgentContainerName]
+	assert.Contains(t, dcaEnvVars, &corev1.EnvVar{
+		Name:  DDAdmissionControllerCertificateSecretName,
+		Value: "foo-admission-controller-cert",
+	})
+	assert.Contains(t, dcaEnvVars, &corev1.EnvVar{
+		Name:  DDAdmissionControllerWebhookControllerEnabled,
+		Value: "false",
+	})
+}
+
+func Test_admissionControllerFeature_Validate(t *testing.T) {
+	tests := []struct {
+		name                      string
+		operatorIssuedCertificate bool
+		selectors                 int
+		wantErr                   string
+	}{
+		{
+			name:      "several sidecar selectors with webhooks managed by the Cluster Agent",
+			selectors: 2,
+		},
+		{
+			name:                      "one sidecar selector with webhooks managed by the operator",
+			operatorIssuedCertificate: true,
+			selectors:                 1,
+		},
+		{
+			name:                      "several sidecar selectors with webhooks managed by the operator",
+			operatorIssuedCertificate: true,
+			selectors:                 2,
+			wantErr:                   "only one agentSidecarInjection selector is supported when the operator manages the webhook configurations",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := testutils.NewDatadogAgentBuilder().
+				WithAdmissionControllerEnabled(true).
+				WithSidecarInjectionEnabled(true)
+			if tt.operatorIssuedCertificate {
+				builder = builder.WithAdmissionControllerOperatorIssuedCertificate()
+			}
+			dda := builder.Build()
+			for i := 0; i < tt.selectors; i++ {
+				dda.Spec.Features.AdmissionController.AgentSidecarInjection.Selectors = append(dda.Spec.Features.AdmissionController.AgentSidecarInjection.Selectors, &v2alpha1.Selector{
+					ObjectSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}},
+				})
+			}
+
+			f := buildAdmissionControllerFeature(&feature.Options{}).(*admissionControllerFeature)
+			f.Configure(dda, &dda.Spec, nil)
+
+			err := f.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func getCertificate(t testing.TB, store store.StoreClient) certmanager.Certificate {
+	var certificate certmanager.Certificate
+	obj, found := store.Get(kubernetes.CertManagerCertificatesKind, "bar", "foo-admission-controller")
+	if !found {
+		t.Fatal("Certificate not found")
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.(*unstructured.Unstructured).UnstructuredContent(), &certificate); err != nil {
+		t.Fatalf("unable to convert the Certificate: %s", err)
+	}
+	return certificate
+}
@@ -12,16 +12,11 @@ import (
 	"github.com/DataDog/datadog-operator/pkg/kubernetes/rbac"
 )
 
-func getRBACClusterPolicyRules(webhookName string, cwsInstrumentationEnabled bool, cwsInstrumentationMode string) []rbacv1.PolicyRule {
+// getRBACClusterPolicyRules returns the cluster policy rules of the Cluster Agent.
+// manageWebhooks is false when the operator manages the webhook configurations: the Cluster Agent then only reads them.
+func getRBACClusterPolicyRules(webhookName string, manageWebhooks, cwsInstrumentationEnabled bool, cwsInstrumentationMode string) []rbacv1.PolicyRule {
+	// ValidatingWebhooksConfigs and MutatingWebhooksConfigs
 	clusterPolicyRules := []rbacv1.PolicyRule{
-		// ValidatingWebhooksConfigs and MutatingWebhooksConfigs
-		{
-			APIGroups: []string{rbac.AdmissionAPIGroup},
-			Resources: []string{rbac.ValidatingConfigResource, rbac.MutatingConfigResource},
-			Verbs: []string{
-				rbac.CreateVerb,
-			},
-		},
 		{
 			APIGroups:     []string{rbac.AdmissionAPIGroup},
 			Resources:     []string{rbac.ValidatingConfigResource, rbac.MutatingConfigResource},
@@ -30,10 +25,34 @@ func getRBACClusterPolicyRules(webhookName string, cwsInstrumentationEnabled boo
 				rbac.GetVerb,
 				rbac.ListVerb,
 				rbac.WatchVerb,
-				rbac.UpdateVerb,
-				rbac.DeleteVerb,
 			},
 		},
+	}
+	if manageWebhooks {
+		clusterPolicyRules = []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{rbac.AdmissionAPIGroup},
+				Resources: []string{rbac.ValidatingConfigResource, rbac.MutatingConfigResource},
+				Verbs: []string{
+					rbac.CreateVerb,
+				},
+			},
+			{
+				APIGroups:     []string{rbac.AdmissionAPIGroup},
+				Resources:     []string{rbac.ValidatingConfigResource, rbac.MutatingConfigResource},
+				ResourceNames: []string{webhookName},
+				Verbs: []string{
+					rbac.GetVerb,
+					rbac.ListVerb,
+					rbac.WatchVerb,
+					rbac.UpdateVerb,
+					rbac.DeleteVerb,
+				},
+			},
+		}
+	}
+
+	clusterPolicyRules = append(clusterPolicyRules, []rbacv1.PolicyRule{
 		// ExtendedDaemonsetReplicaSets
 		{
 			APIGroups: []string{extendeddaemonset.GroupVersion.Group},
@@ -73,7 +92,7 @@ func getRBACClusterPolicyRules(webhookName string, cwsInstrumentationEnabled boo
 				rbac.GetVerb,
 			},
 		},
-	}
+	}...)
 
 	if cwsInstrumentationEnabled && cwsInstrumentationMode == "remote_copy" {
 		clusterPolicyRules = append(clusterPolicyRules, rbacv1.PolicyRule{
@@ -88,6 +107,8 @@ func getRBACClusterPolicyRules(webhookName string, cwsInstrumentationEnabled boo
 	return clusterPolicyRules
 }
 
+// getRBACPolicyRules returns the policy rules of the Cluster Agent.
+// The Cluster Agent writes the Secret storing its certificate, even when the certificate is issued by the operator.
 func getRBACPolicyRules() []rbacv1.PolicyRule {
 	return []rbacv1.PolicyRule{
 		// Secrets
@@ -0,0 +1,367 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package admissioncontroller
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/pkg/certificate"
+	certmanager "github.com/DataDog/datadog-operator/pkg/certmanager/v1"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+// webhook is a webhook served by the Cluster Agent Admission Controller
+type webhook struct {
+	name       string
+	path       string
+	apiGroups  []string
+	resources  []string
+	operations []admissionregistrationv1.OperationType
+}
+
+var (
+	agentConfigWebhook = webhook{
+		name:       "datadog.webhook.agent.config",
+		path:       "/injectconfig",
+		apiGroups:  []string{""},
+		resources:  []string{"pods"},
+		operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+	}
+	standardTagsWebhook = webhook{
+		name:       "datadog.webhook.standard.tags",
+		path:       "/injecttags",
+		apiGroups:  []string{""},
+		resources:  []string{"pods"},
+		operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+	}
+	libInjectionWebhook = webhook{
+		name:       "datadog.webhook.lib.injection",
+		path:       "/injectlib",
+		apiGroups:  []string{""},
+		resources:  []string{"pods"},
+		operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+	}
+	agentSidecarWebhook = webhook{
+		name:       "datadog.webhook.agent.sidecar",
+		path:       "/agentsidecar",
+		apiGroups:  []string{""},
+		resources:  []string{"pods"},
+		operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+	}
+	cwsPodInstrumentationWebhook = webhook{
+		name:       "datadog.webhook.cws.pod.instrumentation",
+		path:       "/inject-pod-cws",
+		apiGroups:  []string{""},
+		resources:  []string{"pods"},
+		operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+	}
+	cwsExecInstrumentationWebhook = webhook{
+		name:       "datadog.webhook.cws.exec.instrumentation",
+		path:       "/inject-command-cws",
+		apiGroups:  []string{""},
+		resources:  []string{"pods/exec"},
+		operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Connect},
+	}
+	autoscalingWebhook = webhook{
+		name:       "datadog.webhook.autoscaling",
+		path:       "/autoscaling",
+		apiGroups:  []string{""},
+		resources:  []string{"pods"},
+		operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+	}
+	kubernetesAdmissionEventsWebhook = webhook{
+		name:       "datadog.webhook.kubernetes.admission.events",
+		path:       "/kubernetes-admission-events",
+		apiGroups:  []string{"*"},
+		resources:  []string{"*"},
+		operations: []admissionregistrationv1.OperationType{admissionregistrationv1.OperationAll},
+	}
+)
+
+func getCertificateName(owner metav1.Object) string {
+	return fmt.Sprintf("%s-%s", owner.GetName(), admissionControllerCertificateSuffix)
+}
+
+// getCertificateSecretName returns the name of the secret read by the Cluster Agent, with the cert.pem and key.pem keys.
+func getCertificateSecretName(owner metav1.Object) string {
+	return fmt.Sprintf("%s-%s", getCertificateName(owner), admissionControllerCertificateSecretSuffix)
+}
+
+// getCertManagerSecretName returns the name of the kubernetes.io/tls secret written by cert-manager.
+func getCertManagerSecretName(owner metav1.Object) string {
+	return fmt.Sprintf("%s-%s", getCertificateName(owner), admissionControllerCertManagerSecretSuffix)
+}
+
+func getSelfSignedIssuerName(owner metav1.Object) string {
+	return fmt.Sprintf("%s-%s", getCertificateName(owner), admissionControllerSelfSignedIssuerSuffix)
+}
+
+func (f *admissionControllerFeature) getCertificateDNSNames() []string {
+	ns := f.owner.GetNamespace()
+	return []string{
+		fmt.Sprintf("%s.%s.svc", f.serviceName, ns),
+		fmt.Sprintf("%s.%s.svc.cluster.local", f.serviceName, ns),
+	}
+}
+
+// manageWebhookConfigurations adds to the store the certificate of the webhooks, issued by cert-manager or by the operator,
+// and the webhook configurations pointing to the Admission Controller service.
+// The certificate is stored in the secret read by the Cluster Agent, whose keys differ from the ones written by cert-manager:
+// the certificate written by cert-manager is copied to it by the store.
+func (f *admissionControllerFeature) manageWebhookConfigurations(managers feature.ResourceManagers) error {
+	ns := f.owner.GetNamespace()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getCertificateSecretName(f.owner),
+			Namespace: ns,
+		},
+	}
+	var annotations map[string]string
+	if f.certManagerConfig != nil {
+		if err := f.manageCertManagerCertificate(managers); err != nil {
+			return err
+		}
+		secret.Annotations = map[string]string{
+			certificate.CopyFromAnnotationKey: getCertManagerSecretName(f.owner),
+		}
+		annotations = map[string]string{
+			certmanager.InjectCAFromAnnotationKey: fmt.Sprintf("%s/%s", ns, getCertificateName(f.owner)),
+		}
+	} else {
+		secret.Annotations = map[string]string{
+			certificate.IssueForAnnotationKey: strings.Join(f.getCertificateDNSNames(), ","),
+		}
+		annotations = map[string]string{
+			certificate.InjectCAFromSecretAnnotationKey: fmt.Sprintf("%s/%s", ns, secret.Name),
+		}
+	}
+	if err := managers.Store().AddOrUpdate(kubernetes.SecretsKind, secret); err != nil {
+		return err
+	}
+
+	if mutatingWebhooks := f.getMutatingWebhooks(); len(mutatingWebhooks) > 0 {
+		webhookConfig := &admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        f.webhookName,
+				Annotations: annotations,
+			},
+			Webhooks: mutatingWebhooks,
+		}
+		if err := managers.Store().AddOrUpdate(kubernetes.MutatingWebhookConfigurationsKind, webhookConfig); err != nil {
+			return err
+		}
+	}
+
+	if validatingWebhooks := f.getValidatingWebhooks(); len(validatingWebhooks) > 0 {
+		webhookConfig := &admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        f.webhookName,
+				Annotations: annotations,
+			},
+			Webhooks: validatingWebhooks,
+		}
+		if err := managers.Store().AddOrUpdate(kubernetes.ValidatingWebhookConfigurationsKind, webhookConfig); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// manageCertManagerCertificate adds to the store the cert-manager resources issuing the certificate of the webhooks.
+// The certificate is renewed before the Cluster Agent considers it has to renew it itself.
+func (f *admissionControllerFeature) manageCertManagerCertificate(managers feature.ResourceManagers) error {
+	ns := f.owner.GetNamespace()
+	platformInfo := managers.Store().GetPlatformInfo()
+	if !platformInfo.IsCertManagerSupported() {
+		return fmt.Errorf("cert-manager is not installed in the cluster, the certificate of the Admission Controller webhooks cannot be issued")
+	}
+
+	issuerRef := certmanager.ObjectReference{
+		Name:  getSelfSignedIssuerName(f.owner),
+		Kind:  certmanager.IssuerKind,
+		Group: certmanager.GroupName,
+	}
+	if f.certManagerConfig.issuerRef != nil {
+		issuerRef = *f.certManagerConfig.issuerRef
+	} else if err := managers.CertManagerManager().AddIssuer(issuerRef.Name, ns, certmanager.IssuerSpec{SelfSigned: &certmanager.SelfSignedIssuer{}}); err != nil {
+		return err
+	}
+
+	certificateSpec := certmanager.CertificateSpec{
+		DNSNames:    f.getCertificateDNSNames(),
+		SecretName:  getCertManagerSecretName(f.owner),
+		IssuerRef:   issuerRef,
+		Duration:    &metav1.Duration{Duration: admissionControllerCertManagerCertificateDays * 24 * time.Hour},
+		RenewBefore: &metav1.Duration{Duration: admissionControllerCertManagerRenewBeforeDays * 24 * time.Hour},
+	}
+	return managers.CertManagerManager().AddCertificate(getCertificateName(f.owner), ns, certificateSpec)
+}
+
+// getMutatingWebhooks returns the mutating webhooks registered by the Cluster Agent for the enabled features.
+func (f *admissionControllerFeature) getMutatingWebhooks() []admissionregistrationv1.MutatingWebhook {
+	if f.mutationWebhookConfig != nil && !f.mutationWebhookConfig.enabled {
+		return nil
+	}
+
+	// The configuration, tags and library injection webhooks only mutate the labelled pods, unless mutateUnlabelled is set
+	labelSelector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{admissionControllerEnabledLabelKey: "true"},
+	}
+	if f.mutateUnlabelled {
+		labelSelector = newNotDisabledLabelSelector()
+	}
+
+	webhooks := []admissionregistrationv1.MutatingWebhook{
+		f.newMutatingWebhook(agentConfigWebhook, &metav1.LabelSelector{}, labelSelector),
+		f.newMutatingWebhook(standardTagsWebhook, &metav1.LabelSelector{}, labelSelector),
+	}
+
+	// The Single Step Instrumentation mutates the pods of the instrumented namespaces which are not explicitly disabled
+	if f.instrumentationNamespaces != nil {
+		webhooks = append(webhooks, f.newMutatingWebhook(libInjectionWebhook, newInstrumentationNamespaceSelector(*f.instrumentationNamespaces), newNotDisabledLabelSelector()))
+	} else {
+		webhooks = append(webhooks, f.newMutatingWebhook(libInjectionWebhook, &metav1.LabelSelector{}, labelSelector))
+	}
+
+	if f.agentSidecarConfig != nil && f.agentSidecarConfig.enabled {
+		// Only one sidecar selector is supported, more are rejected by Validate
+		namespaceSelector, objectSelector := &metav1.LabelSelector{}, &metav1.LabelSelector{}
+		if len(f.agentSidecarConfig.selectors) > 0 {
+			if f.agentSidecarConfig.selectors[0].NamespaceSelector != nil {
+				namespaceSelector = f.agentSidecarConfig.selectors[0].NamespaceSelector
+			}
+			if f.agentSidecarConfig.selectors[0].ObjectSelector != nil {
+				objectSelector = f.agentSidecarConfig.selectors[0].ObjectSelector
+			}
+		}
+		webhooks = append(webhooks, f.newMutatingWebhook(agentSidecarWebhook, namespaceSelector, objectSelector))
+	}
+
+	if f.cwsInstrumentationEnabled {
+		webhooks = append(webhooks,
+			f.newMutatingWebhook(cwsPodInstrumentationWebhook, &metav1.LabelSelector{}, &metav1.LabelSelector{}),
+			f.newMutatingWebhook(cwsExecInstrumentationWebhook, &metav1.LabelSelector{}, &metav1.LabelSelector{}),
+		)
+	}
+
+	if f.workloadAutoscalingEnabled {
+		webhooks = append(webhooks, f.newMutatingWebhook(autoscalingWebhook, &metav1.LabelSelector{}, &metav1.LabelSelector{}))
+	}
+
+	return webhooks
+}
+
+// newNotDisabledLabelSelector selects the pods which are not explicitly disabled with the admission.datadoghq.com/enabled label.
+func newNotDisabledLabelSelector() *metav1.LabelSelector {
+	return &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      admissionControllerEnabledLabelKey,
+				Operator: metav1.LabelSelectorOpNotIn,
+				Values:   []string{"false"},
+			},
+		},
+	}
+}
+
+// newInstrumentationNamespaceSelector selects the enabled namespaces if any, and the namespaces which are not disabled otherwise.
+func newInstrumentationNamespaceSelector(namespaces constants.InstrumentationNamespaces) *metav1.LabelSelector {
+	requirement := metav1.LabelSelectorRequirement{
+		Key:      corev1.LabelMetadataName,
+		Operator: metav1.LabelSelectorOpNotIn,
+		Values:   namespaces.Disabled,
+	}
+	if len(namespaces.Enabled) > 0 {
+		requirement.Operator = metav1.LabelSelectorOpIn
+		requirement.Values = namespaces.Enabled
+	}
+	return &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{requirement}}
+}
+
+func (f *admissionControllerFeature) getValidatingWebhooks() []admissionregistrationv1.ValidatingWebhook {
+	if f.validationWebhookConfig != nil && !f.validationWebhookConfig.enabled {
+		return nil
+	}
+	if f.kubernetesAdmissionEvents == nil || !f.kubernetesAdmissionEvents.enabled {
+		return nil
+	}
+
+	w := kubernetesAdmissionEventsWebhook
+	return []admissionregistrationv1.ValidatingWebhook{
+		{
+			Name:                    w.name,
+			ClientConfig:            f.newWebhookClientConfig(w),
+			Rules:                   newWebhookRules(w),
+			FailurePolicy:           f.getFailurePolicy(),
+			MatchPolicy:             apiutils.NewPointer(admissionregistrationv1.Equivalent),
+			NamespaceSelector:       &metav1.LabelSelector{},
+			ObjectSelector:          &metav1.LabelSelector{},
+			SideEffects:             apiutils.NewPointer(admissionregistrationv1.SideEffectClassNone),
+			TimeoutSeconds:          apiutils.NewInt32Pointer(defaultAdmissionControllerWebhookTimeoutSeconds),
+			AdmissionReviewVersions: []string{"v1", "v1beta1"},
+		},
+	}
+}
+
+// newMutatingWebhook returns a webhook with the fields defaulted by the API server set, to compare it with the current one.
+func (f *admissionControllerFeature) newMutatingWebhook(w webhook, namespaceSelector, objectSelector *metav1.LabelSelector) admissionregistrationv1.MutatingWebhook {
+	return admissionregistrationv1.MutatingWebhook{
+		Name:                    w.name,
+		ClientConfig:            f.newWebhookClientConfig(w),
+		Rules:                   newWebhookRules(w),
+		FailurePolicy:           f.getFailurePolicy(),
+		MatchPolicy:             apiutils.NewPointer(admissionregistrationv1.Equivalent),
+		NamespaceSelector:       namespaceSelector,
+		ObjectSelector:          objectSelector,
+		SideEffects:             apiutils.NewPointer(admissionregistrationv1.SideEffectClassNone),
+		TimeoutSeconds:          apiutils.NewInt32Pointer(defaultAdmissionControllerWebhookTimeoutSeconds),
+		AdmissionReviewVersions: []string{"v1", "v1beta1"},
+		ReinvocationPolicy:      apiutils.NewPointer(admissionregistrationv1.NeverReinvocationPolicy),
+	}
+}
+
+func (f *admissionControllerFeature) newWebhookClientConfig(w webhook) admissionregistrationv1.WebhookClientConfig {
+	return admissionregistrationv1.WebhookClientConfig{
+		Service: &admissionregistrationv1.ServiceReference{
+			Namespace: f.owner.GetNamespace(),
+			Name:      f.serviceName,
+			Path:      &w.path,
+			Port:      apiutils.NewInt32Pointer(defaultAdmissionControllerServicePort),
+		},
+	}
+}
+
+func newWebhookRules(w webhook) []admissionregistrationv1.RuleWithOperations {
+	return []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: w.operations,
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   w.apiGroups,
+				APIVersions: []string{"*"},
+				Resources:   w.resources,
+				Scope:       apiutils.NewPointer(admissionregistrationv1.AllScopes),
+			},
+		},
+	}
+}
+
+func (f *admissionControllerFeature) getFailurePolicy() *admissionregistrationv1.FailurePolicyType {
+	if f.failurePolicy == string(admissionregistrationv1.Fail) {
+		return apiutils.NewPointer(admissionregistrationv1.Fail)
+	}
+	return apiutils.NewPointer(admissionregistrationv1.Ignore)
+}
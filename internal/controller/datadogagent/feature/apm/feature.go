@@ -66,6 +66,8 @@ type apmFeature struct {
 
 	createKubernetesNetworkPolicy bool
 	createCiliumNetworkPolicy     bool
+	createCalicoNetworkPolicy     bool
+	createAdminNetworkPolicy      bool
 
 	singleStepInstrumentation *instrumentationConfig
 
@@ -132,9 +134,14 @@ func (f *apmFeature) Configure(dda metav1.Object, ddaSpec *v2alpha1.DatadogAgent
 		f.hostPortHostPort = *apm.HostPortConfig.Port
 		if f.hostPortEnabled {
 			if enabled, flavor := constants.IsNetworkPolicyEnabled(ddaSpec); enabled {
-				if flavor == v2alpha1.NetworkPolicyFlavorCilium {
+				switch flavor {
+				case v2alpha1.NetworkPolicyFlavorCilium:
 					f.createCiliumNetworkPolicy = true
-				} else {
+				case v2alpha1.NetworkPolicyFlavorCalico:
+					f.createCalicoNetworkPolicy = true
+				case v2alpha1.NetworkPolicyFlavorAdminNetworkPolicy:
+					f.createAdminNetworkPolicy = true
+				default:
 					f.createKubernetesNetworkPolicy = true
 				}
 			}
@@ -210,6 +217,32 @@ func (f *apmFeature) shouldEnableLanguageDetection() bool {
 		f.singleStepInstrumentation.languageDetection.enabled
 }
 
+// Validate checks that the APM configuration can be applied.
+func (f *apmFeature) Validate() error {
+	if f.singleStepInstrumentation != nil &&
+		len(f.singleStepInstrumentation.enabledNamespaces) > 0 &&
+		len(f.singleStepInstrumentation.disabledNamespaces) > 0 {
+		return fmt.Errorf("instrumentation.enabledNamespaces and instrumentation.disabledNamespaces cannot be set together")
+	}
+	return nil
+}
+
+// HostPorts returns the host ports used by the feature.
+func (f *apmFeature) HostPorts() map[string]int32 {
+	if !f.hostPortEnabled {
+		return nil
+	}
+	return map[string]int32{constants.DefaultApmPortName: f.hostPortHostPort}
+}
+
+// HostAccess returns the host accesses of the feature.
+func (f *apmFeature) HostAccess() []string {
+	if !f.udsEnabled {
+		return nil
+	}
+	return []string{feature.HostPathAccess(filepath.Dir(f.udsHostFilepath))}
+}
+
 // ManageDependencies allows a feature to manage its dependencies.
 // Feature's dependencies should be added in the store.
 func (f *apmFeature) ManageDependencies(managers feature.ResourceManagers) error {
@@ -287,6 +320,14 @@ func (f *apmFeature) ManageDependencies(managers feature.ResourceManagers) error
 				},
 			}
 			return managers.CiliumPolicyManager().AddCiliumPolicy(policyName, f.owner.GetNamespace(), policySpecs)
+		} else if f.createCalicoNetworkPolicy {
+			return managers.CalicoPolicyManager().AddCalicoGlobalNetworkPolicy(
+				objects.BuildCalicoIngressPolicy(f.owner, v2alpha1.NodeAgentComponentName, nil, f.hostPortHostPort),
+			)
+		} else if f.createAdminNetworkPolicy {
+			return managers.AdminNetworkPolicyManager().AddAdminNetworkPolicy(
+				objects.BuildAdminNetworkPolicyIngress(f.owner, v2alpha1.NodeAgentComponentName, "ingress-apm", nil, f.hostPortHostPort),
+			)
 		}
 	}
 
@@ -487,3 +528,9 @@ func (f *apmFeature) manageNodeAgent(agentContainerName apicommon.AgentContainer
 func (f *apmFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *apmFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
@@ -0,0 +1,225 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package apm
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+)
+
+// supportedTracerLanguages are the languages of the tracers the Single Step Instrumentation can inject.
+var supportedTracerLanguages = []string{"dotnet", "java", "js", "php", "python", "ruby"}
+
+// GetInstrumentationTargets returns the targets of the Single Step Instrumentation, or nil if the instrumentation is disabled.
+func GetInstrumentationTargets(ddaSpec *v2alpha1.DatadogAgentSpec) []v2alpha1.SSITarget {
+	if ddaSpec.Features == nil || !shouldEnableAPM(ddaSpec.Features.APM) {
+		return nil
+	}
+	ssi := ddaSpec.Features.APM.SingleStepInstrumentation
+	if ssi == nil || !apiutils.BoolValue(ssi.Enabled) {
+		return nil
+	}
+	return ssi.Targets
+}
+
+// targetsListPageSize is the number of namespaces or pods listed at once to evaluate the targets.
+const targetsListPageSize = 500
+
+// GetTargetsStatus evaluates the targets of the Single Step Instrumentation against the namespaces and the pods of the cluster.
+// Only the metadata of the namespaces and the pods are listed, by pages.
+func GetTargetsStatus(ctx context.Context, k8sClient client.Reader, targets []v2alpha1.SSITarget, namespaces constants.InstrumentationNamespaces) ([]v2alpha1.SSITargetStatus, error) {
+	namespaceItems, err := listMetadata(ctx, k8sClient, "NamespaceList")
+	if err != nil {
+		return nil, fmt.Errorf("unable to list namespaces: %w", err)
+	}
+
+	podItems, err := listMetadata(ctx, k8sClient, "PodList")
+	if err != nil {
+		return nil, fmt.Errorf("unable to list pods: %w", err)
+	}
+
+	return EvaluateTargets(targets, namespaces, namespaceItems, podItems), nil
+}
+
+// listMetadata lists the metadata of all the objects of a core list kind, by pages of targetsListPageSize objects.
+func listMetadata(ctx context.Context, k8sClient client.Reader, listKind string) ([]metav1.PartialObjectMetadata, error) {
+	var items []metav1.PartialObjectMetadata
+	continueToken := ""
+	for {
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind(listKind))
+		opts := []client.ListOption{client.Limit(targetsListPageSize)}
+		if continueToken != "" {
+			opts = append(opts, client.Continue(continueToken))
+		}
+		if err := k8sClient.List(ctx, list, opts...); err != nil {
+			return nil, err
+		}
+		items = append(items, list.Items...)
+		continueToken = list.GetContinue()
+		if continueToken == "" {
+			return items, nil
+		}
+	}
+}
+
+// EvaluateTargets returns the namespaces and pods matched by each target.
+// Like the Cluster Agent, the disabled namespaces are never matched, the targets are rejected when enabled namespaces
+// are set, and a pod matched by several targets is instrumented with the first one.
+func EvaluateTargets(targets []v2alpha1.SSITarget, rules constants.InstrumentationNamespaces, namespaces []metav1.PartialObjectMetadata, pods []metav1.PartialObjectMetadata) []v2alpha1.SSITargetStatus {
+	if len(rules.Enabled) > 0 {
+		statuses := make([]v2alpha1.SSITargetStatus, len(targets))
+		for i := range targets {
+			statuses[i].Name = targetName(i, &targets[i])
+			statuses[i].Error = "targets cannot be used with enabledNamespaces"
+		}
+		return statuses
+	}
+	namespaces = slices.DeleteFunc(slices.Clone(namespaces), func(ns metav1.PartialObjectMetadata) bool {
+		return slices.Contains(rules.Disabled, ns.Name)
+	})
+
+	statuses := make([]v2alpha1.SSITargetStatus, len(targets))
+	matchers := make([]*targetMatcher, len(targets))
+	for i := range targets {
+		statuses[i].Name = targetName(i, &targets[i])
+		statuses[i].InvalidTracerVersions = invalidTracerVersions(targets[i].TracerVersions)
+
+		matcher, err := newTargetMatcher(&targets[i])
+		if err != nil {
+			statuses[i].Error = err.Error()
+			continue
+		}
+		matchers[i] = matcher
+		for _, ns := range namespaces {
+			if matcher.matchesNamespace(ns.Name, ns.Labels) {
+				statuses[i].MatchedNamespaces++
+			}
+		}
+	}
+
+	namespaceLabels := make(map[string]map[string]string, len(namespaces))
+	for _, ns := range namespaces {
+		namespaceLabels[ns.Name] = ns.Labels
+	}
+
+	overlaps := make([]map[int]struct{}, len(targets))
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || slices.Contains(rules.Disabled, pod.Namespace) {
+			continue
+		}
+		var previous []int
+		for i, matcher := range matchers {
+			if matcher == nil || !matcher.matchesNamespace(pod.Namespace, namespaceLabels[pod.Namespace]) || !matcher.pods.Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+			statuses[i].MatchedPods++
+			if len(previous) == 0 {
+				statuses[i].AppliedPods++
+			}
+			for _, j := range previous {
+				if overlaps[i] == nil {
+					overlaps[i] = map[int]struct{}{}
+				}
+				overlaps[i][j] = struct{}{}
+			}
+			previous = append(previous, i)
+		}
+	}
+
+	for i := range overlaps {
+		indexes := make([]int, 0, len(overlaps[i]))
+		for j := range overlaps[i] {
+			indexes = append(indexes, j)
+		}
+		sort.Ints(indexes)
+		for _, j := range indexes {
+			statuses[i].OverlappingTargets = append(statuses[i].OverlappingTargets, statuses[j].Name)
+		}
+	}
+
+	return statuses
+}
+
+// targetMatcher matches the namespaces and the pods of a target.
+type targetMatcher struct {
+	namespaceNames []string
+	namespaces     labels.Selector
+	pods           labels.Selector
+}
+
+func newTargetMatcher(target *v2alpha1.SSITarget) (*targetMatcher, error) {
+	matcher := &targetMatcher{
+		namespaces: labels.Everything(),
+		pods:       labels.Everything(),
+	}
+
+	if target.PodSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(target.PodSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid podSelector: %w", err)
+		}
+		matcher.pods = selector
+	}
+
+	if nsSelector := target.NamespaceSelector; nsSelector != nil {
+		if len(nsSelector.MatchNames) > 0 {
+			if len(nsSelector.MatchLabels) > 0 || len(nsSelector.MatchExpressions) > 0 {
+				return nil, fmt.Errorf("namespaceSelector.matchNames cannot be used with matchLabels or matchExpressions")
+			}
+			matcher.namespaceNames = nsSelector.MatchNames
+		} else {
+			selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+				MatchLabels:      nsSelector.MatchLabels,
+				MatchExpressions: nsSelector.MatchExpressions,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("invalid namespaceSelector: %w", err)
+			}
+			matcher.namespaces = selector
+		}
+	}
+
+	return matcher, nil
+}
+
+func (m *targetMatcher) matchesNamespace(name string, nsLabels map[string]string) bool {
+	if len(m.namespaceNames) > 0 {
+		return slices.Contains(m.namespaceNames, name)
+	}
+	return m.namespaces.Matches(labels.Set(nsLabels))
+}
+
+// targetName returns the name of a target, or its position in the targets if it has no name.
+func targetName(index int, target *v2alpha1.SSITarget) string {
+	if target.Name != "" {
+		return target.Name
+	}
+	return fmt.Sprintf("targets[%d]", index)
+}
+
+// invalidTracerVersions returns the sorted languages of tracerVersions which cannot be injected.
+func invalidTracerVersions(tracerVersions map[string]string) []string {
+	var invalid []string
+	for language := range tracerVersions {
+		if !slices.Contains(supportedTracerLanguages, language) {
+			invalid = append(invalid, language)
+		}
+	}
+	sort.Strings(invalid)
+	return invalid
+}
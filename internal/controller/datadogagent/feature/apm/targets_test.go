@@ -0,0 +1,161 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package apm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+)
+
+func newNamespaceMetadata(name string, nsLabels map[string]string) metav1.PartialObjectMetadata {
+	return metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nsLabels}}
+}
+
+func newPodMetadata(namespace, name string, podLabels map[string]string) metav1.PartialObjectMetadata {
+	return metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: podLabels}}
+}
+
+func Test_EvaluateTargets(t *testing.T) {
+	namespaces := []metav1.PartialObjectMetadata{
+		newNamespaceMetadata("billing", map[string]string{"team": "payments"}),
+		newNamespaceMetadata("checkout", map[string]string{"team": "payments"}),
+		newNamespaceMetadata("monitoring", nil),
+		newNamespaceMetadata("kube-system", nil),
+		newNamespaceMetadata("datadog", nil),
+	}
+	deletedPod := newPodMetadata("checkout", "cart-old", map[string]string{"app": "cart", "language": "java"})
+	deletedPod.DeletionTimestamp = &metav1.Time{}
+	pods := []metav1.PartialObjectMetadata{
+		newPodMetadata("billing", "invoices", map[string]string{"app": "invoices", "language": "python"}),
+		newPodMetadata("checkout", "cart", map[string]string{"app": "cart", "language": "java"}),
+		newPodMetadata("checkout", "payment", map[string]string{"app": "payment", "language": "java"}),
+		newPodMetadata("monitoring", "prometheus", map[string]string{"app": "prometheus"}),
+		newPodMetadata("kube-system", "coredns", map[string]string{"app": "coredns"}),
+		newPodMetadata("datadog", "datadog-agent", map[string]string{"app": "datadog-agent"}),
+		deletedPod,
+	}
+
+	defaultRules := constants.GetInstrumentationNamespaces(&v2alpha1.DatadogAgentSpec{}, "datadog")
+
+	tests := []struct {
+		name    string
+		targets []v2alpha1.SSITarget
+		rules   *constants.InstrumentationNamespaces
+		want    []v2alpha1.SSITargetStatus
+	}{
+		{
+			name: "target without selectors",
+			targets: []v2alpha1.SSITarget{
+				{Name: "all"},
+			},
+			want: []v2alpha1.SSITargetStatus{
+				{Name: "all", MatchedNamespaces: 3, MatchedPods: 4, AppliedPods: 4},
+			},
+		},
+		{
+			name: "namespace labels and pod selector",
+			targets: []v2alpha1.SSITarget{
+				{
+					Name:              "java-payments",
+					NamespaceSelector: &v2alpha1.NamespaceSelector{MatchLabels: map[string]string{"team": "payments"}},
+					PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"language": "java"}},
+				},
+			},
+			want: []v2alpha1.SSITargetStatus{
+				{Name: "java-payments", MatchedNamespaces: 2, MatchedPods: 2, AppliedPods: 2},
+			},
+		},
+		{
+			name: "overlapping targets",
+			targets: []v2alpha1.SSITarget{
+				{
+					Name:              "cart",
+					NamespaceSelector: &v2alpha1.NamespaceSelector{MatchNames: []string{"checkout"}},
+					PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "cart"}},
+				},
+				{
+					NamespaceSelector: &v2alpha1.NamespaceSelector{MatchNames: []string{"checkout", "billing"}},
+				},
+			},
+			want: []v2alpha1.SSITargetStatus{
+				{Name: "cart", MatchedNamespaces: 1, MatchedPods: 1, AppliedPods: 1},
+				{Name: "targets[1]", MatchedNamespaces: 2, MatchedPods: 3, AppliedPods: 2, OverlappingTargets: []string{"cart"}},
+			},
+		},
+		{
+			name: "invalid tracer versions",
+			targets: []v2alpha1.SSITarget{
+				{
+					Name:           "tracers",
+					TracerVersions: map[string]string{"java": "1", "node": "5", "golang": "1"},
+				},
+			},
+			want: []v2alpha1.SSITargetStatus{
+				{Name: "tracers", MatchedNamespaces: 3, MatchedPods: 4, AppliedPods: 4, InvalidTracerVersions: []string{"golang", "node"}},
+			},
+		},
+		{
+			name: "invalid namespace selector",
+			targets: []v2alpha1.SSITarget{
+				{
+					Name: "invalid",
+					NamespaceSelector: &v2alpha1.NamespaceSelector{
+						MatchNames:  []string{"billing"},
+						MatchLabels: map[string]string{"team": "payments"},
+					},
+				},
+				{Name: "all"},
+			},
+			want: []v2alpha1.SSITargetStatus{
+				{Name: "invalid", Error: "namespaceSelector.matchNames cannot be used with matchLabels or matchExpressions"},
+				{Name: "all", MatchedNamespaces: 3, MatchedPods: 4, AppliedPods: 4},
+			},
+		},
+		{
+			name: "disabled namespaces",
+			targets: []v2alpha1.SSITarget{
+				{Name: "all"},
+			},
+			rules: &constants.InstrumentationNamespaces{Disabled: []string{"kube-system", "datadog", "monitoring"}},
+			want: []v2alpha1.SSITargetStatus{
+				{Name: "all", MatchedNamespaces: 2, MatchedPods: 3, AppliedPods: 3},
+			},
+		},
+		{
+			name: "disabled namespace selected by name",
+			targets: []v2alpha1.SSITarget{
+				{Name: "system", NamespaceSelector: &v2alpha1.NamespaceSelector{MatchNames: []string{"kube-system", "billing"}}},
+			},
+			want: []v2alpha1.SSITargetStatus{
+				{Name: "system", MatchedNamespaces: 1, MatchedPods: 1, AppliedPods: 1},
+			},
+		},
+		{
+			name: "enabled namespaces",
+			targets: []v2alpha1.SSITarget{
+				{Name: "all"},
+			},
+			rules: &constants.InstrumentationNamespaces{Enabled: []string{"billing"}, Disabled: defaultRules.Disabled},
+			want: []v2alpha1.SSITargetStatus{
+				{Name: "all", Error: "targets cannot be used with enabledNamespaces"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := defaultRules
+			if tt.rules != nil {
+				rules = *tt.rules
+			}
+			assert.Equal(t, tt.want, EvaluateTargets(tt.targets, rules, namespaces, pods))
+		})
+	}
+}
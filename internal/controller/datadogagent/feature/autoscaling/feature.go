@@ -128,3 +128,9 @@ func (f *autoscalingFeature) ManageNodeAgent(managers feature.PodTemplateManager
 func (f *autoscalingFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *autoscalingFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
@@ -37,6 +37,8 @@ type clusterChecksFeature struct {
 
 	createKubernetesNetworkPolicy bool
 	createCiliumNetworkPolicy     bool
+	createCalicoNetworkPolicy     bool
+	createAdminNetworkPolicy      bool
 
 	customConfigAnnotationKey   string
 	customConfigAnnotationValue string
@@ -63,9 +65,14 @@ func (f *clusterChecksFeature) Configure(dda metav1.Object, ddaSpec *v2alpha1.Da
 		f.owner = dda
 
 		if enabled, flavor := constants.IsNetworkPolicyEnabled(ddaSpec); enabled {
-			if flavor == v2alpha1.NetworkPolicyFlavorCilium {
+			switch flavor {
+			case v2alpha1.NetworkPolicyFlavorCilium:
 				f.createCiliumNetworkPolicy = true
-			} else {
+			case v2alpha1.NetworkPolicyFlavorCalico:
+				f.createCalicoNetworkPolicy = true
+			case v2alpha1.NetworkPolicyFlavorAdminNetworkPolicy:
+				f.createAdminNetworkPolicy = true
+			default:
 				f.createKubernetesNetworkPolicy = true
 			}
 		}
@@ -142,6 +149,14 @@ func (f *clusterChecksFeature) ManageDependencies(managers feature.ResourceManag
 			},
 		}
 		return managers.CiliumPolicyManager().AddCiliumPolicy(policyName, f.owner.GetNamespace(), policySpecs)
+	} else if f.createCalicoNetworkPolicy {
+		return managers.CalicoPolicyManager().AddCalicoGlobalNetworkPolicy(
+			objects.BuildCalicoIngressPolicy(f.owner, v2alpha1.ClusterAgentComponentName, &ccrPodSelector, common.DefaultClusterAgentServicePort),
+		)
+	} else if f.createAdminNetworkPolicy {
+		return managers.AdminNetworkPolicyManager().AddAdminNetworkPolicy(
+			objects.BuildAdminNetworkPolicyIngress(f.owner, v2alpha1.ClusterAgentComponentName, "ingress-cluster-checks-runner", &ccrPodSelector, common.DefaultClusterAgentServicePort),
+		)
 	}
 
 	return nil
@@ -236,6 +251,10 @@ func (f *clusterChecksFeature) ManageClusterChecksRunner(managers feature.PodTem
 	return nil
 }
 
+func (f *clusterChecksFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
+
 func (f *clusterChecksFeature) updateConfigHash(dda metav1.Object, ddaSpec *v2alpha1.DatadogAgentSpec) {
 	hash, err := comparison.GenerateMD5ForSpec(ddaSpec.Features.ClusterChecks)
 	if err != nil {
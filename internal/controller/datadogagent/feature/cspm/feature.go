@@ -355,3 +355,9 @@ func (f *cspmFeature) ManageNodeAgent(managers feature.PodTemplateManagers, prov
 func (f *cspmFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *cspmFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
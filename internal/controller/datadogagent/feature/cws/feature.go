@@ -368,3 +368,9 @@ func (f *cwsFeature) ManageNodeAgent(managers feature.PodTemplateManagers, provi
 func (f *cwsFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *cwsFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
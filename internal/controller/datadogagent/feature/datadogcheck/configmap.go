@@ -0,0 +1,49 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogcheck
+
+import (
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+)
+
+// checkConfigFile is the format of a check configuration file in the conf.d directory.
+type checkConfigFile struct {
+	ADIdentifiers []string               `json:"ad_identifiers,omitempty"`
+	ClusterCheck  bool                   `json:"cluster_check,omitempty"`
+	InitConfig    *apiextensionsv1.JSON  `json:"init_config"`
+	Instances     []apiextensionsv1.JSON `json:"instances"`
+}
+
+// buildCheckConfigFile renders the configuration file of a check.
+func buildCheckConfigFile(spec *v1alpha1.DatadogCheckSpec) (string, error) {
+	config := checkConfigFile{
+		ADIdentifiers: spec.ADIdentifiers,
+		ClusterCheck:  apiutils.BoolValue(spec.ClusterCheck),
+		InitConfig:    spec.InitConfig,
+		Instances:     spec.Instances,
+	}
+	if config.InitConfig == nil || len(config.InitConfig.Raw) == 0 {
+		config.InitConfig = &apiextensionsv1.JSON{Raw: []byte("{}")}
+	}
+
+	content, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// getConfigMapKey returns the key of the check configuration file in the ConfigMap.
+// Namespaces cannot contain underscores, so keys are unique across namespaces.
+func getConfigMapKey(check *v1alpha1.DatadogCheck) string {
+	return fmt.Sprintf("%s_%s.yaml", check.Namespace, check.Name)
+}
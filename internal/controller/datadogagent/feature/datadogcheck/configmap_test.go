@@ -0,0 +1,76 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogcheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+)
+
+func Test_buildCheckConfigFile(t *testing.T) {
+	tests := []struct {
+		name string
+		spec *v1alpha1.DatadogCheckSpec
+		want string
+	}{
+		{
+			name: "node check without init config",
+			spec: &v1alpha1.DatadogCheckSpec{
+				Name:      "http_check",
+				Instances: []apiextensionsv1.JSON{{Raw: []byte(`{"name": "example", "url": "http://example.com"}`)}},
+			},
+			want: `init_config: {}
+instances:
+- name: example
+  url: http://example.com
+`,
+		},
+		{
+			name: "autodiscovery check",
+			spec: &v1alpha1.DatadogCheckSpec{
+				Name:          "redisdb",
+				InitConfig:    &apiextensionsv1.JSON{Raw: []byte(`{"service": "cache"}`)},
+				Instances:     []apiextensionsv1.JSON{{Raw: []byte(`{"port": 6379}`)}, {Raw: []byte(`{"port": 6380}`)}},
+				ADIdentifiers: []string{"redis"},
+			},
+			want: `ad_identifiers:
+- redis
+init_config:
+  service: cache
+instances:
+- port: 6379
+- port: 6380
+`,
+		},
+		{
+			name: "cluster check",
+			spec: &v1alpha1.DatadogCheckSpec{
+				Name:         "http_check",
+				Instances:    []apiextensionsv1.JSON{{Raw: []byte(`{"url": "http://example.com"}`)}},
+				ClusterCheck: apiutils.NewBoolPointer(true),
+			},
+			want: `cluster_check: true
+init_config: {}
+instances:
+- url: http://example.com
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildCheckConfigFile(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
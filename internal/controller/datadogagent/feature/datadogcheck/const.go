@@ -0,0 +1,11 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogcheck
+
+const (
+	nodeChecksConfigMapSuffix    = "datadog-checks"
+	clusterChecksConfigMapSuffix = "cluster-datadog-checks"
+)
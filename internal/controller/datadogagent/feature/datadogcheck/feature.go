@@ -0,0 +1,230 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogcheck
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
+)
+
+func init() {
+	err := feature.Register(feature.DatadogCheckIDType, buildDatadogCheckFeature)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func buildDatadogCheckFeature(options *feature.Options) feature.Feature {
+	datadogCheckFeat := &datadogCheckFeature{}
+
+	if options != nil {
+		datadogCheckFeat.logger = options.Logger
+		datadogCheckFeat.checks = options.DatadogChecks
+	}
+	return datadogCheckFeat
+}
+
+type datadogCheckFeature struct {
+	checks []v1alpha1.DatadogCheck
+
+	owner         metav1.Object
+	nodeConfig    *checksConfig
+	clusterConfig *checksConfig
+
+	logger logr.Logger
+}
+
+// checksConfig contains the check configuration files of an Agent component.
+type checksConfig struct {
+	configMapName string
+	// data contains the configuration files, indexed by ConfigMap key.
+	data map[string]string
+	// keys contains the ConfigMap keys of each check, indexed by check name.
+	keys            map[string][]string
+	annotationValue string
+}
+
+// ID returns the ID of the Feature
+func (f *datadogCheckFeature) ID() feature.IDType {
+	return feature.DatadogCheckIDType
+}
+
+// Configure is used to configure the feature from a v2alpha1.DatadogAgent instance.
+func (f *datadogCheckFeature) Configure(dda metav1.Object, ddaSpec *v2alpha1.DatadogAgentSpec, _ *v2alpha1.RemoteConfigConfiguration) (reqComp feature.RequiredComponents) {
+	f.owner = dda
+
+	var nodeChecks, clusterChecks []v1alpha1.DatadogCheck
+	for _, check := range f.checks {
+		if !apiutils.BoolValue(check.Spec.ClusterCheck) {
+			nodeChecks = append(nodeChecks, check)
+			continue
+		}
+		if !constants.IsClusterChecksEnabled(ddaSpec) {
+			f.logger.Info("Cluster checks are disabled, ignoring DatadogCheck", "namespace", check.Namespace, "name", check.Name)
+			continue
+		}
+		clusterChecks = append(clusterChecks, check)
+	}
+
+	if len(nodeChecks) > 0 {
+		f.nodeConfig = f.buildChecksConfig(fmt.Sprintf("%s-%s", dda.GetName(), nodeChecksConfigMapSuffix), nodeChecks)
+		reqComp.Agent.IsRequired = apiutils.NewBoolPointer(true)
+		reqComp.Agent.Containers = []apicommon.AgentContainerName{apicommon.CoreAgentContainerName}
+	}
+
+	if len(clusterChecks) > 0 {
+		f.clusterConfig = f.buildChecksConfig(fmt.Sprintf("%s-%s", dda.GetName(), clusterChecksConfigMapSuffix), clusterChecks)
+		reqComp.ClusterAgent.IsRequired = apiutils.NewBoolPointer(true)
+		reqComp.ClusterAgent.Containers = []apicommon.AgentContainerName{apicommon.ClusterAgentContainerName}
+	}
+
+	return reqComp
+}
+
+// buildChecksConfig renders the configuration files of the checks.
+func (f *datadogCheckFeature) buildChecksConfig(configMapName string, checks []v1alpha1.DatadogCheck) *checksConfig {
+	config := &checksConfig{
+		configMapName: configMapName,
+		data:          map[string]string{},
+		keys:          map[string][]string{},
+	}
+
+	for i := range checks {
+		check := &checks[i]
+		content, err := buildCheckConfigFile(&check.Spec)
+		if err != nil {
+			f.logger.Error(err, "couldn't generate the configuration of DatadogCheck", "namespace", check.Namespace, "name", check.Name)
+			continue
+		}
+		key := getConfigMapKey(check)
+		config.data[key] = content
+		config.keys[check.Spec.Name] = append(config.keys[check.Spec.Name], key)
+	}
+
+	hash, err := comparison.GenerateMD5ForSpec(config.data)
+	if err != nil {
+		f.logger.Error(err, "couldn't generate hash for DatadogCheck config")
+	}
+	config.annotationValue = hash
+
+	return config
+}
+
+// ManageDependencies allows a feature to manage its dependencies.
+// Feature's dependencies should be added in the store.
+func (f *datadogCheckFeature) ManageDependencies(managers feature.ResourceManagers) error {
+	for _, config := range []*checksConfig{f.nodeConfig, f.clusterConfig} {
+		if config == nil {
+			continue
+		}
+		if err := managers.ConfigMapManager().AddConfigMap(config.configMapName, f.owner.GetNamespace(), config.data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ManageClusterAgent allows a feature to configure the ClusterAgent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *datadogCheckFeature) ManageClusterAgent(managers feature.PodTemplateManagers) error {
+	if f.clusterConfig != nil {
+		f.clusterConfig.mount(managers)
+	}
+
+	return nil
+}
+
+// ManageSingleContainerNodeAgent allows a feature to configure the Agent container for the Node Agent's corev1.PodTemplateSpec
+// if SingleContainerStrategy is enabled and can be used with the configured feature set.
+// It should do nothing if the feature doesn't need to configure it.
+func (f *datadogCheckFeature) ManageSingleContainerNodeAgent(managers feature.PodTemplateManagers, provider string) error {
+	if f.nodeConfig != nil {
+		f.nodeConfig.mount(managers)
+	}
+
+	return nil
+}
+
+// ManageNodeAgent allows a feature to configure the Node Agent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *datadogCheckFeature) ManageNodeAgent(managers feature.PodTemplateManagers, provider string) error {
+	if f.nodeConfig != nil {
+		f.nodeConfig.mount(managers)
+	}
+
+	return nil
+}
+
+// ManageClusterChecksRunner allows a feature to configure the ClusterChecksRunnerAgent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *datadogCheckFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
+	return nil
+}
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *datadogCheckFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
+
+// mount adds the configuration files of the checks to the `confd` volume, in the `<check>.d` folder of each check.
+// The files of this volume are copied in the conf.d directory of the container, by the init-config container for the
+// node Agent and by the entrypoint for the Cluster Agent, so the default configuration files of the integrations are kept.
+func (c *checksConfig) mount(managers feature.PodTemplateManagers) {
+	checkNames := make([]string, 0, len(c.keys))
+	for checkName := range c.keys {
+		checkNames = append(checkNames, checkName)
+	}
+	// Sort the check names so that the items order is consistent between reconcile loops
+	sort.Strings(checkNames)
+
+	var items []corev1.KeyToPath
+	for _, checkName := range checkNames {
+		for _, key := range c.keys[checkName] {
+			items = append(items, corev1.KeyToPath{Key: key, Path: fmt.Sprintf("%s.d/%s", checkName, key)})
+		}
+	}
+
+	// The `confd` volume is replaced, or merged with the extraConfd ConfigMap by the override.
+	vol := corev1.Volume{
+		Name: common.ConfdVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{
+						ConfigMap: &corev1.ConfigMapProjection{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: c.configMapName,
+							},
+							Items: items,
+						},
+					},
+				},
+			},
+		},
+	}
+	managers.Volume().AddVolume(&vol)
+
+	// Add md5 hash annotation for configMap
+	if c.annotationValue != "" {
+		managers.Annotation().AddAnnotation(object.GetChecksumAnnotationKey(feature.DatadogCheckIDType), c.annotationValue)
+	}
+}
@@ -0,0 +1,174 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogcheck
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/fake"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/test"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	"github.com/DataDog/datadog-operator/pkg/testutils"
+)
+
+const resourcesName = "foo"
+const resourcesNamespace = "bar"
+
+func newDatadogCheck(namespace, name, checkName string, clusterCheck bool) v1alpha1.DatadogCheck {
+	return v1alpha1.DatadogCheck{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: v1alpha1.DatadogCheckSpec{
+			Name:         checkName,
+			Instances:    []apiextensionsv1.JSON{{Raw: []byte(`{"url": "http://example.com"}`)}},
+			ClusterCheck: apiutils.NewBoolPointer(clusterCheck),
+		},
+	}
+}
+
+func Test_datadogCheckFeature_Configure(t *testing.T) {
+	nodeChecks := []v1alpha1.DatadogCheck{
+		newDatadogCheck("app1", "web", "http_check", false),
+		newDatadogCheck("app2", "web", "http_check", false),
+		newDatadogCheck("app1", "cache", "redisdb", false),
+	}
+	clusterChecks := []v1alpha1.DatadogCheck{
+		newDatadogCheck("app1", "website", "http_check", true),
+	}
+
+	tests := test.FeatureTestSuite{
+		{
+			Name:          "no DatadogCheck",
+			DDA:           testutils.NewInitializedDatadogAgentBuilder(resourcesNamespace, resourcesName).Build(),
+			WantConfigure: false,
+		},
+		{
+			Name: "node checks",
+			DDA:  testutils.NewInitializedDatadogAgentBuilder(resourcesNamespace, resourcesName).Build(),
+			FeatureOptions: &feature.Options{
+				DatadogChecks: nodeChecks,
+			},
+			WantConfigure:        true,
+			WantDependenciesFunc: datadogCheckWantDepsFunc("foo-datadog-checks", nodeChecks),
+			Agent: datadogCheckWantResourcesFunc("foo-datadog-checks", nodeChecks, map[string][]string{
+				"http_check": {"app1_web.yaml", "app2_web.yaml"},
+				"redisdb":    {"app1_cache.yaml"},
+			}),
+		},
+		{
+			Name: "cluster check with cluster checks disabled",
+			DDA: testutils.NewInitializedDatadogAgentBuilder(resourcesNamespace, resourcesName).
+				WithClusterChecksEnabled(false).
+				Build(),
+			FeatureOptions: &feature.Options{
+				DatadogChecks: clusterChecks,
+			},
+			WantConfigure: false,
+		},
+		{
+			Name: "cluster check",
+			DDA: testutils.NewInitializedDatadogAgentBuilder(resourcesNamespace, resourcesName).
+				WithClusterChecksEnabled(true).
+				Build(),
+			FeatureOptions: &feature.Options{
+				DatadogChecks: clusterChecks,
+			},
+			WantConfigure:        true,
+			WantDependenciesFunc: datadogCheckWantDepsFunc("foo-cluster-datadog-checks", clusterChecks),
+			ClusterAgent: datadogCheckWantResourcesFunc("foo-cluster-datadog-checks", clusterChecks, map[string][]string{
+				"http_check": {"app1_website.yaml"},
+			}),
+		},
+	}
+
+	tests.Run(t, buildDatadogCheckFeature)
+}
+
+func wantConfigData(t testing.TB, checks []v1alpha1.DatadogCheck) map[string]string {
+	data := map[string]string{}
+	for i := range checks {
+		content, err := buildCheckConfigFile(&checks[i].Spec)
+		assert.NoError(t, err)
+		data[getConfigMapKey(&checks[i])] = content
+	}
+	return data
+}
+
+func datadogCheckWantDepsFunc(configMapName string, checks []v1alpha1.DatadogCheck) func(t testing.TB, store store.StoreClient) {
+	return func(t testing.TB, store store.StoreClient) {
+		obj, found := store.Get(kubernetes.ConfigMapKind, resourcesNamespace, configMapName)
+		if !found {
+			t.Error("Should have created a ConfigMap")
+			return
+		}
+
+		cm := obj.(*corev1.ConfigMap)
+		wantData := wantConfigData(t, checks)
+		assert.True(t, apiutils.IsEqualStruct(cm.Data, wantData), "ConfigMap data \ndiff = %s", cmp.Diff(cm.Data, wantData))
+	}
+}
+
+func datadogCheckWantResourcesFunc(configMapName string, checks []v1alpha1.DatadogCheck, keysByCheck map[string][]string) *test.ComponentTest {
+	return test.NewDefaultComponentTest().WithWantFunc(
+		func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+			mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+			var items []corev1.KeyToPath
+			for _, checkName := range []string{"http_check", "redisdb"} {
+				for _, key := range keysByCheck[checkName] {
+					items = append(items, corev1.KeyToPath{Key: key, Path: fmt.Sprintf("%s.d/%s", checkName, key)})
+				}
+			}
+			expectedVols := []*corev1.Volume{
+				{
+					Name: "confd",
+					VolumeSource: corev1.VolumeSource{
+						Projected: &corev1.ProjectedVolumeSource{
+							Sources: []corev1.VolumeProjection{
+								{
+									ConfigMap: &corev1.ConfigMapProjection{
+										LocalObjectReference: corev1.LocalObjectReference{
+											Name: configMapName,
+										},
+										Items: items,
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			vols := mgr.VolumeMgr.Volumes
+			assert.True(t, apiutils.IsEqualStruct(vols, expectedVols), "Volumes \ndiff = %s", cmp.Diff(vols, expectedVols))
+
+			// The checks are copied from the confd volume, they must not be mounted over the conf.d directory
+			assert.Empty(t, mgr.VolumeMountMgr.VolumeMountsByC)
+
+			hash, err := comparison.GenerateMD5ForSpec(wantConfigData(t, checks))
+			assert.NoError(t, err)
+			wantAnnotations := map[string]string{
+				fmt.Sprintf(constants.MD5ChecksumAnnotationKey, feature.DatadogCheckIDType): hash,
+			}
+			annotations := mgr.AnnotationMgr.Annotations
+			assert.True(t, apiutils.IsEqualStruct(annotations, wantAnnotations), "Annotations \ndiff = %s", cmp.Diff(annotations, wantAnnotations))
+		})
+}
@@ -108,6 +108,26 @@ func (f *dogstatsdFeature) Configure(dda metav1.Object, ddaSpec *v2alpha1.Datado
 	return reqComp
 }
 
+// HostPorts returns the host ports used by the feature.
+func (f *dogstatsdFeature) HostPorts() map[string]int32 {
+	if !f.hostPortEnabled {
+		return nil
+	}
+	return map[string]int32{dogstatsdHostPortName: f.hostPortHostPort}
+}
+
+// HostAccess returns the host accesses of the feature.
+func (f *dogstatsdFeature) HostAccess() []string {
+	if !f.udsEnabled {
+		return nil
+	}
+	access := []string{feature.HostPathAccess(filepath.Dir(f.udsHostFilepath))}
+	if f.originDetectionEnabled {
+		access = append(access, feature.HostPIDAccess)
+	}
+	return access
+}
+
 // ManageDependencies allows a feature to manage its dependencies.
 // Feature's dependencies should be added in the store.
 func (f *dogstatsdFeature) ManageDependencies(managers feature.ResourceManagers) error {
@@ -285,3 +305,9 @@ func (f *dogstatsdFeature) manageNodeAgent(agentContainerName apicommon.AgentCon
 func (f *dogstatsdFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *dogstatsdFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
@@ -74,3 +74,9 @@ func (f *dummyFeature) ManageNodeAgent(managers feature.PodTemplateManagers, pro
 func (f *dummyFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *dummyFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
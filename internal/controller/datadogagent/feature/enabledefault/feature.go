@@ -113,3 +113,9 @@ func (f *defaultFeature) ManageNodeAgent(managers feature.PodTemplateManagers, p
 func (f *defaultFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *defaultFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
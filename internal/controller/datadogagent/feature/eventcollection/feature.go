@@ -205,3 +205,9 @@ func (f *eventCollectionFeature) ManageNodeAgent(_ feature.PodTemplateManagers,
 func (f *eventCollectionFeature) ManageClusterChecksRunner(_ feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *eventCollectionFeature) ManageOtelAgentGateway(_ feature.PodTemplateManagers) error {
+	return nil
+}
@@ -58,6 +58,7 @@ type externalMetricsFeature struct {
 
 	createKubernetesNetworkPolicy bool
 	createCiliumNetworkPolicy     bool
+	createCalicoNetworkPolicy     bool
 	registerAPIService            bool
 }
 
@@ -127,9 +128,14 @@ func (f *externalMetricsFeature) Configure(dda metav1.Object, ddaSpec *v2alpha1.
 		f.serviceAccountName = constants.GetClusterAgentServiceAccount(dda.GetName(), ddaSpec)
 
 		if enabled, flavor := constants.IsNetworkPolicyEnabled(ddaSpec); enabled {
-			if flavor == v2alpha1.NetworkPolicyFlavorCilium {
+			switch flavor {
+			case v2alpha1.NetworkPolicyFlavorCilium:
 				f.createCiliumNetworkPolicy = true
-			} else {
+			case v2alpha1.NetworkPolicyFlavorCalico:
+				f.createCalicoNetworkPolicy = true
+			case v2alpha1.NetworkPolicyFlavorAdminNetworkPolicy:
+				// Admin network policies can only select pods as ingress peers, not the API server
+			default:
 				f.createKubernetesNetworkPolicy = true
 			}
 		}
@@ -258,6 +264,10 @@ func (f *externalMetricsFeature) ManageDependencies(managers feature.ResourceMan
 			},
 		}
 		return managers.CiliumPolicyManager().AddCiliumPolicy(policyName, f.owner.GetNamespace(), policySpecs)
+	} else if f.createCalicoNetworkPolicy {
+		return managers.CalicoPolicyManager().AddCalicoGlobalNetworkPolicy(
+			objects.BuildCalicoIngressPolicy(f.owner, v2alpha1.ClusterAgentComponentName, nil, f.port),
+		)
 	}
 
 	return nil
@@ -356,3 +366,9 @@ func (f *externalMetricsFeature) ManageNodeAgent(managers feature.PodTemplateMan
 func (f *externalMetricsFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *externalMetricsFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
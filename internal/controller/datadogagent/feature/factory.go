@@ -8,12 +8,14 @@ package feature
 import (
 	"fmt"
 	"slices"
+	"strings"
 	"sync"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/pkg/constants"
 )
 
 func init() {
@@ -32,14 +34,17 @@ func Register(id IDType, buildFunc BuildFunc) error {
 	return nil
 }
 
-// BuildFeatures use to build a list features depending of the v2alpha1.DatadogAgent instance
-func BuildFeatures(dda metav1.Object, ddaSpec *v2alpha1.DatadogAgentSpec, ddaRCStatus *v2alpha1.RemoteConfigConfiguration, options *Options) ([]Feature, []Feature, RequiredComponents) {
+// BuildFeatures use to build a list features depending of the v2alpha1.DatadogAgent instance.
+// It also returns the features blocked by the security profile, with the reason they are blocked.
+func BuildFeatures(dda metav1.Object, ddaSpec *v2alpha1.DatadogAgentSpec, ddaRCStatus *v2alpha1.RemoteConfigConfiguration, options *Options) ([]Feature, []Feature, RequiredComponents, map[IDType]string) {
 	builderMutex.RLock()
 	defer builderMutex.RUnlock()
 
 	var configuredFeatures []Feature
 	var enabledFeatures []Feature
 	var requiredComponents RequiredComponents
+	var blockedFeatures map[IDType]string
+	restricted := constants.IsRestrictedSecurityProfile(ddaSpec)
 
 	// to always return in feature in the same order we need to sort the map keys
 	sortedkeys := make([]IDType, 0, len(featureBuilders))
@@ -52,6 +57,18 @@ func BuildFeatures(dda metav1.Object, ddaSpec *v2alpha1.DatadogAgentSpec, ddaRCS
 		feat := featureBuilders[id](options)
 		featureID := feat.ID()
 		reqComponents := feat.Configure(dda, ddaSpec, ddaRCStatus)
+		if restricted && reqComponents.IsEnabled() {
+			if violations := restrictedProfileViolations(feat, &reqComponents); len(violations) > 0 {
+				// blocked features don't require any component
+				if blockedFeatures == nil {
+					blockedFeatures = map[IDType]string{}
+				}
+				blockedFeatures[featureID] = fmt.Sprintf("the %s security profile does not allow: %s",
+					v2alpha1.RestrictedSecurityProfile, strings.Join(violations, ", "))
+				options.Logger.V(1).Info("Feature blocked by the security profile", "featureID", featureID)
+				continue
+			}
+		}
 		if reqComponents.IsEnabled() {
 			// enabled features
 			enabledFeatures = append(enabledFeatures, feat)
@@ -73,9 +90,9 @@ func BuildFeatures(dda metav1.Object, ddaSpec *v2alpha1.DatadogAgentSpec, ddaRCS
 		!requiredComponents.Agent.IsPrivileged() {
 
 		requiredComponents.Agent.Containers = []common.AgentContainerName{common.UnprivilegedSingleAgentContainerName}
-		return configuredFeatures, enabledFeatures, requiredComponents
+		return configuredFeatures, enabledFeatures, requiredComponents, blockedFeatures
 	}
-	return configuredFeatures, enabledFeatures, requiredComponents
+	return configuredFeatures, enabledFeatures, requiredComponents, blockedFeatures
 }
 
 var (
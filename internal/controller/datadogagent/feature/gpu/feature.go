@@ -1,10 +1,16 @@
 package gpu
 
 import (
+	"context"
+	"fmt"
 	"path"
 
 	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
@@ -69,6 +75,18 @@ func (f *gpuFeature) ManageDependencies(managers feature.ResourceManagers) error
 	return nil
 }
 
+// Degraded reports the feature as degraded if the runtime class set on the Agent pods does not exist:
+// the Agent pods cannot be scheduled without it.
+func (f *gpuFeature) Degraded(ctx context.Context, k8sClient client.Reader) (string, string) {
+	if f.podRuntimeClassName == "" {
+		return "", ""
+	}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: f.podRuntimeClassName}, &nodev1.RuntimeClass{}); apierrors.IsNotFound(err) {
+		return feature.MissingRuntimeClassReason, fmt.Sprintf("the GPU runtime class %s does not exist", f.podRuntimeClassName)
+	}
+	return "", ""
+}
+
 // ManageClusterAgent allows a feature to configure the ClusterAgent's corev1.PodTemplateSpec
 // It should do nothing if the feature doesn't need to configure it.
 func (f *gpuFeature) ManageClusterAgent(feature.PodTemplateManagers) error {
@@ -205,3 +223,9 @@ func (f *gpuFeature) ManageSingleContainerNodeAgent(feature.PodTemplateManagers,
 func (f *gpuFeature) ManageClusterChecksRunner(feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *gpuFeature) ManageOtelAgentGateway(feature.PodTemplateManagers) error {
+	return nil
+}
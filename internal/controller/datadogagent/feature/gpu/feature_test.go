@@ -1,12 +1,17 @@
 package gpu
 
 import (
+	"context"
 	"path"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
@@ -229,3 +234,53 @@ func Test_GPUMonitoringFeature_Configure(t *testing.T) {
 
 	tests.Run(t, buildFeature)
 }
+
+func Test_GPUMonitoringFeature_Degraded(t *testing.T) {
+	tests := []struct {
+		name             string
+		runtimeClassName *string
+		existing         []client.Object
+		wantReason       string
+	}{
+		{
+			name:       "default runtime class missing",
+			wantReason: feature.MissingRuntimeClassReason,
+		},
+		{
+			name:     "default runtime class exists",
+			existing: []client.Object{&nodev1.RuntimeClass{ObjectMeta: metav1.ObjectMeta{Name: defaultGPURuntimeClass}}},
+		},
+		{
+			name:             "alternative runtime class missing",
+			runtimeClassName: apiutils.NewStringPointer(alternativeRuntimeClass),
+			existing:         []client.Object{&nodev1.RuntimeClass{ObjectMeta: metav1.ObjectMeta{Name: defaultGPURuntimeClass}}},
+			wantReason:       feature.MissingRuntimeClassReason,
+		},
+		{
+			name:             "no runtime class",
+			runtimeClassName: apiutils.NewStringPointer(""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dda := &v2alpha1.DatadogAgent{
+				Spec: v2alpha1.DatadogAgentSpec{
+					Features: &v2alpha1.DatadogFeatures{
+						GPU: &v2alpha1.GPUFeatureConfig{
+							Enabled:             apiutils.NewBoolPointer(true),
+							PodRuntimeClassName: tt.runtimeClassName,
+						},
+					},
+					Global: &v2alpha1.GlobalConfig{Kubelet: &v2alpha1.KubeletConfig{}},
+				},
+			}
+			f := buildFeature(nil).(*gpuFeature)
+			f.Configure(dda, &dda.Spec, nil)
+
+			c := fakeclient.NewClientBuilder().WithObjects(tt.existing...).Build()
+			reason, _ := f.Degraded(context.TODO(), c)
+			assert.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
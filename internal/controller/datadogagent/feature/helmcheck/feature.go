@@ -173,3 +173,9 @@ func (f *helmCheckFeature) ManageNodeAgent(managers feature.PodTemplateManagers,
 func (f *helmCheckFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *helmCheckFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
@@ -27,6 +27,8 @@ const (
 	LiveProcessIDType = "live_process"
 	// OtelAgentIDType Otel Agent feature.
 	OtelAgentIDType = "otel_agent"
+	// OtelAgentGatewayIDType Otel Agent Gateway feature.
+	OtelAgentGatewayIDType = "otel_agent_gateway"
 	// ProcessDiscoveryIDType Process Discovery feature.
 	ProcessDiscoveryIDType = "process_discovery"
 	// KubernetesAPIServerIDType Kube APIServer feature.
@@ -73,4 +75,6 @@ const (
 	ServiceDiscoveryType = "service_discovery"
 	// GPUIDType GPU monitoring feature.
 	GPUIDType = "gpu"
+	// DatadogCheckIDType DatadogCheck integration checks feature.
+	DatadogCheckIDType = "datadog_check"
 )
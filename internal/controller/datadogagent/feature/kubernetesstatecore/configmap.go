@@ -7,11 +7,15 @@ package kubernetesstatecore
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
 
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object/configmap"
 )
 
@@ -23,7 +27,7 @@ func (f *ksmFeature) buildKSMCoreConfigMap(collectorOpts collectorOptions) (*cor
 		return configmap.BuildConfigMapConfigData(f.owner.GetNamespace(), f.customConfig.ConfigData, f.configConfigMapName, ksmCoreCheckName)
 	}
 
-	configMap := buildDefaultConfigMap(f.owner.GetNamespace(), f.configConfigMapName, ksmCheckConfig(f.runInClusterChecksRunner, collectorOpts))
+	configMap := buildDefaultConfigMap(f.owner.GetNamespace(), f.configConfigMapName, ksmCheckConfig(f.runInClusterChecksRunner, collectorOpts, f.instanceOpts))
 	return configMap, nil
 }
 
@@ -46,50 +50,221 @@ func buildDefaultConfigMap(namespace, cmName string, content string) *corev1.Con
 // cluster checks are enabled but without Cluster Check Runners, we don't want
 // to set this check as a cluster check, because then it would be scheduled in
 // the DaemonSet agent instead of the DCA.
-func ksmCheckConfig(clusterCheck bool, collectorOpts collectorOptions) string {
+func ksmCheckConfig(clusterCheck bool, collectorOpts collectorOptions, instanceOpts instanceOptions) string {
 	stringVal := strconv.FormatBool(clusterCheck)
 	config := fmt.Sprintf(`---
 cluster_check: %s
 init_config:
 instances:
-  - skip_leader_election: %s
-    collectors:
-    - pods
-    - replicationcontrollers
-    - statefulsets
-    - nodes
-    - cronjobs
-    - jobs
-    - replicasets
-    - deployments
-    - configmaps
-    - services
-    - endpoints
-    - daemonsets
-    - horizontalpodautoscalers
-    - poddisruptionbudgets
-    - limitranges
-    - resourcequotas
-    - secrets
-    - namespaces
-    - persistentvolumeclaims
-    - persistentvolumes
-    - ingresses
-    - storageclasses
-    - volumeattachments
-`, stringVal, stringVal)
-
-	if collectorOpts.enableVPA {
-		config += "    - verticalpodautoscalers\n"
-	}
-
-	if collectorOpts.enableAPIService {
-		config += "    - apiservices\n"
-	}
-
-	if collectorOpts.enableCRD {
-		config += "    - customresourcedefinitions\n"
+`, stringVal)
+
+	for i, shard := range getShards(getCollectors(collectorOpts), instanceOpts) {
+		config += fmt.Sprintf("  - skip_leader_election: %s\n", stringVal)
+		config += "    collectors:\n"
+		for _, collector := range shard.collectors {
+			config += fmt.Sprintf("    - %s\n", collector)
+		}
+		// The custom resources are only collected by the first instance, to avoid duplicated metrics
+		var customResources []v2alpha1.KubeStateMetricsCoreCustomResource
+		if i == 0 {
+			customResources = collectorOpts.customResources
+		}
+		config += instanceConfig(shard.namespaces, instanceOpts, customResources)
 	}
 
 	return config
 }
+
+// getCollectors returns the collectors of the check: the allowed collectors, or the default ones,
+// without the denied collectors.
+func getCollectors(collectorOpts collectorOptions) []string {
+	collectors := collectorOpts.allowed
+	if len(collectors) == 0 {
+		collectors = append([]string{}, defaultCollectors...)
+		if collectorOpts.enableVPA {
+			collectors = append(collectors, "verticalpodautoscalers")
+		}
+		if collectorOpts.enableAPIService {
+			collectors = append(collectors, "apiservices")
+		}
+		if collectorOpts.enableCRD {
+			collectors = append(collectors, "customresourcedefinitions")
+		}
+	}
+
+	if len(collectorOpts.denied) == 0 {
+		return collectors
+	}
+	filtered := make([]string, 0, len(collectors))
+	for _, collector := range collectors {
+		if !slices.Contains(collectorOpts.denied, collector) {
+			filtered = append(filtered, collector)
+		}
+	}
+	return filtered
+}
+
+// ksmShard contains the collectors and the namespaces of a check instance
+type ksmShard struct {
+	collectors []string
+	namespaces []string
+}
+
+// getShards splits the check into instances, by collectors or by namespaces.
+func getShards(collectors []string, instanceOpts instanceOptions) []ksmShard {
+	shards := []ksmShard{}
+	if instanceOpts.shardByNamespaces {
+		// The cluster-scoped resources are only collected by the first instance, to avoid duplicated metrics
+		namespacedCollectors := make([]string, 0, len(collectors))
+		for _, collector := range collectors {
+			if !slices.Contains(clusterScopedCollectors, collector) {
+				namespacedCollectors = append(namespacedCollectors, collector)
+			}
+		}
+		if len(namespacedCollectors) == 0 {
+			return []ksmShard{{collectors: collectors, namespaces: instanceOpts.namespaces}}
+		}
+
+		for i, namespaces := range splitList(instanceOpts.namespaces, instanceOpts.shards) {
+			shard := ksmShard{collectors: namespacedCollectors, namespaces: namespaces}
+			if i == 0 {
+				shard.collectors = collectors
+			}
+			shards = append(shards, shard)
+		}
+		return shards
+	}
+
+	for _, shardCollectors := range splitList(collectors, instanceOpts.shards) {
+		shards = append(shards, ksmShard{collectors: shardCollectors, namespaces: instanceOpts.namespaces})
+	}
+	return shards
+}
+
+// splitList splits a list into the given number of parts of similar size.
+func splitList(list []string, parts int) [][]string {
+	if parts > len(list) {
+		parts = len(list)
+	}
+	if parts <= 1 {
+		return [][]string{list}
+	}
+
+	split := make([][]string, 0, parts)
+	for i := 0; i < parts; i++ {
+		split = append(split, list[i*len(list)/parts:(i+1)*len(list)/parts])
+	}
+	return split
+}
+
+// ksmInstance contains the options of a check instance set from the structured configuration
+type ksmInstance struct {
+	Namespaces        []string                     `json:"namespaces,omitempty"`
+	LabelsAsTags      map[string]map[string]string `json:"labels_as_tags,omitempty"`
+	AnnotationsAsTags map[string]map[string]string `json:"annotations_as_tags,omitempty"`
+	CustomResource    *customResourceConfig        `json:"custom_resource,omitempty"`
+}
+
+// customResourceConfig follows the kube-state-metrics custom resource state configuration
+type customResourceConfig struct {
+	Spec customResourceSpec `json:"spec"`
+}
+
+type customResourceSpec struct {
+	Resources []customResource `json:"resources"`
+}
+
+type customResource struct {
+	GroupVersionKind groupVersionKind    `json:"groupVersionKind"`
+	ResourcePlural   string              `json:"resourcePlural,omitempty"`
+	MetricNamePrefix *string             `json:"metricNamePrefix,omitempty"`
+	LabelsFromPath   map[string][]string `json:"labelsFromPath,omitempty"`
+	Metrics          []customMetric      `json:"metrics"`
+}
+
+type groupVersionKind struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+type customMetric struct {
+	Name string           `json:"name"`
+	Help string           `json:"help,omitempty"`
+	Each customMetricEach `json:"each"`
+}
+
+type customMetricEach struct {
+	Type  v2alpha1.KubeStateMetricsCoreMetricType `json:"type"`
+	Gauge *customMetricValue                      `json:"gauge,omitempty"`
+	Info  *customMetricValue                      `json:"info,omitempty"`
+}
+
+type customMetricValue struct {
+	Path           []string            `json:"path"`
+	ValueFrom      []string            `json:"valueFrom,omitempty"`
+	LabelsFromPath map[string][]string `json:"labelsFromPath,omitempty"`
+}
+
+// instanceConfig returns the options of a check instance, indented to be appended to the instance.
+func instanceConfig(namespaces []string, instanceOpts instanceOptions, customResources []v2alpha1.KubeStateMetricsCoreCustomResource) string {
+	instance := ksmInstance{
+		Namespaces:        namespaces,
+		LabelsAsTags:      instanceOpts.labelsAsTags,
+		AnnotationsAsTags: instanceOpts.annotationsAsTags,
+	}
+	if len(customResources) > 0 {
+		instance.CustomResource = &customResourceConfig{}
+		for _, cr := range customResources {
+			instance.CustomResource.Spec.Resources = append(instance.CustomResource.Spec.Resources, buildCustomResource(cr))
+		}
+	}
+	if len(instance.Namespaces) == 0 && len(instance.LabelsAsTags) == 0 && len(instance.AnnotationsAsTags) == 0 && instance.CustomResource == nil {
+		return ""
+	}
+
+	// Marshalling these types cannot fail
+	content, _ := yaml.Marshal(instance)
+	var config string
+	for _, line := range strings.Split(strings.TrimSuffix(string(content), "\n"), "\n") {
+		config += fmt.Sprintf("    %s\n", line)
+	}
+	return config
+}
+
+func buildCustomResource(cr v2alpha1.KubeStateMetricsCoreCustomResource) customResource {
+	resource := customResource{
+		GroupVersionKind: groupVersionKind{
+			Group:   cr.Group,
+			Version: cr.Version,
+			Kind:    cr.Kind,
+		},
+		MetricNamePrefix: cr.MetricNamePrefix,
+		LabelsFromPath:   cr.LabelsFromPath,
+	}
+	if cr.Resource != nil {
+		resource.ResourcePlural = *cr.Resource
+	}
+	for _, metric := range cr.Metrics {
+		value := &customMetricValue{
+			Path:           metric.Path,
+			LabelsFromPath: metric.LabelsFromPath,
+		}
+		each := customMetricEach{Type: v2alpha1.KubeStateMetricsCoreMetricTypeGauge}
+		if metric.Type != nil {
+			each.Type = *metric.Type
+		}
+		if each.Type == v2alpha1.KubeStateMetricsCoreMetricTypeInfo {
+			each.Info = value
+		} else {
+			value.ValueFrom = metric.ValueFrom
+			each.Gauge = value
+		}
+		resource.Metrics = append(resource.Metrics, customMetric{
+			Name: metric.Name,
+			Help: metric.Help,
+			Each: each,
+		})
+	}
+	return resource
+}
@@ -10,9 +10,13 @@ import (
 	"testing"
 
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
 )
 
 func Test_ksmFeature_buildKSMCoreConfigMap(t *testing.T) {
@@ -55,7 +59,7 @@ instances:
 				runInClusterChecksRunner: true,
 				configConfigMapName:      defaultKubeStateMetricsCoreConf,
 			},
-			want: buildDefaultConfigMap(owner.GetNamespace(), defaultKubeStateMetricsCoreConf, ksmCheckConfig(true, defaultOptions)),
+			want: buildDefaultConfigMap(owner.GetNamespace(), defaultKubeStateMetricsCoreConf, ksmCheckConfig(true, defaultOptions, instanceOptions{})),
 		},
 		{
 			name: "override",
@@ -78,7 +82,7 @@ instances:
 				runInClusterChecksRunner: false,
 				configConfigMapName:      defaultKubeStateMetricsCoreConf,
 			},
-			want: buildDefaultConfigMap(owner.GetNamespace(), defaultKubeStateMetricsCoreConf, ksmCheckConfig(false, defaultOptions)),
+			want: buildDefaultConfigMap(owner.GetNamespace(), defaultKubeStateMetricsCoreConf, ksmCheckConfig(false, defaultOptions, instanceOptions{})),
 		},
 		{
 			name: "with vpa",
@@ -89,7 +93,7 @@ instances:
 				configConfigMapName:      defaultKubeStateMetricsCoreConf,
 				collectorOpts:            optionsWithVPA,
 			},
-			want: buildDefaultConfigMap(owner.GetNamespace(), defaultKubeStateMetricsCoreConf, ksmCheckConfig(true, optionsWithVPA)),
+			want: buildDefaultConfigMap(owner.GetNamespace(), defaultKubeStateMetricsCoreConf, ksmCheckConfig(true, optionsWithVPA, instanceOptions{})),
 		},
 		{
 			name: "with CRDs",
@@ -100,7 +104,7 @@ instances:
 				configConfigMapName:      defaultKubeStateMetricsCoreConf,
 				collectorOpts:            optionsWithCRD,
 			},
-			want: buildDefaultConfigMap(owner.GetNamespace(), defaultKubeStateMetricsCoreConf, ksmCheckConfig(true, optionsWithCRD)),
+			want: buildDefaultConfigMap(owner.GetNamespace(), defaultKubeStateMetricsCoreConf, ksmCheckConfig(true, optionsWithCRD, instanceOptions{})),
 		},
 		{
 			name: "with APIServices",
@@ -111,7 +115,7 @@ instances:
 				configConfigMapName:      defaultKubeStateMetricsCoreConf,
 				collectorOpts:            optionsWithAPIService,
 			},
-			want: buildDefaultConfigMap(owner.GetNamespace(), defaultKubeStateMetricsCoreConf, ksmCheckConfig(true, optionsWithAPIService)),
+			want: buildDefaultConfigMap(owner.GetNamespace(), defaultKubeStateMetricsCoreConf, ksmCheckConfig(true, optionsWithAPIService, instanceOptions{})),
 		},
 	}
 	for _, tt := range tests {
@@ -135,3 +139,148 @@ instances:
 		})
 	}
 }
+
+func Test_ksmCheckConfig_structured(t *testing.T) {
+	infoType := v2alpha1.KubeStateMetricsCoreMetricTypeInfo
+	collectorOpts := collectorOptions{
+		allowed: []string{"pods", "deployments", "nodes"},
+		denied:  []string{"nodes"},
+		customResources: []v2alpha1.KubeStateMetricsCoreCustomResource{
+			{
+				Group:            "example.com",
+				Version:          "v1",
+				Kind:             "Foo",
+				Resource:         apiutils.NewStringPointer("foos"),
+				MetricNamePrefix: apiutils.NewStringPointer("example_foo"),
+				Metrics: []v2alpha1.KubeStateMetricsCoreCustomResourceMetric{
+					{
+						Name:      "replicas",
+						Path:      []string{"status", "replicas"},
+						ValueFrom: []string{"ready"},
+					},
+					{
+						Name:           "info",
+						Type:           &infoType,
+						Path:           []string{"metadata"},
+						LabelsFromPath: map[string][]string{"name": {"name"}},
+					},
+				},
+			},
+		},
+	}
+	instanceOpts := instanceOptions{
+		namespaces:        []string{"default"},
+		labelsAsTags:      map[string]map[string]string{"pod": {"app": "app"}},
+		annotationsAsTags: map[string]map[string]string{"deployment": {"team": "team"}},
+		shards:            2,
+	}
+
+	var config struct {
+		ClusterCheck bool `json:"cluster_check"`
+		Instances    []struct {
+			SkipLeaderElection bool                         `json:"skip_leader_election"`
+			Collectors         []string                     `json:"collectors"`
+			Namespaces         []string                     `json:"namespaces"`
+			LabelsAsTags       map[string]map[string]string `json:"labels_as_tags"`
+			AnnotationsAsTags  map[string]map[string]string `json:"annotations_as_tags"`
+			CustomResource     *customResourceConfig        `json:"custom_resource"`
+		} `json:"instances"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(ksmCheckConfig(true, collectorOpts, instanceOpts)), &config))
+
+	assert.True(t, config.ClusterCheck)
+	require.Len(t, config.Instances, 2)
+	assert.Equal(t, []string{"pods"}, config.Instances[0].Collectors)
+	assert.Equal(t, []string{"deployments"}, config.Instances[1].Collectors)
+	for _, instance := range config.Instances {
+		assert.True(t, instance.SkipLeaderElection)
+		assert.Equal(t, []string{"default"}, instance.Namespaces)
+		assert.Equal(t, instanceOpts.labelsAsTags, instance.LabelsAsTags)
+		assert.Equal(t, instanceOpts.annotationsAsTags, instance.AnnotationsAsTags)
+	}
+
+	// The custom resources are only collected by the first instance
+	assert.Nil(t, config.Instances[1].CustomResource)
+	require.NotNil(t, config.Instances[0].CustomResource)
+	resources := config.Instances[0].CustomResource.Spec.Resources
+	require.Len(t, resources, 1)
+	assert.Equal(t, groupVersionKind{Group: "example.com", Version: "v1", Kind: "Foo"}, resources[0].GroupVersionKind)
+	assert.Equal(t, "foos", resources[0].ResourcePlural)
+	assert.Equal(t, "example_foo", *resources[0].MetricNamePrefix)
+	assert.Equal(t, []customMetric{
+		{
+			Name: "replicas",
+			Each: customMetricEach{
+				Type:  v2alpha1.KubeStateMetricsCoreMetricTypeGauge,
+				Gauge: &customMetricValue{Path: []string{"status", "replicas"}, ValueFrom: []string{"ready"}},
+			},
+		},
+		{
+			Name: "info",
+			Each: customMetricEach{
+				Type: v2alpha1.KubeStateMetricsCoreMetricTypeInfo,
+				Info: &customMetricValue{Path: []string{"metadata"}, LabelsFromPath: map[string][]string{"name": {"name"}}},
+			},
+		},
+	}, resources[0].Metrics)
+}
+
+func Test_getCollectors(t *testing.T) {
+	tests := []struct {
+		name          string
+		collectorOpts collectorOptions
+		want          []string
+	}{
+		{
+			name:          "default collectors",
+			collectorOpts: collectorOptions{enableVPA: true},
+			want:          append(append([]string{}, defaultCollectors...), "verticalpodautoscalers"),
+		},
+		{
+			name:          "denied collectors",
+			collectorOpts: collectorOptions{denied: []string{"secrets", "configmaps"}},
+			want: []string{"pods", "replicationcontrollers", "statefulsets", "nodes", "cronjobs", "jobs", "replicasets", "deployments",
+				"services", "endpoints", "daemonsets", "horizontalpodautoscalers", "poddisruptionbudgets", "limitranges", "resourcequotas",
+				"namespaces", "persistentvolumeclaims", "persistentvolumes", "ingresses", "storageclasses", "volumeattachments"},
+		},
+		{
+			name:          "allowed collectors",
+			collectorOpts: collectorOptions{enableCRD: true, allowed: []string{"pods", "nodes"}},
+			want:          []string{"pods", "nodes"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, getCollectors(tt.collectorOpts))
+		})
+	}
+}
+
+func Test_splitList(t *testing.T) {
+	collectors := []string{"pods", "nodes", "jobs", "secrets", "services"}
+
+	assert.Equal(t, [][]string{collectors}, splitList(collectors, 0))
+	assert.Equal(t, [][]string{{"pods", "nodes"}, {"jobs", "secrets", "services"}}, splitList(collectors, 2))
+	assert.Equal(t, [][]string{{"pods"}, {"nodes"}, {"jobs"}, {"secrets"}, {"services"}}, splitList(collectors, 10))
+}
+
+func Test_getShards(t *testing.T) {
+	collectors := []string{"pods", "nodes", "jobs", "namespaces"}
+
+	// By collectors
+	assert.Equal(t, []ksmShard{
+		{collectors: []string{"pods", "nodes"}, namespaces: []string{"default"}},
+		{collectors: []string{"jobs", "namespaces"}, namespaces: []string{"default"}},
+	}, getShards(collectors, instanceOptions{shards: 2, namespaces: []string{"default"}}))
+
+	// By namespaces, the cluster-scoped resources are only collected by the first instance
+	assert.Equal(t, []ksmShard{
+		{collectors: collectors, namespaces: []string{"default"}},
+		{collectors: []string{"pods", "jobs"}, namespaces: []string{"kube-system", "monitoring"}},
+	}, getShards(collectors, instanceOptions{shards: 2, shardByNamespaces: true, namespaces: []string{"default", "kube-system", "monitoring"}}))
+
+	// By namespaces without namespaced collectors
+	assert.Equal(t, []ksmShard{
+		{collectors: []string{"nodes"}, namespaces: []string{"default", "kube-system"}},
+	}, getShards([]string{"nodes"}, instanceOptions{shards: 2, shardByNamespaces: true, namespaces: []string{"default", "kube-system"}}))
+}
@@ -21,6 +21,48 @@ const (
 	defaultKubeStateMetricsCoreConf string = "kube-state-metrics-core-config"
 )
 
+// defaultCollectors are the collectors of the check when no collector is allowed explicitly
+var defaultCollectors = []string{
+	"pods",
+	"replicationcontrollers",
+	"statefulsets",
+	"nodes",
+	"cronjobs",
+	"jobs",
+	"replicasets",
+	"deployments",
+	"configmaps",
+	"services",
+	"endpoints",
+	"daemonsets",
+	"horizontalpodautoscalers",
+	"poddisruptionbudgets",
+	"limitranges",
+	"resourcequotas",
+	"secrets",
+	"namespaces",
+	"persistentvolumeclaims",
+	"persistentvolumes",
+	"ingresses",
+	"storageclasses",
+	"volumeattachments",
+}
+
+// clusterScopedCollectors are the collectors of cluster-scoped resources, which are not filtered by namespace
+var clusterScopedCollectors = []string{
+	"nodes",
+	"namespaces",
+	"persistentvolumes",
+	"storageclasses",
+	"volumeattachments",
+	"apiservices",
+	"customresourcedefinitions",
+	"certificatesigningrequests",
+	"mutatingwebhookconfigurations",
+	"validatingwebhookconfigurations",
+	"ingressclasses",
+}
+
 // GetKubeStateMetricsRBACResourceName return the RBAC resources name
 func GetKubeStateMetricsRBACResourceName(owner metav1.Object, suffix string) string {
 	return fmt.Sprintf("%s-%s-%s-%s", owner.GetNamespace(), owner.GetName(), kubeStateMetricsRBACPrefix, suffix)
@@ -59,6 +59,10 @@ type ksmFeature struct {
 	customConfigAnnotationKey   string
 	customConfigAnnotationValue string
 
+	collectorsConfig *v2alpha1.KubeStateMetricsCoreCollectorsConfig
+	customResources  []v2alpha1.KubeStateMetricsCoreCustomResource
+	instanceOpts     instanceOptions
+
 	logger logr.Logger
 }
 
@@ -119,6 +123,30 @@ func (f *ksmFeature) Configure(dda metav1.Object, ddaSpec *v2alpha1.DatadogAgent
 			}
 			f.customConfigAnnotationValue = hash
 			f.customConfigAnnotationKey = object.GetChecksumAnnotationKey(feature.KubernetesStateCoreIDType)
+		} else if hasStructuredConfig(ddaSpec.Features.KubeStateMetricsCore) {
+			ksmConfig := ddaSpec.Features.KubeStateMetricsCore
+			f.collectorsConfig = ksmConfig.Collectors
+			f.customResources = ksmConfig.CustomResources
+			f.instanceOpts = instanceOptions{
+				namespaces:        ksmConfig.Namespaces,
+				labelsAsTags:      ksmConfig.LabelsAsTags,
+				annotationsAsTags: ksmConfig.AnnotationsAsTags,
+			}
+			// The check is only split when it is dispatched to the Cluster Checks Runners
+			if ksmConfig.Sharding != nil && apiutils.BoolValue(ksmConfig.Sharding.Enabled) && f.runInClusterChecksRunner {
+				f.instanceOpts.shards = getShardCount(ddaSpec)
+				f.instanceOpts.shardByNamespaces = ksmConfig.Sharding.Strategy != nil && *ksmConfig.Sharding.Strategy == v2alpha1.KubeStateMetricsCoreShardingByNamespaces
+			}
+
+			// The Cluster Agent is restarted to load the new configuration, also when the number of shards changes
+			hash, err := comparison.GenerateMD5ForSpec(ksmConfigChecksum{Config: ksmConfig, Shards: f.instanceOpts.shards})
+			if err != nil {
+				f.logger.Error(err, "couldn't generate hash for ksm core structured config")
+			} else {
+				f.logger.V(2).Info("built ksm core from structured config", "hash", hash)
+			}
+			f.customConfigAnnotationValue = hash
+			f.customConfigAnnotationKey = object.GetChecksumAnnotationKey(feature.KubernetesStateCoreIDType)
 		}
 
 		f.configConfigMapName = constants.GetConfName(dda, f.customConfig, defaultKubeStateMetricsCoreConf)
@@ -127,10 +155,85 @@ func (f *ksmFeature) Configure(dda metav1.Object, ddaSpec *v2alpha1.DatadogAgent
 	return output
 }
 
+// ksmConfigChecksum contains the inputs of the structured configuration of the check
+type ksmConfigChecksum struct {
+	Config *v2alpha1.KubeStateMetricsCoreFeatureConfig `json:"config"`
+	Shards int                                         `json:"shards,omitempty"`
+}
+
+// getShardCount returns the number of instances the check is split into: the explicit number of shards,
+// or the number of replicas of the Cluster Checks Runners.
+func getShardCount(ddaSpec *v2alpha1.DatadogAgentSpec) int {
+	sharding := ddaSpec.Features.KubeStateMetricsCore.Sharding
+	if sharding.Shards != nil {
+		return int(*sharding.Shards)
+	}
+	if ccrOverride, ok := ddaSpec.Override[v2alpha1.ClusterChecksRunnerComponentName]; ok && ccrOverride != nil && ccrOverride.Replicas != nil {
+		return int(*ccrOverride.Replicas)
+	}
+	return 1
+}
+
+// hasStructuredConfig returns true if the check is configured with the structured fields of the feature
+func hasStructuredConfig(config *v2alpha1.KubeStateMetricsCoreFeatureConfig) bool {
+	return config.Collectors != nil ||
+		len(config.LabelsAsTags) > 0 ||
+		len(config.AnnotationsAsTags) > 0 ||
+		len(config.Namespaces) > 0 ||
+		len(config.CustomResources) > 0 ||
+		config.Sharding != nil
+}
+
 type collectorOptions struct {
 	enableVPA        bool
 	enableAPIService bool
 	enableCRD        bool
+	// allowed replaces the default collectors when it is not empty
+	allowed         []string
+	denied          []string
+	customResources []v2alpha1.KubeStateMetricsCoreCustomResource
+}
+
+// instanceOptions contains the options set on each instance of the check
+type instanceOptions struct {
+	namespaces        []string
+	labelsAsTags      map[string]map[string]string
+	annotationsAsTags map[string]map[string]string
+	// shards is the number of instances the check is split into
+	shards int
+	// shardByNamespaces splits the namespaces between the instances instead of the collectors
+	shardByNamespaces bool
+}
+
+// Validate checks that the structured configuration of the check can be applied.
+func (f *ksmFeature) Validate() error {
+	if f.collectorsConfig != nil && len(f.collectorsConfig.Allow) > 0 && len(getCollectors(f.getCollectorOptions(collectorOptions{}))) == 0 {
+		return fmt.Errorf("collectors.deny removes all the collectors of collectors.allow")
+	}
+	if f.instanceOpts.shardByNamespaces && len(f.instanceOpts.namespaces) == 0 {
+		return fmt.Errorf("sharding.strategy %s requires namespaces to be set", v2alpha1.KubeStateMetricsCoreShardingByNamespaces)
+	}
+	for _, cr := range f.customResources {
+		if cr.Version == "" || cr.Kind == "" {
+			return fmt.Errorf("customResources: the version and the kind of the custom resources of group %q are required", cr.Group)
+		}
+		for _, metric := range cr.Metrics {
+			if metric.Name == "" {
+				return fmt.Errorf("customResources: the metrics of %s need a name", cr.Kind)
+			}
+		}
+	}
+	return nil
+}
+
+// getCollectorOptions adds the collectors configured in the feature to the options.
+func (f *ksmFeature) getCollectorOptions(collectorOpts collectorOptions) collectorOptions {
+	if f.collectorsConfig != nil {
+		collectorOpts.allowed = f.collectorsConfig.Allow
+		collectorOpts.denied = f.collectorsConfig.Deny
+	}
+	collectorOpts.customResources = f.customResources
+	return collectorOpts
 }
 
 // ManageDependencies allows a feature to manage its dependencies.
@@ -139,11 +242,11 @@ func (f *ksmFeature) ManageDependencies(managers feature.ResourceManagers) error
 	// Create a configMap if CustomConfig.ConfigData is provided and CustomConfig.ConfigMap == nil,
 	// OR if the default configMap is needed.
 	pInfo := managers.Store().GetPlatformInfo()
-	collectorOpts := collectorOptions{
+	collectorOpts := f.getCollectorOptions(collectorOptions{
 		enableVPA:        pInfo.IsResourceSupported("VerticalPodAutoscaler"),
 		enableAPIService: f.collectAPIServiceMetrics,
 		enableCRD:        f.collectCRDMetrics,
-	}
+	})
 	configCM, err := f.buildKSMCoreConfigMap(collectorOpts)
 	if err != nil {
 		return err
@@ -237,3 +340,9 @@ func (f *ksmFeature) ManageNodeAgent(managers feature.PodTemplateManagers, provi
 func (f *ksmFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *ksmFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
@@ -90,6 +90,16 @@ func Test_ksmFeature_Configure(t *testing.T) {
 			ClusterAgent:  ksmClusterAgentWantFunc(true),
 			Agent:         test.NewDefaultComponentTest().WithWantFunc(ksmAgentSingleAgentWantFunc),
 		},
+		{
+			Name: "ksm-core enabled, structured config",
+			DDA: testutils.NewDatadogAgentBuilder().
+				WithKSMEnabled(true).
+				WithKSMNamespaces([]string{"default"}).
+				Build(),
+			WantConfigure: true,
+			ClusterAgent:  ksmStructuredConfigClusterAgentWantFunc(),
+			Agent:         test.NewDefaultComponentTest().WithWantFunc(ksmAgentNodeWantFunc),
+		},
 	}
 
 	tests.Run(t, buildKSMFeature)
@@ -129,6 +139,28 @@ func ksmClusterAgentWantFunc(hasCustomConfig bool) *test.ComponentTest {
 	)
 }
 
+func ksmStructuredConfigClusterAgentWantFunc() *test.ComponentTest {
+	return test.NewDefaultComponentTest().WithWantFunc(
+		func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+			mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+			// The Cluster Agent is restarted when the structured config changes
+			hash, err := comparison.GenerateMD5ForSpec(ksmConfigChecksum{
+				Config: &v2alpha1.KubeStateMetricsCoreFeatureConfig{
+					Enabled:    apiutils.NewBoolPointer(true),
+					Namespaces: []string{"default"},
+				},
+			})
+			assert.NoError(t, err)
+			wantAnnotations := map[string]string{
+				fmt.Sprintf(constants.MD5ChecksumAnnotationKey, feature.KubernetesStateCoreIDType): hash,
+			}
+			annotations := mgr.AnnotationMgr.Annotations
+			assert.True(t, apiutils.IsEqualStruct(annotations, wantAnnotations), "Annotations \ndiff = %s", cmp.Diff(annotations, wantAnnotations))
+		},
+	)
+}
+
 func ksmAgentNodeWantFunc(t testing.TB, mgrInterface feature.PodTemplateManagers) {
 	ksmAgentWantFunc(t, mgrInterface, apicommon.CoreAgentContainerName)
 }
@@ -149,3 +181,128 @@ func ksmAgentWantFunc(t testing.TB, mgrInterface feature.PodTemplateManagers, ag
 	}
 	assert.True(t, apiutils.IsEqualStruct(agentEnvVars, want), "Agent envvars \ndiff = %s", cmp.Diff(agentEnvVars, want))
 }
+
+func Test_ksmFeature_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *v2alpha1.KubeStateMetricsCoreFeatureConfig
+		wantErr string
+	}{
+		{
+			name: "valid structured config",
+			config: &v2alpha1.KubeStateMetricsCoreFeatureConfig{
+				Collectors: &v2alpha1.KubeStateMetricsCoreCollectorsConfig{Allow: []string{"pods", "nodes"}, Deny: []string{"nodes"}},
+				CustomResources: []v2alpha1.KubeStateMetricsCoreCustomResource{
+					{Group: "example.com", Version: "v1", Kind: "Foo", Metrics: []v2alpha1.KubeStateMetricsCoreCustomResourceMetric{{Name: "replicas", Path: []string{"status", "replicas"}}}},
+				},
+			},
+		},
+		{
+			name: "all the allowed collectors are denied",
+			config: &v2alpha1.KubeStateMetricsCoreFeatureConfig{
+				Collectors: &v2alpha1.KubeStateMetricsCoreCollectorsConfig{Allow: []string{"pods"}, Deny: []string{"pods"}},
+			},
+			wantErr: "collectors.deny removes all the collectors of collectors.allow",
+		},
+		{
+			name: "custom resource without kind",
+			config: &v2alpha1.KubeStateMetricsCoreFeatureConfig{
+				CustomResources: []v2alpha1.KubeStateMetricsCoreCustomResource{{Group: "example.com", Version: "v1"}},
+			},
+			wantErr: `customResources: the version and the kind of the custom resources of group "example.com" are required`,
+		},
+		{
+			name: "sharding by namespaces without namespaces",
+			config: &v2alpha1.KubeStateMetricsCoreFeatureConfig{
+				Sharding: &v2alpha1.KubeStateMetricsCoreShardingConfig{
+					Enabled:  apiutils.NewBoolPointer(true),
+					Strategy: apiutils.NewPointer(v2alpha1.KubeStateMetricsCoreShardingByNamespaces),
+				},
+			},
+			wantErr: "sharding.strategy Namespaces requires namespaces to be set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.Enabled = apiutils.NewBoolPointer(true)
+			dda := testutils.NewDatadogAgentBuilder().
+				WithClusterChecksEnabled(true).
+				WithClusterChecksUseCLCEnabled(true).
+				Build()
+			dda.Spec.Features.KubeStateMetricsCore = tt.config
+
+			f := buildKSMFeature(&feature.Options{}).(*ksmFeature)
+			f.Configure(dda, &dda.Spec, nil)
+
+			err := f.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_ksmFeature_sharding(t *testing.T) {
+	tests := []struct {
+		name                    string
+		sharding                *v2alpha1.KubeStateMetricsCoreShardingConfig
+		useClusterChecksRunners bool
+		ccrReplicas             *int32
+		wantShards              int
+	}{
+		{
+			name:                    "sharding disabled",
+			sharding:                &v2alpha1.KubeStateMetricsCoreShardingConfig{Shards: apiutils.NewInt32Pointer(3)},
+			useClusterChecksRunners: true,
+			wantShards:              0,
+		},
+		{
+			name:                    "explicit number of shards",
+			sharding:                &v2alpha1.KubeStateMetricsCoreShardingConfig{Enabled: apiutils.NewBoolPointer(true), Shards: apiutils.NewInt32Pointer(3)},
+			useClusterChecksRunners: true,
+			ccrReplicas:             apiutils.NewInt32Pointer(5),
+			wantShards:              3,
+		},
+		{
+			name:                    "number of shards derived from the Cluster Checks Runners replicas",
+			sharding:                &v2alpha1.KubeStateMetricsCoreShardingConfig{Enabled: apiutils.NewBoolPointer(true)},
+			useClusterChecksRunners: true,
+			ccrReplicas:             apiutils.NewInt32Pointer(5),
+			wantShards:              5,
+		},
+		{
+			name:                    "default number of Cluster Checks Runners replicas",
+			sharding:                &v2alpha1.KubeStateMetricsCoreShardingConfig{Enabled: apiutils.NewBoolPointer(true)},
+			useClusterChecksRunners: true,
+			wantShards:              1,
+		},
+		{
+			name:                    "check running in the Cluster Agent",
+			sharding:                &v2alpha1.KubeStateMetricsCoreShardingConfig{Enabled: apiutils.NewBoolPointer(true), Shards: apiutils.NewInt32Pointer(3)},
+			useClusterChecksRunners: false,
+			wantShards:              0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dda := testutils.NewDatadogAgentBuilder().
+				WithKSMEnabled(true).
+				WithClusterChecksEnabled(true).
+				WithClusterChecksUseCLCEnabled(tt.useClusterChecksRunners).
+				Build()
+			dda.Spec.Features.KubeStateMetricsCore.Sharding = tt.sharding
+			if tt.ccrReplicas != nil {
+				dda.Spec.Override = map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride{
+					v2alpha1.ClusterChecksRunnerComponentName: {Replicas: tt.ccrReplicas},
+				}
+			}
+
+			f := buildKSMFeature(&feature.Options{}).(*ksmFeature)
+			f.Configure(dda, &dda.Spec, nil)
+
+			assert.Equal(t, tt.wantShards, f.instanceOpts.shards)
+		})
+	}
+}
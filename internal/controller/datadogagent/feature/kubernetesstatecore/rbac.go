@@ -6,8 +6,12 @@
 package kubernetesstatecore
 
 import (
+	"slices"
+	"strings"
+
 	rbacv1 "k8s.io/api/rbac/v1"
 
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	"github.com/DataDog/datadog-operator/pkg/kubernetes/rbac"
 )
 
@@ -102,7 +106,9 @@ func getRBACPolicyRules(collectorOpts collectorOptions) []rbacv1.PolicyRule {
 		},
 	}
 
-	if collectorOpts.enableAPIService {
+	// The optional collectors can be enabled by their flag or listed in the allowed collectors
+	collectors := getCollectors(collectorOpts)
+	if slices.Contains(collectors, "apiservices") {
 		rbacRules = append(rbacRules, rbacv1.PolicyRule{
 			APIGroups: []string{rbac.RegistrationAPIGroup},
 			Resources: []string{
@@ -111,7 +117,7 @@ func getRBACPolicyRules(collectorOpts collectorOptions) []rbacv1.PolicyRule {
 		})
 	}
 
-	if collectorOpts.enableCRD {
+	if slices.Contains(collectors, "customresourcedefinitions") {
 		rbacRules = append(rbacRules, rbacv1.PolicyRule{
 			APIGroups: []string{rbac.APIExtensionsAPIGroup},
 			Resources: []string{
@@ -120,6 +126,13 @@ func getRBACPolicyRules(collectorOpts collectorOptions) []rbacv1.PolicyRule {
 		})
 	}
 
+	for _, cr := range collectorOpts.customResources {
+		rbacRules = append(rbacRules, rbacv1.PolicyRule{
+			APIGroups: []string{cr.Group},
+			Resources: []string{getCustomResourcePlural(cr)},
+		})
+	}
+
 	commonVerbs := []string{
 		rbac.ListVerb,
 		rbac.WatchVerb,
@@ -131,3 +144,11 @@ func getRBACPolicyRules(collectorOpts collectorOptions) []rbacv1.PolicyRule {
 
 	return rbacRules
 }
+
+// getCustomResourcePlural returns the plural name of a custom resource, defaulting to the lowercase kind followed by `s`
+func getCustomResourcePlural(cr v2alpha1.KubeStateMetricsCoreCustomResource) string {
+	if cr.Resource != nil && *cr.Resource != "" {
+		return *cr.Resource
+	}
+	return strings.ToLower(cr.Kind) + "s"
+}
@@ -0,0 +1,64 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package kubernetesstatecore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/DataDog/datadog-operator/pkg/kubernetes/rbac"
+)
+
+func Test_getRBACPolicyRules(t *testing.T) {
+	tests := []struct {
+		name            string
+		collectorOpts   collectorOptions
+		wantAPIServices bool
+		wantCRDs        bool
+	}{
+		{
+			name: "default collectors",
+		},
+		{
+			name:            "optional collectors enabled",
+			collectorOpts:   collectorOptions{enableAPIService: true, enableCRD: true},
+			wantAPIServices: true,
+			wantCRDs:        true,
+		},
+		{
+			name:            "optional collectors allowed",
+			collectorOpts:   collectorOptions{allowed: []string{"pods", "apiservices", "customresourcedefinitions"}},
+			wantAPIServices: true,
+			wantCRDs:        true,
+		},
+		{
+			name:          "optional collectors enabled but not allowed",
+			collectorOpts: collectorOptions{enableAPIService: true, enableCRD: true, allowed: []string{"pods"}},
+		},
+		{
+			name:          "optional collectors denied",
+			collectorOpts: collectorOptions{enableAPIService: true, enableCRD: true, denied: []string{"apiservices", "customresourcedefinitions"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hasAPIServices, hasCRDs bool
+			for _, rule := range getRBACPolicyRules(tt.collectorOpts) {
+				for _, resource := range rule.Resources {
+					switch resource {
+					case rbac.APIServicesResource:
+						hasAPIServices = true
+					case rbac.CustomResourceDefinitionsResource:
+						hasCRDs = true
+					}
+				}
+			}
+			assert.Equal(t, tt.wantAPIServices, hasAPIServices)
+			assert.Equal(t, tt.wantCRDs, hasCRDs)
+		})
+	}
+}
@@ -64,6 +64,14 @@ func (f *liveContainerFeature) Configure(_ metav1.Object, ddaSpec *v2alpha1.Data
 	return reqComp
 }
 
+// HostAccess returns the host accesses of the feature.
+func (f *liveContainerFeature) HostAccess() []string {
+	return []string{
+		feature.HostPathAccess(common.CgroupsHostPath),
+		feature.HostPathAccess(common.ProcdirHostPath),
+	}
+}
+
 // ManageDependencies allows a feature to manage its dependencies.
 // Feature's dependencies should be added in the store.
 func (f *liveContainerFeature) ManageDependencies(managers feature.ResourceManagers) error {
@@ -133,3 +141,9 @@ func (f *liveContainerFeature) manageNodeAgent(agentContainerName apicommon.Agen
 func (f *liveContainerFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *liveContainerFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
@@ -72,6 +72,15 @@ func (f *liveProcessFeature) Configure(_ metav1.Object, ddaSpec *v2alpha1.Datado
 	return reqComp
 }
 
+// HostAccess returns the host accesses of the feature.
+func (f *liveProcessFeature) HostAccess() []string {
+	return []string{
+		feature.HostPathAccess(common.PasswdHostPath),
+		feature.HostPathAccess(common.CgroupsHostPath),
+		feature.HostPathAccess(common.ProcdirHostPath),
+	}
+}
+
 // ManageDependencies allows a feature to manage its dependencies.
 // Feature's dependencies should be added in the store.
 func (f *liveProcessFeature) ManageDependencies(managers feature.ResourceManagers) error {
@@ -164,3 +173,9 @@ func (f *liveProcessFeature) manageNodeAgent(agentContainerName apicommon.AgentC
 func (f *liveProcessFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *liveProcessFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
@@ -6,6 +6,7 @@
 package logcollection
 
 import (
+	"encoding/json"
 	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
@@ -16,6 +17,7 @@ import (
 	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/merger"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object/volume"
 )
 
@@ -40,6 +42,17 @@ type logCollectionFeature struct {
 	containerSymlinksPath      string
 	tempStoragePath            string
 	openFilesLimit             int32
+	processingRules            []processingRule
+	containerInclude           string
+	containerExclude           string
+}
+
+// processingRule is the Agent representation of a v2alpha1.LogProcessingRule
+type processingRule struct {
+	Type               string `json:"type"`
+	Name               string `json:"name"`
+	Pattern            string `json:"pattern"`
+	ReplacePlaceholder string `json:"replace_placeholder,omitempty"`
 }
 
 // ID returns the ID of the Feature
@@ -68,6 +81,16 @@ func (f *logCollectionFeature) Configure(_ metav1.Object, ddaSpec *v2alpha1.Data
 		if logCollection.OpenFilesLimit != nil {
 			f.openFilesLimit = *logCollection.OpenFilesLimit
 		}
+		for _, rule := range logCollection.ProcessingRules {
+			f.processingRules = append(f.processingRules, processingRule{
+				Type:               string(rule.Type),
+				Name:               rule.Name,
+				Pattern:            rule.Pattern,
+				ReplacePlaceholder: apiutils.StringValue(rule.ReplacePlaceholder),
+			})
+		}
+		f.containerInclude = common.GetContainerFilter(logCollection.ContainerInclude)
+		f.containerExclude = common.GetContainerFilter(logCollection.ContainerExclude)
 
 		reqComp = feature.RequiredComponents{
 			Agent: feature.RequiredComponent{
@@ -81,6 +104,16 @@ func (f *logCollectionFeature) Configure(_ metav1.Object, ddaSpec *v2alpha1.Data
 	return reqComp
 }
 
+// HostAccess returns the host accesses of the feature.
+func (f *logCollectionFeature) HostAccess() []string {
+	return []string{
+		feature.HostPathAccess(f.tempStoragePath),
+		feature.HostPathAccess(f.podLogsPath),
+		feature.HostPathAccess(f.containerLogsPath),
+		feature.HostPathAccess(f.containerSymlinksPath),
+	}
+}
+
 // ManageDependencies allows a feature to manage its dependencies.
 // Feature's dependencies should be added in the store.
 func (f *logCollectionFeature) ManageDependencies(managers feature.ResourceManagers) error {
@@ -97,15 +130,13 @@ func (f *logCollectionFeature) ManageClusterAgent(managers feature.PodTemplateMa
 // if SingleContainerStrategy is enabled and can be used with the configured feature set.
 // It should do nothing if the feature doesn't need to configure it.
 func (f *logCollectionFeature) ManageSingleContainerNodeAgent(managers feature.PodTemplateManagers, provider string) error {
-	f.manageNodeAgent(apicommon.UnprivilegedSingleAgentContainerName, managers, provider)
-	return nil
+	return f.manageNodeAgent(apicommon.UnprivilegedSingleAgentContainerName, managers, provider)
 }
 
 // ManageNodeAgent allows a feature to configure the Node Agent's corev1.PodTemplateSpec
 // It should do nothing if the feature doesn't need to configure it.
 func (f *logCollectionFeature) ManageNodeAgent(managers feature.PodTemplateManagers, provider string) error {
-	f.manageNodeAgent(apicommon.CoreAgentContainerName, managers, provider)
-	return nil
+	return f.manageNodeAgent(apicommon.CoreAgentContainerName, managers, provider)
 }
 
 func (f *logCollectionFeature) manageNodeAgent(agentContainerName apicommon.AgentContainerName, managers feature.PodTemplateManagers, provider string) error {
@@ -148,6 +179,33 @@ func (f *logCollectionFeature) manageNodeAgent(agentContainerName apicommon.Agen
 			Value: strconv.FormatInt(int64(f.openFilesLimit), 10),
 		})
 	}
+	if len(f.processingRules) > 0 {
+		rules, err := json.Marshal(f.processingRules)
+		if err != nil {
+			return err
+		}
+		managers.EnvVar().AddEnvVarToContainer(agentContainerName, &corev1.EnvVar{
+			Name:  DDLogsConfigProcessingRules,
+			Value: string(rules),
+		})
+	}
+	// The container filters of the feature extend the global logs filters
+	if f.containerInclude != "" {
+		if err := managers.EnvVar().AddEnvVarToContainerWithMergeFunc(agentContainerName, &corev1.EnvVar{
+			Name:  common.DDContainerIncludeLogs,
+			Value: f.containerInclude,
+		}, merger.AppendToValueEnvVarMergeFunction); err != nil {
+			return err
+		}
+	}
+	if f.containerExclude != "" {
+		if err := managers.EnvVar().AddEnvVarToContainerWithMergeFunc(agentContainerName, &corev1.EnvVar{
+			Name:  common.DDContainerExcludeLogs,
+			Value: f.containerExclude,
+		}, merger.AppendToValueEnvVarMergeFunction); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -157,3 +215,9 @@ func (f *logCollectionFeature) manageNodeAgent(agentContainerName apicommon.Agen
 func (f *logCollectionFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *logCollectionFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
@@ -90,6 +91,68 @@ func Test_LogCollectionFeature_Configure(t *testing.T) {
 				},
 			),
 		},
+		{
+			Name: "processing rules",
+			DDA: testutils.NewDatadogAgentBuilder().
+				WithLogCollectionEnabled(true).
+				WithLogCollectionProcessingRules([]v2alpha1.LogProcessingRule{
+					{
+						Name:    "exclude_healthchecks",
+						Type:    v2alpha1.LogProcessingRuleExcludeAtMatch,
+						Pattern: "GET /healthz",
+					},
+					{
+						Name:               "mask_tokens",
+						Type:               v2alpha1.LogProcessingRuleMaskSequences,
+						Pattern:            "token=\\w+",
+						ReplacePlaceholder: apiutils.NewStringPointer("token=[masked]"),
+					},
+				}).
+				BuildWithDefaults(),
+			WantConfigure: true,
+			Agent: test.NewDefaultComponentTest().WithWantFunc(
+				func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+					wantEnvVars := createEnvVars("true", "false", "true")
+					wantEnvVars = append(wantEnvVars, &corev1.EnvVar{
+						Name:  DDLogsConfigProcessingRules,
+						Value: `[{"type":"exclude_at_match","name":"exclude_healthchecks","pattern":"GET /healthz"},{"type":"mask_sequences","name":"mask_tokens","pattern":"token=\\w+","replace_placeholder":"token=[masked]"}]`,
+					})
+					assertWants(t, mgrInterface, getWantVolumeMounts(), getWantVolumes(), wantEnvVars)
+				},
+			),
+		},
+		{
+			Name: "container filters",
+			DDA: testutils.NewDatadogAgentBuilder().
+				WithLogCollectionEnabled(true).
+				WithLogCollectionContainerFilters(
+					&v2alpha1.ContainerFilter{
+						Images: []string{"^nginx$"},
+					},
+					&v2alpha1.ContainerFilter{
+						Namespaces: []string{"^kube-system$", "^monitoring$"},
+						Names:      []string{"^istio-proxy$"},
+					},
+				).
+				BuildWithDefaults(),
+			WantConfigure: true,
+			Agent: test.NewDefaultComponentTest().WithWantFunc(
+				func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+					wantEnvVars := createEnvVars("true", "false", "true")
+					wantEnvVars = append(wantEnvVars,
+						&corev1.EnvVar{
+							Name:  common.DDContainerIncludeLogs,
+							Value: "image:^nginx$",
+						},
+						&corev1.EnvVar{
+							Name:  common.DDContainerExcludeLogs,
+							Value: "kube_namespace:^kube-system$ kube_namespace:^monitoring$ name:^istio-proxy$",
+						},
+					)
+					assertWants(t, mgrInterface, getWantVolumeMounts(), getWantVolumes(), wantEnvVars)
+				},
+			),
+		},
 		{
 			Name: "custom volumes",
 			DDA: testutils.NewDatadogAgentBuilder().
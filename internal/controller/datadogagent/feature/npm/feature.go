@@ -6,8 +6,14 @@
 package npm
 
 import (
+	"context"
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
@@ -34,6 +40,9 @@ func buildNPMFeature(options *feature.Options) feature.Feature {
 type npmFeature struct {
 	collectDNSStats bool
 	enableConntrack bool
+
+	// seccompConfigMap is the ConfigMap containing the custom seccomp profile of system-probe, if any
+	seccompConfigMap *types.NamespacedName
 }
 
 // ID returns the ID of the Feature
@@ -42,7 +51,7 @@ func (f *npmFeature) ID() feature.IDType {
 }
 
 // Configure is used to configure the feature from a v2alpha1.DatadogAgent instance.
-func (f *npmFeature) Configure(_ metav1.Object, ddaSpec *v2alpha1.DatadogAgentSpec, _ *v2alpha1.RemoteConfigConfiguration) (reqComp feature.RequiredComponents) {
+func (f *npmFeature) Configure(dda metav1.Object, ddaSpec *v2alpha1.DatadogAgentSpec, _ *v2alpha1.RemoteConfigConfiguration) (reqComp feature.RequiredComponents) {
 	if ddaSpec.Features == nil {
 		return
 	}
@@ -61,6 +70,13 @@ func (f *npmFeature) Configure(_ metav1.Object, ddaSpec *v2alpha1.DatadogAgentSp
 		npm := ddaSpec.Features.NPM
 		f.collectDNSStats = apiutils.BoolValue(npm.CollectDNSStats)
 		f.enableConntrack = apiutils.BoolValue(npm.EnableConntrack)
+
+		if override, found := ddaSpec.Override[v2alpha1.NodeAgentComponentName]; found && override != nil {
+			if container, found := override.Containers[apicommon.SystemProbeContainerName]; found && container != nil &&
+				container.SeccompConfig != nil && container.SeccompConfig.CustomProfile != nil && container.SeccompConfig.CustomProfile.ConfigMap != nil {
+				f.seccompConfigMap = &types.NamespacedName{Namespace: dda.GetNamespace(), Name: container.SeccompConfig.CustomProfile.ConfigMap.Name}
+			}
+		}
 	}
 
 	return reqComp
@@ -72,6 +88,18 @@ func (f *npmFeature) ManageDependencies(managers feature.ResourceManagers) error
 	return nil
 }
 
+// Degraded reports the feature as degraded if the custom seccomp profile of system-probe does not exist:
+// system-probe cannot start without it.
+func (f *npmFeature) Degraded(ctx context.Context, k8sClient client.Reader) (string, string) {
+	if f.seccompConfigMap == nil {
+		return "", ""
+	}
+	if err := k8sClient.Get(ctx, *f.seccompConfigMap, &corev1.ConfigMap{}); apierrors.IsNotFound(err) {
+		return feature.MissingConfigMapReason, fmt.Sprintf("the system-probe seccomp profile ConfigMap %s does not exist", f.seccompConfigMap)
+	}
+	return "", ""
+}
+
 // ManageClusterAgent allows a feature to configure the ClusterAgent's corev1.PodTemplateSpec
 // It should do nothing if the feature doesn't need to configure it.
 func (f *npmFeature) ManageClusterAgent(managers feature.PodTemplateManagers) error {
@@ -177,3 +205,9 @@ func (f *npmFeature) ManageNodeAgent(managers feature.PodTemplateManagers, provi
 func (f *npmFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *npmFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
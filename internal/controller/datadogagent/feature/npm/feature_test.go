@@ -6,6 +6,7 @@
 package npm
 
 import (
+	"context"
 	"testing"
 
 	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
@@ -20,6 +21,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func Test_npmFeature_Configure(t *testing.T) {
@@ -225,3 +229,61 @@ func Test_npmFeature_Configure(t *testing.T) {
 
 	tests.Run(t, buildNPMFeature)
 }
+
+func Test_npmFeature_Degraded(t *testing.T) {
+	seccompOverride := map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride{
+		v2alpha1.NodeAgentComponentName: {
+			Containers: map[apicommon.AgentContainerName]*v2alpha1.DatadogAgentGenericContainer{
+				apicommon.SystemProbeContainerName: {
+					SeccompConfig: &v2alpha1.SeccompConfig{
+						CustomProfile: &v2alpha1.CustomConfig{
+							ConfigMap: &v2alpha1.ConfigMapConfig{Name: "custom-seccomp"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		override   map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride
+		existing   []client.Object
+		wantReason string
+	}{
+		{
+			name: "default seccomp profile",
+		},
+		{
+			name:       "custom seccomp profile ConfigMap missing",
+			override:   seccompOverride,
+			existing:   []client.Object{&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "custom-seccomp"}}},
+			wantReason: feature.MissingConfigMapReason,
+		},
+		{
+			name:     "custom seccomp profile ConfigMap exists",
+			override: seccompOverride,
+			existing: []client.Object{&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "datadog", Name: "custom-seccomp"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dda := &v2alpha1.DatadogAgent{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "datadog", Name: "datadog"},
+				Spec: v2alpha1.DatadogAgentSpec{
+					Features: &v2alpha1.DatadogFeatures{
+						NPM: &v2alpha1.NPMFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+					},
+					Override: tt.override,
+				},
+			}
+			f := buildNPMFeature(nil).(*npmFeature)
+			f.Configure(dda, &dda.Spec, nil)
+
+			c := fakeclient.NewClientBuilder().WithObjects(tt.existing...).Build()
+			reason, _ := f.Degraded(context.TODO(), c)
+			assert.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
@@ -133,3 +133,9 @@ func (f *oomKillFeature) ManageNodeAgent(managers feature.PodTemplateManagers, p
 func (f *oomKillFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *oomKillFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
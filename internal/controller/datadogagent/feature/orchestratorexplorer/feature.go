@@ -312,3 +312,9 @@ func (f *orchestratorExplorerFeature) ManageClusterChecksRunner(managers feature
 
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *orchestratorExplorerFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
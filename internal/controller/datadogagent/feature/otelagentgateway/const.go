@@ -0,0 +1,20 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package otelagentgateway
+
+import (
+	componentotelgateway "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/otelagentgateway"
+)
+
+const (
+	otelAgentGatewayVolumeName = "otel-agent-gateway-config-volume"
+	otelGatewayConfigFileName  = "otel-gateway-config.yaml"
+	// defaultOtelAgentGatewayConf default otel agent gateway ConfigMap name
+	defaultOtelAgentGatewayConf string = "otel-agent-gateway-config"
+
+	otelGRPCPortName = componentotelgateway.OtelGRPCPortName
+	otelHTTPPortName = componentotelgateway.OtelHTTPPortName
+)
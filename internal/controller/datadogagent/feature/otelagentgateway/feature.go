@@ -0,0 +1,258 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package otelagentgateway
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	componentotelgateway "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/otelagentgateway"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/otelagentgateway/defaultconfig"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object/configmap"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object/volume"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+func init() {
+	err := feature.Register(feature.OtelAgentGatewayIDType, buildOtelAgentGatewayFeature)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func buildOtelAgentGatewayFeature(options *feature.Options) feature.Feature {
+	otelAgentGatewayFeat := &otelAgentGatewayFeature{}
+
+	if options != nil {
+		otelAgentGatewayFeat.logger = options.Logger
+	}
+
+	return otelAgentGatewayFeat
+}
+
+type otelAgentGatewayFeature struct {
+	owner         metav1.Object
+	customConfig  *v2alpha1.CustomConfig
+	configMapName string
+	ports         []*corev1.ContainerPort
+
+	customConfigAnnotationKey   string
+	customConfigAnnotationValue string
+
+	logger logr.Logger
+}
+
+// ID returns the ID of the Feature
+func (f *otelAgentGatewayFeature) ID() feature.IDType {
+	return feature.OtelAgentGatewayIDType
+}
+
+// Configure is used to configure the feature from a v2alpha1.DatadogAgent instance.
+func (f *otelAgentGatewayFeature) Configure(dda metav1.Object, ddaSpec *v2alpha1.DatadogAgentSpec, _ *v2alpha1.RemoteConfigConfiguration) (reqComp feature.RequiredComponents) {
+	if ddaSpec.Features == nil || ddaSpec.Features.OtelAgentGateway == nil || !apiutils.BoolValue(ddaSpec.Features.OtelAgentGateway.Enabled) {
+		return reqComp
+	}
+
+	f.owner = dda
+	f.customConfig = ddaSpec.Features.OtelAgentGateway.Conf
+	f.configMapName = constants.GetConfName(dda, f.customConfig, defaultOtelAgentGatewayConf)
+
+	if len(ddaSpec.Features.OtelAgentGateway.Ports) == 0 {
+		f.ports = []*corev1.ContainerPort{
+			{
+				Name:          otelGRPCPortName,
+				ContainerPort: common.DefaultOtelGRPCPort,
+				Protocol:      corev1.ProtocolTCP,
+			},
+			{
+				Name:          otelHTTPPortName,
+				ContainerPort: common.DefaultOtelHTTPPort,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		}
+	} else {
+		f.ports = ddaSpec.Features.OtelAgentGateway.Ports
+	}
+
+	reqComp.OtelAgentGateway = feature.RequiredComponent{
+		IsRequired: apiutils.NewBoolPointer(true),
+		Containers: []apicommon.AgentContainerName{apicommon.OtelAgent},
+	}
+
+	return reqComp
+}
+
+// ManageDependencies allows a feature to manage its dependencies.
+// Feature's dependencies should be added in the store.
+func (f *otelAgentGatewayFeature) ManageDependencies(managers feature.ResourceManagers) error {
+	// The configMap is created when the configuration is not referenced from an existing configMap
+	if f.customConfig == nil || f.customConfig.ConfigMap == nil {
+		configData := f.defaultConfigData()
+		if f.customConfig != nil && f.customConfig.ConfigData != nil {
+			configData = *f.customConfig.ConfigData
+		}
+
+		configMap, err := configmap.BuildConfigMapConfigData(f.owner.GetNamespace(), &configData, f.configMapName, otelGatewayConfigFileName)
+		if err != nil {
+			return err
+		}
+
+		// Add md5 hash annotation for configMap
+		f.customConfigAnnotationKey = object.GetChecksumAnnotationKey(feature.OtelAgentGatewayIDType)
+		f.customConfigAnnotationValue, err = comparison.GenerateMD5ForSpec(configData)
+		if err != nil {
+			return err
+		}
+		annotations := object.MergeAnnotationsLabels(f.logger, configMap.Annotations, map[string]string{f.customConfigAnnotationKey: f.customConfigAnnotationValue}, "*")
+		configMap.SetAnnotations(annotations)
+
+		if err := managers.Store().AddOrUpdate(kubernetes.ConfigMapKind, configMap); err != nil {
+			return err
+		}
+	}
+
+	// The node Agents forward their telemetry to the gateway through its Service
+	servicePorts := make([]corev1.ServicePort, 0, len(f.ports))
+	for _, port := range f.ports {
+		servicePorts = append(servicePorts, corev1.ServicePort{
+			Name:       port.Name,
+			Port:       port.ContainerPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(int(port.ContainerPort)),
+		})
+	}
+	if err := managers.ServiceManager().AddService(
+		componentotelgateway.GetOtelAgentGatewayServiceName(f.owner),
+		f.owner.GetNamespace(),
+		componentotelgateway.GetOtelAgentGatewayServiceSelector(f.owner),
+		servicePorts,
+		nil,
+	); err != nil {
+		return err
+	}
+
+	// The node otel-agents resolve the gateway pods through the headless Service to load balance the traces by trace ID
+	headlessService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      componentotelgateway.GetOtelAgentGatewayHeadlessServiceName(f.owner),
+			Namespace: f.owner.GetNamespace(),
+		},
+		Spec: corev1.ServiceSpec{
+			Type:      corev1.ServiceTypeClusterIP,
+			ClusterIP: corev1.ClusterIPNone,
+			Selector:  componentotelgateway.GetOtelAgentGatewayServiceSelector(f.owner),
+			Ports:     servicePorts,
+		},
+	}
+	return managers.Store().AddOrUpdate(kubernetes.ServicesKind, headlessService)
+}
+
+// defaultConfigData returns the default gateway configuration, listening on the configured OTLP ports
+func (f *otelAgentGatewayFeature) defaultConfigData() string {
+	config := defaultconfig.DefaultOtelAgentGatewayConfig
+	for _, port := range f.ports {
+		switch {
+		case port.Name == otelGRPCPortName && port.ContainerPort != common.DefaultOtelGRPCPort:
+			config = strings.Replace(config, strconv.Itoa(common.DefaultOtelGRPCPort), strconv.Itoa(int(port.ContainerPort)), 1)
+		case port.Name == otelHTTPPortName && port.ContainerPort != common.DefaultOtelHTTPPort:
+			config = strings.Replace(config, strconv.Itoa(common.DefaultOtelHTTPPort), strconv.Itoa(int(port.ContainerPort)), 1)
+		}
+	}
+	return config
+}
+
+// ManageClusterAgent allows a feature to configure the ClusterAgent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *otelAgentGatewayFeature) ManageClusterAgent(managers feature.PodTemplateManagers) error {
+	return nil
+}
+
+// ManageSingleContainerNodeAgent allows a feature to configure the Agent container for the Node Agent's corev1.PodTemplateSpec
+// if SingleContainerStrategy is enabled and can be used with the configured feature set.
+// It should do nothing if the feature doesn't need to configure it.
+func (f *otelAgentGatewayFeature) ManageSingleContainerNodeAgent(managers feature.PodTemplateManagers, provider string) error {
+	return nil
+}
+
+// ManageNodeAgent allows a feature to configure the Node Agent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *otelAgentGatewayFeature) ManageNodeAgent(managers feature.PodTemplateManagers, provider string) error {
+	return nil
+}
+
+// ManageClusterChecksRunner allows a feature to configure the ClusterChecksRunner's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *otelAgentGatewayFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
+	return nil
+}
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *otelAgentGatewayFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	var vol corev1.Volume
+	if f.customConfig != nil && f.customConfig.ConfigMap != nil {
+		// Custom config is referenced via ConfigMap
+		vol = volume.GetVolumeFromConfigMap(f.customConfig.ConfigMap, f.configMapName, otelAgentGatewayVolumeName)
+	} else {
+		// Otherwise, the configMap was created in ManageDependencies
+		vol = volume.GetBasicVolume(f.configMapName, otelAgentGatewayVolumeName)
+	}
+	managers.Volume().AddVolume(&vol)
+
+	configPaths := []string{}
+	if f.customConfig != nil && f.customConfig.ConfigMap != nil && len(f.customConfig.ConfigMap.Items) > 0 {
+		for _, item := range f.customConfig.ConfigMap.Items {
+			configPaths = append(configPaths, common.ConfigVolumePath+"/otel/"+item.Path)
+		}
+		volMount := corev1.VolumeMount{
+			Name:      otelAgentGatewayVolumeName,
+			MountPath: common.ConfigVolumePath + "/otel/",
+		}
+		managers.VolumeMount().AddVolumeMountToContainer(&volMount, apicommon.OtelAgent)
+	} else {
+		configPaths = append(configPaths, common.ConfigVolumePath+"/"+otelGatewayConfigFileName)
+		volMount := volume.GetVolumeMountWithSubPath(otelAgentGatewayVolumeName, common.ConfigVolumePath+"/"+otelGatewayConfigFileName, otelGatewayConfigFileName)
+		managers.VolumeMount().AddVolumeMountToContainer(&volMount, apicommon.OtelAgent)
+	}
+
+	// Add config to otel-agent container command
+	for id, container := range managers.PodTemplateSpec().Spec.Containers {
+		if container.Name == string(apicommon.OtelAgent) {
+			for _, configPath := range configPaths {
+				managers.PodTemplateSpec().Spec.Containers[id].Command = append(managers.PodTemplateSpec().Spec.Containers[id].Command,
+					"--config="+configPath,
+				)
+			}
+		}
+	}
+
+	// Add md5 hash annotation for configMap
+	if f.customConfigAnnotationKey != "" && f.customConfigAnnotationValue != "" {
+		managers.Annotation().AddAnnotation(f.customConfigAnnotationKey, f.customConfigAnnotationValue)
+	}
+
+	// The gateway is reached through its Service: the ports are not bound to the host
+	for _, port := range f.ports {
+		containerPort := *port
+		containerPort.HostPort = 0
+		managers.Port().AddPortToContainer(apicommon.OtelAgent, &containerPort)
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package otelagentgateway
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/fake"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/otelagentgateway/defaultconfig"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/test"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	"github.com/DataDog/datadog-operator/pkg/images"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	"github.com/DataDog/datadog-operator/pkg/testutils"
+)
+
+const (
+	defaultConfigMapName = "-otel-agent-gateway-config"
+	serviceName          = "-otel-agent-gateway"
+	headlessServiceName  = "-otel-agent-gateway-headless"
+)
+
+func Test_otelAgentGatewayFeature_Configure(t *testing.T) {
+	tests := test.FeatureTestSuite{
+		{
+			Name:          "otel agent gateway not configured",
+			DDA:           testutils.NewDatadogAgentBuilder().Build(),
+			WantConfigure: false,
+		},
+		{
+			Name: "otel agent gateway disabled",
+			DDA: testutils.NewDatadogAgentBuilder().
+				WithOtelAgentGatewayEnabled(false).
+				Build(),
+			WantConfigure: false,
+		},
+		{
+			Name: "otel agent gateway enabled with the default config",
+			DDA: testutils.NewDatadogAgentBuilder().
+				WithOtelAgentGatewayEnabled(true).
+				Build(),
+			WantConfigure:        true,
+			WantDependenciesFunc: testExpectedDependencies(defaultconfig.DefaultOtelAgentGatewayConfig, 4317, 4318),
+			OtelAgentGateway:     testExpectedGateway(defaultConfigMapName, 4317, 4318, true),
+		},
+		{
+			Name: "otel agent gateway enabled with non default ports",
+			DDA: testutils.NewDatadogAgentBuilder().
+				WithOtelAgentGatewayEnabled(true).
+				WithOtelAgentGatewayPorts(4444, 5555).
+				Build(),
+			WantConfigure: true,
+			WantDependenciesFunc: testExpectedDependencies(
+				strings.Replace(strings.Replace(defaultconfig.DefaultOtelAgentGatewayConfig, "4317", "4444", 1), "4318", "5555", 1),
+				4444, 5555,
+			),
+			OtelAgentGateway: testExpectedGateway(defaultConfigMapName, 4444, 5555, true),
+		},
+		{
+			Name: "otel agent gateway enabled with configMap",
+			DDA: testutils.NewDatadogAgentBuilder().
+				WithOtelAgentGatewayEnabled(true).
+				WithOtelAgentGatewayConfigMap().
+				Build(),
+			WantConfigure: true,
+			WantDependenciesFunc: func(t testing.TB, store store.StoreClient) {
+				// configMap is provided by user, no need to create it.
+				_, found := store.Get(kubernetes.ConfigMapKind, "", defaultConfigMapName)
+				assert.False(t, found)
+				_, found = store.Get(kubernetes.ServicesKind, "", serviceName)
+				assert.True(t, found)
+			},
+			OtelAgentGateway: testExpectedGateway("user-provided-config-map", 4317, 4318, false),
+		},
+	}
+
+	tests.Run(t, buildOtelAgentGatewayFeature)
+}
+
+func Test_otelAgentGatewayFeature_RequiredComponents(t *testing.T) {
+	dda := testutils.NewDatadogAgentBuilder().
+		WithOtelAgentGatewayEnabled(true).
+		Build()
+
+	f := buildOtelAgentGatewayFeature(&feature.Options{})
+	reqComp := f.Configure(dda, &dda.Spec, nil)
+
+	assert.True(t, reqComp.OtelAgentGateway.IsEnabled())
+	assert.Equal(t, []apicommon.AgentContainerName{apicommon.OtelAgent}, reqComp.OtelAgentGateway.Containers)
+	assert.False(t, reqComp.Agent.IsEnabled())
+	assert.False(t, reqComp.ClusterAgent.IsEnabled())
+	assert.False(t, reqComp.ClusterChecksRunner.IsEnabled())
+}
+
+func testExpectedDependencies(expectedConfig string, grpcPort, httpPort int32) func(testing.TB, store.StoreClient) {
+	return func(t testing.TB, store store.StoreClient) {
+		configMapObject, found := store.Get(kubernetes.ConfigMapKind, "", defaultConfigMapName)
+		assert.True(t, found)
+		configMap := configMapObject.(*corev1.ConfigMap)
+		assert.Equal(t, map[string]string{otelGatewayConfigFileName: expectedConfig}, configMap.Data)
+
+		serviceObject, found := store.Get(kubernetes.ServicesKind, "", serviceName)
+		assert.True(t, found)
+		service := serviceObject.(*corev1.Service)
+		assert.Equal(t, []corev1.ServicePort{
+			{
+				Name:       otelGRPCPortName,
+				Port:       grpcPort,
+				Protocol:   corev1.ProtocolTCP,
+				TargetPort: intstr.FromInt(int(grpcPort)),
+			},
+			{
+				Name:       otelHTTPPortName,
+				Port:       httpPort,
+				Protocol:   corev1.ProtocolTCP,
+				TargetPort: intstr.FromInt(int(httpPort)),
+			},
+		}, service.Spec.Ports)
+		// The gateway Service keeps the default internal traffic policy
+		clusterPolicy := corev1.ServiceInternalTrafficPolicyCluster
+		assert.Equal(t, &clusterPolicy, service.Spec.InternalTrafficPolicy)
+
+		// The headless Service exposes the gateway pods to the node otel-agents load balancing exporter
+		headlessServiceObject, found := store.Get(kubernetes.ServicesKind, "", headlessServiceName)
+		assert.True(t, found)
+		headlessService := headlessServiceObject.(*corev1.Service)
+		assert.Equal(t, corev1.ClusterIPNone, headlessService.Spec.ClusterIP)
+		assert.Equal(t, service.Spec.Selector, headlessService.Spec.Selector)
+		assert.Equal(t, service.Spec.Ports, headlessService.Spec.Ports)
+	}
+}
+
+func testExpectedGateway(configMapName string, grpcPort, httpPort int32, wantAnnotation bool) *test.ComponentTest {
+	return test.NewDefaultComponentTest().WithCreateFunc(
+		func(t testing.TB) (feature.PodTemplateManagers, string) {
+			newPTS := corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:    string(apicommon.OtelAgent),
+							Image:   images.GetLatestAgentImageWithSuffix(false, false, true),
+							Command: []string{"otel-agent"},
+						},
+					},
+				},
+			}
+			return fake.NewPodTemplateManagers(t, newPTS), ""
+		},
+	).WithWantFunc(
+		func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+			mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+			assert.Equal(t, []*corev1.VolumeMount{
+				{
+					Name:      otelAgentGatewayVolumeName,
+					MountPath: common.ConfigVolumePath + "/" + otelGatewayConfigFileName,
+					SubPath:   otelGatewayConfigFileName,
+					ReadOnly:  true,
+				},
+			}, mgr.VolumeMountMgr.VolumeMountsByC[apicommon.OtelAgent])
+
+			assert.Len(t, mgr.VolumeMgr.Volumes, 1)
+			assert.Equal(t, configMapName, mgr.VolumeMgr.Volumes[0].ConfigMap.Name)
+
+			assert.Equal(t, []string{"otel-agent", "--config=" + common.ConfigVolumePath + "/" + otelGatewayConfigFileName},
+				mgr.PodTemplateSpec().Spec.Containers[0].Command)
+
+			// The ports are reached through the Service, not bound to the host
+			assert.Equal(t, []*corev1.ContainerPort{
+				{
+					Name:          otelGRPCPortName,
+					ContainerPort: grpcPort,
+					Protocol:      corev1.ProtocolTCP,
+				},
+				{
+					Name:          otelHTTPPortName,
+					ContainerPort: httpPort,
+					Protocol:      corev1.ProtocolTCP,
+				},
+			}, mgr.PortMgr.PortsByC[apicommon.OtelAgent])
+
+			_, found := mgr.AnnotationMgr.Annotations["checksum/otel_agent_gateway-custom-config"]
+			assert.Equal(t, wantAnnotation, found)
+		},
+	)
+}
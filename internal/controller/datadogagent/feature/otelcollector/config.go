@@ -0,0 +1,522 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package otelcollector
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+)
+
+const (
+	otlpReceiverName             = "otlp"
+	prometheusReceiverName       = "prometheus"
+	batchProcessorName           = "batch"
+	k8sAttributesProcessorName   = "k8sattributes"
+	infraAttributesProcessorName = "infraattributes"
+	datadogExporterName          = "datadog"
+	debugExporterName            = "debug"
+	loadBalancingExporterName    = "loadbalancing"
+	datadogConnectorName         = "datadog/connector"
+
+	tracesPipelineType  = "traces"
+	metricsPipelineType = "metrics"
+	logsPipelineType    = "logs"
+
+	defaultPrometheusScrapeInterval   = "60s"
+	defaultBatchTimeout               = "10s"
+	defaultInfraAttributesCardinality = 2
+	defaultDebugVerbosity             = "basic"
+
+	// gatewayRoutingKey routes the spans of a trace to the same gateway pod, so that the gateway computes complete trace stats
+	gatewayRoutingKey = "traceID"
+
+	// k8sAttributesNodeNameEnvVar is the env var of the otel-agent container used to only watch the pods of the node
+	k8sAttributesNodeNameEnvVar = "K8S_NODE_NAME"
+)
+
+// hasStructuredConfig returns true if the otel-agent configuration is generated from the structured configuration
+func hasStructuredConfig(config *v2alpha1.OtelCollectorFeatureConfig) bool {
+	return config.Receivers != nil || config.Processors != nil || config.Exporters != nil || len(config.Pipelines) > 0
+}
+
+// collectorConfig is the OTel Collector configuration generated from the structured configuration
+type collectorConfig struct {
+	Receivers  map[string]any `json:"receivers"`
+	Processors map[string]any `json:"processors,omitempty"`
+	Exporters  map[string]any `json:"exporters"`
+	Connectors map[string]any `json:"connectors,omitempty"`
+	Service    serviceConfig  `json:"service"`
+}
+
+type serviceConfig struct {
+	Pipelines map[string]pipelineConfig `json:"pipelines"`
+}
+
+type pipelineConfig struct {
+	Receivers  []string `json:"receivers"`
+	Processors []string `json:"processors,omitempty"`
+	Exporters  []string `json:"exporters"`
+}
+
+type otlpReceiver struct {
+	Protocols map[string]otlpProtocol `json:"protocols"`
+}
+
+type otlpProtocol struct {
+	Endpoint string `json:"endpoint"`
+}
+
+type prometheusReceiver struct {
+	Config prometheusConfig `json:"config"`
+}
+
+type prometheusConfig struct {
+	ScrapeConfigs []prometheusScrapeConfig `json:"scrape_configs"`
+}
+
+type prometheusScrapeConfig struct {
+	JobName        string                   `json:"job_name"`
+	ScrapeInterval string                   `json:"scrape_interval"`
+	StaticConfigs  []prometheusStaticConfig `json:"static_configs"`
+}
+
+type prometheusStaticConfig struct {
+	Targets []string `json:"targets"`
+}
+
+type batchProcessor struct {
+	Timeout          string `json:"timeout"`
+	SendBatchSize    *int32 `json:"send_batch_size,omitempty"`
+	SendBatchMaxSize *int32 `json:"send_batch_max_size,omitempty"`
+}
+
+type k8sAttributesProcessor struct {
+	Passthrough bool                  `json:"passthrough"`
+	Filter      k8sAttributesFilter   `json:"filter"`
+	Extract     *k8sAttributesExtract `json:"extract,omitempty"`
+}
+
+type k8sAttributesFilter struct {
+	NodeFromEnvVar string `json:"node_from_env_var"`
+}
+
+type k8sAttributesExtract struct {
+	Metadata    []string               `json:"metadata,omitempty"`
+	Labels      []k8sAttributesTagRule `json:"labels,omitempty"`
+	Annotations []k8sAttributesTagRule `json:"annotations,omitempty"`
+}
+
+type k8sAttributesTagRule struct {
+	TagName string `json:"tag_name"`
+	Key     string `json:"key"`
+	From    string `json:"from"`
+}
+
+type infraAttributesProcessor struct {
+	Cardinality int32 `json:"cardinality"`
+}
+
+type datadogExporter struct {
+	API datadogExporterAPI `json:"api"`
+}
+
+type datadogExporterAPI struct {
+	Key  string `json:"key"`
+	Site string `json:"site"`
+}
+
+type loadBalancingExporter struct {
+	RoutingKey string                        `json:"routing_key"`
+	Protocol   loadBalancingExporterProtocol `json:"protocol"`
+	Resolver   loadBalancingExporterResolver `json:"resolver"`
+}
+
+type loadBalancingExporterProtocol struct {
+	OTLP loadBalancingExporterOTLP `json:"otlp"`
+}
+
+type loadBalancingExporterOTLP struct {
+	TLS loadBalancingExporterTLS `json:"tls"`
+}
+
+type loadBalancingExporterTLS struct {
+	Insecure bool `json:"insecure"`
+}
+
+type loadBalancingExporterResolver struct {
+	DNS loadBalancingExporterDNS `json:"dns"`
+}
+
+type loadBalancingExporterDNS struct {
+	Hostname string `json:"hostname"`
+	Port     string `json:"port"`
+}
+
+type debugExporter struct {
+	Verbosity string `json:"verbosity"`
+}
+
+type datadogConnector struct {
+	Traces datadogConnectorTraces `json:"traces"`
+}
+
+type datadogConnectorTraces struct {
+	ComputeTopLevelBySpanKind bool `json:"compute_top_level_by_span_kind"`
+	PeerTagsAggregation       bool `json:"peer_tags_aggregation"`
+	ComputeStatsBySpanKind    bool `json:"compute_stats_by_span_kind"`
+}
+
+// collectorComponents contains the names of the components enabled by the structured configuration
+type collectorComponents struct {
+	receivers  []string
+	processors []string
+	exporters  []string
+}
+
+// getEnabledComponents returns the enabled components. The processors are listed in the order they run in the default pipelines.
+// When the OTel Agent Gateway is enabled, the telemetry is exported to the gateway instead of Datadog.
+func getEnabledComponents(config *v2alpha1.OtelCollectorFeatureConfig, gatewayEnabled bool) collectorComponents {
+	components := collectorComponents{}
+
+	grpc, http := getOTLPProtocols(config)
+	if isProtocolEnabled(grpc) || isProtocolEnabled(http) {
+		components.receivers = append(components.receivers, otlpReceiverName)
+	}
+	if receivers := config.Receivers; receivers == nil || receivers.Prometheus == nil || enabledByDefault(receivers.Prometheus.Enabled) {
+		components.receivers = append(components.receivers, prometheusReceiverName)
+	}
+
+	if isK8sAttributesEnabled(config) {
+		components.processors = append(components.processors, k8sAttributesProcessorName)
+	}
+	if processors := config.Processors; processors == nil || processors.InfraAttributes == nil || enabledByDefault(processors.InfraAttributes.Enabled) {
+		components.processors = append(components.processors, infraAttributesProcessorName)
+	}
+	if processors := config.Processors; processors == nil || processors.Batch == nil || enabledByDefault(processors.Batch.Enabled) {
+		components.processors = append(components.processors, batchProcessorName)
+	}
+
+	if gatewayEnabled {
+		components.exporters = append(components.exporters, loadBalancingExporterName)
+	} else {
+		components.exporters = append(components.exporters, datadogExporterName)
+	}
+	if isDebugExporterEnabled(config) {
+		components.exporters = append(components.exporters, debugExporterName)
+	}
+
+	return components
+}
+
+func getOTLPProtocols(config *v2alpha1.OtelCollectorFeatureConfig) (grpc, http *v2alpha1.OtelCollectorOTLPProtocolConfig) {
+	if config.Receivers == nil || config.Receivers.OTLP == nil {
+		return nil, nil
+	}
+	return config.Receivers.OTLP.GRPC, config.Receivers.OTLP.HTTP
+}
+
+func isProtocolEnabled(protocol *v2alpha1.OtelCollectorOTLPProtocolConfig) bool {
+	return protocol == nil || enabledByDefault(protocol.Enabled)
+}
+
+// enabledByDefault returns true if a component enabled by default is not explicitly disabled
+func enabledByDefault(enabled *bool) bool {
+	return enabled == nil || *enabled
+}
+
+func isK8sAttributesEnabled(config *v2alpha1.OtelCollectorFeatureConfig) bool {
+	return config != nil && config.Processors != nil && config.Processors.K8sAttributes != nil && apiutils.BoolValue(config.Processors.K8sAttributes.Enabled)
+}
+
+func isDebugExporterEnabled(config *v2alpha1.OtelCollectorFeatureConfig) bool {
+	return config.Exporters != nil && config.Exporters.Debug != nil && apiutils.BoolValue(config.Exporters.Debug.Enabled)
+}
+
+// getDefaultPipelines returns the traces, metrics and logs pipelines using all the enabled components.
+// The trace stats are computed by the datadog connector, unless the telemetry is exported to the OTel Agent Gateway.
+func getDefaultPipelines(components collectorComponents) map[string]pipelineConfig {
+	computeStats := slices.Contains(components.exporters, datadogExporterName)
+	otlpReceivers := []string{}
+	if slices.Contains(components.receivers, otlpReceiverName) {
+		otlpReceivers = append(otlpReceivers, otlpReceiverName)
+	}
+	metricsReceivers := slices.Clone(otlpReceivers)
+	tracesExporters := slices.Clone(components.exporters)
+	if computeStats {
+		metricsReceivers = append(metricsReceivers, datadogConnectorName)
+		tracesExporters = append(tracesExporters, datadogConnectorName)
+	}
+	if slices.Contains(components.receivers, prometheusReceiverName) {
+		metricsReceivers = append(metricsReceivers, prometheusReceiverName)
+	}
+
+	return map[string]pipelineConfig{
+		tracesPipelineType: {
+			Receivers:  otlpReceivers,
+			Processors: components.processors,
+			Exporters:  tracesExporters,
+		},
+		metricsPipelineType: {
+			Receivers:  metricsReceivers,
+			Processors: components.processors,
+			Exporters:  components.exporters,
+		},
+		logsPipelineType: {
+			Receivers:  otlpReceivers,
+			Processors: components.processors,
+			Exporters:  components.exporters,
+		},
+	}
+}
+
+// buildCollectorConfig generates the otel-agent configuration from the structured configuration.
+// The OTLP endpoints listen by default on the gRPC and HTTP ports of the otel-agent container.
+// When the OTel Agent Gateway is enabled, the telemetry is load balanced by trace ID across the gateway pods.
+func buildCollectorConfig(config *v2alpha1.OtelCollectorFeatureConfig, gatewayEnabled bool, grpcPort, httpPort int) (string, error) {
+	components := getEnabledComponents(config, gatewayEnabled)
+	collector := collectorConfig{
+		Receivers:  map[string]any{},
+		Processors: map[string]any{},
+		Exporters:  map[string]any{},
+	}
+	if !gatewayEnabled {
+		collector.Connectors = map[string]any{
+			datadogConnectorName: datadogConnector{
+				Traces: datadogConnectorTraces{
+					ComputeTopLevelBySpanKind: true,
+					PeerTagsAggregation:       true,
+					ComputeStatsBySpanKind:    true,
+				},
+			},
+		}
+	}
+
+	if slices.Contains(components.receivers, otlpReceiverName) {
+		grpc, http := getOTLPProtocols(config)
+		protocols := map[string]otlpProtocol{}
+		if isProtocolEnabled(grpc) {
+			protocols["grpc"] = otlpProtocol{Endpoint: getEndpoint(grpc, grpcPort)}
+		}
+		if isProtocolEnabled(http) {
+			protocols["http"] = otlpProtocol{Endpoint: getEndpoint(http, httpPort)}
+		}
+		collector.Receivers[otlpReceiverName] = otlpReceiver{Protocols: protocols}
+	}
+	if slices.Contains(components.receivers, prometheusReceiverName) {
+		scrapeInterval := defaultPrometheusScrapeInterval
+		if config.Receivers != nil && config.Receivers.Prometheus != nil && config.Receivers.Prometheus.ScrapeInterval != nil {
+			scrapeInterval = config.Receivers.Prometheus.ScrapeInterval.Duration.String()
+		}
+		collector.Receivers[prometheusReceiverName] = prometheusReceiver{
+			Config: prometheusConfig{
+				ScrapeConfigs: []prometheusScrapeConfig{
+					{
+						JobName:        "otelcol",
+						ScrapeInterval: scrapeInterval,
+						StaticConfigs:  []prometheusStaticConfig{{Targets: []string{"0.0.0.0:8888"}}},
+					},
+				},
+			},
+		}
+	}
+
+	for _, processor := range components.processors {
+		switch processor {
+		case k8sAttributesProcessorName:
+			collector.Processors[processor] = buildK8sAttributesProcessor(config.Processors.K8sAttributes)
+		case infraAttributesProcessorName:
+			cardinality := int32(defaultInfraAttributesCardinality)
+			if config.Processors != nil && config.Processors.InfraAttributes != nil && config.Processors.InfraAttributes.Cardinality != nil {
+				cardinality = *config.Processors.InfraAttributes.Cardinality
+			}
+			collector.Processors[processor] = infraAttributesProcessor{Cardinality: cardinality}
+		case batchProcessorName:
+			batch := batchProcessor{Timeout: defaultBatchTimeout}
+			if config.Processors != nil && config.Processors.Batch != nil {
+				if config.Processors.Batch.Timeout != nil {
+					batch.Timeout = config.Processors.Batch.Timeout.Duration.String()
+				}
+				batch.SendBatchSize = config.Processors.Batch.SendBatchSize
+				batch.SendBatchMaxSize = config.Processors.Batch.SendBatchMaxSize
+			}
+			collector.Processors[processor] = batch
+		}
+	}
+
+	if gatewayEnabled {
+		collector.Exporters[loadBalancingExporterName] = loadBalancingExporter{
+			RoutingKey: gatewayRoutingKey,
+			Protocol: loadBalancingExporterProtocol{
+				OTLP: loadBalancingExporterOTLP{TLS: loadBalancingExporterTLS{Insecure: true}},
+			},
+			Resolver: loadBalancingExporterResolver{
+				DNS: loadBalancingExporterDNS{
+					Hostname: "${env:" + DDOtelCollectorGatewayHostname + "}",
+					Port:     "${env:" + DDOtelCollectorGatewayPort + "}",
+				},
+			},
+		}
+	} else {
+		collector.Exporters[datadogExporterName] = datadogExporter{
+			API: datadogExporterAPI{
+				Key:  "${env:DD_API_KEY}",
+				Site: "${env:DD_SITE}",
+			},
+		}
+	}
+	if slices.Contains(components.exporters, debugExporterName) {
+		verbosity := defaultDebugVerbosity
+		if config.Exporters.Debug.Verbosity != nil {
+			verbosity = *config.Exporters.Debug.Verbosity
+		}
+		collector.Exporters[debugExporterName] = debugExporter{Verbosity: verbosity}
+	}
+
+	if len(config.Pipelines) == 0 {
+		collector.Service.Pipelines = getDefaultPipelines(components)
+	} else {
+		collector.Service.Pipelines = make(map[string]pipelineConfig, len(config.Pipelines))
+		for _, pipeline := range config.Pipelines {
+			collector.Service.Pipelines[pipeline.Name] = pipelineConfig{
+				Receivers:  pipeline.Receivers,
+				Processors: pipeline.Processors,
+				Exporters:  pipeline.Exporters,
+			}
+		}
+	}
+
+	out, err := yaml.Marshal(collector)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func getEndpoint(protocol *v2alpha1.OtelCollectorOTLPProtocolConfig, port int) string {
+	if protocol != nil && protocol.Endpoint != nil {
+		return *protocol.Endpoint
+	}
+	return "0.0.0.0:" + strconv.Itoa(port)
+}
+
+func buildK8sAttributesProcessor(config *v2alpha1.OtelCollectorK8sAttributesProcessorConfig) k8sAttributesProcessor {
+	processor := k8sAttributesProcessor{
+		Filter: k8sAttributesFilter{NodeFromEnvVar: k8sAttributesNodeNameEnvVar},
+	}
+	if len(config.Metadata) == 0 && len(config.PodLabels) == 0 && len(config.PodAnnotations) == 0 {
+		return processor
+	}
+
+	processor.Extract = &k8sAttributesExtract{
+		Metadata:    config.Metadata,
+		Labels:      getTagRules(config.PodLabels),
+		Annotations: getTagRules(config.PodAnnotations),
+	}
+	return processor
+}
+
+// getTagRules converts a map of pod labels or annotations to tags into sorted k8sattributes rules
+func getTagRules(tags map[string]string) []k8sAttributesTagRule {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	rules := make([]k8sAttributesTagRule, 0, len(keys))
+	for _, key := range keys {
+		rules = append(rules, k8sAttributesTagRule{TagName: tags[key], Key: key, From: "pod"})
+	}
+	return rules
+}
+
+// validateStructuredConfig checks that the structured configuration generates a valid OTel Collector configuration
+func validateStructuredConfig(config *v2alpha1.OtelCollectorFeatureConfig, gatewayEnabled bool) error {
+	var errs []error
+	components := getEnabledComponents(config, gatewayEnabled)
+
+	grpc, http := getOTLPProtocols(config)
+	if !slices.Contains(components.receivers, otlpReceiverName) {
+		errs = append(errs, fmt.Errorf("receivers.otlp: at least one of grpc and http must be enabled"))
+	}
+	for _, protocol := range []struct {
+		name   string
+		config *v2alpha1.OtelCollectorOTLPProtocolConfig
+	}{{"grpc", grpc}, {"http", http}} {
+		if protocol.config != nil && isProtocolEnabled(protocol.config) && protocol.config.Endpoint != nil {
+			if _, _, err := net.SplitHostPort(*protocol.config.Endpoint); err != nil {
+				errs = append(errs, fmt.Errorf("receivers.otlp.%s.endpoint: %w", protocol.name, err))
+			}
+		}
+	}
+
+	if config.Processors != nil && config.Processors.Batch != nil {
+		batch := config.Processors.Batch
+		if batch.SendBatchSize != nil && batch.SendBatchMaxSize != nil && *batch.SendBatchMaxSize < *batch.SendBatchSize {
+			errs = append(errs, fmt.Errorf("processors.batch: sendBatchMaxSize must be greater than or equal to sendBatchSize"))
+		}
+	}
+
+	connectorExported, connectorReceived := false, false
+	for _, pipeline := range config.Pipelines {
+		pipelineType, _, _ := strings.Cut(pipeline.Name, "/")
+		if !slices.Contains([]string{tracesPipelineType, metricsPipelineType, logsPipelineType}, pipelineType) {
+			errs = append(errs, fmt.Errorf("pipelines[%s]: the type of the pipeline must be %s, %s or %s", pipeline.Name, tracesPipelineType, metricsPipelineType, logsPipelineType))
+			continue
+		}
+		if len(pipeline.Receivers) == 0 || len(pipeline.Exporters) == 0 {
+			errs = append(errs, fmt.Errorf("pipelines[%s]: at least one receiver and one exporter are required", pipeline.Name))
+		}
+
+		for _, receiver := range pipeline.Receivers {
+			switch {
+			case receiver == datadogConnectorName && gatewayEnabled:
+				errs = append(errs, fmt.Errorf("pipelines[%s]: %s cannot be used with the OTel Agent Gateway, which computes the trace stats", pipeline.Name, datadogConnectorName))
+			case receiver == datadogConnectorName:
+				connectorReceived = true
+				if pipelineType != metricsPipelineType {
+					errs = append(errs, fmt.Errorf("pipelines[%s]: %s can only be a receiver of %s pipelines", pipeline.Name, datadogConnectorName, metricsPipelineType))
+				}
+			case !slices.Contains(components.receivers, receiver):
+				errs = append(errs, fmt.Errorf("pipelines[%s]: unknown or disabled receiver %q", pipeline.Name, receiver))
+			case receiver == prometheusReceiverName && pipelineType != metricsPipelineType:
+				errs = append(errs, fmt.Errorf("pipelines[%s]: %s can only be a receiver of %s pipelines", pipeline.Name, prometheusReceiverName, metricsPipelineType))
+			}
+		}
+		for _, processor := range pipeline.Processors {
+			if !slices.Contains(components.processors, processor) {
+				errs = append(errs, fmt.Errorf("pipelines[%s]: unknown or disabled processor %q", pipeline.Name, processor))
+			}
+		}
+		for _, exporter := range pipeline.Exporters {
+			switch {
+			case exporter == datadogConnectorName && gatewayEnabled:
+				errs = append(errs, fmt.Errorf("pipelines[%s]: %s cannot be used with the OTel Agent Gateway, which computes the trace stats", pipeline.Name, datadogConnectorName))
+			case exporter == datadogConnectorName:
+				connectorExported = true
+				if pipelineType != tracesPipelineType {
+					errs = append(errs, fmt.Errorf("pipelines[%s]: %s can only be an exporter of %s pipelines", pipeline.Name, datadogConnectorName, tracesPipelineType))
+				}
+			case !slices.Contains(components.exporters, exporter):
+				errs = append(errs, fmt.Errorf("pipelines[%s]: unknown or disabled exporter %q", pipeline.Name, exporter))
+			}
+		}
+	}
+	if connectorExported != connectorReceived {
+		errs = append(errs, fmt.Errorf("pipelines: %s must be both an exporter of a %s pipeline and a receiver of a %s pipeline", datadogConnectorName, tracesPipelineType, metricsPipelineType))
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,357 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package otelcollector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/pkg/testutils"
+)
+
+func Test_buildCollectorConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         *v2alpha1.OtelCollectorFeatureConfig
+		gatewayEnabled bool
+		grpcPort       int
+		want           string
+	}{
+		{
+			name: "default components",
+			config: &v2alpha1.OtelCollectorFeatureConfig{
+				Receivers: &v2alpha1.OtelCollectorReceiversConfig{},
+			},
+			grpcPort: 4317,
+			want: `connectors:
+  datadog/connector:
+    traces:
+      compute_stats_by_span_kind: true
+      compute_top_level_by_span_kind: true
+      peer_tags_aggregation: true
+exporters:
+  datadog:
+    api:
+      key: ${env:DD_API_KEY}
+      site: ${env:DD_SITE}
+processors:
+  batch:
+    timeout: 10s
+  infraattributes:
+    cardinality: 2
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+      http:
+        endpoint: 0.0.0.0:4318
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: otelcol
+        scrape_interval: 60s
+        static_configs:
+        - targets:
+          - 0.0.0.0:8888
+service:
+  pipelines:
+    logs:
+      exporters:
+      - datadog
+      processors:
+      - infraattributes
+      - batch
+      receivers:
+      - otlp
+    metrics:
+      exporters:
+      - datadog
+      processors:
+      - infraattributes
+      - batch
+      receivers:
+      - otlp
+      - datadog/connector
+      - prometheus
+    traces:
+      exporters:
+      - datadog
+      - datadog/connector
+      processors:
+      - infraattributes
+      - batch
+      receivers:
+      - otlp
+`,
+		},
+		{
+			name: "configured components and pipelines",
+			config: &v2alpha1.OtelCollectorFeatureConfig{
+				Receivers: &v2alpha1.OtelCollectorReceiversConfig{
+					OTLP: &v2alpha1.OtelCollectorOTLPReceiverConfig{
+						HTTP: &v2alpha1.OtelCollectorOTLPProtocolConfig{Enabled: apiutils.NewBoolPointer(false)},
+					},
+					Prometheus: &v2alpha1.OtelCollectorPrometheusReceiverConfig{Enabled: apiutils.NewBoolPointer(false)},
+				},
+				Processors: &v2alpha1.OtelCollectorProcessorsConfig{
+					Batch: &v2alpha1.OtelCollectorBatchProcessorConfig{
+						Timeout:       &metav1.Duration{Duration: 5 * time.Second},
+						SendBatchSize: apiutils.NewInt32Pointer(1000),
+					},
+					K8sAttributes: &v2alpha1.OtelCollectorK8sAttributesProcessorConfig{
+						Enabled:   apiutils.NewBoolPointer(true),
+						Metadata:  []string{"k8s.pod.name"},
+						PodLabels: map[string]string{"app": "app", "team": "team"},
+					},
+					InfraAttributes: &v2alpha1.OtelCollectorInfraAttributesProcessorConfig{Enabled: apiutils.NewBoolPointer(false)},
+				},
+				Exporters: &v2alpha1.OtelCollectorExportersConfig{
+					Debug: &v2alpha1.OtelCollectorDebugExporterConfig{Enabled: apiutils.NewBoolPointer(true)},
+				},
+				Pipelines: []v2alpha1.OtelCollectorPipeline{
+					{Name: "traces", Receivers: []string{"otlp"}, Processors: []string{"k8sattributes", "batch"}, Exporters: []string{"datadog", "debug"}},
+				},
+			},
+			grpcPort: 5317,
+			want: `connectors:
+  datadog/connector:
+    traces:
+      compute_stats_by_span_kind: true
+      compute_top_level_by_span_kind: true
+      peer_tags_aggregation: true
+exporters:
+  datadog:
+    api:
+      key: ${env:DD_API_KEY}
+      site: ${env:DD_SITE}
+  debug:
+    verbosity: basic
+processors:
+  batch:
+    send_batch_size: 1000
+    timeout: 5s
+  k8sattributes:
+    extract:
+      labels:
+      - from: pod
+        key: app
+        tag_name: app
+      - from: pod
+        key: team
+        tag_name: team
+      metadata:
+      - k8s.pod.name
+    filter:
+      node_from_env_var: K8S_NODE_NAME
+    passthrough: false
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:5317
+service:
+  pipelines:
+    traces:
+      exporters:
+      - datadog
+      - debug
+      processors:
+      - k8sattributes
+      - batch
+      receivers:
+      - otlp
+`,
+		},
+		{
+			name: "otel agent gateway enabled",
+			config: &v2alpha1.OtelCollectorFeatureConfig{
+				Receivers: &v2alpha1.OtelCollectorReceiversConfig{
+					Prometheus: &v2alpha1.OtelCollectorPrometheusReceiverConfig{Enabled: apiutils.NewBoolPointer(false)},
+				},
+			},
+			gatewayEnabled: true,
+			grpcPort:       4317,
+			want: `exporters:
+  loadbalancing:
+    protocol:
+      otlp:
+        tls:
+          insecure: true
+    resolver:
+      dns:
+        hostname: ${env:DD_OTELCOLLECTOR_GATEWAY_HOSTNAME}
+        port: ${env:DD_OTELCOLLECTOR_GATEWAY_PORT}
+    routing_key: traceID
+processors:
+  batch:
+    timeout: 10s
+  infraattributes:
+    cardinality: 2
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+      http:
+        endpoint: 0.0.0.0:4318
+service:
+  pipelines:
+    logs:
+      exporters:
+      - loadbalancing
+      processors:
+      - infraattributes
+      - batch
+      receivers:
+      - otlp
+    metrics:
+      exporters:
+      - loadbalancing
+      processors:
+      - infraattributes
+      - batch
+      receivers:
+      - otlp
+    traces:
+      exporters:
+      - loadbalancing
+      processors:
+      - infraattributes
+      - batch
+      receivers:
+      - otlp
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := buildCollectorConfig(tt.config, tt.gatewayEnabled, tt.grpcPort, 4318)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, config)
+			assert.NoError(t, validateStructuredConfig(tt.config, tt.gatewayEnabled))
+
+			// The generated configuration is valid YAML
+			var out map[string]any
+			assert.NoError(t, yaml.Unmarshal([]byte(config), &out))
+		})
+	}
+}
+
+func Test_validateStructuredConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         *v2alpha1.OtelCollectorFeatureConfig
+		gatewayEnabled bool
+		wantErr        string
+	}{
+		{
+			name: "otlp protocols disabled",
+			config: &v2alpha1.OtelCollectorFeatureConfig{
+				Receivers: &v2alpha1.OtelCollectorReceiversConfig{
+					OTLP: &v2alpha1.OtelCollectorOTLPReceiverConfig{
+						GRPC: &v2alpha1.OtelCollectorOTLPProtocolConfig{Enabled: apiutils.NewBoolPointer(false)},
+						HTTP: &v2alpha1.OtelCollectorOTLPProtocolConfig{Enabled: apiutils.NewBoolPointer(false)},
+					},
+				},
+			},
+			wantErr: "receivers.otlp: at least one of grpc and http must be enabled",
+		},
+		{
+			name: "invalid endpoint",
+			config: &v2alpha1.OtelCollectorFeatureConfig{
+				Receivers: &v2alpha1.OtelCollectorReceiversConfig{
+					OTLP: &v2alpha1.OtelCollectorOTLPReceiverConfig{
+						GRPC: &v2alpha1.OtelCollectorOTLPProtocolConfig{Endpoint: apiutils.NewStringPointer("0.0.0.0")},
+					},
+				},
+			},
+			wantErr: "receivers.otlp.grpc.endpoint: address 0.0.0.0: missing port in address",
+		},
+		{
+			name: "batch max size lower than the batch size",
+			config: &v2alpha1.OtelCollectorFeatureConfig{
+				Processors: &v2alpha1.OtelCollectorProcessorsConfig{
+					Batch: &v2alpha1.OtelCollectorBatchProcessorConfig{
+						SendBatchSize:    apiutils.NewInt32Pointer(100),
+						SendBatchMaxSize: apiutils.NewInt32Pointer(10),
+					},
+				},
+			},
+			wantErr: "processors.batch: sendBatchMaxSize must be greater than or equal to sendBatchSize",
+		},
+		{
+			name: "invalid pipeline type",
+			config: &v2alpha1.OtelCollectorFeatureConfig{
+				Pipelines: []v2alpha1.OtelCollectorPipeline{
+					{Name: "profiles", Receivers: []string{"otlp"}, Exporters: []string{"datadog"}},
+				},
+			},
+			wantErr: "pipelines[profiles]: the type of the pipeline must be traces, metrics or logs",
+		},
+		{
+			name: "disabled components",
+			config: &v2alpha1.OtelCollectorFeatureConfig{
+				Pipelines: []v2alpha1.OtelCollectorPipeline{
+					{Name: "logs/custom", Receivers: []string{"prometheus"}, Processors: []string{"k8sattributes"}, Exporters: []string{"debug"}},
+				},
+			},
+			wantErr: `[pipelines[logs/custom]: prometheus can only be a receiver of metrics pipelines, pipelines[logs/custom]: unknown or disabled processor "k8sattributes", pipelines[logs/custom]: unknown or disabled exporter "debug"]`,
+		},
+		{
+			name: "connector only used as exporter",
+			config: &v2alpha1.OtelCollectorFeatureConfig{
+				Pipelines: []v2alpha1.OtelCollectorPipeline{
+					{Name: "traces", Receivers: []string{"otlp"}, Exporters: []string{"datadog/connector"}},
+				},
+			},
+			wantErr: "pipelines: datadog/connector must be both an exporter of a traces pipeline and a receiver of a metrics pipeline",
+		},
+		{
+			name: "datadog exporter and connector with the otel agent gateway",
+			config: &v2alpha1.OtelCollectorFeatureConfig{
+				Pipelines: []v2alpha1.OtelCollectorPipeline{
+					{Name: "traces", Receivers: []string{"otlp"}, Exporters: []string{"datadog", "datadog/connector"}},
+					{Name: "metrics", Receivers: []string{"datadog/connector"}, Exporters: []string{"loadbalancing"}},
+				},
+			},
+			gatewayEnabled: true,
+			wantErr:        `[pipelines[traces]: unknown or disabled exporter "datadog", pipelines[traces]: datadog/connector cannot be used with the OTel Agent Gateway, which computes the trace stats, pipelines[metrics]: datadog/connector cannot be used with the OTel Agent Gateway, which computes the trace stats]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, validateStructuredConfig(tt.config, tt.gatewayEnabled), tt.wantErr)
+		})
+	}
+}
+
+func Test_otelCollectorFeature_Validate(t *testing.T) {
+	dda := testutils.NewDatadogAgentBuilder().
+		WithOTelCollectorEnabled(true).
+		Build()
+	dda.Spec.Features.OtelCollector.Pipelines = []v2alpha1.OtelCollectorPipeline{
+		{Name: "metrics", Receivers: []string{"jaeger"}, Exporters: []string{"datadog"}},
+	}
+
+	f := buildOtelCollectorFeature(&feature.Options{}).(*otelCollectorFeature)
+	f.Configure(dda, &dda.Spec, nil)
+	assert.EqualError(t, f.Validate(), `pipelines[metrics]: unknown or disabled receiver "jaeger"`)
+
+	// The structured configuration is ignored when conf is set
+	dda.Spec.Features.OtelCollector.Conf = &v2alpha1.CustomConfig{ConfigData: apiutils.NewStringPointer("receivers: {}")}
+	f = buildOtelCollectorFeature(&feature.Options{}).(*otelCollectorFeature)
+	f.Configure(dda, &dda.Spec, nil)
+	assert.NoError(t, f.Validate())
+}
@@ -48,3 +48,51 @@ service:
       receivers: [otlp]
       processors: [infraattributes, batch]
       exporters: [datadog]`
+
+// DefaultOtelCollectorGatewayConfig is the otel-agent default config when the OTel Agent Gateway is enabled:
+// the telemetry is load balanced by trace ID across the gateway pods, which compute the trace stats and export to Datadog.
+var DefaultOtelCollectorGatewayConfig = `
+receivers:
+  prometheus:
+    config:
+      scrape_configs:
+        - job_name: "otelcol"
+          scrape_interval: 60s
+          static_configs:
+            - targets: ["0.0.0.0:8888"]
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+      http:
+        endpoint: 0.0.0.0:4318
+exporters:
+  loadbalancing:
+    routing_key: traceID
+    protocol:
+      otlp:
+        tls:
+          insecure: true
+    resolver:
+      dns:
+        hostname: ${env:DD_OTELCOLLECTOR_GATEWAY_HOSTNAME}
+        port: ${env:DD_OTELCOLLECTOR_GATEWAY_PORT}
+processors:
+  infraattributes:
+    cardinality: 2
+  batch:
+    timeout: 10s
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [infraattributes, batch]
+      exporters: [loadbalancing]
+    metrics:
+      receivers: [otlp, prometheus]
+      processors: [infraattributes, batch]
+      exporters: [loadbalancing]
+    logs:
+      receivers: [otlp]
+      processors: [infraattributes, batch]
+      exporters: [loadbalancing]`
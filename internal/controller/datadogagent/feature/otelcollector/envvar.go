@@ -11,4 +11,6 @@ const (
 	DDOtelCollectorCoreConfigEnabled          = "DD_OTELCOLLECTOR_ENABLED"
 	DDOtelCollectorCoreConfigExtensionURL     = "DD_OTELCOLLECTOR_EXTENSION_URL"
 	DDOtelCollectorCoreConfigExtensionTimeout = "DD_OTELCOLLECTOR_EXTENSION_TIMEOUT"
+	DDOtelCollectorGatewayHostname            = "DD_OTELCOLLECTOR_GATEWAY_HOSTNAME"
+	DDOtelCollectorGatewayPort                = "DD_OTELCOLLECTOR_GATEWAY_PORT"
 )
@@ -13,6 +13,7 @@ import (
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	componentotelgateway "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/otelagentgateway"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/otelcollector/defaultconfig"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
@@ -42,11 +43,12 @@ func buildOtelCollectorFeature(options *feature.Options) feature.Feature {
 }
 
 type otelCollectorFeature struct {
-	customConfig    *v2alpha1.CustomConfig
-	owner           metav1.Object
-	configMapName   string
-	ports           []*corev1.ContainerPort
-	coreAgentConfig coreAgentConfig
+	customConfig     *v2alpha1.CustomConfig
+	structuredConfig *v2alpha1.OtelCollectorFeatureConfig
+	owner            metav1.Object
+	configMapName    string
+	ports            []*corev1.ContainerPort
+	coreAgentConfig  coreAgentConfig
 
 	customConfigAnnotationKey   string
 	customConfigAnnotationValue string
@@ -54,6 +56,12 @@ type otelCollectorFeature struct {
 	forceEnableLocalService bool
 	localServiceName        string
 
+	serviceAccountName string
+
+	// gatewayHostname and gatewayPort are the headless Service hostname and OTLP gRPC port of the OTel Agent Gateway when it is enabled
+	gatewayHostname string
+	gatewayPort     int32
+
 	logger logr.Logger
 }
 
@@ -71,6 +79,8 @@ func (o *otelCollectorFeature) Configure(dda metav1.Object, ddaSpec *v2alpha1.Da
 	o.owner = dda
 	if ddaSpec.Features.OtelCollector.Conf != nil {
 		o.customConfig = ddaSpec.Features.OtelCollector.Conf
+	} else if hasStructuredConfig(ddaSpec.Features.OtelCollector) {
+		o.structuredConfig = ddaSpec.Features.OtelCollector
 	}
 	o.configMapName = constants.GetConfName(dda, o.customConfig, defaultOTelAgentConf)
 
@@ -78,6 +88,11 @@ func (o *otelCollectorFeature) Configure(dda metav1.Object, ddaSpec *v2alpha1.Da
 		o.forceEnableLocalService = apiutils.BoolValue(ddaSpec.Global.LocalService.ForceEnableLocalService)
 	}
 	o.localServiceName = constants.GetLocalAgentServiceName(dda.GetName(), ddaSpec)
+	o.serviceAccountName = constants.GetAgentServiceAccount(dda.GetName(), ddaSpec)
+	if gatewayConfig := ddaSpec.Features.OtelAgentGateway; gatewayConfig != nil && apiutils.BoolValue(gatewayConfig.Enabled) {
+		o.gatewayHostname = componentotelgateway.GetOtelAgentGatewayHeadlessServiceHostname(dda)
+		o.gatewayPort = componentotelgateway.GetOtelAgentGatewayGRPCPort(gatewayConfig)
+	}
 
 	if ddaSpec.Features.OtelCollector.CoreConfig != nil {
 		o.coreAgentConfig.enabled = ddaSpec.Features.OtelCollector.CoreConfig.Enabled
@@ -161,8 +176,21 @@ func (o *otelCollectorFeature) ManageDependencies(managers feature.ResourceManag
 		}
 	}
 
-	if o.customConfig.ConfigData == nil && o.customConfig.ConfigMap == nil {
+	if o.customConfig.ConfigData == nil && o.customConfig.ConfigMap == nil && o.structuredConfig != nil {
+		// Invalid structured configurations are not rendered
+		if err := validateStructuredConfig(o.structuredConfig, o.gatewayEnabled()); err != nil {
+			return err
+		}
+		config, err := buildCollectorConfig(o.structuredConfig, o.gatewayEnabled(), grpcPort, httpPort)
+		if err != nil {
+			return err
+		}
+		o.customConfig.ConfigData = &config
+	} else if o.customConfig.ConfigData == nil && o.customConfig.ConfigMap == nil {
 		var defaultConfig = defaultconfig.DefaultOtelCollectorConfig
+		if o.gatewayEnabled() {
+			defaultConfig = defaultconfig.DefaultOtelCollectorGatewayConfig
+		}
 		if grpcPort != 4317 {
 			defaultConfig = strings.Replace(defaultConfig, "4317", strconv.Itoa(grpcPort), 1)
 		}
@@ -210,9 +238,29 @@ func (o *otelCollectorFeature) ManageDependencies(managers feature.ResourceManag
 		}
 	}
 
+	// The k8sattributes processor watches the pods of the node and their owners
+	if isK8sAttributesEnabled(o.structuredConfig) {
+		if err := managers.RBACManager().AddClusterPolicyRules(o.owner.GetNamespace(), getRBACResourceName(o.owner), o.serviceAccountName, getK8sAttributesRBACPolicyRules()); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// gatewayEnabled returns true if the otel-agent telemetry is forwarded to the OTel Agent Gateway
+func (o *otelCollectorFeature) gatewayEnabled() bool {
+	return o.gatewayHostname != ""
+}
+
+// Validate rejects the structured configurations generating an invalid OTel Collector configuration
+func (o *otelCollectorFeature) Validate() error {
+	if o.structuredConfig == nil {
+		return nil
+	}
+	return validateStructuredConfig(o.structuredConfig, o.gatewayEnabled())
+}
+
 func (o *otelCollectorFeature) ManageClusterAgent(managers feature.PodTemplateManagers) error {
 	return nil
 }
@@ -343,6 +391,29 @@ func (o *otelCollectorFeature) ManageNodeAgent(managers feature.PodTemplateManag
 		})
 	}
 
+	// The default and structured configurations load balance to the gateway, custom configurations can reference its hostname and port
+	if o.gatewayEnabled() {
+		managers.EnvVar().AddEnvVarToContainer(apicommon.OtelAgent, &corev1.EnvVar{
+			Name:  DDOtelCollectorGatewayHostname,
+			Value: o.gatewayHostname,
+		})
+		managers.EnvVar().AddEnvVarToContainer(apicommon.OtelAgent, &corev1.EnvVar{
+			Name:  DDOtelCollectorGatewayPort,
+			Value: strconv.Itoa(int(o.gatewayPort)),
+		})
+	}
+
+	if isK8sAttributesEnabled(o.structuredConfig) {
+		managers.EnvVar().AddEnvVarToContainer(apicommon.OtelAgent, &corev1.EnvVar{
+			Name: k8sAttributesNodeNameEnvVar,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: common.FieldPathSpecNodeName,
+				},
+			},
+		})
+	}
+
 	return nil
 }
 
@@ -353,3 +424,7 @@ func (o *otelCollectorFeature) ManageSingleContainerNodeAgent(managers feature.P
 func (o *otelCollectorFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+func (o *otelCollectorFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
@@ -374,6 +375,54 @@ func Test_otelCollectorFeature_Configure(t *testing.T) {
 				defaultVolumes(defaultLocalObjectReferenceName),
 			),
 		},
+		// gateway
+		{
+			Name: "otel agent enabled with gateway",
+			DDA: testutils.NewInitializedDatadogAgentBuilder("ns", "dda").
+				WithOTelCollectorEnabled(true).
+				WithOtelAgentGatewayEnabled(true).
+				WithOtelAgentGatewayPorts(4444, 5555).
+				Build(),
+			WantConfigure: true,
+			WantDependenciesFunc: func(t testing.TB, store store.StoreClient) {
+				configMapObject, found := store.Get(kubernetes.ConfigMapKind, "ns", "dda-otel-agent-config")
+				assert.True(t, found)
+				assert.Equal(t, defaultconfig.DefaultOtelCollectorGatewayConfig, configMapObject.(*corev1.ConfigMap).Data[otelConfigFileName])
+			},
+			Agent: test.NewDefaultComponentTest().WithWantFunc(
+				func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+					mgr := mgrInterface.(*fake.PodTemplateManagers)
+					assert.Contains(t, mgr.EnvVarMgr.EnvVarsByC[apicommon.OtelAgent], &corev1.EnvVar{
+						Name:  DDOtelCollectorGatewayHostname,
+						Value: "dda-otel-agent-gateway-headless.ns.svc",
+					})
+					assert.Contains(t, mgr.EnvVarMgr.EnvVarsByC[apicommon.OtelAgent], &corev1.EnvVar{
+						Name:  DDOtelCollectorGatewayPort,
+						Value: "4444",
+					})
+				},
+			),
+		},
+		{
+			Name: "otel agent enabled with gateway and structured config",
+			DDA: func() *v2alpha1.DatadogAgent {
+				dda := testutils.NewInitializedDatadogAgentBuilder("ns", "dda").
+					WithOTelCollectorEnabled(true).
+					WithOtelAgentGatewayEnabled(true).
+					Build()
+				dda.Spec.Features.OtelCollector.Receivers = &v2alpha1.OtelCollectorReceiversConfig{}
+				return dda
+			}(),
+			WantConfigure: true,
+			WantDependenciesFunc: func(t testing.TB, store store.StoreClient) {
+				configMapObject, found := store.Get(kubernetes.ConfigMapKind, "ns", "dda-otel-agent-config")
+				assert.True(t, found)
+				config := configMapObject.(*corev1.ConfigMap).Data[otelConfigFileName]
+				// The structured config is exported to the gateway, which computes the trace stats
+				assert.Contains(t, config, "routing_key: traceID")
+				assert.NotContains(t, config, "datadog/connector")
+			},
+		},
 	}
 	tests.Run(t, buildOtelCollectorFeature)
 }
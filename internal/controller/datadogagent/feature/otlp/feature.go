@@ -15,6 +15,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
@@ -64,6 +65,8 @@ type otlpFeature struct {
 
 	createKubernetesNetworkPolicy bool
 	createCiliumNetworkPolicy     bool
+	createCalicoNetworkPolicy     bool
+	createAdminNetworkPolicy      bool
 
 	owner metav1.Object
 }
@@ -129,9 +132,14 @@ func (f *otlpFeature) Configure(dda metav1.Object, ddaSpec *v2alpha1.DatadogAgen
 	}
 	if f.grpcEnabled || f.httpEnabled {
 		if enabled, flavor := constants.IsNetworkPolicyEnabled(ddaSpec); enabled {
-			if flavor == v2alpha1.NetworkPolicyFlavorCilium {
+			switch flavor {
+			case v2alpha1.NetworkPolicyFlavorCilium:
 				f.createCiliumNetworkPolicy = true
-			} else {
+			case v2alpha1.NetworkPolicyFlavorCalico:
+				f.createCalicoNetworkPolicy = true
+			case v2alpha1.NetworkPolicyFlavorAdminNetworkPolicy:
+				f.createAdminNetworkPolicy = true
+			default:
 				f.createKubernetesNetworkPolicy = true
 			}
 		}
@@ -140,6 +148,40 @@ func (f *otlpFeature) Configure(dda metav1.Object, ddaSpec *v2alpha1.DatadogAgen
 	return reqComp
 }
 
+// Validate checks that the OTLP endpoints can be used.
+func (f *otlpFeature) Validate() error {
+	var errs []error
+	if f.grpcEnabled {
+		if err := validateOTLPGRPCEndpoint(f.grpcEndpoint); err != nil {
+			errs = append(errs, fmt.Errorf("invalid OTLP/gRPC endpoint: %w", err))
+		} else if _, err := extractPortEndpoint(f.grpcEndpoint); err != nil {
+			errs = append(errs, fmt.Errorf("failed to extract port from OTLP/gRPC endpoint: %w", err))
+		}
+	}
+	if f.httpEnabled {
+		if _, err := extractPortEndpoint(f.httpEndpoint); err != nil {
+			errs = append(errs, fmt.Errorf("failed to extract port from OTLP/HTTP endpoint: %w", err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// HostPorts returns the host ports used by the feature.
+func (f *otlpFeature) HostPorts() map[string]int32 {
+	ports := map[string]int32{}
+	if f.grpcEnabled && f.grpcHostPortEnabled {
+		if port := hostPort(f.grpcEndpoint, f.grpcCustomHostPort); port != 0 {
+			ports[otlpGRPCPortName] = port
+		}
+	}
+	if f.httpEnabled && f.httpHostPortEnabled {
+		if port := hostPort(f.httpEndpoint, f.httpCustomHostPort); port != 0 {
+			ports[otlpHTTPPortName] = port
+		}
+	}
+	return ports
+}
+
 // ManageDependencies allows a feature to manage its dependencies.
 // Feature's dependencies should be added in the store.
 func (f *otlpFeature) ManageDependencies(managers feature.ResourceManagers) error {
@@ -223,6 +265,18 @@ func (f *otlpFeature) ManageDependencies(managers feature.ResourceManagers) erro
 			if err := managers.CiliumPolicyManager().AddCiliumPolicy(policyName, f.owner.GetNamespace(), policySpecs); err != nil {
 				return err
 			}
+		} else if f.createCalicoNetworkPolicy {
+			if err := managers.CalicoPolicyManager().AddCalicoGlobalNetworkPolicy(
+				objects.BuildCalicoIngressPolicy(f.owner, v2alpha1.NodeAgentComponentName, nil, port),
+			); err != nil {
+				return err
+			}
+		} else if f.createAdminNetworkPolicy {
+			if err := managers.AdminNetworkPolicyManager().AddAdminNetworkPolicy(
+				objects.BuildAdminNetworkPolicyIngress(f.owner, v2alpha1.NodeAgentComponentName, "ingress-otlp-grpc", nil, port),
+			); err != nil {
+				return err
+			}
 		}
 	}
 	if f.httpEnabled {
@@ -302,6 +356,18 @@ func (f *otlpFeature) ManageDependencies(managers feature.ResourceManagers) erro
 			if err := managers.CiliumPolicyManager().AddCiliumPolicy(policyName, f.owner.GetNamespace(), policySpecs); err != nil {
 				return err
 			}
+		} else if f.createCalicoNetworkPolicy {
+			if err := managers.CalicoPolicyManager().AddCalicoGlobalNetworkPolicy(
+				objects.BuildCalicoIngressPolicy(f.owner, v2alpha1.NodeAgentComponentName, nil, port),
+			); err != nil {
+				return err
+			}
+		} else if f.createAdminNetworkPolicy {
+			if err := managers.AdminNetworkPolicyManager().AddAdminNetworkPolicy(
+				objects.BuildAdminNetworkPolicyIngress(f.owner, v2alpha1.NodeAgentComponentName, "ingress-otlp-http", nil, port),
+			); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -344,6 +410,18 @@ func extractPortEndpoint(endpoint string) (int32, error) {
 	return 0, fmt.Errorf("%q does not have a port explicitly set", endpoint)
 }
 
+// hostPort returns the host port exposed for an endpoint, 0 if it cannot be determined.
+func hostPort(endpoint string, customHostPort int32) int32 {
+	if customHostPort != 0 {
+		return customHostPort
+	}
+	port, err := extractPortEndpoint(endpoint)
+	if err != nil {
+		return 0
+	}
+	return port
+}
+
 // ManageSingleContainerNodeAgent allows a feature to configure the Agent container for the Node Agent's corev1.PodTemplateSpec
 // if SingleContainerStrategy is enabled and can be used with the configured feature set.
 // It should do nothing if the feature doesn't need to configure it.
@@ -476,3 +554,9 @@ func (f *otlpFeature) ManageNodeAgent(managers feature.PodTemplateManagers, prov
 func (f *otlpFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *otlpFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
@@ -60,6 +60,15 @@ func (p *processDiscoveryFeature) Configure(_ metav1.Object, ddaSpec *v2alpha1.D
 	return reqComp
 }
 
+// HostAccess returns the host accesses of the feature.
+func (p processDiscoveryFeature) HostAccess() []string {
+	return []string{
+		feature.HostPathAccess(common.PasswdHostPath),
+		feature.HostPathAccess(common.CgroupsHostPath),
+		feature.HostPathAccess(common.ProcdirHostPath),
+	}
+}
+
 func (p processDiscoveryFeature) ManageDependencies(managers feature.ResourceManagers) error {
 	return nil
 }
@@ -124,3 +133,7 @@ func (p processDiscoveryFeature) manageNodeAgent(agentContainerName apicommon.Ag
 func (p processDiscoveryFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+func (p processDiscoveryFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
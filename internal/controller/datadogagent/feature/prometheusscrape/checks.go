@@ -0,0 +1,226 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package prometheusscrape
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+)
+
+// scrapeCheck is the Agent configuration of a Prometheus check with custom discovery rules.
+type scrapeCheck struct {
+	Autodiscovery  *scrapeAutodiscovery     `json:"autodiscovery,omitempty"`
+	Configurations []map[string]interface{} `json:"configurations"`
+}
+
+type scrapeAutodiscovery struct {
+	KubernetesAnnotations    *scrapeAnnotations `json:"kubernetes_annotations,omitempty"`
+	KubernetesContainerNames []string           `json:"kubernetes_container_names,omitempty"`
+}
+
+type scrapeAnnotations struct {
+	Include map[string]string `json:"include,omitempty"`
+	Exclude map[string]string `json:"exclude,omitempty"`
+}
+
+// Fields supported by the Agent in the `additionalConfigs` checks; the Agent silently ignores the other ones.
+var (
+	checkFields         = []string{"autodiscovery", "configurations"}
+	autodiscoveryFields = []string{"kubernetes_annotations", "kubernetes_container_names", "kubernetes_namespaces"}
+	annotationsFields   = []string{"exclude", "include"}
+)
+
+// buildScrapeChecks returns the JSON value of DD_PROMETHEUS_SCRAPE_CHECKS: the checks of `additionalConfigs` followed by the rules.
+// Like apiutils.YAMLToJSONString, it returns an empty string when the checks can't be converted.
+func buildScrapeChecks(additionalConfigs string, rules []v2alpha1.PrometheusScrapeRule, version int) string {
+	if len(rules) == 0 {
+		if additionalConfigs == "" {
+			return ""
+		}
+		return apiutils.YAMLToJSONString(additionalConfigs)
+	}
+
+	checks := []interface{}{}
+	if additionalConfigs != "" {
+		// Invalid additional configs are reported by Validate, the rules are still applied.
+		var additionalChecks []interface{}
+		if err := yaml.Unmarshal([]byte(additionalConfigs), &additionalChecks); err == nil {
+			checks = append(checks, additionalChecks...)
+		}
+	}
+	for i := range rules {
+		checks = append(checks, buildRuleCheck(&rules[i], version))
+	}
+
+	jsonValue, err := json.Marshal(checks)
+	if err != nil {
+		return ""
+	}
+	return string(jsonValue)
+}
+
+// buildRuleCheck converts a rule into the configuration of a Prometheus check.
+func buildRuleCheck(rule *v2alpha1.PrometheusScrapeRule, version int) scrapeCheck {
+	check := scrapeCheck{}
+
+	if ad := rule.Autodiscovery; ad != nil {
+		check.Autodiscovery = &scrapeAutodiscovery{
+			KubernetesContainerNames: ad.ContainerNames,
+		}
+		if len(ad.IncludeAnnotations) > 0 || len(ad.ExcludeAnnotations) > 0 {
+			check.Autodiscovery.KubernetesAnnotations = &scrapeAnnotations{
+				Include: ad.IncludeAnnotations,
+				Exclude: ad.ExcludeAnnotations,
+			}
+		}
+	}
+
+	instance := map[string]interface{}{}
+	if rule.Namespace != nil {
+		instance["namespace"] = *rule.Namespace
+	}
+
+	metrics := []interface{}{}
+	for _, metric := range rule.Metrics {
+		metrics = append(metrics, metric)
+	}
+	if len(rule.RenameMetrics) > 0 {
+		if len(metrics) == 0 {
+			// Keep collecting all the metrics, not only the renamed ones
+			metrics = append(metrics, allMetrics(version))
+		}
+		metrics = append(metrics, rule.RenameMetrics)
+	}
+	if len(metrics) > 0 {
+		instance["metrics"] = metrics
+	}
+
+	if len(rule.ExcludeMetrics) > 0 {
+		if version == 1 {
+			instance["ignore_metrics"] = rule.ExcludeMetrics
+		} else {
+			instance["exclude_metrics"] = rule.ExcludeMetrics
+		}
+	}
+
+	check.Configurations = []map[string]interface{}{instance}
+	return check
+}
+
+// allMetrics returns the pattern matching all the metrics in the given version of the OpenMetrics check.
+func allMetrics(version int) string {
+	if version == 1 {
+		return "*"
+	}
+	return ".*"
+}
+
+// validateAdditionalConfigs checks that the Agent can parse the `additionalConfigs` checks.
+// It returns a warning for each field of the checks that the Agent doesn't know and silently ignores.
+func validateAdditionalConfigs(additionalConfigs string) ([]string, error) {
+	if additionalConfigs == "" {
+		return nil, nil
+	}
+
+	var value interface{}
+	if err := yaml.Unmarshal([]byte(additionalConfigs), &value); err != nil {
+		return nil, fmt.Errorf("additionalConfigs must be valid YAML: %w", err)
+	}
+	checks, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("additionalConfigs must be a list of checks")
+	}
+
+	var warnings []string
+	for i, c := range checks {
+		path := fmt.Sprintf("additionalConfigs[%d]", i)
+		check, ok := c.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s must be an object", path)
+		}
+		warnings = append(warnings, unknownFieldWarnings(path, check, checkFields)...)
+
+		if ad, found := check["autodiscovery"]; found {
+			adObject, ok := ad.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s.autodiscovery must be an object", path)
+			}
+			warnings = append(warnings, unknownFieldWarnings(path+".autodiscovery", adObject, autodiscoveryFields)...)
+			if annotations, found := adObject["kubernetes_annotations"]; found {
+				annotationsObject, ok := annotations.(map[string]interface{})
+				if !ok {
+					return nil, fmt.Errorf("%s.autodiscovery.kubernetes_annotations must be an object", path)
+				}
+				warnings = append(warnings, unknownFieldWarnings(path+".autodiscovery.kubernetes_annotations", annotationsObject, annotationsFields)...)
+			}
+		}
+
+		if configurations, found := check["configurations"]; found {
+			instances, ok := configurations.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s.configurations must be a list of objects", path)
+			}
+			for _, instance := range instances {
+				if _, ok := instance.(map[string]interface{}); !ok {
+					return nil, fmt.Errorf("%s.configurations must be a list of objects", path)
+				}
+			}
+		}
+	}
+
+	return warnings, nil
+}
+
+// unknownFieldWarnings returns a warning for each field of the object which is not in the known fields.
+func unknownFieldWarnings(path string, object map[string]interface{}, known []string) []string {
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	// Sort the names so that the same warnings are reported for the same configuration
+	sort.Strings(names)
+
+	var warnings []string
+	for _, name := range names {
+		if !slices.Contains(known, name) {
+			warnings = append(warnings, fmt.Sprintf("%s: unknown field %q is ignored by the Agent", path, name))
+		}
+	}
+	return warnings
+}
+
+// validateRules checks that the metrics of the rules can be matched by the Agent.
+func validateRules(rules []v2alpha1.PrometheusScrapeRule, version int) error {
+	for i, rule := range rules {
+		// The OpenMetrics check v1 uses wildcards instead of regular expressions
+		if version != 1 {
+			for _, field := range []struct {
+				name    string
+				metrics []string
+			}{{"metrics", rule.Metrics}, {"excludeMetrics", rule.ExcludeMetrics}} {
+				for _, metric := range field.metrics {
+					if _, err := regexp.Compile(metric); err != nil {
+						return fmt.Errorf("rules[%d].%s: invalid regular expression %q", i, field.name, metric)
+					}
+				}
+			}
+		}
+		for name, newName := range rule.RenameMetrics {
+			if name == "" || newName == "" {
+				return fmt.Errorf("rules[%d].renameMetrics: metric names must not be empty", i)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package prometheusscrape
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/pkg/testutils"
+)
+
+func Test_buildScrapeChecks(t *testing.T) {
+	tests := []struct {
+		name              string
+		additionalConfigs string
+		rules             []v2alpha1.PrometheusScrapeRule
+		version           int
+		want              string
+	}{
+		{
+			name: "no additional configs nor rules",
+			want: "",
+		},
+		{
+			name:              "additional configs only",
+			additionalConfigs: "- configurations:\n  - timeout: 5\n",
+			want:              `[{"configurations":[{"timeout":5}]}]`,
+		},
+		{
+			name: "rule with default autodiscovery",
+			rules: []v2alpha1.PrometheusScrapeRule{
+				{Namespace: apiutils.NewStringPointer("app")},
+			},
+			want: `[{"configurations":[{"namespace":"app"}]}]`,
+		},
+		{
+			name: "rule with container names",
+			rules: []v2alpha1.PrometheusScrapeRule{
+				{
+					Autodiscovery: &v2alpha1.PrometheusScrapeAutodiscovery{
+						ExcludeAnnotations: map[string]string{"team": "infra"},
+						ContainerNames:     []string{"app"},
+					},
+				},
+			},
+			want: `[{"autodiscovery":{"kubernetes_annotations":{"exclude":{"team":"infra"}},"kubernetes_container_names":["app"]},"configurations":[{}]}]`,
+		},
+		{
+			name: "renamed metrics keep collecting all the metrics",
+			rules: []v2alpha1.PrometheusScrapeRule{
+				{RenameMetrics: map[string]string{"http_requests": "requests"}},
+			},
+			want: `[{"configurations":[{"metrics":[".*",{"http_requests":"requests"}]}]}]`,
+		},
+		{
+			name: "OpenMetrics v1",
+			rules: []v2alpha1.PrometheusScrapeRule{
+				{
+					ExcludeMetrics: []string{"go_*"},
+					RenameMetrics:  map[string]string{"http_requests": "requests"},
+				},
+			},
+			version: 1,
+			want:    `[{"configurations":[{"ignore_metrics":["go_*"],"metrics":["*",{"http_requests":"requests"}]}]}]`,
+		},
+		{
+			name:              "invalid additional configs are ignored with rules",
+			additionalConfigs: "autodiscovery: {}",
+			rules: []v2alpha1.PrometheusScrapeRule{
+				{Metrics: []string{"http_.*"}},
+			},
+			want: `[{"configurations":[{"metrics":["http_.*"]}]}]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, buildScrapeChecks(tt.additionalConfigs, tt.rules, tt.version))
+		})
+	}
+}
+
+func Test_prometheusScrapeFeature_Validate(t *testing.T) {
+	tests := []struct {
+		name              string
+		additionalConfigs string
+		rules             []v2alpha1.PrometheusScrapeRule
+		version           int
+		wantErr           string
+		wantWarnings      []string
+	}{
+		{
+			name: "valid config",
+			additionalConfigs: `
+- autodiscovery:
+    kubernetes_annotations:
+      include:
+        app: web
+    kubernetes_container_names:
+    - web
+  configurations:
+  - timeout: 5`,
+			rules: []v2alpha1.PrometheusScrapeRule{
+				{Metrics: []string{"http_.*"}, RenameMetrics: map[string]string{"http_requests": "requests"}},
+			},
+		},
+		{
+			name:              "invalid YAML",
+			additionalConfigs: "- configurations: [",
+			wantErr:           "additionalConfigs must be valid YAML",
+		},
+		{
+			name:              "not a list",
+			additionalConfigs: "configurations: []",
+			wantErr:           "additionalConfigs must be a list of checks",
+		},
+		{
+			name:              "typo in a check field",
+			additionalConfigs: "- configuration:\n  - timeout: 5",
+			wantWarnings:      []string{`additionalConfigs[0]: unknown field "configuration" is ignored by the Agent`},
+		},
+		{
+			name:              "typo in an autodiscovery field",
+			additionalConfigs: "- autodiscovery:\n    kubernetes_annotation:\n      include:\n        app: web",
+			wantWarnings:      []string{`additionalConfigs[0].autodiscovery: unknown field "kubernetes_annotation" is ignored by the Agent`},
+		},
+		{
+			name:              "typo in the annotations",
+			additionalConfigs: "- autodiscovery:\n    kubernetes_annotations:\n      includes:\n        app: web",
+			wantWarnings:      []string{`additionalConfigs[0].autodiscovery.kubernetes_annotations: unknown field "includes" is ignored by the Agent`},
+		},
+		{
+			name:              "typos in several checks",
+			additionalConfigs: "- configuration:\n  - timeout: 5\n- autodiscovery:\n    kubernetes_annotation:\n      include:\n        app: web\n    kubernetes_namespace:\n    - web",
+			wantWarnings: []string{
+				`additionalConfigs[0]: unknown field "configuration" is ignored by the Agent`,
+				`additionalConfigs[1].autodiscovery: unknown field "kubernetes_annotation" is ignored by the Agent`,
+				`additionalConfigs[1].autodiscovery: unknown field "kubernetes_namespace" is ignored by the Agent`,
+			},
+		},
+		{
+			name:              "configurations is not a list of objects",
+			additionalConfigs: "- configurations:\n    timeout: 5",
+			wantErr:           "additionalConfigs[0].configurations must be a list of objects",
+		},
+		{
+			name: "invalid metric regular expression",
+			rules: []v2alpha1.PrometheusScrapeRule{
+				{Metrics: []string{"http_.*"}},
+				{ExcludeMetrics: []string{"go_("}},
+			},
+			wantErr: `rules[1].excludeMetrics: invalid regular expression "go_("`,
+		},
+		{
+			name: "wildcards with OpenMetrics v1",
+			rules: []v2alpha1.PrometheusScrapeRule{
+				{Metrics: []string{"*_total"}},
+			},
+			version: 1,
+		},
+		{
+			name: "empty renamed metric",
+			rules: []v2alpha1.PrometheusScrapeRule{
+				{RenameMetrics: map[string]string{"http_requests": ""}},
+			},
+			wantErr: "rules[0].renameMetrics: metric names must not be empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := testutils.NewDatadogAgentBuilder().
+				WithPrometheusScrapeEnabled(true).
+				WithPrometheusScrapeAdditionalConfigs(tt.additionalConfigs).
+				WithPrometheusScrapeRules(tt.rules)
+			if tt.version != 0 {
+				builder = builder.WithPrometheusScrapeVersion(tt.version)
+			}
+			dda := builder.Build()
+
+			f := buildPrometheusScrapeFeature(&feature.Options{}).(*prometheusScrapeFeature)
+			f.Configure(dda, &dda.Spec, nil)
+
+			err := f.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantWarnings, f.Warnings())
+		})
+	}
+}
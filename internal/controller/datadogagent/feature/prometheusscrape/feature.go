@@ -33,7 +33,9 @@ func buildPrometheusScrapeFeature(options *feature.Options) feature.Feature {
 type prometheusScrapeFeature struct {
 	enableServiceEndpoints bool
 	additionalConfigs      string
+	rules                  []v2alpha1.PrometheusScrapeRule
 	openmetricsVersion     int
+	scrapeChecks           string
 }
 
 // ID returns the ID of the Feature
@@ -57,6 +59,8 @@ func (f *prometheusScrapeFeature) Configure(_ metav1.Object, ddaSpec *v2alpha1.D
 		if prometheusScrape.Version != nil {
 			f.openmetricsVersion = *prometheusScrape.Version
 		}
+		f.rules = prometheusScrape.Rules
+		f.scrapeChecks = buildScrapeChecks(f.additionalConfigs, f.rules, f.openmetricsVersion)
 		reqComp = feature.RequiredComponents{
 			Agent: feature.RequiredComponent{
 				IsRequired: apiutils.NewBoolPointer(true),
@@ -76,6 +80,21 @@ func (f *prometheusScrapeFeature) Configure(_ metav1.Object, ddaSpec *v2alpha1.D
 	return reqComp
 }
 
+// Validate checks that the Agent can use the additional configs and the rules.
+func (f *prometheusScrapeFeature) Validate() error {
+	if _, err := validateAdditionalConfigs(f.additionalConfigs); err != nil {
+		return err
+	}
+	return validateRules(f.rules, f.openmetricsVersion)
+}
+
+// Warnings reports the fields of the additional configs that the Agent doesn't know.
+// The Agent ignores them, so a typo would silently disable the scraping of the check.
+func (f *prometheusScrapeFeature) Warnings() []string {
+	warnings, _ := validateAdditionalConfigs(f.additionalConfigs)
+	return warnings
+}
+
 // ManageDependencies allows a feature to manage its dependencies.
 // Feature's dependencies should be added in the store.
 func (f *prometheusScrapeFeature) ManageDependencies(managers feature.ResourceManagers) error {
@@ -93,10 +112,10 @@ func (f *prometheusScrapeFeature) ManageClusterAgent(managers feature.PodTemplat
 		Name:  DDPrometheusScrapeServiceEndpoints,
 		Value: strconv.FormatBool(f.enableServiceEndpoints),
 	})
-	if f.additionalConfigs != "" {
+	if f.scrapeChecks != "" {
 		managers.EnvVar().AddEnvVarToContainer(apicommon.ClusterAgentContainerName, &corev1.EnvVar{
 			Name:  DDPrometheusScrapeChecks,
-			Value: apiutils.YAMLToJSONString(f.additionalConfigs),
+			Value: f.scrapeChecks,
 		})
 	}
 	if f.openmetricsVersion != 0 {
@@ -133,10 +152,10 @@ func (f *prometheusScrapeFeature) manageNodeAgent(agentContainerName apicommon.A
 		Name:  DDPrometheusScrapeServiceEndpoints,
 		Value: strconv.FormatBool(f.enableServiceEndpoints),
 	})
-	if f.additionalConfigs != "" {
+	if f.scrapeChecks != "" {
 		managers.EnvVar().AddEnvVarToContainer(agentContainerName, &corev1.EnvVar{
 			Name:  DDPrometheusScrapeChecks,
-			Value: apiutils.YAMLToJSONString(f.additionalConfigs),
+			Value: f.scrapeChecks,
 		})
 	}
 	if f.openmetricsVersion != 0 {
@@ -154,3 +173,9 @@ func (f *prometheusScrapeFeature) manageNodeAgent(agentContainerName apicommon.A
 func (f *prometheusScrapeFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *prometheusScrapeFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/fake"
@@ -36,6 +37,19 @@ func Test_prometheusScrapeFeature_Configure(t *testing.T) {
   timeout: 5`
 	jsonConfigs := `[{"autodiscovery":{"kubernetes_annotations":{"exclude":{"custom_exclude_label":"true"},"include":{"custom_include_label":"true"}},"kubernetes_container_names":["my-app"]},"configurations":[{"send_distribution_buckets":true}],"timeout":5}]`
 
+	rules := []v2alpha1.PrometheusScrapeRule{
+		{
+			Autodiscovery: &v2alpha1.PrometheusScrapeAutodiscovery{
+				IncludeAnnotations: map[string]string{"app": "web"},
+			},
+			Namespace:      apiutils.NewStringPointer("web"),
+			Metrics:        []string{"http_.*"},
+			ExcludeMetrics: []string{"http_debug_.*"},
+			RenameMetrics:  map[string]string{"http_requests": "requests"},
+		},
+	}
+	jsonConfigsWithRules := `[{"autodiscovery":{"kubernetes_annotations":{"exclude":{"custom_exclude_label":"true"},"include":{"custom_include_label":"true"}},"kubernetes_container_names":["my-app"]},"configurations":[{"send_distribution_buckets":true}],"timeout":5},{"autodiscovery":{"kubernetes_annotations":{"include":{"app":"web"}}},"configurations":[{"exclude_metrics":["http_debug_.*"],"metrics":["http_.*",{"http_requests":"requests"}],"namespace":"web"}]}]`
+
 	tests := test.FeatureTestSuite{
 		{
 			Name: "Prometheus scrape not enabled",
@@ -165,6 +179,53 @@ func Test_prometheusScrapeFeature_Configure(t *testing.T) {
 				},
 			),
 		},
+		{
+			Name: "Prometheus scrape rules",
+			DDA: testutils.NewDatadogAgentBuilder().
+				WithPrometheusScrapeEnabled(true).
+				WithPrometheusScrapeAdditionalConfigs(yamlConfigs).
+				WithPrometheusScrapeRules(rules).
+				Build(),
+			WantConfigure: true,
+			Agent: test.NewDefaultComponentTest().WithWantFunc(
+				func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+					wantEnvVars := []*corev1.EnvVar{
+						{
+							Name:  DDPrometheusScrapeEnabled,
+							Value: "true",
+						},
+						{
+							Name:  DDPrometheusScrapeServiceEndpoints,
+							Value: "false",
+						},
+						{
+							Name:  DDPrometheusScrapeChecks,
+							Value: jsonConfigsWithRules,
+						},
+					}
+					assertContainerEnvVars(t, mgrInterface, apicommon.CoreAgentContainerName, wantEnvVars)
+				},
+			),
+			ClusterAgent: test.NewDefaultComponentTest().WithWantFunc(
+				func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+					wantEnvVars := []*corev1.EnvVar{
+						{
+							Name:  DDPrometheusScrapeEnabled,
+							Value: "true",
+						},
+						{
+							Name:  DDPrometheusScrapeServiceEndpoints,
+							Value: "false",
+						},
+						{
+							Name:  DDPrometheusScrapeChecks,
+							Value: jsonConfigsWithRules,
+						},
+					}
+					assertContainerEnvVars(t, mgrInterface, apicommon.ClusterAgentContainerName, wantEnvVars)
+				},
+			),
+		},
 		{
 			Name: "version specified",
 			DDA: testutils.NewDatadogAgentBuilder().
@@ -133,3 +133,9 @@ func (f *rcFeature) ManageNodeAgent(managers feature.PodTemplateManagers, provid
 func (f *rcFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *rcFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
@@ -119,6 +119,18 @@ func mergeConfigs(ddaSpec *v2alpha1.DatadogAgentSpec, ddaRCStatus *v2alpha1.Remo
 	}
 }
 
+// HostAccess returns the host accesses of the feature.
+func (f *sbomFeature) HostAccess() []string {
+	var access []string
+	if f.containerImageUncompressedLayersSupport {
+		access = append(access, feature.HostPathAccess(containerdDirVolumePath), feature.HostPathAccess(criDirVolumePath))
+	}
+	if f.hostEnabled {
+		access = append(access, feature.HostPathAccess(common.HostRootHostPath))
+	}
+	return access
+}
+
 // ManageDependencies allows a feature to manage its dependencies.
 // Feature's dependencies should be added in the store.
 func (f *sbomFeature) ManageDependencies(managers feature.ResourceManagers) error {
@@ -220,3 +232,9 @@ func (f *sbomFeature) ManageNodeAgent(managers feature.PodTemplateManagers, prov
 func (f *sbomFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *sbomFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
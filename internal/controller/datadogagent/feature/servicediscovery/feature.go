@@ -151,3 +151,9 @@ func (f *serviceDiscoveryFeature) ManageSingleContainerNodeAgent(feature.PodTemp
 func (f *serviceDiscoveryFeature) ManageClusterChecksRunner(feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *serviceDiscoveryFeature) ManageOtelAgentGateway(feature.PodTemplateManagers) error {
+	return nil
+}
@@ -0,0 +1,95 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package feature
+
+import (
+	"context"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+const (
+	// DependenciesErrorReason is the reason of a Feature failing to manage its dependencies
+	DependenciesErrorReason = "DependenciesError"
+	// MissingConfigMapReason is the reason of a Feature using a ConfigMap that does not exist
+	MissingConfigMapReason = "MissingConfigMap"
+	// MissingRuntimeClassReason is the reason of a Feature using a RuntimeClass that does not exist
+	MissingRuntimeClassReason = "MissingRuntimeClass"
+	// InvalidConfigurationReason is the reason of a Feature rejecting its configuration
+	InvalidConfigurationReason = "InvalidConfiguration"
+	// IgnoredConfigurationReason is the reason of a Feature with a configuration partly ignored by the Agent
+	IgnoredConfigurationReason = "IgnoredConfiguration"
+	// SecurityProfileReason is the reason of a Feature blocked by the security profile
+	SecurityProfileReason = "SecurityProfile"
+)
+
+// NewStatuses returns the status of the configured, enabled and blocked Features, indexed by Feature ID.
+func NewStatuses(configuredFeatures, enabledFeatures []Feature, blockedFeatures map[IDType]string) map[string]v2alpha1.FeatureStatus {
+	if len(configuredFeatures) == 0 && len(enabledFeatures) == 0 && len(blockedFeatures) == 0 {
+		return nil
+	}
+
+	statuses := make(map[string]v2alpha1.FeatureStatus, len(configuredFeatures)+len(enabledFeatures)+len(blockedFeatures))
+	for id, message := range blockedFeatures {
+		statuses[string(id)] = v2alpha1.FeatureStatus{
+			State:   v2alpha1.FeatureStateBlocked,
+			Reason:  SecurityProfileReason,
+			Message: message,
+		}
+	}
+	for _, feat := range configuredFeatures {
+		statuses[string(feat.ID())] = v2alpha1.FeatureStatus{State: v2alpha1.FeatureStateConfigured}
+	}
+	for _, feat := range enabledFeatures {
+		statuses[string(feat.ID())] = v2alpha1.FeatureStatus{State: v2alpha1.FeatureStateEnabled}
+	}
+	return statuses
+}
+
+// SetDegraded marks a Feature as degraded in statuses.
+func SetDegraded(statuses map[string]v2alpha1.FeatureStatus, id IDType, reason, message string) {
+	if statuses == nil {
+		return
+	}
+	statuses[string(id)] = v2alpha1.FeatureStatus{
+		State:   v2alpha1.FeatureStateDegraded,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// UpdateDegradedStatuses marks the enabled Features implementing ValidatingFeature or DegradedFeature as degraded in statuses
+// if they cannot work as configured, and the ones implementing WarningFeature if the Agent ignores a part of their configuration.
+func UpdateDegradedStatuses(ctx context.Context, k8sClient client.Reader, enabledFeatures []Feature, statuses map[string]v2alpha1.FeatureStatus) {
+	for _, feat := range enabledFeatures {
+		// An invalid configuration is reported over the errors it causes when managing the dependencies
+		if validatingFeat, ok := feat.(ValidatingFeature); ok {
+			if err := validatingFeat.Validate(); err != nil {
+				SetDegraded(statuses, feat.ID(), InvalidConfigurationReason, err.Error())
+				continue
+			}
+		}
+		// A Feature that failed to manage its dependencies keeps this reason
+		if statuses[string(feat.ID())].State == v2alpha1.FeatureStateDegraded {
+			continue
+		}
+		// A Feature that cannot work is reported over the configuration ignored by the Agent
+		if degradedFeat, ok := feat.(DegradedFeature); ok {
+			if reason, message := degradedFeat.Degraded(ctx, k8sClient); reason != "" {
+				SetDegraded(statuses, feat.ID(), reason, message)
+				continue
+			}
+		}
+		if warningFeat, ok := feat.(WarningFeature); ok {
+			if warnings := warningFeat.Warnings(); len(warnings) > 0 {
+				SetDegraded(statuses, feat.ID(), IgnoredConfigurationReason, strings.Join(warnings, "; "))
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package feature
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+// statusTestFeature only implements the Feature methods used to compute the feature statuses
+type statusTestFeature struct {
+	Feature
+	id IDType
+}
+
+func (f *statusTestFeature) ID() IDType {
+	return f.id
+}
+
+type degradedTestFeature struct {
+	statusTestFeature
+	reason string
+}
+
+func (f *degradedTestFeature) Degraded(context.Context, client.Reader) (string, string) {
+	if f.reason == "" {
+		return "", ""
+	}
+	return f.reason, "degraded for testing"
+}
+
+type invalidTestFeature struct {
+	statusTestFeature
+}
+
+func (f *invalidTestFeature) Validate() error {
+	return errors.New("invalid for testing")
+}
+
+type warningTestFeature struct {
+	statusTestFeature
+}
+
+func (f *warningTestFeature) Warnings() []string {
+	return []string{"ignored field for testing", "other ignored field for testing"}
+}
+
+type degradedWarningTestFeature struct {
+	degradedTestFeature
+}
+
+func (f *degradedWarningTestFeature) Warnings() []string {
+	return []string{"ignored field for testing"}
+}
+
+func TestStatuses(t *testing.T) {
+	configured := &statusTestFeature{id: "configured"}
+	enabled := &statusTestFeature{id: "enabled"}
+	degraded := &degradedTestFeature{statusTestFeature: statusTestFeature{id: "degraded"}, reason: MissingConfigMapReason}
+	healthy := &degradedTestFeature{statusTestFeature: statusTestFeature{id: "healthy"}}
+	failingDependencies := &degradedTestFeature{statusTestFeature: statusTestFeature{id: "failing"}, reason: MissingConfigMapReason}
+	invalid := &invalidTestFeature{statusTestFeature: statusTestFeature{id: "invalid"}}
+	warning := &warningTestFeature{statusTestFeature: statusTestFeature{id: "warning"}}
+	degradedWarning := &degradedWarningTestFeature{degradedTestFeature: degradedTestFeature{statusTestFeature: statusTestFeature{id: "degraded-warning"}, reason: MissingRuntimeClassReason}}
+	healthyWarning := &degradedWarningTestFeature{degradedTestFeature: degradedTestFeature{statusTestFeature: statusTestFeature{id: "healthy-warning"}}}
+
+	assert.Nil(t, NewStatuses(nil, nil, nil))
+
+	statuses := NewStatuses([]Feature{configured}, []Feature{enabled, degraded, healthy, failingDependencies, invalid, warning, degradedWarning, healthyWarning}, map[IDType]string{"blocked": "blocked for testing"})
+	SetDegraded(statuses, failingDependencies.ID(), DependenciesErrorReason, "unable to add dependencies")
+	SetDegraded(statuses, invalid.ID(), DependenciesErrorReason, "unable to add dependencies")
+	UpdateDegradedStatuses(context.TODO(), fake.NewClientBuilder().Build(), []Feature{enabled, degraded, healthy, failingDependencies, invalid, warning, degradedWarning, healthyWarning}, statuses)
+
+	assert.Equal(t, map[string]v2alpha1.FeatureStatus{
+		"configured":       {State: v2alpha1.FeatureStateConfigured},
+		"enabled":          {State: v2alpha1.FeatureStateEnabled},
+		"degraded":         {State: v2alpha1.FeatureStateDegraded, Reason: MissingConfigMapReason, Message: "degraded for testing"},
+		"healthy":          {State: v2alpha1.FeatureStateEnabled},
+		"failing":          {State: v2alpha1.FeatureStateDegraded, Reason: DependenciesErrorReason, Message: "unable to add dependencies"},
+		"invalid":          {State: v2alpha1.FeatureStateDegraded, Reason: InvalidConfigurationReason, Message: "invalid for testing"},
+		"warning":          {State: v2alpha1.FeatureStateDegraded, Reason: IgnoredConfigurationReason, Message: "ignored field for testing; other ignored field for testing"},
+		"degraded-warning": {State: v2alpha1.FeatureStateDegraded, Reason: MissingRuntimeClassReason, Message: "degraded for testing"},
+		"healthy-warning":  {State: v2alpha1.FeatureStateDegraded, Reason: IgnoredConfigurationReason, Message: "ignored field for testing"},
+		"blocked":          {State: v2alpha1.FeatureStateBlocked, Reason: SecurityProfileReason, Message: "blocked for testing"},
+	}, statuses)
+}
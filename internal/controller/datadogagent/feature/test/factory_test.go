@@ -26,6 +26,7 @@ func TestBuilder(t *testing.T) {
 		featureOptions         feature.Options
 		wantCoreAgentComponent bool
 		wantAgentContainer     map[common.AgentContainerName]bool
+		wantBlockedFeatures    []feature.IDType
 	}{
 		{
 			name: "Default DDA",
@@ -259,14 +260,64 @@ func TestBuilder(t *testing.T) {
 				common.AgentDataPlaneContainerName:          false,
 			},
 		},
+		{
+			name: "GPU monitoring enabled, restricted security profile",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithGPUMonitoringEnabled(true).
+				WithSecurityProfile(v2alpha1.RestrictedSecurityProfile).
+				BuildWithDefaults(),
+			wantAgentContainer: map[common.AgentContainerName]bool{
+				common.UnprivilegedSingleAgentContainerName: false,
+				common.CoreAgentContainerName:               false,
+				common.ProcessAgentContainerName:            false,
+				common.TraceAgentContainerName:              false,
+				common.SystemProbeContainerName:             false,
+				common.SecurityAgentContainerName:           false,
+				common.OtelAgent:                            false,
+				common.AgentDataPlaneContainerName:          false,
+			},
+			wantBlockedFeatures: []feature.IDType{feature.APMIDType, feature.GPUIDType, feature.LiveContainerIDType},
+		},
+		{
+			name: "APM enabled with a host port, restricted security profile",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithAPMEnabled(true).
+				WithAPMUDSEnabled(false, "/var/run/datadog/apm.socket").
+				WithAPMHostPortEnabled(true, nil).
+				WithLiveContainerCollectionEnabled(false).
+				WithSecurityProfile(v2alpha1.RestrictedSecurityProfile).
+				BuildWithDefaults(),
+			wantAgentContainer: map[common.AgentContainerName]bool{
+				common.TraceAgentContainerName: false,
+			},
+			wantBlockedFeatures: []feature.IDType{feature.APMIDType},
+		},
+		{
+			name: "APM enabled without host access, restricted security profile",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithAPMEnabled(true).
+				WithAPMUDSEnabled(false, "/var/run/datadog/apm.socket").
+				WithAPMHostPortEnabled(false, nil).
+				WithLiveContainerCollectionEnabled(false).
+				WithSecurityProfile(v2alpha1.RestrictedSecurityProfile).
+				BuildWithDefaults(),
+			wantAgentContainer: map[common.AgentContainerName]bool{
+				common.TraceAgentContainerName: true,
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, _, requiredComponents := feature.BuildFeatures(tt.dda, &tt.dda.Spec, tt.dda.Status.RemoteConfigConfiguration, &tt.featureOptions)
+			_, _, requiredComponents, blockedFeatures := feature.BuildFeatures(tt.dda, &tt.dda.Spec, tt.dda.Status.RemoteConfigConfiguration, &tt.featureOptions)
 
 			assert.True(t, *requiredComponents.Agent.IsRequired)
 
+			assert.Len(t, blockedFeatures, len(tt.wantBlockedFeatures))
+			for _, id := range tt.wantBlockedFeatures {
+				assert.Contains(t, blockedFeatures, id)
+			}
+
 			for name, required := range tt.wantAgentContainer {
 				assert.Equal(t, required, wantAgentContainer(name, requiredComponents), "container %s", name)
 			}
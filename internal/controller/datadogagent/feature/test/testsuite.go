@@ -44,6 +44,7 @@ type FeatureTest struct {
 	Agent               *ComponentTest
 	ClusterAgent        *ComponentTest
 	ClusterChecksRunner *ComponentTest
+	OtelAgentGateway    *ComponentTest
 	// Want
 	WantConfigure             bool
 	WantManageDependenciesErr bool
@@ -53,7 +54,7 @@ type FeatureTest struct {
 // Options use to provide some option to the test.
 type Options struct{}
 
-// ComponentTest use to configure how to test a component (Cluster-Agent, Agent, ClusterChecksRunner)
+// ComponentTest use to configure how to test a component (Cluster-Agent, Agent, ClusterChecksRunner, OtelAgentGateway)
 type ComponentTest struct {
 	CreateFunc func(testing.TB) (feature.PodTemplateManagers, string)
 	WantFunc   func(testing.TB, feature.PodTemplateManagers)
@@ -123,7 +124,7 @@ func runTest(t *testing.T, tt FeatureTest) {
 	if tt.DDA != nil {
 		var configuredFeatures []feature.Feature
 		var enabledFeatures []feature.Feature
-		configuredFeatures, enabledFeatures, gotConfigure = feature.BuildFeatures(tt.DDA, &tt.DDA.Spec, tt.DDA.Status.RemoteConfigConfiguration, featureOptions)
+		configuredFeatures, enabledFeatures, gotConfigure, _ = feature.BuildFeatures(tt.DDA, &tt.DDA.Spec, tt.DDA.Status.RemoteConfigConfiguration, featureOptions)
 		features = append(configuredFeatures, enabledFeatures...)
 		dda = tt.DDA
 	} else {
@@ -169,6 +170,12 @@ func runTest(t *testing.T, tt FeatureTest) {
 			_ = feat.ManageClusterChecksRunner(tplManager)
 			tt.ClusterChecksRunner.WantFunc(t, tplManager)
 		}
+
+		if tt.OtelAgentGateway != nil {
+			tplManager, _ := tt.OtelAgentGateway.CreateFunc(t)
+			_ = feat.ManageOtelAgentGateway(tplManager)
+			tt.OtelAgentGateway.WantFunc(t, tplManager)
+		}
 	}
 }
 
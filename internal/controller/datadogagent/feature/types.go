@@ -6,11 +6,15 @@
 package feature
 
 import (
+	"context"
+
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/merger"
@@ -22,18 +26,19 @@ type RequiredComponents struct {
 	ClusterAgent        RequiredComponent
 	Agent               RequiredComponent
 	ClusterChecksRunner RequiredComponent
+	OtelAgentGateway    RequiredComponent
 }
 
 // IsEnabled returns true if any of the components need to be enabled
 func (rc *RequiredComponents) IsEnabled() bool {
-	return rc.ClusterAgent.IsEnabled() || rc.Agent.IsEnabled() || rc.ClusterChecksRunner.IsEnabled()
+	return rc.ClusterAgent.IsEnabled() || rc.Agent.IsEnabled() || rc.ClusterChecksRunner.IsEnabled() || rc.OtelAgentGateway.IsEnabled()
 }
 
 // IsConfigured returns true if any of the components need to be configured even if not enabled
 // Can be used for features that may require configuration even when disabled
 // Example: setting an env var to false to disable the feature
 func (rc *RequiredComponents) IsConfigured() bool {
-	return rc.ClusterAgent.IsConfigured() || rc.Agent.IsConfigured() || rc.ClusterChecksRunner.IsConfigured()
+	return rc.ClusterAgent.IsConfigured() || rc.Agent.IsConfigured() || rc.ClusterChecksRunner.IsConfigured() || rc.OtelAgentGateway.IsConfigured()
 }
 
 // Merge use to merge 2 RequiredComponents
@@ -43,6 +48,7 @@ func (rc *RequiredComponents) Merge(in *RequiredComponents) *RequiredComponents
 	rc.ClusterAgent.Merge(&in.ClusterAgent)
 	rc.Agent.Merge(&in.Agent)
 	rc.ClusterChecksRunner.Merge(&in.ClusterChecksRunner)
+	rc.OtelAgentGateway.Merge(&in.OtelAgentGateway)
 	return rc
 }
 
@@ -70,12 +76,18 @@ func (rc *RequiredComponent) IsConfigured() bool {
 
 // IsPrivileged checks whether component requires privileged access.
 func (rc *RequiredComponent) IsPrivileged() bool {
+	return len(rc.PrivilegedContainers()) > 0
+}
+
+// PrivilegedContainers returns the containers of the component requiring privileged access.
+func (rc *RequiredComponent) PrivilegedContainers() []common.AgentContainerName {
+	var privileged []common.AgentContainerName
 	for _, container := range rc.Containers {
 		if container == common.SecurityAgentContainerName || container == common.SystemProbeContainerName {
-			return true
+			privileged = append(privileged, container)
 		}
 	}
-	return false
+	return privileged
 }
 
 func (rc *RequiredComponent) SingleContainerStrategyEnabled() bool {
@@ -147,11 +159,64 @@ type Feature interface {
 	// ManageClusterChecksRunner allows a feature to configure the ClusterChecksRunnerAgent's corev1.PodTemplateSpec
 	// It should do nothing if the feature doesn't need to configure it.
 	ManageClusterChecksRunner(managers PodTemplateManagers) error
+	// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+	// It should do nothing if the feature doesn't need to configure it.
+	ManageOtelAgentGateway(managers PodTemplateManagers) error
+}
+
+// ValidatingFeature is an optional interface a Feature can implement to reject a configuration
+// it cannot apply. Validate is called after Configure.
+type ValidatingFeature interface {
+	Validate() error
+}
+
+// WarningFeature is an optional interface a Feature can implement to report a configuration it can apply
+// but which is likely a mistake, for instance fields ignored by the Agent.
+type WarningFeature interface {
+	// Warnings returns the warnings about the configuration of the Feature.
+	// It is called after Configure.
+	Warnings() []string
+}
+
+// HostPortFeature is an optional interface a Feature can implement when it exposes ports on the host.
+// It is used to detect host port collisions between features.
+type HostPortFeature interface {
+	// HostPorts returns the host ports used by the Feature, keyed by port name.
+	// It is called after Configure.
+	HostPorts() map[string]int32
+}
+
+// HostAccessFeature is an optional interface a Feature can implement when it accesses the host
+// through hostPath volumes or host namespaces.
+// It is used to block the Feature when the security profile does not allow it.
+type HostAccessFeature interface {
+	// HostAccess returns the host accesses of the Feature, e.g. "hostPath /var/log/pods" or "hostPID".
+	// It is called after Configure.
+	HostAccess() []string
+}
+
+// HostPIDAccess is the host access of a Feature using the host PID namespace.
+const HostPIDAccess = "hostPID"
+
+// HostPathAccess returns the host access of a Feature mounting a hostPath volume.
+func HostPathAccess(path string) string {
+	return "hostPath " + path
+}
+
+// DegradedFeature is an optional interface a Feature can implement to report that it cannot work as configured,
+// for instance because a resource it needs does not exist in the cluster.
+type DegradedFeature interface {
+	// Degraded returns a CamelCase reason and a message if the Feature is degraded, or an empty reason otherwise.
+	// It is called after ManageDependencies, for the enabled Features. k8sClient reads from the API server:
+	// the checked resources are not in the cache of the operator.
+	Degraded(ctx context.Context, k8sClient client.Reader) (reason string, message string)
 }
 
 // Options option that can be pass to the Interface.Configure function
 type Options struct {
 	Logger logr.Logger
+	// DatadogChecks are the valid DatadogCheck resources to configure in the Agents.
+	DatadogChecks []v1alpha1.DatadogCheck
 }
 
 // BuildFunc function type used by each Feature during its factory registration.
@@ -166,33 +231,45 @@ type ResourceManagers interface {
 	NetworkPolicyManager() merger.NetworkPolicyManager
 	ServiceManager() merger.ServiceManager
 	CiliumPolicyManager() merger.CiliumPolicyManager
+	CalicoPolicyManager() merger.CalicoPolicyManager
+	AdminNetworkPolicyManager() merger.AdminNetworkPolicyManager
 	ConfigMapManager() merger.ConfigMapManager
 	APIServiceManager() merger.APIServiceManager
+	CertManagerManager() merger.CertManagerManager
+	SeccompProfileManager() merger.SeccompProfileManager
 }
 
 // NewResourceManagers return new instance of the ResourceManagers interface
 func NewResourceManagers(store store.StoreClient) ResourceManagers {
 	return &resourceManagersImpl{
-		store:         store,
-		rbac:          merger.NewRBACManager(store),
-		secret:        merger.NewSecretManager(store),
-		networkPolicy: merger.NewNetworkPolicyManager(store),
-		service:       merger.NewServiceManager(store),
-		cilium:        merger.NewCiliumPolicyManager(store),
-		configMap:     merger.NewConfigMapManager(store),
-		apiService:    merger.NewAPIServiceManager(store),
+		store:              store,
+		rbac:               merger.NewRBACManager(store),
+		secret:             merger.NewSecretManager(store),
+		networkPolicy:      merger.NewNetworkPolicyManager(store),
+		service:            merger.NewServiceManager(store),
+		cilium:             merger.NewCiliumPolicyManager(store),
+		calico:             merger.NewCalicoPolicyManager(store),
+		adminNetworkPolicy: merger.NewAdminNetworkPolicyManager(store),
+		configMap:          merger.NewConfigMapManager(store),
+		apiService:         merger.NewAPIServiceManager(store),
+		certManager:        merger.NewCertManagerManager(store),
+		seccompProfile:     merger.NewSeccompProfileManager(store),
 	}
 }
 
 type resourceManagersImpl struct {
-	store         store.StoreClient
-	rbac          merger.RBACManager
-	secret        merger.SecretManager
-	networkPolicy merger.NetworkPolicyManager
-	service       merger.ServiceManager
-	cilium        merger.CiliumPolicyManager
-	configMap     merger.ConfigMapManager
-	apiService    merger.APIServiceManager
+	store              store.StoreClient
+	rbac               merger.RBACManager
+	secret             merger.SecretManager
+	networkPolicy      merger.NetworkPolicyManager
+	service            merger.ServiceManager
+	cilium             merger.CiliumPolicyManager
+	calico             merger.CalicoPolicyManager
+	adminNetworkPolicy merger.AdminNetworkPolicyManager
+	configMap          merger.ConfigMapManager
+	apiService         merger.APIServiceManager
+	certManager        merger.CertManagerManager
+	seccompProfile     merger.SeccompProfileManager
 }
 
 func (impl *resourceManagersImpl) Store() store.StoreClient {
@@ -219,6 +296,14 @@ func (impl *resourceManagersImpl) CiliumPolicyManager() merger.CiliumPolicyManag
 	return impl.cilium
 }
 
+func (impl *resourceManagersImpl) CalicoPolicyManager() merger.CalicoPolicyManager {
+	return impl.calico
+}
+
+func (impl *resourceManagersImpl) AdminNetworkPolicyManager() merger.AdminNetworkPolicyManager {
+	return impl.adminNetworkPolicy
+}
+
 func (impl *resourceManagersImpl) ConfigMapManager() merger.ConfigMapManager {
 	return impl.configMap
 }
@@ -227,6 +312,14 @@ func (impl *resourceManagersImpl) APIServiceManager() merger.APIServiceManager {
 	return impl.apiService
 }
 
+func (impl *resourceManagersImpl) CertManagerManager() merger.CertManagerManager {
+	return impl.certManager
+}
+
+func (impl *resourceManagersImpl) SeccompProfileManager() merger.SeccompProfileManager {
+	return impl.seccompProfile
+}
+
 // PodTemplateManagers used to access the different PodTemplateSpec manager.
 type PodTemplateManagers interface {
 	// PodTemplateSpec used to access directly the PodTemplateSpec.
@@ -182,3 +182,9 @@ func (f *usmFeature) ManageNodeAgent(managers feature.PodTemplateManagers, provi
 func (f *usmFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
 	return nil
 }
+
+// ManageOtelAgentGateway allows a feature to configure the OTel Agent Gateway's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *usmFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return nil
+}
@@ -0,0 +1,104 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package feature
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+// ValidateFeatures builds the features of a DatadogAgent and checks that their configuration can be applied together.
+// It also returns the warnings of the features about a configuration they can apply.
+// The ddaSpec is expected to be defaulted.
+func ValidateFeatures(dda metav1.Object, ddaSpec *v2alpha1.DatadogAgentSpec, ddaRCStatus *v2alpha1.RemoteConfigConfiguration, options *Options) ([]string, error) {
+	_, enabledFeatures, requiredComponents, _ := BuildFeatures(dda, ddaSpec, ddaRCStatus, options)
+
+	var warnings []string
+	var errs []error
+	// hostPorts keeps track of the feature using each host port
+	hostPorts := map[int32]string{}
+	for _, feat := range enabledFeatures {
+		if validatingFeat, ok := feat.(ValidatingFeature); ok {
+			if err := validatingFeat.Validate(); err != nil {
+				errs = append(errs, fmt.Errorf("feature %s: %w", feat.ID(), err))
+			}
+		}
+		if warningFeat, ok := feat.(WarningFeature); ok {
+			for _, warning := range warningFeat.Warnings() {
+				warnings = append(warnings, fmt.Sprintf("feature %s: %s", feat.ID(), warning))
+			}
+		}
+
+		hostPortFeat, ok := feat.(HostPortFeature)
+		if !ok {
+			continue
+		}
+		ports := hostPortFeat.HostPorts()
+		names := make([]string, 0, len(ports))
+		for name := range ports {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			port := ports[name]
+			user := fmt.Sprintf("%s/%s", feat.ID(), name)
+			if other, found := hostPorts[port]; found {
+				errs = append(errs, fmt.Errorf("host port %d is used by both %s and %s", port, other, user))
+				continue
+			}
+			hostPorts[port] = user
+		}
+	}
+
+	if ddaSpec.Global != nil &&
+		ddaSpec.Global.ContainerStrategy != nil &&
+		*ddaSpec.Global.ContainerStrategy == v2alpha1.SingleContainerStrategy &&
+		requiredComponents.Agent.IsEnabled() &&
+		requiredComponents.Agent.IsPrivileged() {
+		errs = append(errs, fmt.Errorf("container strategy %q cannot be used with features requiring privileged containers: %s", v2alpha1.SingleContainerStrategy, joinContainerNames(requiredComponents.Agent.PrivilegedContainers())))
+	}
+
+	return warnings, utilerrors.NewAggregate(errs)
+}
+
+// restrictedProfileViolations returns what an enabled feature requires that the restricted security profile does not allow:
+// privileged containers, host ports and host accesses.
+func restrictedProfileViolations(feat Feature, reqComponents *RequiredComponents) []string {
+	var violations []string
+	if reqComponents.Agent.IsPrivileged() {
+		violations = append(violations, fmt.Sprintf("privileged containers %s", joinContainerNames(reqComponents.Agent.PrivilegedContainers())))
+	}
+	if hostPortFeat, ok := feat.(HostPortFeature); ok {
+		ports := hostPortFeat.HostPorts()
+		names := make([]string, 0, len(ports))
+		for name := range ports {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			violations = append(violations, fmt.Sprintf("hostPort %d", ports[name]))
+		}
+	}
+	if hostAccessFeat, ok := feat.(HostAccessFeature); ok {
+		violations = append(violations, hostAccessFeat.HostAccess()...)
+	}
+	return violations
+}
+
+func joinContainerNames(containers []common.AgentContainerName) string {
+	names := make([]string, 0, len(containers))
+	for _, container := range containers {
+		names = append(names, string(container))
+	}
+	return strings.Join(names, ", ")
+}
@@ -77,6 +77,10 @@ func (r *Reconciler) finalizeDadV2(reqLogger logr.Logger, obj client.Object) err
 		return err
 	}
 
+	if err := r.nodePartitionCleanup(context.TODO(), obj.GetUID()); err != nil {
+		return err
+	}
+
 	reqLogger.Info("Successfully finalized DatadogAgent")
 	return nil
 }
@@ -137,6 +141,14 @@ func (r *Reconciler) cleanUpClusterLevelResources(reqLogger logr.Logger, dda cli
 	deleteObjectsForResource(r.client, dda, kubernetes.ObjectFromKind(kubernetes.ClusterRolesKind, r.platformInfo))
 	deleteObjectsForResource(r.client, dda, kubernetes.ObjectFromKind(kubernetes.ClusterRoleBindingKind, r.platformInfo))
 	deleteObjectsForResource(r.client, dda, kubernetes.ObjectFromKind(kubernetes.APIServiceKind, r.platformInfo))
+	deleteObjectsForResource(r.client, dda, kubernetes.ObjectFromKind(kubernetes.MutatingWebhookConfigurationsKind, r.platformInfo))
+	deleteObjectsForResource(r.client, dda, kubernetes.ObjectFromKind(kubernetes.ValidatingWebhookConfigurationsKind, r.platformInfo))
+	if r.platformInfo.IsCalicoGlobalNetworkPolicySupported() {
+		deleteObjectsForResource(r.client, dda, kubernetes.ObjectFromKind(kubernetes.CalicoGlobalNetworkPoliciesKind, r.platformInfo))
+	}
+	if r.platformInfo.IsAdminNetworkPolicySupported() {
+		deleteObjectsForResource(r.client, dda, kubernetes.ObjectFromKind(kubernetes.AdminNetworkPoliciesKind, r.platformInfo))
+	}
 
 	return nil
 }
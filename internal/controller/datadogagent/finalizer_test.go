@@ -15,6 +15,7 @@ import (
 	"github.com/DataDog/datadog-operator/pkg/kubernetes/rbac"
 
 	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -136,6 +137,18 @@ func Test_handleFinalizer(t *testing.T) {
 		initialKubeObjects = append(initialKubeObjects, node)
 	}
 
+	// The admission controller webhook configurations are cluster-scoped as well
+	webhookLabels := map[string]string{
+		"operator.datadoghq.com/managed-by-store": "true",
+		"app.kubernetes.io/part-of":               "foo-bar",
+		"app.kubernetes.io/managed-by":            "datadog-operator",
+	}
+	existingWebhookConfigurations := []client.Object{
+		&admissionregistrationv1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: component.GetClusterAgentName(dda) + "-webhook", Labels: webhookLabels}},
+		&admissionregistrationv1.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: component.GetClusterAgentName(dda) + "-webhook", Labels: webhookLabels}},
+	}
+	initialKubeObjects = append(initialKubeObjects, existingWebhookConfigurations...)
+
 	reconciler := reconcilerForFinalizerTest(initialKubeObjects)
 
 	_, err := reconciler.handleFinalizer(logf.Log.WithName("Handle Finalizer V2 test"), dda, reconciler.finalizeDadV2)
@@ -159,6 +172,12 @@ func Test_handleFinalizer(t *testing.T) {
 		}
 	}
 
+	// Check that the webhook configurations associated with the Datadog Agent have been deleted
+	for _, webhookConfiguration := range existingWebhookConfigurations {
+		err = reconciler.client.Get(context.TODO(), client.ObjectKeyFromObject(webhookConfiguration), webhookConfiguration.DeepCopyObject().(client.Object))
+		assert.True(t, apierrors.IsNotFound(err), fmt.Sprintf("%T %s not deleted", webhookConfiguration, webhookConfiguration.GetName()))
+	}
+
 	// Check that the nodes don't have the profile label anymore
 	for _, node := range nodes {
 		currentNode := &corev1.Node{}
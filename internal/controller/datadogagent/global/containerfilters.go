@@ -0,0 +1,45 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package global
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+)
+
+// applyContainerFilters sets the container include and exclude filters on all the containers of a pod template spec
+func applyContainerFilters(manager feature.PodTemplateManagers, ddaSpec *v2alpha1.DatadogAgentSpec) {
+	filters := ddaSpec.Global.ContainerFilters
+	if filters == nil {
+		return
+	}
+
+	addContainerFilterEnvVars(manager, filters.Global, common.DDContainerInclude, common.DDContainerExclude)
+	addContainerFilterEnvVars(manager, filters.Metrics, common.DDContainerIncludeMetrics, common.DDContainerExcludeMetrics)
+	addContainerFilterEnvVars(manager, filters.Logs, common.DDContainerIncludeLogs, common.DDContainerExcludeLogs)
+}
+
+func addContainerFilterEnvVars(manager feature.PodTemplateManagers, rules *v2alpha1.ContainerFilterRules, includeEnvVarName, excludeEnvVarName string) {
+	if rules == nil {
+		return
+	}
+
+	if include := common.GetContainerFilter(rules.Include); include != "" {
+		manager.EnvVar().AddEnvVar(&corev1.EnvVar{
+			Name:  includeEnvVarName,
+			Value: include,
+		})
+	}
+	if exclude := common.GetContainerFilter(rules.Exclude); exclude != "" {
+		manager.EnvVar().AddEnvVar(&corev1.EnvVar{
+			Name:  excludeEnvVarName,
+			Value: exclude,
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package global
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/fake"
+	"github.com/DataDog/datadog-operator/pkg/testutils"
+)
+
+func Test_applyContainerFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		dda     *v2alpha1.DatadogAgent
+		wantEnv []*corev1.EnvVar
+	}{
+		{
+			name:    "no container filters",
+			dda:     testutils.NewDatadogAgentBuilder().Build(),
+			wantEnv: nil,
+		},
+		{
+			name: "global, metrics and logs container filters",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithGlobalContainerFilters(&v2alpha1.ContainerFiltersConfig{
+					Global: &v2alpha1.ContainerFilterRules{
+						Exclude: &v2alpha1.ContainerFilter{
+							Namespaces: []string{"^kube-system$"},
+							Images:     []string{"^pause$"},
+						},
+					},
+					Metrics: &v2alpha1.ContainerFilterRules{
+						Include: &v2alpha1.ContainerFilter{Names: []string{"^app$"}},
+						Exclude: &v2alpha1.ContainerFilter{Names: []string{"^sidecar$"}},
+					},
+					Logs: &v2alpha1.ContainerFilterRules{
+						Include: &v2alpha1.ContainerFilter{Namespaces: []string{"^prod$", "^staging$"}},
+					},
+				}).
+				Build(),
+			wantEnv: []*corev1.EnvVar{
+				{Name: common.DDContainerExclude, Value: "kube_namespace:^kube-system$ image:^pause$"},
+				{Name: common.DDContainerIncludeMetrics, Value: "name:^app$"},
+				{Name: common.DDContainerExcludeMetrics, Value: "name:^sidecar$"},
+				{Name: common.DDContainerIncludeLogs, Value: "kube_namespace:^prod$ kube_namespace:^staging$"},
+			},
+		},
+		{
+			name: "empty filter rules",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithGlobalContainerFilters(&v2alpha1.ContainerFiltersConfig{
+					Global: &v2alpha1.ContainerFilterRules{
+						Include: &v2alpha1.ContainerFilter{},
+					},
+				}).
+				Build(),
+			wantEnv: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := fake.NewPodTemplateManagers(t, corev1.PodTemplateSpec{})
+
+			applyContainerFilters(manager, &tt.dda.Spec)
+
+			envVars := manager.EnvVarMgr.EnvVarsByC[apicommon.AllContainers]
+			assert.ElementsMatch(t, tt.wantEnv, envVars, "envvars \ndiff = %s", cmp.Diff(tt.wantEnv, envVars))
+		})
+	}
+}
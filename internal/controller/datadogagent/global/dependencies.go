@@ -12,6 +12,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
@@ -21,6 +22,7 @@ import (
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/clusteragent"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/clusterchecksrunner"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/objects"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/otelagentgateway"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
 	"github.com/DataDog/datadog-operator/pkg/constants"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils"
@@ -72,9 +74,13 @@ func addComponentDependencies(logger logr.Logger, ddaMeta metav1.Object, ddaSpec
 	if componentName == v2alpha1.NodeAgentComponentName {
 		// Create a configmap for the default seccomp profile in the System Probe.
 		// This is mounted in the init-volume container in the agent default code.
+		// With the Security Profiles Operator, the profile is created as a SeccompProfile instead.
 		for _, containerName := range rc.Containers {
 			if containerName == apicommon.SystemProbeContainerName {
-				if !useSystemProbeCustomSeccomp(ddaSpec) {
+				platformInfo := manager.Store().GetPlatformInfo()
+				if UseSystemProbeSecurityProfilesOperator(ddaSpec, &platformInfo) {
+					errs = append(errs, addSystemProbeSeccompProfile(ddaMeta, manager))
+				} else if !useSystemProbeCustomSeccomp(ddaSpec) {
 					errs = append(errs, manager.ConfigMapManager().AddConfigMap(
 						common.GetDefaultSeccompConfigMapName(ddaMeta),
 						ddaMeta.GetNamespace(),
@@ -231,6 +237,8 @@ func rbacDependencies(ddaMeta metav1.Object, ddaSpec *v2alpha1.DatadogAgentSpec,
 		return nodeAgentDependencies(ddaMeta, ddaSpec, manager)
 	case v2alpha1.ClusterChecksRunnerComponentName:
 		return clusterChecksRunnerDependencies(ddaMeta, ddaSpec, manager)
+	case v2alpha1.OtelAgentGatewayComponentName:
+		return otelAgentGatewayDependencies(ddaMeta, ddaSpec, manager)
 	}
 
 	return nil
@@ -304,24 +312,70 @@ func clusterChecksRunnerDependencies(ddaMeta metav1.Object, ddaSpec *v2alpha1.Da
 	return nil
 }
 
+func otelAgentGatewayDependencies(ddaMeta metav1.Object, ddaSpec *v2alpha1.DatadogAgentSpec, manager feature.ResourceManagers) error {
+	var errs []error
+	serviceAccountName := constants.GetOtelAgentGatewayServiceAccount(ddaMeta.GetName(), ddaSpec)
+	rbacResourcesName := otelagentgateway.GetOtelAgentGatewayRbacResourcesName(ddaMeta)
+
+	// Service account
+	if err := manager.RBACManager().AddServiceAccountByComponent(ddaMeta.GetNamespace(), serviceAccountName, string(v2alpha1.OtelAgentGatewayComponentName)); err != nil {
+		errs = append(errs, err)
+	}
+
+	// ClusterRole creation
+	if err := manager.RBACManager().AddClusterPolicyRulesByComponent(ddaMeta.GetNamespace(), rbacResourcesName, serviceAccountName, otelagentgateway.GetDefaultOtelAgentGatewayClusterRolePolicyRules(), string(v2alpha1.OtelAgentGatewayComponentName)); err != nil {
+		errs = append(errs, err)
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
 func addNetworkPolicyDependencies(ddaMeta metav1.Object, ddaSpec *v2alpha1.DatadogAgentSpec, manager feature.ResourceManagers, componentName v2alpha1.ComponentName) error {
 	config := ddaSpec.Global
 	if enabled, flavor := constants.IsNetworkPolicyEnabled(ddaSpec); enabled {
 		switch flavor {
 		case v2alpha1.NetworkPolicyFlavorKubernetes:
-			return manager.NetworkPolicyManager().AddKubernetesNetworkPolicy(objects.BuildKubernetesNetworkPolicy(ddaMeta, componentName))
+			name, namespace, podSelector, policyTypes, ingress, egress := objects.BuildKubernetesNetworkPolicy(ddaMeta, componentName)
+			extraIngress, extraEgress := objects.BuildKubernetesNetworkPolicyExtraRules(
+				config.NetworkPolicy.ExtraIngress[componentName],
+				config.NetworkPolicy.ExtraEgress[componentName],
+			)
+			return manager.NetworkPolicyManager().AddKubernetesNetworkPolicy(name, namespace, podSelector, policyTypes, append(ingress, extraIngress...), append(egress, extraEgress...))
 		case v2alpha1.NetworkPolicyFlavorCilium:
 			var dnsSelectorEndpoints []metav1.LabelSelector
 			if config.NetworkPolicy.DNSSelectorEndpoints != nil {
 				dnsSelectorEndpoints = config.NetworkPolicy.DNSSelectorEndpoints
 			}
-			return manager.CiliumPolicyManager().AddCiliumPolicy(
-				objects.BuildCiliumPolicy(
+			name, namespace, policySpecs := objects.BuildCiliumPolicy(
+				ddaMeta,
+				*config.Site,
+				getURLEndpoint(ddaSpec),
+				constants.IsHostNetworkEnabled(ddaSpec, v2alpha1.ClusterAgentComponentName),
+				dnsSelectorEndpoints,
+				componentName,
+			)
+			policySpecs = append(policySpecs, objects.BuildCiliumPolicyExtraRules(
+				ddaMeta,
+				componentName,
+				config.NetworkPolicy.ExtraIngress[componentName],
+				config.NetworkPolicy.ExtraEgress[componentName],
+			)...)
+			return manager.CiliumPolicyManager().AddCiliumPolicy(name, namespace, policySpecs)
+		case v2alpha1.NetworkPolicyFlavorCalico:
+			return manager.CalicoPolicyManager().AddCalicoGlobalNetworkPolicy(
+				objects.BuildCalicoPolicy(
+					ddaMeta,
+					constants.IsHostNetworkEnabled(ddaSpec, v2alpha1.ClusterAgentComponentName),
+					componentName,
+				),
+			)
+		case v2alpha1.NetworkPolicyFlavorAdminNetworkPolicy:
+			return manager.AdminNetworkPolicyManager().AddAdminNetworkPolicy(
+				objects.BuildAdminNetworkPolicy(
 					ddaMeta,
 					*config.Site,
 					getURLEndpoint(ddaSpec),
 					constants.IsHostNetworkEnabled(ddaSpec, v2alpha1.ClusterAgentComponentName),
-					dnsSelectorEndpoints,
 					componentName,
 				),
 			)
@@ -342,6 +396,8 @@ func addSecretBackendDependencies(logger logr.Logger, ddaMeta metav1.Object, dda
 			componentSaName = constants.GetAgentServiceAccount(ddaMeta.GetName(), ddaSpec)
 		case v2alpha1.ClusterChecksRunnerComponentName:
 			componentSaName = constants.GetClusterChecksRunnerServiceAccount(ddaMeta.GetName(), ddaSpec)
+		case v2alpha1.OtelAgentGatewayComponentName:
+			componentSaName = constants.GetOtelAgentGatewayServiceAccount(ddaMeta.GetName(), ddaSpec)
 		}
 
 		agentName := ddaMeta.GetName()
@@ -42,6 +42,7 @@ func ApplyGlobalSettingsClusterAgent(logger logr.Logger, manager feature.PodTemp
 	resourcesManager feature.ResourceManagers, requiredComponents feature.RequiredComponents) {
 	applyGlobalSettings(logger, manager, ddaMeta, ddaSpec, resourcesManager, requiredComponents)
 	applyClusterAgentResources(manager, ddaSpec)
+	applyContainerFilters(manager, ddaSpec)
 }
 
 // ApplyGlobalSettingsClusterChecksRunner applies the global settings for the ClusterChecksRunner component.
@@ -49,6 +50,13 @@ func ApplyGlobalSettingsClusterChecksRunner(logger logr.Logger, manager feature.
 	resourcesManager feature.ResourceManagers, requiredComponents feature.RequiredComponents) {
 	applyGlobalSettings(logger, manager, ddaMeta, ddaSpec, resourcesManager, requiredComponents)
 	applyClusterChecksRunnerResources(manager, ddaSpec)
+	applyContainerFilters(manager, ddaSpec)
+}
+
+// ApplyGlobalSettingsOtelAgentGateway applies the global settings for the OtelAgentGateway component.
+func ApplyGlobalSettingsOtelAgentGateway(logger logr.Logger, manager feature.PodTemplateManagers, ddaMeta metav1.Object, ddaSpec *v2alpha1.DatadogAgentSpec,
+	resourcesManager feature.ResourceManagers, requiredComponents feature.RequiredComponents) {
+	applyGlobalSettings(logger, manager, ddaMeta, ddaSpec, resourcesManager, requiredComponents)
 }
 
 // ApplyGlobalSettingsNodeAgent applies the global settings for the NodeAgent component.
@@ -56,6 +64,9 @@ func ApplyGlobalSettingsNodeAgent(logger logr.Logger, manager feature.PodTemplat
 	resourcesManager feature.ResourceManagers, singleContainerStrategyEnabled bool, requiredComponents feature.RequiredComponents) {
 	applyGlobalSettings(logger, manager, ddaMeta, ddaSpec, resourcesManager, requiredComponents)
 	applyNodeAgentResources(manager, ddaSpec, singleContainerStrategyEnabled)
+	applyContainerFilters(manager, ddaSpec)
+	platformInfo := resourcesManager.Store().GetPlatformInfo()
+	applySystemProbeSeccompProfile(manager, ddaMeta, ddaSpec, &platformInfo)
 }
 
 // ApplyGlobalSettings use to apply global setting to a PodTemplateSpec
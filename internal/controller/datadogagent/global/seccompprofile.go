@@ -0,0 +1,79 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package global
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/agent"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/pkg/images"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	securityprofilesoperator "github.com/DataDog/datadog-operator/pkg/securityprofilesoperator/v1beta1"
+)
+
+// addSystemProbeSeccompProfile creates the SeccompProfile of the default System Probe seccomp profile.
+// The profile is annotated with the Agent version it was written for, to detect a drift with the Agent image.
+func addSystemProbeSeccompProfile(ddaMeta metav1.Object, manager feature.ResourceManagers) error {
+	profileSpec, err := agent.DefaultSeccompProfileSpecForSystemProbe()
+	if err != nil {
+		return err
+	}
+	return manager.SeccompProfileManager().AddSeccompProfile(
+		ddaMeta.GetNamespace(),
+		common.GetDefaultSeccompProfileName(ddaMeta),
+		map[string]string{common.SeccompProfileAgentVersionAnnotationKey: images.AgentLatestVersion},
+		profileSpec,
+	)
+}
+
+// applySystemProbeSeccompProfile makes the System Probe container use the SeccompProfile installed by the
+// Security Profiles Operator. The seccomp-setup init container and its volumes are not needed anymore.
+func applySystemProbeSeccompProfile(manager feature.PodTemplateManagers, ddaMeta metav1.Object, ddaSpec *v2alpha1.DatadogAgentSpec, platformInfo *kubernetes.PlatformInfo) {
+	if !UseSystemProbeSecurityProfilesOperator(ddaSpec, platformInfo) {
+		return
+	}
+
+	podSpec := &manager.PodTemplateSpec().Spec
+	found := false
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name != string(apicommon.SystemProbeContainerName) {
+			continue
+		}
+		found = true
+		if podSpec.Containers[i].SecurityContext == nil {
+			podSpec.Containers[i].SecurityContext = &corev1.SecurityContext{}
+		}
+		podSpec.Containers[i].SecurityContext.SeccompProfile = &corev1.SeccompProfile{
+			Type:             corev1.SeccompProfileTypeLocalhost,
+			LocalhostProfile: apiutils.NewStringPointer(securityprofilesoperator.LocalhostProfile(ddaMeta.GetNamespace(), common.GetDefaultSeccompProfileName(ddaMeta))),
+		}
+	}
+	if !found {
+		return
+	}
+
+	initContainers := podSpec.InitContainers[:0]
+	for _, initContainer := range podSpec.InitContainers {
+		if initContainer.Name != string(apicommon.SeccompSetupContainerName) {
+			initContainers = append(initContainers, initContainer)
+		}
+	}
+	podSpec.InitContainers = initContainers
+
+	volumes := podSpec.Volumes[:0]
+	for _, volume := range podSpec.Volumes {
+		if volume.Name != common.SeccompRootVolumeName && volume.Name != common.SeccompSecurityVolumeName {
+			volumes = append(volumes, volume)
+		}
+	}
+	podSpec.Volumes = volumes
+}
@@ -0,0 +1,143 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package global
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/agent"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/fake"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	securityprofilesoperator "github.com/DataDog/datadog-operator/pkg/securityprofilesoperator/v1beta1"
+)
+
+func Test_applySystemProbeSeccompProfile(t *testing.T) {
+	ddaMeta := &metav1.ObjectMeta{Name: "foo", Namespace: "bar"}
+	newPodTemplate := func() corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{Name: string(apicommon.InitConfigContainerName)},
+					{Name: string(apicommon.SeccompSetupContainerName)},
+				},
+				Containers: []corev1.Container{
+					{Name: string(apicommon.CoreAgentContainerName)},
+					{
+						Name: string(apicommon.SystemProbeContainerName),
+						SecurityContext: &corev1.SecurityContext{
+							SeccompProfile: &corev1.SeccompProfile{
+								Type:             corev1.SeccompProfileTypeLocalhost,
+								LocalhostProfile: apiutils.NewStringPointer(common.SystemProbeSeccompProfileName),
+							},
+						},
+					},
+				},
+				Volumes: []corev1.Volume{
+					{Name: common.ConfigVolumeName},
+					{Name: common.SeccompSecurityVolumeName},
+					{Name: common.SeccompRootVolumeName},
+				},
+			},
+		}
+	}
+	newSpec := func(seccompConfig *v2alpha1.SeccompConfig) *v2alpha1.DatadogAgentSpec {
+		return &v2alpha1.DatadogAgentSpec{
+			Override: map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride{
+				v2alpha1.NodeAgentComponentName: {
+					Containers: map[apicommon.AgentContainerName]*v2alpha1.DatadogAgentGenericContainer{
+						apicommon.SystemProbeContainerName: {
+							SeccompConfig: seccompConfig,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	supportedPlatformInfo := kubernetes.NewPlatformInfoFromVersionMaps(nil, map[string]string{
+		securityprofilesoperator.SeccompProfileKind: securityprofilesoperator.GroupVersion.String(),
+	}, nil)
+
+	tests := []struct {
+		name         string
+		ddaSpec      *v2alpha1.DatadogAgentSpec
+		platformInfo kubernetes.PlatformInfo
+		want         func() corev1.PodTemplateSpec
+	}{
+		{
+			name:         "security profiles operator not enabled",
+			ddaSpec:      &v2alpha1.DatadogAgentSpec{},
+			platformInfo: supportedPlatformInfo,
+			want:         newPodTemplate,
+		},
+		{
+			name: "custom profile takes precedence",
+			ddaSpec: newSpec(&v2alpha1.SeccompConfig{
+				SecurityProfilesOperator: apiutils.NewBoolPointer(true),
+				CustomProfile: &v2alpha1.CustomConfig{
+					ConfigMap: &v2alpha1.ConfigMapConfig{Name: "custom-seccomp"},
+				},
+			}),
+			platformInfo: supportedPlatformInfo,
+			want:         newPodTemplate,
+		},
+		{
+			name:    "security profiles operator enabled, SeccompProfile API not served",
+			ddaSpec: newSpec(&v2alpha1.SeccompConfig{SecurityProfilesOperator: apiutils.NewBoolPointer(true)}),
+			platformInfo: kubernetes.NewPlatformInfoFromVersionMaps(nil, map[string]string{
+				securityprofilesoperator.SeccompProfileKind: "example.com/v1",
+			}, nil),
+			want: newPodTemplate,
+		},
+		{
+			name:         "security profiles operator enabled",
+			ddaSpec:      newSpec(&v2alpha1.SeccompConfig{SecurityProfilesOperator: apiutils.NewBoolPointer(true)}),
+			platformInfo: supportedPlatformInfo,
+			want: func() corev1.PodTemplateSpec {
+				podTemplate := newPodTemplate()
+				podTemplate.Spec.InitContainers = podTemplate.Spec.InitContainers[:1]
+				podTemplate.Spec.Containers[1].SecurityContext.SeccompProfile.LocalhostProfile = apiutils.NewStringPointer("operator/bar/foo-system-probe-seccomp.json")
+				podTemplate.Spec.Volumes = podTemplate.Spec.Volumes[:1]
+				return podTemplate
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := fake.NewPodTemplateManagers(t, newPodTemplate())
+
+			applySystemProbeSeccompProfile(manager, ddaMeta, tt.ddaSpec, &tt.platformInfo)
+
+			assert.Equal(t, tt.want(), *manager.PodTemplateSpec())
+		})
+	}
+}
+
+func Test_DefaultSeccompProfileSpecForSystemProbe(t *testing.T) {
+	profileSpec, err := agent.DefaultSeccompProfileSpecForSystemProbe()
+	require.NoError(t, err)
+
+	assert.Equal(t, "SCMP_ACT_ERRNO", profileSpec.DefaultAction)
+	require.NotEmpty(t, profileSpec.Syscalls)
+	for _, syscall := range profileSpec.Syscalls {
+		if len(syscall.Names) == 1 && syscall.Names[0] == "setns" {
+			assert.Len(t, syscall.Args, 1)
+			assert.Equal(t, uint64(1073741824), syscall.Args[0].Value)
+			return
+		}
+	}
+	t.Error("missing setns syscall in the default system-probe seccomp profile")
+}
@@ -0,0 +1,134 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package global
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+)
+
+const (
+	// appArmorAnnotationPrefix is the prefix of the deprecated AppArmor container annotations
+	appArmorAnnotationPrefix = "container.apparmor.security.beta.kubernetes.io/"
+	appArmorUnconfined       = "unconfined"
+	// netBindServiceCapability is the only capability containers can add with the Pod Security Standard `restricted`
+	netBindServiceCapability corev1.Capability = "NET_BIND_SERVICE"
+	// restrictedRunAsUser is the UID of the non-root `dd-agent` user of the Datadog images
+	restrictedRunAsUser int64 = 100
+)
+
+// ApplySecurityProfile sets security contexts compliant with the Pod Security Standard `restricted` on the pod
+// and its containers when the restricted security profile is selected.
+// It also removes the hostPath volumes, the host ports and the host namespaces, which the features requiring them
+// are blocked for, but the default pod templates still use.
+// It must be applied last, after the features and the overrides, which can add privileges back to the pod template.
+func ApplySecurityProfile(manager feature.PodTemplateManagers, ddaSpec *v2alpha1.DatadogAgentSpec) {
+	if !constants.IsRestrictedSecurityProfile(ddaSpec) {
+		return
+	}
+
+	podTemplate := manager.PodTemplateSpec()
+	if podTemplate.Spec.SecurityContext == nil {
+		podTemplate.Spec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	podSecurityContext := podTemplate.Spec.SecurityContext
+	podSecurityContext.RunAsNonRoot = apiutils.NewBoolPointer(true)
+	if podSecurityContext.RunAsUser == nil || *podSecurityContext.RunAsUser == 0 {
+		podSecurityContext.RunAsUser = apiutils.NewInt64Pointer(restrictedRunAsUser)
+	}
+	// A Localhost seccomp profile set by an override is allowed
+	if podSecurityContext.SeccompProfile == nil || podSecurityContext.SeccompProfile.Type != corev1.SeccompProfileTypeLocalhost {
+		podSecurityContext.SeccompProfile = &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		}
+	}
+	if podSecurityContext.AppArmorProfile != nil && podSecurityContext.AppArmorProfile.Type == corev1.AppArmorProfileTypeUnconfined {
+		podSecurityContext.AppArmorProfile = nil
+	}
+
+	for key, value := range podTemplate.Annotations {
+		if strings.HasPrefix(key, appArmorAnnotationPrefix) && value == appArmorUnconfined {
+			delete(podTemplate.Annotations, key)
+		}
+	}
+
+	podTemplate.Spec.HostNetwork = false
+	podTemplate.Spec.HostPID = false
+	podTemplate.Spec.HostIPC = false
+
+	hostPathVolumes := map[string]struct{}{}
+	volumes := podTemplate.Spec.Volumes[:0]
+	for _, volume := range podTemplate.Spec.Volumes {
+		if volume.HostPath != nil {
+			hostPathVolumes[volume.Name] = struct{}{}
+			continue
+		}
+		volumes = append(volumes, volume)
+	}
+	podTemplate.Spec.Volumes = volumes
+
+	for i := range podTemplate.Spec.InitContainers {
+		restrictContainer(&podTemplate.Spec.InitContainers[i], hostPathVolumes)
+	}
+	for i := range podTemplate.Spec.Containers {
+		restrictContainer(&podTemplate.Spec.Containers[i], hostPathVolumes)
+	}
+}
+
+func restrictContainer(container *corev1.Container, hostPathVolumes map[string]struct{}) {
+	volumeMounts := container.VolumeMounts[:0]
+	for _, volumeMount := range container.VolumeMounts {
+		if _, found := hostPathVolumes[volumeMount.Name]; !found {
+			volumeMounts = append(volumeMounts, volumeMount)
+		}
+	}
+	container.VolumeMounts = volumeMounts
+
+	for i := range container.Ports {
+		container.Ports[i].HostPort = 0
+	}
+
+	restrictContainerSecurityContext(container)
+}
+
+func restrictContainerSecurityContext(container *corev1.Container) {
+	if container.SecurityContext == nil {
+		container.SecurityContext = &corev1.SecurityContext{}
+	}
+	securityContext := container.SecurityContext
+	securityContext.Privileged = nil
+	securityContext.AllowPrivilegeEscalation = apiutils.NewBoolPointer(false)
+	securityContext.RunAsNonRoot = apiutils.NewBoolPointer(true)
+	if securityContext.RunAsUser != nil && *securityContext.RunAsUser == 0 {
+		securityContext.RunAsUser = nil
+	}
+	// The container inherits the RuntimeDefault seccomp profile of the pod
+	if securityContext.SeccompProfile != nil && securityContext.SeccompProfile.Type == corev1.SeccompProfileTypeUnconfined {
+		securityContext.SeccompProfile = nil
+	}
+	if securityContext.AppArmorProfile != nil && securityContext.AppArmorProfile.Type == corev1.AppArmorProfileTypeUnconfined {
+		securityContext.AppArmorProfile = nil
+	}
+
+	var capabilities []corev1.Capability
+	if securityContext.Capabilities != nil {
+		for _, capability := range securityContext.Capabilities.Add {
+			if capability == netBindServiceCapability {
+				capabilities = append(capabilities, capability)
+			}
+		}
+	}
+	securityContext.Capabilities = &corev1.Capabilities{
+		Add:  capabilities,
+		Drop: []corev1.Capability{"ALL"},
+	}
+}
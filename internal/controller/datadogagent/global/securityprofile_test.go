@@ -0,0 +1,132 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package global
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/fake"
+)
+
+func Test_ApplySecurityProfile(t *testing.T) {
+	newPodTemplate := func() corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					appArmorAnnotationPrefix + string(apicommon.CoreAgentContainerName): appArmorUnconfined,
+				},
+			},
+			Spec: corev1.PodSpec{
+				HostPID: true,
+				Volumes: []corev1.Volume{
+					{Name: "config", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+					{Name: "procdir", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/proc"}}},
+				},
+				InitContainers: []corev1.Container{
+					{
+						Name: string(apicommon.InitConfigContainerName),
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "config", MountPath: "/etc/datadog-agent"},
+							{Name: "procdir", MountPath: "/host/proc"},
+						},
+					},
+				},
+				Containers: []corev1.Container{
+					{
+						Name: string(apicommon.CoreAgentContainerName),
+						Ports: []corev1.ContainerPort{
+							{Name: "dogstatsdport", ContainerPort: 8125, HostPort: 8125},
+						},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "procdir", MountPath: "/host/proc"},
+						},
+						SecurityContext: &corev1.SecurityContext{
+							RunAsUser: apiutils.NewInt64Pointer(0),
+							Capabilities: &corev1.Capabilities{
+								Add: []corev1.Capability{"SYS_ADMIN", netBindServiceCapability},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	restrictedContainerSecurityContext := func(capabilities ...corev1.Capability) *corev1.SecurityContext {
+		return &corev1.SecurityContext{
+			AllowPrivilegeEscalation: apiutils.NewBoolPointer(false),
+			RunAsNonRoot:             apiutils.NewBoolPointer(true),
+			Capabilities: &corev1.Capabilities{
+				Add:  capabilities,
+				Drop: []corev1.Capability{"ALL"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		profile *v2alpha1.SecurityProfileType
+		want    func() corev1.PodTemplateSpec
+	}{
+		{
+			name: "no security profile",
+			want: newPodTemplate,
+		},
+		{
+			name:    "default security profile",
+			profile: securityProfilePointer(v2alpha1.DefaultSecurityProfile),
+			want:    newPodTemplate,
+		},
+		{
+			name:    "restricted security profile",
+			profile: securityProfilePointer(v2alpha1.RestrictedSecurityProfile),
+			want: func() corev1.PodTemplateSpec {
+				podTemplate := newPodTemplate()
+				podTemplate.Annotations = map[string]string{}
+				podTemplate.Spec.SecurityContext = &corev1.PodSecurityContext{
+					RunAsNonRoot: apiutils.NewBoolPointer(true),
+					RunAsUser:    apiutils.NewInt64Pointer(restrictedRunAsUser),
+					SeccompProfile: &corev1.SeccompProfile{
+						Type: corev1.SeccompProfileTypeRuntimeDefault,
+					},
+				}
+				podTemplate.Spec.HostPID = false
+				podTemplate.Spec.Volumes = podTemplate.Spec.Volumes[:1]
+				podTemplate.Spec.InitContainers[0].VolumeMounts = podTemplate.Spec.InitContainers[0].VolumeMounts[:1]
+				podTemplate.Spec.Containers[0].VolumeMounts = []corev1.VolumeMount{}
+				podTemplate.Spec.Containers[0].Ports[0].HostPort = 0
+				podTemplate.Spec.InitContainers[0].SecurityContext = restrictedContainerSecurityContext()
+				podTemplate.Spec.Containers[0].SecurityContext = restrictedContainerSecurityContext(netBindServiceCapability)
+				return podTemplate
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ddaSpec := &v2alpha1.DatadogAgentSpec{
+				Global: &v2alpha1.GlobalConfig{
+					SecurityProfile: tt.profile,
+				},
+			}
+			manager := fake.NewPodTemplateManagers(t, newPodTemplate())
+
+			ApplySecurityProfile(manager, ddaSpec)
+
+			assert.Equal(t, tt.want(), *manager.PodTemplateSpec())
+		})
+	}
+}
+
+func securityProfilePointer(profile v2alpha1.SecurityProfileType) *v2alpha1.SecurityProfileType {
+	return &profile
+}
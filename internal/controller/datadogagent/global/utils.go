@@ -13,9 +13,12 @@ import (
 
 	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
 	"github.com/DataDog/datadog-operator/pkg/secrets"
+	securityprofilesoperator "github.com/DataDog/datadog-operator/pkg/securityprofilesoperator/v1beta1"
 	"github.com/DataDog/datadog-operator/pkg/version"
 )
 
@@ -55,6 +58,28 @@ func useSystemProbeCustomSeccomp(ddaSpec *v2alpha1.DatadogAgentSpec) bool {
 	return false
 }
 
+// UseSystemProbeSecurityProfilesOperator returns true if the default System Probe seccomp profile
+// is managed with the Security Profiles Operator, which requires its SeccompProfile API to be served by the cluster.
+// Otherwise, the profile is copied by the seccomp-setup init container.
+func UseSystemProbeSecurityProfilesOperator(ddaSpec *v2alpha1.DatadogAgentSpec, platformInfo *kubernetes.PlatformInfo) bool {
+	return IsSystemProbeSecurityProfilesOperatorEnabled(ddaSpec) &&
+		platformInfo.IsResourceVersionSupported(securityprofilesoperator.SeccompProfileKind, securityprofilesoperator.GroupVersion)
+}
+
+// IsSystemProbeSecurityProfilesOperatorEnabled returns true if the Security Profiles Operator is enabled
+// for the default System Probe seccomp profile
+func IsSystemProbeSecurityProfilesOperatorEnabled(ddaSpec *v2alpha1.DatadogAgentSpec) bool {
+	if useSystemProbeCustomSeccomp(ddaSpec) {
+		return false
+	}
+	if componentOverride, ok := ddaSpec.Override[v2alpha1.NodeAgentComponentName]; ok && componentOverride != nil {
+		if container, ok := componentOverride.Containers[apicommon.SystemProbeContainerName]; ok && container != nil {
+			return container.SeccompConfig != nil && apiutils.BoolValue(container.SeccompConfig.SecurityProfilesOperator)
+		}
+	}
+	return false
+}
+
 func SetGlobalFromDDA(dda *v2alpha1.DatadogAgent, ddaiGlobal *v2alpha1.GlobalConfig) {
 	setCredentialsFromDDA(dda, ddaiGlobal)
 	setDCATokenFromDDA(dda, ddaiGlobal)
@@ -0,0 +1,43 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/apm"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+)
+
+// updateInstrumentationStatus reports the namespaces and pods matched by the targets of the APM Single Step Instrumentation.
+// The targets are evaluated again when the DatadogAgent changes or the status is stale, as they require listing all the pods
+// of the cluster.
+func (r *Reconciler) updateInstrumentationStatus(ctx context.Context, logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus, now metav1.Time) {
+	targets := apm.GetInstrumentationTargets(&dda.Spec)
+	if len(targets) == 0 {
+		newStatus.Instrumentation = nil
+		return
+	}
+
+	previous := dda.Status.Instrumentation
+	fresh := previous != nil && previous.ObservedGeneration == dda.Generation && isObservedStatusFresh(previous.LastUpdate, now)
+	newStatus.Instrumentation = observeStatus(logger, previous, fresh, "Unable to evaluate the instrumentation targets", func() (*datadoghqv2alpha1.InstrumentationStatus, error) {
+		// The cache only contains the pods of the Agent components
+		targetStatuses, err := apm.GetTargetsStatus(ctx, r.uncachedReader(), targets, constants.GetInstrumentationNamespaces(&dda.Spec, dda.Namespace))
+		if err != nil {
+			return nil, err
+		}
+		return &datadoghqv2alpha1.InstrumentationStatus{
+			LastUpdate:         &now,
+			ObservedGeneration: dda.Generation,
+			Targets:            targetStatuses,
+		}, nil
+	})
+}
@@ -0,0 +1,111 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/pkg/testutils"
+)
+
+func newInstrumentationTestDDA(targets []v2alpha1.SSITarget) *v2alpha1.DatadogAgent {
+	dda := testutils.NewInitializedDatadogAgentBuilder(testNamespace, "foo").
+		WithAPMEnabled(true).
+		WithAPMSingleStepInstrumentationEnabled(true, nil, nil, nil, false, "", targets).
+		Build()
+	dda.Generation = 2
+	return dda
+}
+
+func Test_updateInstrumentationStatus(t *testing.T) {
+	sch := runtime.NewScheme()
+	_ = scheme.AddToScheme(sch)
+
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	targets := []v2alpha1.SSITarget{
+		{
+			Name:              "java",
+			NamespaceSelector: &v2alpha1.NamespaceSelector{MatchNames: []string{"app"}},
+			PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"language": "java"}},
+		},
+	}
+	r := &Reconciler{client: fake.NewClientBuilder().WithScheme(sch).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "web", Labels: map[string]string{"language": "java"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "worker", Labels: map[string]string{"language": "python"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "web", Labels: map[string]string{"language": "java"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "agent", Labels: map[string]string{"language": "java"}}},
+	).Build()}
+
+	t.Run("targets are evaluated", func(t *testing.T) {
+		dda := newInstrumentationTestDDA(targets)
+		status := &v2alpha1.DatadogAgentStatus{}
+
+		r.updateInstrumentationStatus(context.TODO(), logr.Discard(), dda, status, now)
+
+		require.NotNil(t, status.Instrumentation)
+		assert.Equal(t, int64(2), status.Instrumentation.ObservedGeneration)
+		assert.Equal(t, &now, status.Instrumentation.LastUpdate)
+		assert.Equal(t, []v2alpha1.SSITargetStatus{{Name: "java", MatchedNamespaces: 1, MatchedPods: 1, AppliedPods: 1}}, status.Instrumentation.Targets)
+	})
+
+	t.Run("recent status is kept", func(t *testing.T) {
+		dda := newInstrumentationTestDDA(targets)
+		lastUpdate := metav1.NewTime(now.Add(-time.Minute))
+		dda.Status.Instrumentation = &v2alpha1.InstrumentationStatus{LastUpdate: &lastUpdate, ObservedGeneration: 2}
+		status := &v2alpha1.DatadogAgentStatus{}
+
+		r.updateInstrumentationStatus(context.TODO(), logr.Discard(), dda, status, now)
+
+		assert.Equal(t, dda.Status.Instrumentation, status.Instrumentation)
+	})
+
+	t.Run("status is refreshed when the DatadogAgent changes", func(t *testing.T) {
+		dda := newInstrumentationTestDDA(targets)
+		lastUpdate := metav1.NewTime(now.Add(-time.Minute))
+		dda.Status.Instrumentation = &v2alpha1.InstrumentationStatus{LastUpdate: &lastUpdate, ObservedGeneration: 1}
+		status := &v2alpha1.DatadogAgentStatus{}
+
+		r.updateInstrumentationStatus(context.TODO(), logr.Discard(), dda, status, now)
+
+		require.NotNil(t, status.Instrumentation)
+		assert.Equal(t, &now, status.Instrumentation.LastUpdate)
+		assert.Len(t, status.Instrumentation.Targets, 1)
+	})
+
+	t.Run("namespace of the Agent is not instrumented", func(t *testing.T) {
+		dda := newInstrumentationTestDDA([]v2alpha1.SSITarget{{Name: "java", PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"language": "java"}}}})
+		status := &v2alpha1.DatadogAgentStatus{}
+
+		r.updateInstrumentationStatus(context.TODO(), logr.Discard(), dda, status, now)
+
+		require.NotNil(t, status.Instrumentation)
+		assert.Equal(t, []v2alpha1.SSITargetStatus{{Name: "java", MatchedNamespaces: 2, MatchedPods: 2, AppliedPods: 2}}, status.Instrumentation.Targets)
+	})
+
+	t.Run("no targets", func(t *testing.T) {
+		dda := newInstrumentationTestDDA(nil)
+		status := &v2alpha1.DatadogAgentStatus{Instrumentation: &v2alpha1.InstrumentationStatus{}}
+
+		r.updateInstrumentationStatus(context.TODO(), logr.Discard(), dda, status, now)
+
+		assert.Nil(t, status.Instrumentation)
+	})
+}
@@ -0,0 +1,72 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package merger
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	adminnetworkpolicy "github.com/DataDog/datadog-operator/pkg/adminnetworkpolicy/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+// AdminNetworkPolicyManager is used to manage admin network policy resources.
+type AdminNetworkPolicyManager interface {
+	AddAdminNetworkPolicy(name string, policySpec adminnetworkpolicy.AdminNetworkPolicySpec) error
+}
+
+// NewAdminNetworkPolicyManager returns a new AdminNetworkPolicyManager instance
+func NewAdminNetworkPolicyManager(store store.StoreClient) AdminNetworkPolicyManager {
+	manager := &adminNetworkPolicyManagerImpl{
+		store: store,
+	}
+	return manager
+}
+
+// adminNetworkPolicyManagerImpl is used to manage admin network policy resources.
+type adminNetworkPolicyManagerImpl struct {
+	store store.StoreClient
+}
+
+// AddAdminNetworkPolicy creates an admin network policy or adds rules to an admin network policy
+func (m *adminNetworkPolicyManagerImpl) AddAdminNetworkPolicy(name string, policySpec adminnetworkpolicy.AdminNetworkPolicySpec) error {
+	platformInfo := m.store.GetPlatformInfo()
+	if !platformInfo.IsAdminNetworkPolicySupported() {
+		return fmt.Errorf("the %s AdminNetworkPolicy resource is not supported by the cluster, unable to create the policy %s", adminnetworkpolicy.GroupVersion, name)
+	}
+
+	obj, _ := m.store.GetOrCreate(kubernetes.AdminNetworkPoliciesKind, "", name)
+	policy, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("unable to get from the store the Admin Network Policy %s", name)
+	}
+
+	var typedPolicy adminnetworkpolicy.AdminNetworkPolicy
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(policy.UnstructuredContent(), &typedPolicy)
+	if err != nil {
+		return fmt.Errorf("unable to convert unstructured object %s to admin network policy, err: %w", name, err)
+	}
+
+	if policySpec.Priority != 0 {
+		typedPolicy.Spec.Priority = policySpec.Priority
+	}
+	if policySpec.Subject.Namespaces != nil || policySpec.Subject.Pods != nil {
+		typedPolicy.Spec.Subject = policySpec.Subject
+	}
+	typedPolicy.Spec.Ingress = append(typedPolicy.Spec.Ingress, policySpec.Ingress...)
+	typedPolicy.Spec.Egress = append(typedPolicy.Spec.Egress, policySpec.Egress...)
+
+	unstructuredPolicy := &unstructured.Unstructured{}
+	unstructuredPolicy.Object, err = runtime.DefaultUnstructuredConverter.ToUnstructured(&typedPolicy)
+	if err != nil {
+		return fmt.Errorf("unable to convert admin network policy %s to unstructured object, err: %w", name, err)
+	}
+	unstructuredPolicy.SetGroupVersionKind(adminnetworkpolicy.GroupVersionAdminNetworkPolicyKind())
+	return m.store.AddOrUpdate(kubernetes.AdminNetworkPoliciesKind, unstructuredPolicy)
+}
@@ -0,0 +1,109 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package merger
+
+import (
+	"testing"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	adminnetworkpolicy "github.com/DataDog/datadog-operator/pkg/adminnetworkpolicy/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestAdminNetworkPolicyManager_AddAdminNetworkPolicy(t *testing.T) {
+	ns := "bar"
+	name := "bar-foo"
+
+	subject := adminnetworkpolicy.Subject{
+		Pods: &adminnetworkpolicy.NamespacedPod{
+			NamespaceSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{corev1.LabelMetadataName: ns},
+			},
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{kubernetes.AppKubernetesInstanceLabelKey: "foo"},
+			},
+		},
+	}
+	basePolicySpec := adminnetworkpolicy.AdminNetworkPolicySpec{
+		Priority: 50,
+		Subject:  subject,
+		Egress: []adminnetworkpolicy.EgressRule{
+			{
+				Name:   "egress-datadog-intake",
+				Action: adminnetworkpolicy.RuleActionAllow,
+				To: []adminnetworkpolicy.EgressPeer{
+					{
+						DomainNames: []string{"*.datadoghq.com"},
+					},
+				},
+			},
+		},
+	}
+	featurePolicySpec := adminnetworkpolicy.AdminNetworkPolicySpec{
+		Ingress: []adminnetworkpolicy.IngressRule{
+			{
+				Name:   "ingress-apm",
+				Action: adminnetworkpolicy.RuleActionAllow,
+				From: []adminnetworkpolicy.IngressPeer{
+					{
+						Namespaces: &metav1.LabelSelector{},
+					},
+				},
+			},
+		},
+	}
+
+	testScheme := runtime.NewScheme()
+	testScheme.AddKnownTypes(v2alpha1.GroupVersion, &v2alpha1.DatadogAgent{})
+	owner := &v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      "foo",
+		},
+	}
+	if err := NewAdminNetworkPolicyManager(store.NewStore(owner, &store.StoreOptions{Scheme: testScheme})).AddAdminNetworkPolicy(name, basePolicySpec); err == nil {
+		t.Errorf("AdminNetworkPolicyManager.AddAdminNetworkPolicy() expected an error when AdminNetworkPolicy isn't supported")
+	}
+
+	testStore := store.NewStore(owner, &store.StoreOptions{
+		Scheme: testScheme,
+		PlatformInfo: kubernetes.NewPlatformInfoFromVersionMaps(nil, map[string]string{
+			adminnetworkpolicy.AdminNetworkPolicyKind: adminnetworkpolicy.GroupVersion.String(),
+		}, nil),
+	})
+	m := NewAdminNetworkPolicyManager(testStore)
+
+	if err := m.AddAdminNetworkPolicy(name, basePolicySpec); err != nil {
+		t.Errorf("AdminNetworkPolicyManager.AddAdminNetworkPolicy() error = %v", err)
+	}
+	if err := m.AddAdminNetworkPolicy(name, featurePolicySpec); err != nil {
+		t.Errorf("AdminNetworkPolicyManager.AddAdminNetworkPolicy() error = %v", err)
+	}
+
+	obj, found := testStore.Get(kubernetes.AdminNetworkPoliciesKind, "", name)
+	if !found {
+		t.Fatalf("missing AdminNetworkPolicy %s", name)
+	}
+	if len(obj.GetOwnerReferences()) != 0 {
+		t.Errorf("unexpected owner references on the cluster scoped AdminNetworkPolicy %s", name)
+	}
+	var typedPolicy adminnetworkpolicy.AdminNetworkPolicy
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.(*unstructured.Unstructured).UnstructuredContent(), &typedPolicy); err != nil {
+		t.Fatalf("unable to convert unstructured object %s to admin network policy: %s", name, err)
+	}
+	if typedPolicy.Spec.Priority != 50 || typedPolicy.Spec.Subject.Pods == nil {
+		t.Errorf("unexpected priority or subject in AdminNetworkPolicy %s: %+v", name, typedPolicy.Spec)
+	}
+	if len(typedPolicy.Spec.Ingress) != 1 || len(typedPolicy.Spec.Egress) != 1 {
+		t.Errorf("unexpected rules in AdminNetworkPolicy %s: %+v", name, typedPolicy.Spec)
+	}
+}
@@ -0,0 +1,83 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package merger
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	calico "github.com/DataDog/datadog-operator/pkg/calico/v3"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+// CalicoPolicyManager is used to manage calico policy resources.
+type CalicoPolicyManager interface {
+	AddCalicoGlobalNetworkPolicy(name string, policySpec calico.GlobalNetworkPolicySpec) error
+}
+
+// NewCalicoPolicyManager returns a new CalicoPolicyManager instance
+func NewCalicoPolicyManager(store store.StoreClient) CalicoPolicyManager {
+	manager := &calicoPolicyManagerImpl{
+		store: store,
+	}
+	return manager
+}
+
+// calicoPolicyManagerImpl is used to manage calico policy resources.
+type calicoPolicyManagerImpl struct {
+	store store.StoreClient
+}
+
+// AddCalicoGlobalNetworkPolicy creates a calico global network policy or adds rules to a calico global network policy
+func (m *calicoPolicyManagerImpl) AddCalicoGlobalNetworkPolicy(name string, policySpec calico.GlobalNetworkPolicySpec) error {
+	platformInfo := m.store.GetPlatformInfo()
+	if !platformInfo.IsCalicoGlobalNetworkPolicySupported() {
+		return fmt.Errorf("the %s GlobalNetworkPolicy resource is not supported by the cluster, unable to create the policy %s", calico.GroupVersion, name)
+	}
+
+	obj, _ := m.store.GetOrCreate(kubernetes.CalicoGlobalNetworkPoliciesKind, "", name)
+	policy, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("unable to get from the store the Calico Global Network Policy %s", name)
+	}
+
+	var typedPolicy calico.GlobalNetworkPolicy
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(policy.UnstructuredContent(), &typedPolicy)
+	if err != nil {
+		return fmt.Errorf("unable to convert unstructured object %s to calico global network policy, err: %w", name, err)
+	}
+
+	if policySpec.Selector != "" {
+		typedPolicy.Spec.Selector = policySpec.Selector
+	}
+	for _, policyType := range policySpec.Types {
+		if !containsCalicoPolicyType(typedPolicy.Spec.Types, policyType) {
+			typedPolicy.Spec.Types = append(typedPolicy.Spec.Types, policyType)
+		}
+	}
+	typedPolicy.Spec.Ingress = append(typedPolicy.Spec.Ingress, policySpec.Ingress...)
+	typedPolicy.Spec.Egress = append(typedPolicy.Spec.Egress, policySpec.Egress...)
+
+	unstructuredPolicy := &unstructured.Unstructured{}
+	unstructuredPolicy.Object, err = runtime.DefaultUnstructuredConverter.ToUnstructured(&typedPolicy)
+	if err != nil {
+		return fmt.Errorf("unable to convert calico global network policy %s to unstructured object, err: %w", name, err)
+	}
+	unstructuredPolicy.SetGroupVersionKind(calico.GroupVersionGlobalNetworkPolicyKind())
+	return m.store.AddOrUpdate(kubernetes.CalicoGlobalNetworkPoliciesKind, unstructuredPolicy)
+}
+
+func containsCalicoPolicyType(policyTypes []calico.PolicyType, policyType calico.PolicyType) bool {
+	for _, t := range policyTypes {
+		if t == policyType {
+			return true
+		}
+	}
+	return false
+}
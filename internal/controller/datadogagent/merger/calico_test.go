@@ -0,0 +1,169 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package merger
+
+import (
+	"testing"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	calico "github.com/DataDog/datadog-operator/pkg/calico/v3"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCalicoPolicyManager_AddCalicoGlobalNetworkPolicy(t *testing.T) {
+	ns := "bar"
+	name1 := "bar-foo"
+	name2 := "bar-foo2"
+
+	protocolTCP := calico.ProtocolTCP
+	policySpec1 := calico.GlobalNetworkPolicySpec{
+		Selector: "app.kubernetes.io/instance == 'foo' && projectcalico.org/namespace == 'bar'",
+		Types:    []calico.PolicyType{calico.PolicyTypeIngress, calico.PolicyTypeEgress},
+		Egress: []calico.Rule{
+			{
+				Action:   calico.ActionAllow,
+				Protocol: &protocolTCP,
+				Destination: calico.EntityRule{
+					Ports: []int32{443},
+				},
+			},
+		},
+	}
+
+	existingPolicy := calico.GlobalNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name2,
+		},
+		Spec: calico.GlobalNetworkPolicySpec{
+			Selector: "app.kubernetes.io/instance == 'foo' && projectcalico.org/namespace == 'bar'",
+			Types:    []calico.PolicyType{calico.PolicyTypeIngress},
+			Ingress: []calico.Rule{
+				{
+					Action:   calico.ActionAllow,
+					Protocol: &protocolTCP,
+					Destination: calico.EntityRule{
+						Ports: []int32{1001},
+					},
+				},
+			},
+		},
+	}
+	unstructuredPolicy := &unstructured.Unstructured{}
+	var err error
+	unstructuredPolicy.Object, err = runtime.DefaultUnstructuredConverter.ToUnstructured(&existingPolicy)
+	if err != nil {
+		t.Errorf("unable to convert calico global network policy %s to unstructured object: %s", name2, err)
+	}
+	unstructuredPolicy.SetGroupVersionKind(calico.GroupVersionGlobalNetworkPolicyKind())
+
+	testScheme := runtime.NewScheme()
+	testScheme.AddKnownTypes(v2alpha1.GroupVersion, &v2alpha1.DatadogAgent{})
+	storeOptions := &store.StoreOptions{
+		Scheme: testScheme,
+		PlatformInfo: kubernetes.NewPlatformInfoFromVersionMaps(nil, map[string]string{
+			calico.GlobalNetworkPolicyKind: calico.GroupVersion.String(),
+		}, nil),
+	}
+
+	owner := &v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      "foo",
+		},
+	}
+
+	tests := []struct {
+		name         string
+		store        *store.Store
+		policyName   string
+		wantErr      bool
+		validateFunc func(*testing.T, *store.Store)
+	}{
+		{
+			name:       "empty store",
+			store:      store.NewStore(owner, storeOptions),
+			policyName: name1,
+			wantErr:    false,
+			validateFunc: func(t *testing.T, store *store.Store) {
+				obj, found := store.Get(kubernetes.CalicoGlobalNetworkPoliciesKind, "", name1)
+				if !found {
+					t.Fatalf("missing GlobalNetworkPolicy %s", name1)
+				}
+				if len(obj.GetOwnerReferences()) != 0 {
+					t.Errorf("unexpected owner references on the cluster scoped GlobalNetworkPolicy %s", name1)
+				}
+			},
+		},
+		{
+			name:       "GlobalNetworkPolicy not supported",
+			store:      store.NewStore(owner, &store.StoreOptions{Scheme: testScheme}),
+			policyName: name1,
+			wantErr:    true,
+			validateFunc: func(t *testing.T, store *store.Store) {
+				if _, found := store.Get(kubernetes.CalicoGlobalNetworkPoliciesKind, "", name1); found {
+					t.Errorf("unexpected GlobalNetworkPolicy %s", name1)
+				}
+			},
+		},
+		{
+			name: "GlobalNetworkPolicy of the Calico CRDs only",
+			store: store.NewStore(owner, &store.StoreOptions{
+				Scheme: testScheme,
+				PlatformInfo: kubernetes.NewPlatformInfoFromVersionMaps(nil, map[string]string{
+					calico.GlobalNetworkPolicyKind: "crd.projectcalico.org/v1",
+				}, nil),
+			}),
+			policyName: name1,
+			wantErr:    true,
+			validateFunc: func(t *testing.T, store *store.Store) {
+				if _, found := store.Get(kubernetes.CalicoGlobalNetworkPoliciesKind, "", name1); found {
+					t.Errorf("unexpected GlobalNetworkPolicy %s", name1)
+				}
+			},
+		},
+		{
+			name:       "update existing GlobalNetworkPolicy",
+			store:      store.NewStore(owner, storeOptions).AddOrUpdateStore(kubernetes.CalicoGlobalNetworkPoliciesKind, unstructuredPolicy),
+			policyName: name2,
+			wantErr:    false,
+			validateFunc: func(t *testing.T, store *store.Store) {
+				obj, found := store.Get(kubernetes.CalicoGlobalNetworkPoliciesKind, "", name2)
+				if !found {
+					t.Fatalf("missing GlobalNetworkPolicy %s", name2)
+				}
+				var typedPolicy calico.GlobalNetworkPolicy
+				err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.(*unstructured.Unstructured).UnstructuredContent(), &typedPolicy)
+				if err != nil {
+					t.Fatalf("unable to convert unstructured object %s to calico global network policy: %s", name2, err)
+				}
+				if len(typedPolicy.Spec.Ingress) != 1 || len(typedPolicy.Spec.Egress) != 1 {
+					t.Errorf("unexpected rules in GlobalNetworkPolicy %s: %+v", name2, typedPolicy.Spec)
+				}
+				if len(typedPolicy.Spec.Types) != 2 {
+					t.Errorf("unexpected types in GlobalNetworkPolicy %s: %v", name2, typedPolicy.Spec.Types)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &calicoPolicyManagerImpl{
+				store: tt.store,
+			}
+			if err := m.AddCalicoGlobalNetworkPolicy(tt.policyName, policySpec1); (err != nil) != tt.wantErr {
+				t.Errorf("CalicoPolicyManager.AddCalicoGlobalNetworkPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.validateFunc != nil {
+				tt.validateFunc(t, tt.store)
+			}
+		})
+	}
+}
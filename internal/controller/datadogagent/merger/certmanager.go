@@ -0,0 +1,71 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package merger
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	certmanager "github.com/DataDog/datadog-operator/pkg/certmanager/v1"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+// CertManagerManager is used to manage cert-manager resources.
+type CertManagerManager interface {
+	AddIssuer(name, namespace string, spec certmanager.IssuerSpec) error
+	AddCertificate(name, namespace string, spec certmanager.CertificateSpec) error
+}
+
+// NewCertManagerManager returns a new CertManagerManager instance
+func NewCertManagerManager(store store.StoreClient) CertManagerManager {
+	manager := &certManagerManagerImpl{
+		store: store,
+	}
+	return manager
+}
+
+// certManagerManagerImpl is used to manage cert-manager resources.
+type certManagerManagerImpl struct {
+	store store.StoreClient
+}
+
+// AddIssuer creates or updates a cert-manager issuer
+func (m *certManagerManagerImpl) AddIssuer(name, namespace string, spec certmanager.IssuerSpec) error {
+	issuer := &certmanager.Issuer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: spec,
+	}
+	issuer.SetGroupVersionKind(certmanager.GroupVersionIssuerKind())
+	return m.addObject(kubernetes.CertManagerIssuersKind, name, namespace, issuer)
+}
+
+// AddCertificate creates or updates a cert-manager certificate
+func (m *certManagerManagerImpl) AddCertificate(name, namespace string, spec certmanager.CertificateSpec) error {
+	certificate := &certmanager.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: spec,
+	}
+	certificate.SetGroupVersionKind(certmanager.GroupVersionCertificateKind())
+	return m.addObject(kubernetes.CertManagerCertificatesKind, name, namespace, certificate)
+}
+
+func (m *certManagerManagerImpl) addObject(kind kubernetes.ObjectKind, name, namespace string, obj interface{}) error {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return fmt.Errorf("unable to convert %s %s/%s to unstructured object, err: %w", kind, namespace, name, err)
+	}
+	return m.store.AddOrUpdate(kind, &unstructured.Unstructured{Object: content})
+}
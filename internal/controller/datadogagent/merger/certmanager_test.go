@@ -0,0 +1,66 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package merger
+
+import (
+	"testing"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	certmanager "github.com/DataDog/datadog-operator/pkg/certmanager/v1"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCertManagerManager(t *testing.T) {
+	ns := "bar"
+	name := "foo"
+
+	testScheme := runtime.NewScheme()
+	testScheme.AddKnownTypes(v2alpha1.GroupVersion, &v2alpha1.DatadogAgent{})
+	owner := &v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      name,
+		},
+	}
+	testStore := store.NewStore(owner, &store.StoreOptions{Scheme: testScheme})
+	m := NewCertManagerManager(testStore)
+
+	if err := m.AddIssuer("foo-issuer", ns, certmanager.IssuerSpec{SelfSigned: &certmanager.SelfSignedIssuer{}}); err != nil {
+		t.Errorf("CertManagerManager.AddIssuer() error = %v", err)
+	}
+	obj, found := testStore.Get(kubernetes.CertManagerIssuersKind, ns, "foo-issuer")
+	if !found {
+		t.Fatalf("missing Issuer %s/foo-issuer", ns)
+	}
+	if gvk := obj.GetObjectKind().GroupVersionKind(); gvk != certmanager.GroupVersionIssuerKind() {
+		t.Errorf("unexpected Issuer GroupVersionKind %s", gvk)
+	}
+
+	spec := certmanager.CertificateSpec{
+		DNSNames:   []string{"datadog-admission-controller.bar.svc"},
+		SecretName: "foo-cert",
+		IssuerRef:  certmanager.ObjectReference{Name: "foo-issuer", Kind: certmanager.IssuerKind},
+	}
+	if err := m.AddCertificate("foo-cert", ns, spec); err != nil {
+		t.Errorf("CertManagerManager.AddCertificate() error = %v", err)
+	}
+	obj, found = testStore.Get(kubernetes.CertManagerCertificatesKind, ns, "foo-cert")
+	if !found {
+		t.Fatalf("missing Certificate %s/foo-cert", ns)
+	}
+	var certificate certmanager.Certificate
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.(*unstructured.Unstructured).UnstructuredContent(), &certificate); err != nil {
+		t.Fatalf("unable to convert unstructured object %s/foo-cert to certificate: %s", ns, err)
+	}
+	if certificate.Spec.SecretName != "foo-cert" || certificate.Spec.IssuerRef.Name != "foo-issuer" {
+		t.Errorf("unexpected Certificate spec %+v", certificate.Spec)
+	}
+}
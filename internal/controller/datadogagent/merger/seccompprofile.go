@@ -0,0 +1,73 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package merger
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	securityprofilesoperator "github.com/DataDog/datadog-operator/pkg/securityprofilesoperator/v1beta1"
+)
+
+// SeccompProfileManager is used to manage Security Profiles Operator seccomp profile resources.
+type SeccompProfileManager interface {
+	AddSeccompProfile(namespace, name string, annotations map[string]string, profileSpec securityprofilesoperator.SeccompProfileSpec) error
+}
+
+// NewSeccompProfileManager returns a new SeccompProfileManager instance
+func NewSeccompProfileManager(store store.StoreClient) SeccompProfileManager {
+	manager := &seccompProfileManagerImpl{
+		store: store,
+	}
+	return manager
+}
+
+// seccompProfileManagerImpl is used to manage Security Profiles Operator seccomp profile resources.
+type seccompProfileManagerImpl struct {
+	store store.StoreClient
+}
+
+// AddSeccompProfile creates or replaces a Security Profiles Operator seccomp profile
+func (m *seccompProfileManagerImpl) AddSeccompProfile(namespace, name string, annotations map[string]string, profileSpec securityprofilesoperator.SeccompProfileSpec) error {
+	platformInfo := m.store.GetPlatformInfo()
+	if !platformInfo.IsResourceVersionSupported(securityprofilesoperator.SeccompProfileKind, securityprofilesoperator.GroupVersion) {
+		return fmt.Errorf("the Security Profiles Operator SeccompProfile resource is not supported by the cluster, unable to create the profile %s/%s", namespace, name)
+	}
+
+	obj, _ := m.store.GetOrCreate(kubernetes.SeccompProfilesKind, namespace, name)
+	profile, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("unable to get from the store the SeccompProfile %s/%s", namespace, name)
+	}
+
+	var typedProfile securityprofilesoperator.SeccompProfile
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(profile.UnstructuredContent(), &typedProfile)
+	if err != nil {
+		return fmt.Errorf("unable to convert unstructured object %s/%s to seccomp profile, err: %w", namespace, name, err)
+	}
+
+	if typedProfile.Annotations == nil {
+		typedProfile.Annotations = map[string]string{}
+	}
+	for key, value := range annotations {
+		typedProfile.Annotations[key] = value
+	}
+	typedProfile.Spec = profileSpec
+
+	unstructuredProfile := &unstructured.Unstructured{}
+	unstructuredProfile.Object, err = runtime.DefaultUnstructuredConverter.ToUnstructured(&typedProfile)
+	if err != nil {
+		return fmt.Errorf("unable to convert seccomp profile %s/%s to unstructured object, err: %w", namespace, name, err)
+	}
+	// The status is managed by the Security Profiles Operator
+	unstructured.RemoveNestedField(unstructuredProfile.Object, "status")
+	unstructuredProfile.SetGroupVersionKind(securityprofilesoperator.GroupVersionSeccompProfileKind())
+	return m.store.AddOrUpdate(kubernetes.SeccompProfilesKind, unstructuredProfile)
+}
@@ -0,0 +1,152 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package merger
+
+import (
+	"testing"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	securityprofilesoperator "github.com/DataDog/datadog-operator/pkg/securityprofilesoperator/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestSeccompProfileManager_AddSeccompProfile(t *testing.T) {
+	ns := "bar"
+	name1 := "foo-system-probe-seccomp"
+	name2 := "foo2-system-probe-seccomp"
+	versionAnnotationKey := "agent.datadoghq.com/seccomp-profile-agent-version"
+
+	profileSpec := securityprofilesoperator.SeccompProfileSpec{
+		DefaultAction: "SCMP_ACT_ERRNO",
+		Syscalls: []securityprofilesoperator.Syscall{
+			{
+				Names:  []string{"accept4", "bpf"},
+				Action: "SCMP_ACT_ALLOW",
+			},
+		},
+	}
+
+	existingProfile := securityprofilesoperator.SeccompProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   ns,
+			Name:        name2,
+			Annotations: map[string]string{versionAnnotationKey: "7.66.0"},
+		},
+		Spec: securityprofilesoperator.SeccompProfileSpec{
+			DefaultAction: "SCMP_ACT_ERRNO",
+			Syscalls: []securityprofilesoperator.Syscall{
+				{
+					Names:  []string{"accept4"},
+					Action: "SCMP_ACT_ALLOW",
+				},
+			},
+		},
+	}
+	unstructuredProfile := &unstructured.Unstructured{}
+	var err error
+	unstructuredProfile.Object, err = runtime.DefaultUnstructuredConverter.ToUnstructured(&existingProfile)
+	if err != nil {
+		t.Errorf("unable to convert seccomp profile %s to unstructured object: %s", name2, err)
+	}
+	unstructuredProfile.SetGroupVersionKind(securityprofilesoperator.GroupVersionSeccompProfileKind())
+
+	testScheme := runtime.NewScheme()
+	testScheme.AddKnownTypes(v2alpha1.GroupVersion, &v2alpha1.DatadogAgent{})
+	storeOptions := &store.StoreOptions{
+		Scheme: testScheme,
+		PlatformInfo: kubernetes.NewPlatformInfoFromVersionMaps(nil, map[string]string{
+			securityprofilesoperator.SeccompProfileKind: securityprofilesoperator.GroupVersion.String(),
+		}, nil),
+	}
+
+	owner := &v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      "foo",
+		},
+	}
+
+	getProfile := func(t *testing.T, store *store.Store, name string) securityprofilesoperator.SeccompProfile {
+		obj, found := store.Get(kubernetes.SeccompProfilesKind, ns, name)
+		if !found {
+			t.Fatalf("missing SeccompProfile %s", name)
+		}
+		var typedProfile securityprofilesoperator.SeccompProfile
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.(*unstructured.Unstructured).UnstructuredContent(), &typedProfile)
+		if err != nil {
+			t.Fatalf("unable to convert unstructured object %s to seccomp profile: %s", name, err)
+		}
+		return typedProfile
+	}
+
+	tests := []struct {
+		name         string
+		store        *store.Store
+		profileName  string
+		wantErr      bool
+		validateFunc func(*testing.T, *store.Store)
+	}{
+		{
+			name:        "empty store",
+			store:       store.NewStore(owner, storeOptions),
+			profileName: name1,
+			wantErr:     false,
+			validateFunc: func(t *testing.T, store *store.Store) {
+				profile := getProfile(t, store, name1)
+				if profile.Annotations[versionAnnotationKey] != "7.67.0" {
+					t.Errorf("unexpected annotations on SeccompProfile %s: %v", name1, profile.Annotations)
+				}
+				if len(profile.OwnerReferences) != 1 {
+					t.Errorf("missing owner reference on SeccompProfile %s", name1)
+				}
+			},
+		},
+		{
+			name:        "SeccompProfile not supported",
+			store:       store.NewStore(owner, &store.StoreOptions{Scheme: testScheme}),
+			profileName: name1,
+			wantErr:     true,
+			validateFunc: func(t *testing.T, store *store.Store) {
+				if _, found := store.Get(kubernetes.SeccompProfilesKind, ns, name1); found {
+					t.Errorf("unexpected SeccompProfile %s", name1)
+				}
+			},
+		},
+		{
+			name:        "replace existing SeccompProfile",
+			store:       store.NewStore(owner, storeOptions).AddOrUpdateStore(kubernetes.SeccompProfilesKind, unstructuredProfile),
+			profileName: name2,
+			wantErr:     false,
+			validateFunc: func(t *testing.T, store *store.Store) {
+				profile := getProfile(t, store, name2)
+				if profile.Annotations[versionAnnotationKey] != "7.67.0" {
+					t.Errorf("unexpected annotations on SeccompProfile %s: %v", name2, profile.Annotations)
+				}
+				if len(profile.Spec.Syscalls) != 1 || len(profile.Spec.Syscalls[0].Names) != 2 {
+					t.Errorf("unexpected syscalls in SeccompProfile %s: %+v", name2, profile.Spec.Syscalls)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &seccompProfileManagerImpl{
+				store: tt.store,
+			}
+			if err := m.AddSeccompProfile(ns, tt.profileName, map[string]string{versionAnnotationKey: "7.67.0"}, profileSpec); (err != nil) != tt.wantErr {
+				t.Errorf("SeccompProfileManager.AddSeccompProfile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.validateFunc != nil {
+				tt.validateFunc(t, tt.store)
+			}
+		})
+	}
+}
@@ -71,6 +71,27 @@ func MergeConfigMapItemsVolumeMergeFunction(current, newVolume *corev1.Volume) (
 	return mergedVolume, err
 }
 
+// MergeProjectedVolumeMergeFunction used when the ConfigMap of the new corev1.Volume needs to be added to the sources
+// of the existing projected corev1.Volume. The new corev1.Volume replaces the existing one otherwise.
+func MergeProjectedVolumeMergeFunction(current, newVolume *corev1.Volume) (*corev1.Volume, error) {
+	if current.Projected == nil || newVolume.ConfigMap == nil {
+		return newVolume.DeepCopy(), nil
+	}
+
+	mergedVolume := current.DeepCopy()
+	configMap := newVolume.ConfigMap.DeepCopy()
+	source := corev1.VolumeProjection{
+		ConfigMap: &corev1.ConfigMapProjection{
+			LocalObjectReference: configMap.LocalObjectReference,
+			Items:                configMap.Items,
+			Optional:             configMap.Optional,
+		},
+	}
+	mergedVolume.Projected.Sources = append([]corev1.VolumeProjection{source}, mergedVolume.Projected.Sources...)
+
+	return mergedVolume, nil
+}
+
 // IgnoreNewVolumeMergeFunction used when the existing corev1.Volume needs to be kept.
 func IgnoreNewVolumeMergeFunction(current, newVolume *corev1.Volume) (*corev1.Volume, error) {
 	return current.DeepCopy(), nil
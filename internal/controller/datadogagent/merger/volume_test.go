@@ -128,6 +128,47 @@ func TestAddVolumeToPod(t *testing.T) {
 		},
 	}
 
+	volumeProjected := &corev1.Volume{
+		Name: "cm",
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{
+						ConfigMap: &corev1.ConfigMapProjection{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "checks",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	volumeProjectedMerged := &corev1.Volume{
+		Name: "cm",
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{
+						ConfigMap: &corev1.ConfigMapProjection{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "cm",
+							},
+							Items: volumeCM1.ConfigMap.Items,
+						},
+					},
+					{
+						ConfigMap: &corev1.ConfigMapProjection{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "checks",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
 	type args struct {
 		podSpec     *corev1.PodSpec
 		volumeMount *corev1.Volume
@@ -214,6 +255,28 @@ func TestAddVolumeToPod(t *testing.T) {
 			},
 			want: []corev1.Volume{*volumeCM4},
 		},
+		{
+			name: "volume configmap merge in projected volume",
+			args: args{
+				podSpec: &corev1.PodSpec{
+					Volumes: []corev1.Volume{*volumeProjected},
+				},
+				volumeMount: volumeCM1.DeepCopy(),
+				mergeFunc:   MergeProjectedVolumeMergeFunction,
+			},
+			want: []corev1.Volume{*volumeProjectedMerged},
+		},
+		{
+			name: "volume configmap replaces non projected volume",
+			args: args{
+				podSpec: &corev1.PodSpec{
+					Volumes: []corev1.Volume{*volumeCM2},
+				},
+				volumeMount: volumeCM1.DeepCopy(),
+				mergeFunc:   MergeProjectedVolumeMergeFunction,
+			},
+			want: []corev1.Volume{*volumeCM1},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
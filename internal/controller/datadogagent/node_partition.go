@@ -0,0 +1,452 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	"github.com/DataDog/datadog-operator/pkg/condition"
+)
+
+const (
+	nodeSelectorOverlapReason   = "NodeSelectorOverlap"
+	noNodeSelectorOverlapReason = "NoNodeSelectorOverlap"
+
+	// nodePartitionLabelKey is set on the nodes selected by several DatadogAgents, to the UID of the DatadogAgent handling them
+	nodePartitionLabelKey = "agent.datadoghq.com/datadogagent"
+
+	// maxOverlapNodesInMessage limits the number of node names listed in the NodeSelectorOverlap condition
+	maxOverlapNodesInMessage = 5
+)
+
+// nodePartition contains the nodes handled by a DatadogAgent when several DatadogAgents run in the cluster
+type nodePartition struct {
+	// nodes are the nodes selected by the DatadogAgent and handled by it
+	nodes []corev1.Node
+	// excludedNodes are the names of the nodes selected by the DatadogAgent but handled by an older DatadogAgent
+	excludedNodes []string
+	// sharedNodes are the names of the nodes selected by the DatadogAgent and by other DatadogAgents
+	sharedNodes []string
+	// overlaps are the other DatadogAgents selecting the shared nodes
+	overlaps []types.NamespacedName
+	// olderSelectors are the node selectors of the DatadogAgents with precedence over the DatadogAgent, from the oldest
+	olderSelectors []*metav1.LabelSelector
+	// owners are the UIDs of the DatadogAgents handling the nodes selected by several DatadogAgents, indexed by node name.
+	// They are computed for all the nodes, not only the ones selected by the DatadogAgent.
+	owners map[string]types.UID
+}
+
+// applyNodePartition restricts the node Agent of the DatadogAgent to the nodes it handles:
+// the nodes matching `global.nodeSelector`, minus the nodes already handled by an older DatadogAgent.
+// The nodes selected by several DatadogAgents are labelled with the UID of the DatadogAgent handling them, so that
+// the affinity of the node Agent does not depend on the overlaps and is not updated when they change. The nodes
+// not labelled yet, such as new nodes, are excluded from the node selectors of the older DatadogAgents.
+// The nodes selected by other DatadogAgents are reported in the NodeSelectorOverlap condition.
+func (r *Reconciler) applyNodePartition(ctx context.Context, dda *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus, now metav1.Time) error {
+	ddaList := datadoghqv2alpha1.DatadogAgentList{}
+	if err := r.client.List(ctx, &ddaList); err != nil {
+		return err
+	}
+
+	var selector *metav1.LabelSelector
+	if dda.Spec.Global != nil {
+		selector = dda.Spec.Global.NodeSelector
+	}
+	// With a single DatadogAgent, the node list is not needed
+	if selector == nil && len(ddaList.Items) <= 1 {
+		return nil
+	}
+
+	partition := nodePartition{}
+	var partitionUID types.UID
+	if len(ddaList.Items) > 1 {
+		nodeList, err := r.getNodeList(ctx)
+		if err != nil {
+			return err
+		}
+		if partition, err = computeNodePartition(types.NamespacedName{Namespace: dda.Namespace, Name: dda.Name}, selector, ddaList.Items, nodeList); err != nil {
+			return err
+		}
+		// The nodes are labelled before the DaemonSet is updated, so that the node Agent is never scheduled on the nodes of another DatadogAgent
+		if err = r.labelNodesWithPartition(ctx, partition, nodeList); err != nil {
+			return err
+		}
+		partitionUID = dda.UID
+	}
+
+	affinity, err := nodePartitionAffinity(selector, partitionUID, partition.olderSelectors)
+	if err != nil {
+		return err
+	}
+	if dda.Spec.Override == nil {
+		dda.Spec.Override = map[datadoghqv2alpha1.ComponentName]*datadoghqv2alpha1.DatadogAgentComponentOverride{}
+	}
+	nodeAgentOverride, found := dda.Spec.Override[datadoghqv2alpha1.NodeAgentComponentName]
+	if !found || nodeAgentOverride == nil {
+		nodeAgentOverride = &datadoghqv2alpha1.DatadogAgentComponentOverride{}
+		dda.Spec.Override[datadoghqv2alpha1.NodeAgentComponentName] = nodeAgentOverride
+	}
+	nodeAgentOverride.Affinity = common.MergeAffinities(nodeAgentOverride.Affinity, affinity)
+
+	if len(partition.overlaps) == 0 {
+		condition.UpdateDatadogAgentStatusConditions(newStatus, now, common.NodeSelectorOverlapConditionType, metav1.ConditionFalse, noNodeSelectorOverlapReason, "no node is selected by another DatadogAgent", false)
+		return nil
+	}
+	condition.UpdateDatadogAgentStatusConditions(newStatus, now, common.NodeSelectorOverlapConditionType, metav1.ConditionTrue, nodeSelectorOverlapReason, overlapMessage(partition), false)
+	return nil
+}
+
+// labelNodesWithPartition sets the "agent.datadoghq.com/datadogagent" label on the nodes selected by several DatadogAgents
+// and removes it from the other nodes. All the DatadogAgents compute the same labels, so any of them can update them.
+func (r *Reconciler) labelNodesWithPartition(ctx context.Context, partition nodePartition, nodes []corev1.Node) error {
+	for i := range nodes {
+		node := &nodes[i]
+		value, labelExists := node.Labels[nodePartitionLabelKey]
+		owner, shared := partition.owners[node.Name]
+		if (shared && value == string(owner)) || (!shared && !labelExists) {
+			continue
+		}
+
+		newLabels := make(map[string]string, len(node.Labels)+1)
+		for k, v := range node.Labels {
+			newLabels[k] = v
+		}
+		if shared {
+			newLabels[nodePartitionLabelKey] = string(owner)
+		} else {
+			delete(newLabels, nodePartitionLabelKey)
+		}
+
+		modifiedNode := node.DeepCopy()
+		modifiedNode.Labels = newLabels
+		if err := r.client.Patch(ctx, modifiedNode, client.MergeFrom(node)); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// nodePartitionCleanup removes the "agent.datadoghq.com/datadogagent" label set to the UID of a deleted DatadogAgent.
+// The remaining DatadogAgents label the nodes again if they are still shared.
+func (r *Reconciler) nodePartitionCleanup(ctx context.Context, ddaUID types.UID) error {
+	nodeList, err := r.getNodeList(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range nodeList {
+		node := &nodeList[i]
+		if value, found := node.Labels[nodePartitionLabelKey]; !found || value != string(ddaUID) {
+			continue
+		}
+		modifiedNode := node.DeepCopy()
+		delete(modifiedNode.Labels, nodePartitionLabelKey)
+		if err = r.client.Patch(ctx, modifiedNode, client.MergeFrom(node)); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// getDatadogAgentNodeList returns the nodes handled by a DatadogAgent. It is used to scope profiles and
+// introspection to the nodes of the DatadogAgent.
+func (r *Reconciler) getDatadogAgentNodeList(ctx context.Context, ddaNamespacedName types.NamespacedName, selector *metav1.LabelSelector) ([]corev1.Node, error) {
+	nodeList, err := r.getNodeList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ddaList := datadoghqv2alpha1.DatadogAgentList{}
+	if err = r.client.List(ctx, &ddaList); err != nil {
+		return nil, err
+	}
+
+	partition, err := computeNodePartition(ddaNamespacedName, selector, ddaList.Items, nodeList)
+	if err != nil {
+		return nil, err
+	}
+	return partition.nodes, nil
+}
+
+// computeNodePartition returns the nodes handled by a DatadogAgent.
+// A node selected by several DatadogAgents is handled by the oldest one. When two DatadogAgents share an
+// identical creation timestamp, the one whose namespaced name is alphabetically first has priority.
+// The DatadogAgents being deleted are ignored.
+func computeNodePartition(ddaNamespacedName types.NamespacedName, selector *metav1.LabelSelector, ddas []datadoghqv2alpha1.DatadogAgent, nodes []corev1.Node) (nodePartition, error) {
+	partition := nodePartition{}
+
+	ddaSelector, err := nodeSelectorAsSelector(selector)
+	if err != nil {
+		return partition, err
+	}
+
+	var current *datadoghqv2alpha1.DatadogAgent
+	for i := range ddas {
+		if ddas[i].Namespace == ddaNamespacedName.Namespace && ddas[i].Name == ddaNamespacedName.Name {
+			current = &ddas[i]
+		}
+	}
+
+	var others []datadoghqv2alpha1.DatadogAgent
+	var otherSelectors []labels.Selector
+	var olders []*datadoghqv2alpha1.DatadogAgent
+	for i := range ddas {
+		other := &ddas[i]
+		if other == current || other.DeletionTimestamp != nil {
+			continue
+		}
+		var otherSelector *metav1.LabelSelector
+		if other.Spec.Global != nil {
+			otherSelector = other.Spec.Global.NodeSelector
+		}
+		// An invalid selector is reported on its own DatadogAgent, which does not run any node Agent
+		s, err := nodeSelectorAsSelector(otherSelector)
+		if err != nil {
+			continue
+		}
+		others = append(others, *other)
+		otherSelectors = append(otherSelectors, s)
+		// A DatadogAgent not found in the list is being created, it is the newest one
+		if current == nil || hasPrecedence(other, current) {
+			olders = append(olders, other)
+		}
+	}
+	sort.Slice(olders, func(i, j int) bool {
+		return hasPrecedence(olders[i], olders[j])
+	})
+	for _, older := range olders {
+		var olderSelector *metav1.LabelSelector
+		if older.Spec.Global != nil {
+			olderSelector = older.Spec.Global.NodeSelector
+		}
+		partition.olderSelectors = append(partition.olderSelectors, olderSelector)
+	}
+
+	overlaps := map[types.NamespacedName]struct{}{}
+	for _, node := range nodes {
+		nodeLabels := labels.Set(node.Labels)
+		selected := ddaSelector.Matches(nodeLabels)
+		// A DatadogAgent not found in the list is being created, it is the newest one
+		var owner *datadoghqv2alpha1.DatadogAgent
+		matches := 0
+		if selected {
+			owner = current
+			matches++
+		}
+		for i := range others {
+			if !otherSelectors[i].Matches(nodeLabels) {
+				continue
+			}
+			matches++
+			if owner == nil || hasPrecedence(&others[i], owner) {
+				owner = &others[i]
+			}
+			if selected {
+				overlaps[types.NamespacedName{Namespace: others[i].Namespace, Name: others[i].Name}] = struct{}{}
+				partition.sharedNodes = append(partition.sharedNodes, node.Name)
+			}
+		}
+
+		if matches > 1 && owner != nil {
+			if partition.owners == nil {
+				partition.owners = map[string]types.UID{}
+			}
+			partition.owners[node.Name] = owner.UID
+		}
+		if !selected {
+			continue
+		}
+		if owner != current {
+			partition.excludedNodes = append(partition.excludedNodes, node.Name)
+		} else {
+			partition.nodes = append(partition.nodes, node)
+		}
+	}
+
+	for nsName := range overlaps {
+		partition.overlaps = append(partition.overlaps, nsName)
+	}
+	partition.sharedNodes = dedupSorted(partition.sharedNodes)
+	sort.Strings(partition.excludedNodes)
+	sort.Slice(partition.overlaps, func(i, j int) bool {
+		return partition.overlaps[i].String() < partition.overlaps[j].String()
+	})
+
+	return partition, nil
+}
+
+// hasPrecedence returns true if dda1 handles the nodes it shares with dda2
+func hasPrecedence(dda1, dda2 *datadoghqv2alpha1.DatadogAgent) bool {
+	if !dda1.CreationTimestamp.Equal(&dda2.CreationTimestamp) {
+		return dda1.CreationTimestamp.Before(&dda2.CreationTimestamp)
+	}
+	return types.NamespacedName{Namespace: dda1.Namespace, Name: dda1.Name}.String() < types.NamespacedName{Namespace: dda2.Namespace, Name: dda2.Name}.String()
+}
+
+// nodeSelectorAsSelector converts `global.nodeSelector` to a selector. An unset node selector selects all the nodes.
+func nodeSelectorAsSelector(selector *metav1.LabelSelector) (labels.Selector, error) {
+	if selector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(selector)
+}
+
+// nodePartitionAffinity returns the node affinity restricting the node Agent to the nodes matching the selector.
+// When several DatadogAgents run in the cluster, the partitionUID is set to the UID of the DatadogAgent, and the nodes
+// selected by several DatadogAgents are restricted to the one whose UID is set in the "agent.datadoghq.com/datadogagent" label.
+// The nodes without this label are restricted to the nodes not matching the olderSelectors, so that a new node selected
+// by several DatadogAgents only runs the node Agent of the oldest one until it is labelled.
+func nodePartitionAffinity(selector *metav1.LabelSelector, partitionUID types.UID, olderSelectors []*metav1.LabelSelector) (*corev1.Affinity, error) {
+	requirements, err := nodeSelectorRequirements(selector)
+	if err != nil {
+		return nil, err
+	}
+	term := corev1.NodeSelectorTerm{MatchExpressions: requirements}
+	terms := []corev1.NodeSelectorTerm{term}
+	if partitionUID != "" {
+		// The node selector terms are ORed: either the node is not shared, or it is handled by this DatadogAgent
+		notShared := *term.DeepCopy()
+		notShared.MatchExpressions = append(notShared.MatchExpressions, corev1.NodeSelectorRequirement{
+			Key:      nodePartitionLabelKey,
+			Operator: corev1.NodeSelectorOpDoesNotExist,
+		})
+		notSharedTerms := []corev1.NodeSelectorTerm{notShared}
+		for _, olderSelector := range olderSelectors {
+			negated, err := negatedNodeSelectorRequirements(olderSelector)
+			if err != nil {
+				return nil, err
+			}
+			// The node must not match the older selector: it must match one of the negated requirements
+			var excluded []corev1.NodeSelectorTerm
+			for _, notSharedTerm := range notSharedTerms {
+				for _, requirement := range negated {
+					excludedTerm := *notSharedTerm.DeepCopy()
+					excludedTerm.MatchExpressions = append(excludedTerm.MatchExpressions, requirement)
+					excluded = append(excluded, excludedTerm)
+				}
+			}
+			notSharedTerms = excluded
+		}
+		handled := *term.DeepCopy()
+		handled.MatchExpressions = append(handled.MatchExpressions, corev1.NodeSelectorRequirement{
+			Key:      nodePartitionLabelKey,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{string(partitionUID)},
+		})
+		terms = append(notSharedTerms, handled)
+	}
+
+	if len(terms[0].MatchExpressions) == 0 {
+		return nil, nil
+	}
+	return &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: terms,
+			},
+		},
+	}, nil
+}
+
+// nodeSelectorRequirements converts `global.nodeSelector` to node selector requirements, which are ANDed.
+// An unset node selector returns no requirement.
+func nodeSelectorRequirements(selector *metav1.LabelSelector) ([]corev1.NodeSelectorRequirement, error) {
+	if selector == nil {
+		return nil, nil
+	}
+	var requirements []corev1.NodeSelectorRequirement
+	keys := make([]string, 0, len(selector.MatchLabels))
+	for key := range selector.MatchLabels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		requirements = append(requirements, corev1.NodeSelectorRequirement{
+			Key:      key,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{selector.MatchLabels[key]},
+		})
+	}
+	for _, expr := range selector.MatchExpressions {
+		var operator corev1.NodeSelectorOperator
+		switch expr.Operator {
+		case metav1.LabelSelectorOpIn:
+			operator = corev1.NodeSelectorOpIn
+		case metav1.LabelSelectorOpNotIn:
+			operator = corev1.NodeSelectorOpNotIn
+		case metav1.LabelSelectorOpExists:
+			operator = corev1.NodeSelectorOpExists
+		case metav1.LabelSelectorOpDoesNotExist:
+			operator = corev1.NodeSelectorOpDoesNotExist
+		default:
+			return nil, fmt.Errorf("invalid global.nodeSelector operator %q", expr.Operator)
+		}
+		requirements = append(requirements, corev1.NodeSelectorRequirement{
+			Key:      expr.Key,
+			Operator: operator,
+			Values:   expr.Values,
+		})
+	}
+	return requirements, nil
+}
+
+// negatedNodeSelectorRequirements returns the negation of each requirement of `global.nodeSelector`: a node is not
+// selected when it matches any of them. A node selector selecting all the nodes returns no requirement.
+func negatedNodeSelectorRequirements(selector *metav1.LabelSelector) ([]corev1.NodeSelectorRequirement, error) {
+	requirements, err := nodeSelectorRequirements(selector)
+	if err != nil {
+		return nil, err
+	}
+	for i := range requirements {
+		switch requirements[i].Operator {
+		case corev1.NodeSelectorOpIn:
+			requirements[i].Operator = corev1.NodeSelectorOpNotIn
+		case corev1.NodeSelectorOpNotIn:
+			requirements[i].Operator = corev1.NodeSelectorOpIn
+		case corev1.NodeSelectorOpExists:
+			requirements[i].Operator = corev1.NodeSelectorOpDoesNotExist
+		case corev1.NodeSelectorOpDoesNotExist:
+			requirements[i].Operator = corev1.NodeSelectorOpExists
+		}
+	}
+	return requirements, nil
+}
+
+func overlapMessage(partition nodePartition) string {
+	ddaNames := make([]string, len(partition.overlaps))
+	for i, nsName := range partition.overlaps {
+		ddaNames[i] = nsName.String()
+	}
+	nodeNames := partition.sharedNodes
+	if len(nodeNames) > maxOverlapNodesInMessage {
+		nodeNames = append(nodeNames[:maxOverlapNodesInMessage:maxOverlapNodesInMessage], "...")
+	}
+	return fmt.Sprintf("%d node(s) also selected by DatadogAgent(s) %s, only the oldest DatadogAgent runs the Agent on them (%d excluded from this DatadogAgent): %s",
+		len(partition.sharedNodes), strings.Join(ddaNames, ", "), len(partition.excludedNodes), strings.Join(nodeNames, ", "))
+}
+
+// dedupSorted sorts a list of strings and removes the duplicates
+func dedupSorted(in []string) []string {
+	sort.Strings(in)
+	out := in[:0]
+	for i, s := range in {
+		if i == 0 || s != in[i-1] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,431 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	"github.com/DataDog/datadog-operator/pkg/testutils"
+)
+
+func newPartitionTestDDA(name string, creation time.Time, selector *metav1.LabelSelector) v2alpha1.DatadogAgent {
+	dda := testutils.NewInitializedDatadogAgentBuilder(testNamespace, name).
+		WithNodeSelector(selector).
+		Build()
+	dda.UID = types.UID(name + "-uid")
+	dda.CreationTimestamp = metav1.NewTime(creation)
+	return *dda
+}
+
+func newPartitionTestNode(name string, nodeLabels map[string]string) corev1.Node {
+	return corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels}}
+}
+
+func nodeNames(nodes []corev1.Node) []string {
+	var names []string
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+	return names
+}
+
+func Test_computeNodePartition(t *testing.T) {
+	t1 := time.Now().Truncate(time.Second)
+	t2 := t1.Add(time.Minute)
+
+	teamA := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+	teamB := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}}
+	zone1 := &metav1.LabelSelector{MatchLabels: map[string]string{"zone": "1"}}
+
+	nodes := []corev1.Node{
+		newPartitionTestNode("node-a1", map[string]string{"team": "a", "zone": "1"}),
+		newPartitionTestNode("node-a2", map[string]string{"team": "a", "zone": "2"}),
+		newPartitionTestNode("node-b1", map[string]string{"team": "b", "zone": "1"}),
+		newPartitionTestNode("node-none", nil),
+	}
+
+	tests := []struct {
+		name              string
+		dda               string
+		ddas              []v2alpha1.DatadogAgent
+		wantNodes         []string
+		wantExcludedNodes []string
+		wantSharedNodes   []string
+		wantOverlaps      []types.NamespacedName
+		wantOwners        map[string]types.UID
+	}{
+		{
+			name:      "single DatadogAgent without selector",
+			dda:       "dda",
+			ddas:      []v2alpha1.DatadogAgent{newPartitionTestDDA("dda", t1, nil)},
+			wantNodes: []string{"node-a1", "node-a2", "node-b1", "node-none"},
+		},
+		{
+			name: "disjoint selectors",
+			dda:  "dda-b",
+			ddas: []v2alpha1.DatadogAgent{
+				newPartitionTestDDA("dda-a", t1, teamA),
+				newPartitionTestDDA("dda-b", t2, teamB),
+			},
+			wantNodes: []string{"node-b1"},
+		},
+		{
+			name: "overlapping selectors, newer DatadogAgent",
+			dda:  "dda-zone",
+			ddas: []v2alpha1.DatadogAgent{
+				newPartitionTestDDA("dda-a", t1, teamA),
+				newPartitionTestDDA("dda-zone", t2, zone1),
+			},
+			wantNodes:         []string{"node-b1"},
+			wantExcludedNodes: []string{"node-a1"},
+			wantSharedNodes:   []string{"node-a1"},
+			wantOverlaps:      []types.NamespacedName{{Namespace: testNamespace, Name: "dda-a"}},
+			wantOwners:        map[string]types.UID{"node-a1": "dda-a-uid"},
+		},
+		{
+			name: "overlapping selectors, older DatadogAgent",
+			dda:  "dda-a",
+			ddas: []v2alpha1.DatadogAgent{
+				newPartitionTestDDA("dda-a", t1, teamA),
+				newPartitionTestDDA("dda-zone", t2, zone1),
+			},
+			wantNodes:       []string{"node-a1", "node-a2"},
+			wantSharedNodes: []string{"node-a1"},
+			wantOverlaps:    []types.NamespacedName{{Namespace: testNamespace, Name: "dda-zone"}},
+			wantOwners:      map[string]types.UID{"node-a1": "dda-a-uid"},
+		},
+		{
+			name: "overlap between other DatadogAgents",
+			dda:  "dda-b",
+			ddas: []v2alpha1.DatadogAgent{
+				newPartitionTestDDA("dda-a", t1, teamA),
+				newPartitionTestDDA("dda-zone", t1, zone1),
+				newPartitionTestDDA("dda-b", t2, teamB),
+			},
+			wantExcludedNodes: []string{"node-b1"},
+			wantSharedNodes:   []string{"node-b1"},
+			wantOverlaps:      []types.NamespacedName{{Namespace: testNamespace, Name: "dda-zone"}},
+			wantOwners:        map[string]types.UID{"node-a1": "dda-a-uid", "node-b1": "dda-zone-uid"},
+		},
+		{
+			name: "identical creation timestamps, alphabetical order",
+			dda:  "dda-zone",
+			ddas: []v2alpha1.DatadogAgent{
+				newPartitionTestDDA("dda-zone", t1, zone1),
+				newPartitionTestDDA("dda-a", t1, teamA),
+			},
+			wantNodes:         []string{"node-b1"},
+			wantExcludedNodes: []string{"node-a1"},
+			wantSharedNodes:   []string{"node-a1"},
+			wantOverlaps:      []types.NamespacedName{{Namespace: testNamespace, Name: "dda-a"}},
+			wantOwners:        map[string]types.UID{"node-a1": "dda-a-uid"},
+		},
+		{
+			name: "DatadogAgent without selector overlaps with all the others",
+			dda:  "dda-all",
+			ddas: []v2alpha1.DatadogAgent{
+				newPartitionTestDDA("dda-a", t1, teamA),
+				newPartitionTestDDA("dda-all", t2, nil),
+			},
+			wantNodes:         []string{"node-b1", "node-none"},
+			wantExcludedNodes: []string{"node-a1", "node-a2"},
+			wantSharedNodes:   []string{"node-a1", "node-a2"},
+			wantOverlaps:      []types.NamespacedName{{Namespace: testNamespace, Name: "dda-a"}},
+			wantOwners:        map[string]types.UID{"node-a1": "dda-a-uid", "node-a2": "dda-a-uid"},
+		},
+		{
+			name: "DatadogAgent being deleted is ignored",
+			dda:  "dda-zone",
+			ddas: func() []v2alpha1.DatadogAgent {
+				deleted := newPartitionTestDDA("dda-a", t1, teamA)
+				deleted.DeletionTimestamp = &metav1.Time{Time: t2}
+				return []v2alpha1.DatadogAgent{deleted, newPartitionTestDDA("dda-zone", t2, zone1)}
+			}(),
+			wantNodes: []string{"node-a1", "node-b1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var selector *metav1.LabelSelector
+			for _, dda := range tt.ddas {
+				if dda.Name == tt.dda {
+					selector = dda.Spec.Global.NodeSelector
+				}
+			}
+
+			partition, err := computeNodePartition(types.NamespacedName{Namespace: testNamespace, Name: tt.dda}, selector, tt.ddas, nodes)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNodes, nodeNames(partition.nodes))
+			assert.Equal(t, tt.wantExcludedNodes, partition.excludedNodes)
+			assert.Equal(t, tt.wantSharedNodes, partition.sharedNodes)
+			assert.Equal(t, tt.wantOverlaps, partition.overlaps)
+			assert.Equal(t, tt.wantOwners, partition.owners)
+		})
+	}
+}
+
+func Test_nodePartitionAffinity(t *testing.T) {
+	teamA := &metav1.LabelSelector{
+		MatchLabels: map[string]string{"team": "a"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "zone", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"1"}},
+		},
+	}
+	teamARequirements := []corev1.NodeSelectorRequirement{
+		{Key: "team", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}},
+		{Key: "zone", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"1"}},
+	}
+
+	tests := []struct {
+		name           string
+		selector       *metav1.LabelSelector
+		partitionUID   types.UID
+		olderSelectors []*metav1.LabelSelector
+		want           *corev1.Affinity
+		wantErr        bool
+	}{
+		{
+			name: "no selector, single DatadogAgent",
+		},
+		{
+			name:     "selector, single DatadogAgent",
+			selector: teamA,
+			want: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: teamARequirements}},
+					},
+				},
+			},
+		},
+		{
+			name:         "selector, several DatadogAgents",
+			selector:     teamA,
+			partitionUID: "uid",
+			want: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{
+								MatchExpressions: append(append([]corev1.NodeSelectorRequirement{}, teamARequirements...),
+									corev1.NodeSelectorRequirement{Key: nodePartitionLabelKey, Operator: corev1.NodeSelectorOpDoesNotExist},
+								),
+							},
+							{
+								MatchExpressions: append(append([]corev1.NodeSelectorRequirement{}, teamARequirements...),
+									corev1.NodeSelectorRequirement{Key: nodePartitionLabelKey, Operator: corev1.NodeSelectorOpIn, Values: []string{"uid"}},
+								),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:         "no selector, several DatadogAgents",
+			partitionUID: "uid",
+			want: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: nodePartitionLabelKey, Operator: corev1.NodeSelectorOpDoesNotExist}}},
+							{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: nodePartitionLabelKey, Operator: corev1.NodeSelectorOpIn, Values: []string{"uid"}}}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:           "no selector, older DatadogAgents",
+			partitionUID:   "uid",
+			olderSelectors: []*metav1.LabelSelector{teamA, {MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "gpu", Operator: metav1.LabelSelectorOpExists}}}},
+			want: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{MatchExpressions: []corev1.NodeSelectorRequirement{
+								{Key: nodePartitionLabelKey, Operator: corev1.NodeSelectorOpDoesNotExist},
+								{Key: "team", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"a"}},
+								{Key: "gpu", Operator: corev1.NodeSelectorOpDoesNotExist},
+							}},
+							{MatchExpressions: []corev1.NodeSelectorRequirement{
+								{Key: nodePartitionLabelKey, Operator: corev1.NodeSelectorOpDoesNotExist},
+								{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"1"}},
+								{Key: "gpu", Operator: corev1.NodeSelectorOpDoesNotExist},
+							}},
+							{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: nodePartitionLabelKey, Operator: corev1.NodeSelectorOpIn, Values: []string{"uid"}}}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:           "selector, older DatadogAgent selecting all the nodes",
+			selector:       teamA,
+			partitionUID:   "uid",
+			olderSelectors: []*metav1.LabelSelector{nil},
+			want: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{
+								MatchExpressions: append(append([]corev1.NodeSelectorRequirement{}, teamARequirements...),
+									corev1.NodeSelectorRequirement{Key: nodePartitionLabelKey, Operator: corev1.NodeSelectorOpIn, Values: []string{"uid"}},
+								),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid operator",
+			selector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Gt"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nodePartitionAffinity(tt.selector, tt.partitionUID, tt.olderSelectors)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_applyNodePartition(t *testing.T) {
+	sch := runtime.NewScheme()
+	_ = scheme.AddToScheme(sch)
+	_ = v2alpha1.AddToScheme(sch)
+
+	t1 := time.Now().Truncate(time.Second)
+	teamA := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+
+	ddaA := newPartitionTestDDA("dda-a", t1, teamA)
+	ddaAll := newPartitionTestDDA("dda-all", t1.Add(time.Minute), nil)
+	nodeA := newPartitionTestNode("node-a", map[string]string{"team": "a"})
+	nodeB := newPartitionTestNode("node-b", map[string]string{"team": "b"})
+	// nodeStale is labelled with the UID of a deleted DatadogAgent
+	nodeStale := newPartitionTestNode("node-stale", map[string]string{"team": "b", nodePartitionLabelKey: "deleted-uid"})
+
+	tests := []struct {
+		name             string
+		dda              v2alpha1.DatadogAgent
+		objects          []client.Object
+		wantTerms        int
+		wantNodeLabels   map[string]string
+		wantConditionSet bool
+		wantOverlap      metav1.ConditionStatus
+	}{
+		{
+			name:    "single DatadogAgent without selector",
+			dda:     ddaAll,
+			objects: []client.Object{ddaAll.DeepCopy(), nodeA.DeepCopy(), nodeB.DeepCopy()},
+		},
+		{
+			name:      "single DatadogAgent with selector",
+			dda:       ddaA,
+			objects:   []client.Object{ddaA.DeepCopy(), nodeA.DeepCopy(), nodeB.DeepCopy()},
+			wantTerms: 1,
+		},
+		{
+			name:             "overlap with an older DatadogAgent",
+			dda:              ddaAll,
+			objects:          []client.Object{ddaA.DeepCopy(), ddaAll.DeepCopy(), nodeA.DeepCopy(), nodeB.DeepCopy(), nodeStale.DeepCopy()},
+			wantTerms:        2,
+			wantNodeLabels:   map[string]string{"node-a": "dda-a-uid"},
+			wantConditionSet: true,
+			wantOverlap:      metav1.ConditionTrue,
+		},
+		{
+			name:             "overlap with a newer DatadogAgent",
+			dda:              ddaA,
+			objects:          []client.Object{ddaA.DeepCopy(), ddaAll.DeepCopy(), nodeA.DeepCopy(), nodeB.DeepCopy(), nodeStale.DeepCopy()},
+			wantTerms:        2,
+			wantNodeLabels:   map[string]string{"node-a": "dda-a-uid"},
+			wantConditionSet: true,
+			wantOverlap:      metav1.ConditionTrue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciler{client: fake.NewClientBuilder().WithScheme(sch).WithObjects(tt.objects...).Build()}
+			dda := tt.dda.DeepCopy()
+			status := &v2alpha1.DatadogAgentStatus{}
+
+			require.NoError(t, r.applyNodePartition(context.TODO(), dda, status, metav1.NewTime(t1)))
+
+			override := dda.Spec.Override[v2alpha1.NodeAgentComponentName]
+			if tt.wantTerms == 0 {
+				assert.Nil(t, override)
+			} else {
+				require.NotNil(t, override)
+				require.NotNil(t, override.Affinity)
+				assert.Len(t, override.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms, tt.wantTerms)
+			}
+
+			nodeList := corev1.NodeList{}
+			require.NoError(t, r.client.List(context.TODO(), &nodeList))
+			for _, node := range nodeList.Items {
+				value, found := node.Labels[nodePartitionLabelKey]
+				if wantValue, wantFound := tt.wantNodeLabels[node.Name]; wantFound {
+					assert.Equal(t, wantValue, value, node.Name)
+				} else {
+					assert.False(t, found, node.Name)
+				}
+			}
+
+			cond := meta.FindStatusCondition(status.Conditions, common.NodeSelectorOverlapConditionType)
+			if !tt.wantConditionSet {
+				assert.Nil(t, cond)
+			} else {
+				require.NotNil(t, cond)
+				assert.Equal(t, tt.wantOverlap, cond.Status)
+			}
+		})
+	}
+}
+
+func Test_nodePartitionCleanup(t *testing.T) {
+	sch := runtime.NewScheme()
+	_ = scheme.AddToScheme(sch)
+
+	owned := newPartitionTestNode("node-owned", map[string]string{"team": "a", nodePartitionLabelKey: "dda-uid"})
+	other := newPartitionTestNode("node-other", map[string]string{"team": "a", nodePartitionLabelKey: "other-uid"})
+	r := &Reconciler{client: fake.NewClientBuilder().WithScheme(sch).WithObjects(owned.DeepCopy(), other.DeepCopy()).Build()}
+
+	require.NoError(t, r.nodePartitionCleanup(context.TODO(), "dda-uid"))
+
+	node := &corev1.Node{}
+	require.NoError(t, r.client.Get(context.TODO(), types.NamespacedName{Name: owned.Name}, node))
+	assert.Equal(t, map[string]string{"team": "a"}, node.Labels)
+	require.NoError(t, r.client.Get(context.TODO(), types.NamespacedName{Name: other.Name}, node))
+	assert.Equal(t, "other-uid", node.Labels[nodePartitionLabelKey])
+}
@@ -0,0 +1,45 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// observedStatusRefreshPeriod is the minimum period between two observations of a status of an unchanged DatadogAgent
+// which is observed in the cluster rather than computed from the DatadogAgent, as observing it is expensive.
+const observedStatusRefreshPeriod = 5 * time.Minute
+
+// uncachedReader returns the reader of the API server, for the objects which are not in the cache.
+func (r *Reconciler) uncachedReader() client.Reader {
+	if r.apiReader != nil {
+		return r.apiReader
+	}
+	return r.client
+}
+
+// isObservedStatusFresh returns true when a status observed at lastUpdate does not need to be observed again yet.
+func isObservedStatusFresh(lastUpdate *metav1.Time, now metav1.Time) bool {
+	return lastUpdate != nil && now.Sub(lastUpdate.Time) < observedStatusRefreshPeriod
+}
+
+// observeStatus returns previous when it is still fresh, and the status returned by observe otherwise.
+// An observation error is logged and previous is kept, it does not fail the reconcile.
+func observeStatus[T any](logger logr.Logger, previous *T, fresh bool, errMsg string, observe func() (*T, error)) *T {
+	if previous != nil && fresh {
+		return previous
+	}
+	status, err := observe()
+	if err != nil {
+		logger.Error(err, errMsg)
+		return previous
+	}
+	return status
+}
@@ -10,6 +10,7 @@ import (
 	v1 "k8s.io/api/apps/v1"
 
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
 )
 
 // DaemonSet overrides a DaemonSet according to the given override options
@@ -31,6 +32,10 @@ func DaemonSet(daemonSet *v1.DaemonSet, override *v2alpha1.DatadogAgentComponent
 
 		daemonSet.Spec.UpdateStrategy.Type = v1.DaemonSetUpdateStrategyType(override.UpdateStrategy.Type)
 	}
+	// With a staged rollout, the operator deletes the outdated pods itself
+	if override.StagedRollout != nil && apiutils.BoolValue(override.StagedRollout.Enabled) {
+		daemonSet.Spec.UpdateStrategy = v1.DaemonSetUpdateStrategy{Type: v1.OnDeleteDaemonSetStrategyType}
+	}
 }
 
 // ExtendedDaemonSet overrides an ExtendedDaemonSet according to the given override options
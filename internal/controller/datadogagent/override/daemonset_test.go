@@ -115,6 +115,24 @@ func TestDaemonSet(t *testing.T) {
 	assert.Equal(t, "new-name", daemonSet.Name)
 }
 
+func TestDaemonSetStagedRollout(t *testing.T) {
+	daemonSet := makeDaemonSet(
+		apiutils.NewStringPointer("RollingUpdate"),
+		apiutils.NewStringPointer("50%"),
+		nil,
+	)
+	override := makeOverride(
+		apiutils.NewStringPointer("RollingUpdate"),
+		apiutils.NewStringPointer("10%"),
+		nil,
+	)
+	override.StagedRollout = &v2alpha1.StagedRolloutConfig{Enabled: apiutils.NewBoolPointer(true)}
+
+	DaemonSet(&daemonSet, &override)
+
+	assert.Equal(t, v1.DaemonSetUpdateStrategy{Type: v1.OnDeleteDaemonSetStrategyType}, daemonSet.Spec.UpdateStrategy)
+}
+
 func makeDaemonSet(strategyType *string, strategyMaxUnavailable *string, strategyMaxSurge *string) v1.DaemonSet {
 	daemonSet := v1.DaemonSet{
 		Spec: v1.DaemonSetSpec{
@@ -156,6 +156,23 @@ func TestDependencies(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "override otelAgentGateway createPDB without errors",
+			dda: v2alpha1.DatadogAgent{
+				Spec: v2alpha1.DatadogAgentSpec{
+					Override: map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride{
+						v2alpha1.OtelAgentGatewayComponentName: {
+							CreatePodDisruptionBudget: apiutils.NewBoolPointer(true),
+						},
+					},
+					Features: &v2alpha1.DatadogFeatures{
+						OtelAgentGateway: &v2alpha1.OtelAgentGatewayFeatureConfig{
+							Enabled: apiutils.NewBoolPointer(true),
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -467,6 +468,41 @@ func TestPodTemplateSpec(t *testing.T) {
 				assert.True(t, found)
 			},
 		},
+		{
+			name: "override confd with configMap merged in a projected volume",
+			existingManager: func() *fake.PodTemplateManagers {
+				manager := fake.NewPodTemplateManagers(t, v1.PodTemplateSpec{})
+				manager.Volume().AddVolume(&v1.Volume{
+					Name: common.ConfdVolumeName,
+					VolumeSource: v1.VolumeSource{
+						Projected: &v1.ProjectedVolumeSource{
+							Sources: []v1.VolumeProjection{
+								{ConfigMap: &v1.ConfigMapProjection{LocalObjectReference: v1.LocalObjectReference{Name: "datadog-checks"}}},
+							},
+						},
+					},
+				})
+				return manager
+			},
+			override: v2alpha1.DatadogAgentComponentOverride{
+				ExtraConfd: &v2alpha1.MultiCustomConfig{
+					ConfigMap: &v2alpha1.ConfigMapConfig{
+						Name: "extra-confd",
+					},
+				},
+			},
+			validateManager: func(t *testing.T, manager *fake.PodTemplateManagers) {
+				var sources []string
+				for _, vol := range manager.VolumeMgr.Volumes {
+					if vol.Name == common.ConfdVolumeName && vol.Projected != nil {
+						for _, source := range vol.Projected.Sources {
+							sources = append(sources, source.ConfigMap.Name)
+						}
+					}
+				}
+				assert.Equal(t, []string{"extra-confd", "datadog-checks"}, sources)
+			},
+		},
 		{
 			name: "override checksd with configMap",
 			existingManager: func() *fake.PodTemplateManagers {
@@ -1008,7 +1044,7 @@ func TestPodTemplateSpec(t *testing.T) {
 			testLogger := zap.New(zap.UseDevMode(true))
 			logger := testLogger.WithValues("test", t.Name())
 
-			PodTemplateSpec(logger, manager, &test.override, v2alpha1.NodeAgentComponentName, "datadog-agent")
+			require.NoError(t, PodTemplateSpec(logger, manager, &test.override, v2alpha1.NodeAgentComponentName, "datadog-agent"))
 
 			test.validateManager(t, manager)
 		})
@@ -41,7 +41,7 @@ func (r *Reconciler) applyProfilesToDDAISpec(ctx context.Context, logger logr.Lo
 	var err error
 
 	var nodeList []corev1.Node
-	nodeList, err = r.getNodeList(ctx)
+	nodeList, err = r.getDatadogAgentNodeList(ctx, types.NamespacedName{Namespace: ddai.Namespace, Name: ddai.Name}, ddai.Spec.Global.NodeSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -107,15 +107,24 @@ func setProfileSpec(ddai *v1alpha1.DatadogAgentInternal, profile *v1alpha1.Datad
 	// create affinity from ddai and profile prior to re-set after replacing the ddai spec
 	affinity := setProfileDDAIAffinity(ddai, profile)
 	if !agentprofile.IsDefaultProfile(profile.Namespace, profile.Name) {
+		gatewayEnabled := isOtelAgentGatewayEnabled(&ddai.Spec) || isOtelAgentGatewayEnabled(profile.Spec.Config)
 		ddai.Spec = *profile.Spec.Config
-		// DCA and CCR are auto disabled for user created profiles
+		// DCA, CCR and OTel Agent Gateway are auto disabled for user created profiles
 		disableComponent(ddai, v2alpha1.ClusterAgentComponentName)
 		disableComponent(ddai, v2alpha1.ClusterChecksRunnerComponentName)
+		// The OTel Agent Gateway override is only added when the gateway is enabled, to keep the spec of the other profiles unchanged
+		if gatewayEnabled {
+			disableComponent(ddai, v2alpha1.OtelAgentGatewayComponentName)
+		}
 		setProfileNodeAgentOverride(ddai, profile)
 	}
 	ddai.Spec.Override[v2alpha1.NodeAgentComponentName].Affinity = affinity
 }
 
+func isOtelAgentGatewayEnabled(spec *v2alpha1.DatadogAgentSpec) bool {
+	return spec != nil && spec.Features != nil && spec.Features.OtelAgentGateway != nil && apiutils.BoolValue(spec.Features.OtelAgentGateway.Enabled)
+}
+
 func disableComponent(ddai *v1alpha1.DatadogAgentInternal, componentName v2alpha1.ComponentName) {
 	if _, ok := ddai.Spec.Override[componentName]; !ok {
 		ddai.Spec.Override[componentName] = &v2alpha1.DatadogAgentComponentOverride{}
@@ -552,6 +552,47 @@ func Test_setProfileSpec(t *testing.T) {
 	}
 }
 
+func Test_setProfileSpecOtelAgentGateway(t *testing.T) {
+	profile := v1alpha1.DatadogAgentProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo-profile",
+			Namespace: "bar",
+		},
+		Spec: v1alpha1.DatadogAgentProfileSpec{
+			Config: &v2alpha1.DatadogAgentSpec{
+				Override: map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride{
+					v2alpha1.NodeAgentComponentName: {},
+				},
+			},
+		},
+	}
+
+	for _, gatewayEnabled := range []bool{false, true} {
+		ddai := v1alpha1.DatadogAgentInternal{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "bar",
+			},
+			Spec: v2alpha1.DatadogAgentSpec{
+				Features: &v2alpha1.DatadogFeatures{
+					OtelAgentGateway: &v2alpha1.OtelAgentGatewayFeatureConfig{Enabled: apiutils.NewBoolPointer(gatewayEnabled)},
+				},
+				Override: map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride{
+					v2alpha1.NodeAgentComponentName: {},
+				},
+			},
+		}
+
+		setProfileSpec(&ddai, profile.DeepCopy())
+
+		gatewayOverride, found := ddai.Spec.Override[v2alpha1.OtelAgentGatewayComponentName]
+		assert.Equal(t, gatewayEnabled, found)
+		if gatewayEnabled {
+			assert.True(t, apiutils.BoolValue(gatewayOverride.Disabled))
+		}
+	}
+}
+
 func Test_setProfileDDAIMeta(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -0,0 +1,253 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package recommendation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+const (
+	// usageSamplingPeriod is the period between two reads of the usage of the node Agent containers
+	usageSamplingPeriod = 5 * time.Minute
+	// usageHistoryWindow is the period over which the usage samples are aggregated
+	usageHistoryWindow = 24 * time.Hour
+)
+
+// Collector samples the usage of the node Agent containers of the DatadogAgents with resource recommendations
+// in the background, as it requires querying the metrics API or the kubelets of all the nodes, and keeps the
+// recommendations computed from each sample over the history window. It is run by the controller-runtime manager.
+// The history is only kept in memory: it is lost when the operator restarts or loses the leader election.
+type Collector struct {
+	client       client.Client
+	reader       client.Reader
+	restClient   rest.Interface
+	platformInfo *kubernetes.PlatformInfo
+	logger       logr.Logger
+
+	mutex     sync.Mutex
+	histories map[types.NamespacedName]*history
+}
+
+// history holds the recommendations computed from the usage samples of a DatadogAgent, oldest first.
+type history struct {
+	source  string
+	samples []usageSample
+	lastErr error
+}
+
+type usageSample struct {
+	time       time.Time
+	daemonSets []v2alpha1.DaemonSetResourceRecommendation
+}
+
+// Recommendations are the recommendations of a DatadogAgent aggregated over its usage samples.
+type Recommendations struct {
+	// LastSample is the time of the last usage sample.
+	LastSample time.Time
+	// Source is the source of the usage of the last sample.
+	Source string
+	// DaemonSets contains the recommendations of the DaemonSets of the last sample, sorted by name.
+	DaemonSets []v2alpha1.DaemonSetResourceRecommendation
+	// FirstSamples contains the time of the first usage sample of each DaemonSet in the history window.
+	FirstSamples map[string]time.Time
+}
+
+// NewCollector returns a Collector reading the DatadogAgents with client, and the pods and their usage with reader or restClient.
+// The reader should not be cached, so that listing the node Agent pods does not cache all the pods of the cluster.
+func NewCollector(client client.Client, reader client.Reader, restClient rest.Interface, platformInfo *kubernetes.PlatformInfo, logger logr.Logger) *Collector {
+	return &Collector{
+		client:       client,
+		reader:       reader,
+		restClient:   restClient,
+		platformInfo: platformInfo,
+		logger:       logger,
+		histories:    map[types.NamespacedName]*history{},
+	}
+}
+
+// Start samples the usage every usageSamplingPeriod until ctx is done.
+func (c *Collector) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, c.collect, usageSamplingPeriod)
+	return nil
+}
+
+// NeedLeaderElection returns true, as only the leader reconciles the DatadogAgents.
+func (c *Collector) NeedLeaderElection() bool {
+	return true
+}
+
+// Recommendations returns the recommendations of the DatadogAgent aggregated over its usage samples, or nil when its
+// usage has not been sampled. The error of the last sampling is returned when no sample succeeded.
+func (c *Collector) Recommendations(dda types.NamespacedName) (*Recommendations, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	h := c.histories[dda]
+	if h == nil {
+		return nil, nil
+	}
+	if len(h.samples) == 0 {
+		return nil, h.lastErr
+	}
+	return h.aggregate(), nil
+}
+
+func (c *Collector) collect(ctx context.Context) {
+	ddaList := &v2alpha1.DatadogAgentList{}
+	if err := c.client.List(ctx, ddaList); err != nil {
+		c.logger.Error(err, "Unable to list the DatadogAgents")
+		return
+	}
+
+	var ddas []*v2alpha1.DatadogAgent
+	enabled := map[types.NamespacedName]struct{}{}
+	for i := range ddaList.Items {
+		if IsEnabled(&ddaList.Items[i].Spec) {
+			ddas = append(ddas, &ddaList.Items[i])
+			enabled[client.ObjectKeyFromObject(&ddaList.Items[i])] = struct{}{}
+		}
+	}
+	c.mutex.Lock()
+	for key := range c.histories {
+		if _, ok := enabled[key]; !ok {
+			delete(c.histories, key)
+		}
+	}
+	c.mutex.Unlock()
+
+	for _, dda := range ddas {
+		c.sample(ctx, dda, time.Now())
+	}
+}
+
+// sample reads the usage of the node Agent containers of the DatadogAgent, and adds the recommendations computed
+// from it to its history. A read error is logged and recorded, the usage read before it is still sampled.
+func (c *Collector) sample(ctx context.Context, dda *v2alpha1.DatadogAgent, now time.Time) {
+	logger := c.logger.WithValues("datadogagent", client.ObjectKeyFromObject(dda))
+
+	// The node Agent pods of every DaemonSet, including the profile ones, carry the labels of the DatadogAgent
+	podList := &corev1.PodList{}
+	err := c.reader.List(ctx, podList, client.InNamespace(dda.Namespace), client.MatchingLabels{
+		apicommon.AgentDeploymentNameLabelKey:      dda.Name,
+		apicommon.AgentDeploymentComponentLabelKey: constants.DefaultAgentResourceSuffix,
+	})
+	var source string
+	var usage map[string]ContainersUsage
+	if err != nil {
+		err = fmt.Errorf("unable to list the node Agent pods: %w", err)
+	} else {
+		source, usage, err = ReadPodsUsage(ctx, c.reader, c.restClient, c.platformInfo, dda.Namespace, podList.Items)
+	}
+	if err != nil {
+		logger.Error(err, "Unable to read the usage of the node Agent containers", "source", source)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	key := client.ObjectKeyFromObject(dda)
+	h := c.histories[key]
+	if h == nil {
+		h = &history{}
+		c.histories[key] = h
+	}
+	h.lastErr = err
+	if len(usage) > 0 {
+		h.source = source
+		h.samples = append(h.samples, usageSample{time: now, daemonSets: Recommend(podList.Items, usage)})
+	}
+	h.samples = slices.DeleteFunc(h.samples, func(s usageSample) bool {
+		return now.Sub(s.time) > usageHistoryWindow
+	})
+}
+
+// aggregate returns the recommendations of the DaemonSets of the last sample: the requests are the percentile of the
+// requests of the samples, and the memory limit is the maximum of their memory limits.
+func (h *history) aggregate() *Recommendations {
+	last := h.samples[len(h.samples)-1]
+	recommendations := &Recommendations{
+		LastSample:   last.time,
+		Source:       h.source,
+		DaemonSets:   make([]v2alpha1.DaemonSetResourceRecommendation, 0, len(last.daemonSets)),
+		FirstSamples: map[string]time.Time{},
+	}
+	for _, ds := range last.daemonSets {
+		aggregated := v2alpha1.DaemonSetResourceRecommendation{
+			Name:       ds.Name,
+			Pods:       ds.Pods,
+			Containers: make([]v2alpha1.ContainerResourceRecommendation, 0, len(ds.Containers)),
+		}
+		for _, container := range ds.Containers {
+			aggregated.Containers = append(aggregated.Containers, h.aggregateContainer(ds.Name, container.Name))
+		}
+		recommendations.DaemonSets = append(recommendations.DaemonSets, aggregated)
+		for _, s := range h.samples {
+			if slices.ContainsFunc(s.daemonSets, func(sampled v2alpha1.DaemonSetResourceRecommendation) bool { return sampled.Name == ds.Name }) {
+				recommendations.FirstSamples[ds.Name] = s.time
+				break
+			}
+		}
+	}
+	return recommendations
+}
+
+func (h *history) aggregateContainer(dsName, name string) v2alpha1.ContainerResourceRecommendation {
+	var cpuMillis, memoryBytes, memoryLimitBytes []int64
+	for _, s := range h.samples {
+		for _, ds := range s.daemonSets {
+			if ds.Name != dsName {
+				continue
+			}
+			for _, container := range ds.Containers {
+				if container.Name != name {
+					continue
+				}
+				if quantity, ok := container.Requests[corev1.ResourceCPU]; ok {
+					cpuMillis = append(cpuMillis, quantity.MilliValue())
+				}
+				if quantity, ok := container.Requests[corev1.ResourceMemory]; ok {
+					memoryBytes = append(memoryBytes, quantity.Value())
+				}
+				if quantity, ok := container.Limits[corev1.ResourceMemory]; ok {
+					memoryLimitBytes = append(memoryLimitBytes, quantity.Value())
+				}
+			}
+		}
+	}
+
+	recommendation := v2alpha1.ContainerResourceRecommendation{
+		Name:     name,
+		Requests: corev1.ResourceList{},
+	}
+	if len(cpuMillis) > 0 {
+		recommendation.Requests[corev1.ResourceCPU] = *resource.NewMilliQuantity(percentile(cpuMillis, requestPercentile), resource.DecimalSI)
+	}
+	if len(memoryBytes) > 0 {
+		recommendation.Requests[corev1.ResourceMemory] = *resource.NewQuantity(percentile(memoryBytes, requestPercentile), resource.BinarySI)
+	}
+	if len(memoryLimitBytes) > 0 {
+		recommendation.Limits = corev1.ResourceList{
+			corev1.ResourceMemory: *resource.NewQuantity(slices.Max(memoryLimitBytes), resource.BinarySI),
+		}
+	}
+	return recommendation
+}
@@ -0,0 +1,138 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package recommendation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+const collectorTestSummary = `{
+  "pods": [
+    {
+      "podRef": {"name": "dda-agent-abcde", "namespace": "datadog"},
+      "containers": [{"name": "agent", "cpu": {"usageNanoCores": 100000000}, "memory": {"workingSetBytes": 209715200}}]
+    }
+  ]
+}`
+
+func Test_Collector_sample(t *testing.T) {
+	sch := runtime.NewScheme()
+	_ = scheme.AddToScheme(sch)
+
+	dda := &v2alpha1.DatadogAgent{ObjectMeta: metav1.ObjectMeta{Namespace: "datadog", Name: "dda"}}
+	key := types.NamespacedName{Namespace: "datadog", Name: "dda"}
+	agentPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "datadog",
+			Name:      "dda-agent-abcde",
+			Labels: map[string]string{
+				apicommon.AgentDeploymentNameLabelKey:      "dda",
+				apicommon.AgentDeploymentComponentLabelKey: "agent",
+			},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: "dda-agent"}},
+		},
+		Spec: corev1.PodSpec{NodeName: "node-1"},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(sch).WithObjects(agentPod).Build()
+	platformInfo := kubernetes.NewPlatformInfo(nil, nil, nil)
+	now := time.Now().Truncate(time.Second)
+
+	t.Run("error without sample", func(t *testing.T) {
+		c := NewCollector(fakeClient, fakeClient, newKubeletRESTClient(nil), &platformInfo, logr.Discard())
+
+		c.sample(context.TODO(), dda, now)
+
+		recommendations, err := c.Recommendations(key)
+		assert.Error(t, err)
+		assert.Nil(t, recommendations)
+	})
+
+	t.Run("usage is read from the kubelets and kept over the history window", func(t *testing.T) {
+		c := NewCollector(fakeClient, fakeClient, newKubeletRESTClient(map[string]string{"node-1": collectorTestSummary}), &platformInfo, logr.Discard())
+
+		recommendations, err := c.Recommendations(key)
+		require.NoError(t, err)
+		assert.Nil(t, recommendations)
+
+		c.sample(context.TODO(), dda, now.Add(-25*time.Hour))
+		c.sample(context.TODO(), dda, now.Add(-time.Hour))
+		c.sample(context.TODO(), dda, now)
+
+		recommendations, err = c.Recommendations(key)
+		require.NoError(t, err)
+		require.NotNil(t, recommendations)
+		assert.Equal(t, now, recommendations.LastSample)
+		assert.Equal(t, SourceKubelet, recommendations.Source)
+		assert.Equal(t, map[string]time.Time{"dda-agent": now.Add(-time.Hour)}, recommendations.FirstSamples)
+		require.Len(t, recommendations.DaemonSets, 1)
+		ds := recommendations.DaemonSets[0]
+		assert.Equal(t, "dda-agent", ds.Name)
+		assert.Equal(t, int32(1), ds.Pods)
+		require.Len(t, ds.Containers, 1)
+		assert.Equal(t, map[corev1.ResourceName]string{corev1.ResourceCPU: "115m", corev1.ResourceMemory: "230Mi"}, resourceStrings(ds.Containers[0].Requests))
+		assert.Equal(t, map[corev1.ResourceName]string{corev1.ResourceMemory: "250Mi"}, resourceStrings(ds.Containers[0].Limits))
+	})
+}
+
+func Test_history_aggregate(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	newSample := func(sampleTime time.Time, pods int32, cpu, memory, memoryLimit string, dsNames ...string) usageSample {
+		s := usageSample{time: sampleTime}
+		for _, dsName := range dsNames {
+			s.daemonSets = append(s.daemonSets, v2alpha1.DaemonSetResourceRecommendation{
+				Name: dsName,
+				Pods: pods,
+				Containers: []v2alpha1.ContainerResourceRecommendation{
+					{Name: "agent", Requests: newUsage(cpu, memory), Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(memoryLimit)}},
+				},
+			})
+		}
+		return s
+	}
+
+	h := &history{source: SourceMetricsAPI}
+	for i := range 9 {
+		h.samples = append(h.samples, newSample(now.Add(time.Duration(i-10)*time.Hour), 3, "100m", "100Mi", "120Mi", "dda-agent", "dda-agent-removed"))
+	}
+	h.samples = append(h.samples,
+		newSample(now.Add(-time.Hour), 4, "500m", "400Mi", "600Mi", "dda-agent", "dda-agent-gpu"),
+		newSample(now, 5, "200m", "150Mi", "200Mi", "dda-agent", "dda-agent-gpu"),
+	)
+
+	got := h.aggregate()
+
+	assert.Equal(t, now, got.LastSample)
+	assert.Equal(t, SourceMetricsAPI, got.Source)
+	assert.Equal(t, map[string]time.Time{"dda-agent": now.Add(-10 * time.Hour), "dda-agent-gpu": now.Add(-time.Hour)}, got.FirstSamples)
+	require.Len(t, got.DaemonSets, 2)
+
+	// The requests are the 90th percentile of the sampled requests, and the memory limit is their maximum
+	assert.Equal(t, "dda-agent", got.DaemonSets[0].Name)
+	assert.Equal(t, int32(5), got.DaemonSets[0].Pods)
+	require.Len(t, got.DaemonSets[0].Containers, 1)
+	assert.Equal(t, map[corev1.ResourceName]string{corev1.ResourceCPU: "200m", corev1.ResourceMemory: "150Mi"}, resourceStrings(got.DaemonSets[0].Containers[0].Requests))
+	assert.Equal(t, map[corev1.ResourceName]string{corev1.ResourceMemory: "600Mi"}, resourceStrings(got.DaemonSets[0].Containers[0].Limits))
+
+	assert.Equal(t, "dda-agent-gpu", got.DaemonSets[1].Name)
+	assert.Equal(t, map[corev1.ResourceName]string{corev1.ResourceCPU: "500m", corev1.ResourceMemory: "400Mi"}, resourceStrings(got.DaemonSets[1].Containers[0].Requests))
+}
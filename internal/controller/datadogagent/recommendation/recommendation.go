@@ -0,0 +1,275 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package recommendation
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	edsv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+)
+
+const (
+	// requestPercentile is the percentile of the usage across the pods of a DaemonSet used for the requests
+	requestPercentile = 90
+	// requestHeadroomPercent is the headroom added to the usage percentile for the requests
+	requestHeadroomPercent = 15
+	// memoryLimitHeadroomPercent is the headroom added to the maximum memory usage for the memory limit
+	memoryLimitHeadroomPercent = 25
+
+	// minApplyInterval is the minimum usage history of a DaemonSet before its recommendations are applied,
+	// and the minimum period between two updates of its applied recommendations
+	minApplyInterval = 6 * time.Hour
+	// applyThresholdPercent is the minimum difference between a recommended resource and the applied one
+	// for the applied recommendations to be updated
+	applyThresholdPercent = 20
+
+	minCPURequestMillis = 10
+	minMemoryRequest    = 32 * mebibyte
+	mebibyte            = 1024 * 1024
+)
+
+// IsEnabled returns true if the resource recommendations are enabled.
+func IsEnabled(ddaSpec *v2alpha1.DatadogAgentSpec) bool {
+	return ddaSpec.Global != nil && ddaSpec.Global.ResourceRecommendations != nil &&
+		apiutils.BoolValue(ddaSpec.Global.ResourceRecommendations.Enabled)
+}
+
+// IsApplyEnabled returns true if the resource recommendations are enabled and applied to the containers.
+func IsApplyEnabled(ddaSpec *v2alpha1.DatadogAgentSpec) bool {
+	return IsEnabled(ddaSpec) && apiutils.BoolValue(ddaSpec.Global.ResourceRecommendations.Apply)
+}
+
+// Recommend computes the recommended resources of the containers of each DaemonSet from the usage of its pods.
+// The DaemonSets are sorted by name, and those without pod usage are skipped.
+func Recommend(pods []corev1.Pod, usage map[string]ContainersUsage) []v2alpha1.DaemonSetResourceRecommendation {
+	usageByDaemonSet := map[string][]ContainersUsage{}
+	for i := range pods {
+		dsName := daemonSetName(&pods[i])
+		podUsage, ok := usage[pods[i].Name]
+		if dsName == "" || !ok {
+			continue
+		}
+		usageByDaemonSet[dsName] = append(usageByDaemonSet[dsName], podUsage)
+	}
+
+	recommendations := make([]v2alpha1.DaemonSetResourceRecommendation, 0, len(usageByDaemonSet))
+	for dsName, podsUsage := range usageByDaemonSet {
+		recommendations = append(recommendations, v2alpha1.DaemonSetResourceRecommendation{
+			Name:       dsName,
+			Pods:       int32(len(podsUsage)),
+			Containers: recommendContainers(podsUsage),
+		})
+	}
+	sort.Slice(recommendations, func(i, j int) bool {
+		return recommendations[i].Name < recommendations[j].Name
+	})
+	return recommendations
+}
+
+// daemonSetName returns the name of the ExtendedDaemonSet or DaemonSet managing the pod.
+func daemonSetName(pod *corev1.Pod) string {
+	if name := pod.Labels[edsv1alpha1.ExtendedDaemonSetNameLabelKey]; name != "" {
+		return name
+	}
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "DaemonSet" {
+			return owner.Name
+		}
+	}
+	return ""
+}
+
+// recommendContainers sets the requests to the usage percentile with a headroom, and the memory limit
+// to the maximum memory usage with a headroom. CPU is rounded up to the millicore and memory to the mebibyte.
+func recommendContainers(podsUsage []ContainersUsage) []v2alpha1.ContainerResourceRecommendation {
+	cpuMillis := map[string][]int64{}
+	memoryBytes := map[string][]int64{}
+	for _, containers := range podsUsage {
+		for name, containerUsage := range containers {
+			if quantity, ok := containerUsage[corev1.ResourceCPU]; ok {
+				cpuMillis[name] = append(cpuMillis[name], quantity.MilliValue())
+			}
+			if quantity, ok := containerUsage[corev1.ResourceMemory]; ok {
+				memoryBytes[name] = append(memoryBytes[name], quantity.Value())
+			}
+		}
+	}
+
+	names := make([]string, 0, len(cpuMillis)+len(memoryBytes))
+	for name := range cpuMillis {
+		names = append(names, name)
+	}
+	for name := range memoryBytes {
+		if _, ok := cpuMillis[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	recommendations := make([]v2alpha1.ContainerResourceRecommendation, 0, len(names))
+	for _, name := range names {
+		recommendation := v2alpha1.ContainerResourceRecommendation{
+			Name:     name,
+			Requests: corev1.ResourceList{},
+		}
+		if values := cpuMillis[name]; len(values) > 0 {
+			request := max(withHeadroom(percentile(values, requestPercentile), requestHeadroomPercent), minCPURequestMillis)
+			recommendation.Requests[corev1.ResourceCPU] = *resource.NewMilliQuantity(request, resource.DecimalSI)
+		}
+		if values := memoryBytes[name]; len(values) > 0 {
+			request := max(roundUp(withHeadroom(percentile(values, requestPercentile), requestHeadroomPercent), mebibyte), minMemoryRequest)
+			limit := max(roundUp(withHeadroom(slices.Max(values), memoryLimitHeadroomPercent), mebibyte), request)
+			recommendation.Requests[corev1.ResourceMemory] = *resource.NewQuantity(request, resource.BinarySI)
+			recommendation.Limits = corev1.ResourceList{
+				corev1.ResourceMemory: *resource.NewQuantity(limit, resource.BinarySI),
+			}
+		}
+		recommendations = append(recommendations, recommendation)
+	}
+	return recommendations
+}
+
+// percentile returns the nearest-rank percentile of the values.
+func percentile(values []int64, p int) int64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank-1, 0)]
+}
+
+func withHeadroom(value int64, percent int64) int64 {
+	return (value*(100+percent) + 99) / 100
+}
+
+func roundUp(value int64, unit int64) int64 {
+	return (value + unit - 1) / unit * unit
+}
+
+// UpdateApplied sets the applied recommendations of each DaemonSet of status to those of previous, and replaces them
+// with its current recommendations once the usage of the DaemonSet has been sampled for minApplyInterval since
+// firstSamples, at most every minApplyInterval, and when a recommended resource differs by more than
+// applyThresholdPercent from the applied one.
+func UpdateApplied(status, previous *v2alpha1.ResourceRecommendationsStatus, firstSamples map[string]time.Time, now metav1.Time) {
+	for i := range status.DaemonSets {
+		ds := &status.DaemonSets[i]
+		if previous != nil {
+			if idx := slices.IndexFunc(previous.DaemonSets, func(prev v2alpha1.DaemonSetResourceRecommendation) bool {
+				return prev.Name == ds.Name
+			}); idx >= 0 {
+				ds.Applied = previous.DaemonSets[idx].Applied
+				ds.LastApplied = previous.DaemonSets[idx].LastApplied
+			}
+		}
+
+		firstSample, ok := firstSamples[ds.Name]
+		if !ok || now.Sub(firstSample) < minApplyInterval {
+			continue
+		}
+		if ds.LastApplied != nil && now.Sub(ds.LastApplied.Time) < minApplyInterval {
+			continue
+		}
+		if len(ds.Applied) > 0 && !exceedsApplyThreshold(ds.Applied, ds.Containers) {
+			continue
+		}
+		ds.Applied = make([]v2alpha1.ContainerResourceRecommendation, 0, len(ds.Containers))
+		for _, container := range ds.Containers {
+			ds.Applied = append(ds.Applied, *container.DeepCopy())
+		}
+		ds.LastApplied = now.DeepCopy()
+	}
+}
+
+// exceedsApplyThreshold returns true if the recommendations have other containers or resources than the applied ones,
+// or if one of their resources differs by more than applyThresholdPercent from the applied one.
+func exceedsApplyThreshold(applied, recommended []v2alpha1.ContainerResourceRecommendation) bool {
+	if len(applied) != len(recommended) {
+		return true
+	}
+	for _, recommendation := range recommended {
+		idx := slices.IndexFunc(applied, func(container v2alpha1.ContainerResourceRecommendation) bool {
+			return container.Name == recommendation.Name
+		})
+		if idx < 0 {
+			return true
+		}
+		if exceedsResourcesThreshold(applied[idx].Requests, recommendation.Requests) ||
+			exceedsResourcesThreshold(applied[idx].Limits, recommendation.Limits) {
+			return true
+		}
+	}
+	return false
+}
+
+func exceedsResourcesThreshold(applied, recommended corev1.ResourceList) bool {
+	if len(applied) != len(recommended) {
+		return true
+	}
+	for name, quantity := range recommended {
+		appliedQuantity, ok := applied[name]
+		if !ok {
+			return true
+		}
+		appliedValue := appliedQuantity.AsApproximateFloat64()
+		if math.Abs(quantity.AsApproximateFloat64()-appliedValue)*100 > appliedValue*applyThresholdPercent {
+			return true
+		}
+	}
+	return false
+}
+
+// Override returns a node Agent override setting the applied recommendations of the DaemonSet dsName
+// on its containers which have no resources in the overrides, or nil when there is nothing to set.
+func Override(status *v2alpha1.ResourceRecommendationsStatus, dsName string, overrides []*v2alpha1.DatadogAgentComponentOverride) *v2alpha1.DatadogAgentComponentOverride {
+	if status == nil {
+		return nil
+	}
+	idx := slices.IndexFunc(status.DaemonSets, func(ds v2alpha1.DaemonSetResourceRecommendation) bool {
+		return ds.Name == dsName
+	})
+	if idx < 0 {
+		return nil
+	}
+
+	containers := map[apicommon.AgentContainerName]*v2alpha1.DatadogAgentGenericContainer{}
+	for _, recommendation := range status.DaemonSets[idx].Applied {
+		name := apicommon.AgentContainerName(recommendation.Name)
+		if hasResourcesOverride(name, overrides) {
+			continue
+		}
+		containers[name] = &v2alpha1.DatadogAgentGenericContainer{
+			Resources: &corev1.ResourceRequirements{
+				Requests: recommendation.Requests.DeepCopy(),
+				Limits:   recommendation.Limits.DeepCopy(),
+			},
+		}
+	}
+	if len(containers) == 0 {
+		return nil
+	}
+	return &v2alpha1.DatadogAgentComponentOverride{Containers: containers}
+}
+
+func hasResourcesOverride(name apicommon.AgentContainerName, overrides []*v2alpha1.DatadogAgentComponentOverride) bool {
+	for _, override := range overrides {
+		if override == nil {
+			continue
+		}
+		if container, ok := override.Containers[name]; ok && container != nil && container.Resources != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,241 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package recommendation
+
+import (
+	"testing"
+	"time"
+
+	edsv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+)
+
+func newDaemonSetPod(name, dsName string) corev1.Pod {
+	return corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:            name,
+		OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: dsName}},
+	}}
+}
+
+func newUsage(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+func resourceStrings(list corev1.ResourceList) map[corev1.ResourceName]string {
+	if list == nil {
+		return nil
+	}
+	strs := make(map[corev1.ResourceName]string, len(list))
+	for name, quantity := range list {
+		strs[name] = quantity.String()
+	}
+	return strs
+}
+
+func Test_Recommend(t *testing.T) {
+	edsPod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:   "eds-pod",
+		Labels: map[string]string{edsv1alpha1.ExtendedDaemonSetNameLabelKey: "dda-agent-eds"},
+	}}
+	pods := []corev1.Pod{
+		newDaemonSetPod("pod-1", "dda-agent"),
+		newDaemonSetPod("pod-2", "dda-agent"),
+		newDaemonSetPod("pod-3", "dda-agent"),
+		newDaemonSetPod("pending-pod", "dda-agent"),
+		edsPod,
+		{ObjectMeta: metav1.ObjectMeta{Name: "orphan-pod"}},
+	}
+	usage := map[string]ContainersUsage{
+		"pod-1":      {"agent": newUsage("100m", "100Mi"), "trace-agent": newUsage("1m", "10Mi")},
+		"pod-2":      {"agent": newUsage("200m", "200Mi"), "trace-agent": newUsage("1m", "10Mi")},
+		"pod-3":      {"agent": newUsage("300m", "300Mi"), "trace-agent": newUsage("1m", "10Mi")},
+		"eds-pod":    {"agent": newUsage("50m", "64Mi")},
+		"orphan-pod": {"agent": newUsage("1", "1Gi")},
+	}
+
+	got := Recommend(pods, usage)
+	require.Len(t, got, 2)
+
+	assert.Equal(t, "dda-agent", got[0].Name)
+	assert.Equal(t, int32(3), got[0].Pods)
+	require.Len(t, got[0].Containers, 2)
+	assert.Equal(t, "agent", got[0].Containers[0].Name)
+	assert.Equal(t, map[corev1.ResourceName]string{corev1.ResourceCPU: "345m", corev1.ResourceMemory: "345Mi"}, resourceStrings(got[0].Containers[0].Requests))
+	assert.Equal(t, map[corev1.ResourceName]string{corev1.ResourceMemory: "375Mi"}, resourceStrings(got[0].Containers[0].Limits))
+	assert.Equal(t, "trace-agent", got[0].Containers[1].Name)
+	assert.Equal(t, map[corev1.ResourceName]string{corev1.ResourceCPU: "10m", corev1.ResourceMemory: "32Mi"}, resourceStrings(got[0].Containers[1].Requests))
+	assert.Equal(t, map[corev1.ResourceName]string{corev1.ResourceMemory: "32Mi"}, resourceStrings(got[0].Containers[1].Limits))
+
+	assert.Equal(t, "dda-agent-eds", got[1].Name)
+	assert.Equal(t, int32(1), got[1].Pods)
+	require.Len(t, got[1].Containers, 1)
+	assert.Equal(t, map[corev1.ResourceName]string{corev1.ResourceCPU: "58m", corev1.ResourceMemory: "74Mi"}, resourceStrings(got[1].Containers[0].Requests))
+	assert.Equal(t, map[corev1.ResourceName]string{corev1.ResourceMemory: "80Mi"}, resourceStrings(got[1].Containers[0].Limits))
+}
+
+func Test_percentile(t *testing.T) {
+	assert.Equal(t, int64(5), percentile([]int64{5}, 90))
+	assert.Equal(t, int64(9), percentile([]int64{10, 1, 9, 2, 8, 3, 7, 4, 6, 5}, 90))
+	assert.Equal(t, int64(10), percentile([]int64{10, 1, 9, 2, 8, 3, 7, 4, 6, 5, 11}, 90))
+}
+
+func Test_UpdateApplied(t *testing.T) {
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	recentlyApplied := metav1.NewTime(now.Add(-time.Hour))
+	appliedLongAgo := metav1.NewTime(now.Add(-7 * time.Hour))
+	recommended := []v2alpha1.ContainerResourceRecommendation{
+		{Name: "agent", Requests: newUsage("230m", "345Mi"), Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("375Mi")}},
+	}
+	closeToRecommended := []v2alpha1.ContainerResourceRecommendation{
+		{Name: "agent", Requests: newUsage("200m", "320Mi"), Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("375Mi")}},
+	}
+	farFromRecommended := []v2alpha1.ContainerResourceRecommendation{
+		{Name: "agent", Requests: newUsage("150m", "320Mi"), Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("375Mi")}},
+	}
+	newPrevious := func(applied []v2alpha1.ContainerResourceRecommendation, lastApplied *metav1.Time) *v2alpha1.ResourceRecommendationsStatus {
+		return &v2alpha1.ResourceRecommendationsStatus{
+			DaemonSets: []v2alpha1.DaemonSetResourceRecommendation{{Name: "dda-agent", Applied: applied, LastApplied: lastApplied}},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		previous        *v2alpha1.ResourceRecommendationsStatus
+		firstSample     time.Time
+		wantApplied     []v2alpha1.ContainerResourceRecommendation
+		wantLastApplied *metav1.Time
+	}{
+		{
+			name:        "usage not sampled for long enough",
+			firstSample: now.Add(-time.Hour),
+		},
+		{
+			name:            "first recommendations are applied",
+			firstSample:     now.Add(-7 * time.Hour),
+			wantApplied:     recommended,
+			wantLastApplied: &now,
+		},
+		{
+			name:            "recently applied recommendations are kept",
+			previous:        newPrevious(farFromRecommended, &recentlyApplied),
+			firstSample:     now.Add(-7 * time.Hour),
+			wantApplied:     farFromRecommended,
+			wantLastApplied: &recentlyApplied,
+		},
+		{
+			name:            "applied recommendations within the threshold are kept",
+			previous:        newPrevious(closeToRecommended, &appliedLongAgo),
+			firstSample:     now.Add(-7 * time.Hour),
+			wantApplied:     closeToRecommended,
+			wantLastApplied: &appliedLongAgo,
+		},
+		{
+			name:            "applied recommendations beyond the threshold are replaced",
+			previous:        newPrevious(farFromRecommended, &appliedLongAgo),
+			firstSample:     now.Add(-7 * time.Hour),
+			wantApplied:     recommended,
+			wantLastApplied: &now,
+		},
+		{
+			name:            "applied recommendations are kept while the history is rebuilt",
+			previous:        newPrevious(farFromRecommended, &appliedLongAgo),
+			firstSample:     now.Add(-time.Hour),
+			wantApplied:     farFromRecommended,
+			wantLastApplied: &appliedLongAgo,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := &v2alpha1.ResourceRecommendationsStatus{
+				DaemonSets: []v2alpha1.DaemonSetResourceRecommendation{{Name: "dda-agent", Pods: 3, Containers: recommended}},
+			}
+
+			UpdateApplied(status, tt.previous, map[string]time.Time{"dda-agent": tt.firstSample}, now)
+
+			assert.Equal(t, tt.wantApplied, status.DaemonSets[0].Applied)
+			assert.Equal(t, tt.wantLastApplied, status.DaemonSets[0].LastApplied)
+		})
+	}
+}
+
+func Test_Override(t *testing.T) {
+	status := &v2alpha1.ResourceRecommendationsStatus{
+		DaemonSets: []v2alpha1.DaemonSetResourceRecommendation{
+			{
+				Name: "dda-agent",
+				Pods: 3,
+				Applied: []v2alpha1.ContainerResourceRecommendation{
+					{Name: "agent", Requests: newUsage("345m", "345Mi"), Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("375Mi")}},
+					{Name: "trace-agent", Requests: newUsage("10m", "32Mi"), Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("32Mi")}},
+				},
+			},
+		},
+	}
+	userOverride := &v2alpha1.DatadogAgentComponentOverride{
+		Containers: map[apicommon.AgentContainerName]*v2alpha1.DatadogAgentGenericContainer{
+			apicommon.CoreAgentContainerName:  {Resources: &corev1.ResourceRequirements{Requests: newUsage("1", "1Gi")}},
+			apicommon.TraceAgentContainerName: {LogLevel: apiutils.NewStringPointer("debug")},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		status    *v2alpha1.ResourceRecommendationsStatus
+		dsName    string
+		overrides []*v2alpha1.DatadogAgentComponentOverride
+		want      []apicommon.AgentContainerName
+	}{
+		{
+			name:   "no status",
+			dsName: "dda-agent",
+		},
+		{
+			name:   "unknown DaemonSet",
+			status: status,
+			dsName: "dda-agent-gpu",
+		},
+		{
+			name:      "no override",
+			status:    status,
+			dsName:    "dda-agent",
+			overrides: []*v2alpha1.DatadogAgentComponentOverride{nil},
+			want:      []apicommon.AgentContainerName{apicommon.CoreAgentContainerName, apicommon.TraceAgentContainerName},
+		},
+		{
+			name:      "containers with resources in the overrides are skipped",
+			status:    status,
+			dsName:    "dda-agent",
+			overrides: []*v2alpha1.DatadogAgentComponentOverride{userOverride},
+			want:      []apicommon.AgentContainerName{apicommon.TraceAgentContainerName},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Override(tt.status, tt.dsName, tt.overrides)
+			if tt.want == nil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Len(t, got.Containers, len(tt.want))
+			for _, name := range tt.want {
+				require.Contains(t, got.Containers, name)
+				assert.NotNil(t, got.Containers[name].Resources)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package recommendation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	metricsapi "github.com/DataDog/datadog-operator/pkg/metricsapi/v1beta1"
+)
+
+const (
+	// SourceMetricsAPI is the source of the usage read from the metrics API
+	SourceMetricsAPI = "metrics-api"
+	// SourceKubelet is the source of the usage read from the stats summary of the kubelets
+	SourceKubelet = "kubelet"
+
+	// kubeletQueryWorkers is the maximum number of kubelets queried concurrently
+	kubeletQueryWorkers = 10
+	// kubeletQueryTimeout is the timeout of the query of the stats summary of a kubelet
+	kubeletQueryTimeout = 10 * time.Second
+)
+
+// ContainersUsage is the resource usage of the containers of a pod, indexed by container name.
+type ContainersUsage map[string]corev1.ResourceList
+
+// ReadPodsUsage reads the usage of the containers of the pods from the metrics API when the cluster serves it,
+// and from the stats summary of the kubelets of their nodes otherwise. It returns the source of the usage and the usage
+// indexed by pod name. The pods without usage, for instance the pods not started yet, are missing from the result.
+// The usage read before an error is returned along with it.
+func ReadPodsUsage(ctx context.Context, reader client.Reader, restClient rest.Interface, platformInfo *kubernetes.PlatformInfo, namespace string, pods []corev1.Pod) (string, map[string]ContainersUsage, error) {
+	if platformInfo.IsResourceSupported(metricsapi.PodMetricsKind) {
+		usage, err := readMetricsAPIUsage(ctx, reader, namespace, pods)
+		return SourceMetricsAPI, usage, err
+	}
+	if restClient == nil {
+		return SourceKubelet, nil, errors.New("the metrics API is not served and there is no client to query the kubelets")
+	}
+	usage, err := readKubeletUsage(ctx, restClient, namespace, pods)
+	return SourceKubelet, usage, err
+}
+
+func readMetricsAPIUsage(ctx context.Context, reader client.Reader, namespace string, pods []corev1.Pod) (map[string]ContainersUsage, error) {
+	list := metricsapi.EmptyUnstructuredPodMetricsList()
+	if err := reader.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("unable to list the pod metrics: %w", err)
+	}
+
+	names := podNames(pods)
+	usage := make(map[string]ContainersUsage, len(pods))
+	for _, item := range list.Items {
+		if _, ok := names[item.GetName()]; !ok {
+			continue
+		}
+		var podMetrics metricsapi.PodMetrics
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.UnstructuredContent(), &podMetrics); err != nil {
+			return usage, fmt.Errorf("unable to convert the metrics of pod %s: %w", item.GetName(), err)
+		}
+		containers := make(ContainersUsage, len(podMetrics.Containers))
+		for _, container := range podMetrics.Containers {
+			containers[container.Name] = container.Usage
+		}
+		usage[podMetrics.Name] = containers
+	}
+	return usage, nil
+}
+
+// kubeletSummary is the subset of the kubelet stats summary holding the usage of the containers.
+type kubeletSummary struct {
+	Pods []kubeletPodStats `json:"pods"`
+}
+
+type kubeletPodStats struct {
+	PodRef     kubeletPodReference     `json:"podRef"`
+	Containers []kubeletContainerStats `json:"containers"`
+}
+
+type kubeletPodReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+type kubeletContainerStats struct {
+	Name   string              `json:"name"`
+	CPU    *kubeletCPUStats    `json:"cpu,omitempty"`
+	Memory *kubeletMemoryStats `json:"memory,omitempty"`
+}
+
+type kubeletCPUStats struct {
+	UsageNanoCores *uint64 `json:"usageNanoCores,omitempty"`
+}
+
+type kubeletMemoryStats struct {
+	WorkingSetBytes *uint64 `json:"workingSetBytes,omitempty"`
+}
+
+// readKubeletUsage queries the kubelets of the nodes of the pods concurrently, each query being bounded by kubeletQueryTimeout.
+func readKubeletUsage(ctx context.Context, restClient rest.Interface, namespace string, pods []corev1.Pod) (map[string]ContainersUsage, error) {
+	podsByNode := map[string]map[string]struct{}{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		if _, ok := podsByNode[pod.Spec.NodeName]; !ok {
+			podsByNode[pod.Spec.NodeName] = map[string]struct{}{}
+		}
+		podsByNode[pod.Spec.NodeName][pod.Name] = struct{}{}
+	}
+	nodeNames := slices.Sorted(maps.Keys(podsByNode))
+
+	var mutex sync.Mutex
+	usage := make(map[string]ContainersUsage, len(pods))
+	var errs []error
+	workqueue.ParallelizeUntil(ctx, kubeletQueryWorkers, len(nodeNames), func(i int) {
+		nodeUsage, err := readNodeUsage(ctx, restClient, nodeNames[i], namespace, podsByNode[nodeNames[i]])
+		mutex.Lock()
+		defer mutex.Unlock()
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		maps.Copy(usage, nodeUsage)
+	})
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return usage, utilerrors.NewAggregate(errs)
+}
+
+func readNodeUsage(ctx context.Context, restClient rest.Interface, nodeName, namespace string, names map[string]struct{}) (map[string]ContainersUsage, error) {
+	ctx, cancel := context.WithTimeout(ctx, kubeletQueryTimeout)
+	defer cancel()
+	raw, err := restClient.Get().Resource("nodes").Name(nodeName).SubResource("proxy").Suffix("stats", "summary").DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the stats summary of node %s: %w", nodeName, err)
+	}
+	summary := kubeletSummary{}
+	if err = json.Unmarshal(raw, &summary); err != nil {
+		return nil, fmt.Errorf("unable to parse the stats summary of node %s: %w", nodeName, err)
+	}
+	return summary.containersUsage(namespace, names), nil
+}
+
+// containersUsage returns the usage of the containers of the given pods of the namespace, indexed by pod name.
+func (s *kubeletSummary) containersUsage(namespace string, names map[string]struct{}) map[string]ContainersUsage {
+	usage := map[string]ContainersUsage{}
+	for _, pod := range s.Pods {
+		if pod.PodRef.Namespace != namespace {
+			continue
+		}
+		if _, ok := names[pod.PodRef.Name]; !ok {
+			continue
+		}
+		containers := make(ContainersUsage, len(pod.Containers))
+		for _, container := range pod.Containers {
+			resources := corev1.ResourceList{}
+			if container.CPU != nil && container.CPU.UsageNanoCores != nil {
+				resources[corev1.ResourceCPU] = *resource.NewScaledQuantity(int64(*container.CPU.UsageNanoCores), resource.Nano)
+			}
+			if container.Memory != nil && container.Memory.WorkingSetBytes != nil {
+				resources[corev1.ResourceMemory] = *resource.NewQuantity(int64(*container.Memory.WorkingSetBytes), resource.BinarySI)
+			}
+			containers[container.Name] = resources
+		}
+		usage[pod.PodRef.Name] = containers
+	}
+	return usage
+}
+
+func podNames(pods []corev1.Pod) map[string]struct{} {
+	names := make(map[string]struct{}, len(pods))
+	for _, pod := range pods {
+		names[pod.Name] = struct{}{}
+	}
+	return names
+}
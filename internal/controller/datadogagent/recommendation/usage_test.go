@@ -0,0 +1,101 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package recommendation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	restfake "k8s.io/client-go/rest/fake"
+)
+
+const kubeletSummaryJSON = `{
+  "node": {"nodeName": "node-1"},
+  "pods": [
+    {
+      "podRef": {"name": "dda-agent-abcde", "namespace": "datadog", "uid": "1"},
+      "containers": [
+        {"name": "agent", "cpu": {"usageNanoCores": 123456789}, "memory": {"workingSetBytes": 209715200}},
+        {"name": "trace-agent", "cpu": {"usageNanoCores": 2000000}},
+        {"name": "process-agent"}
+      ]
+    },
+    {
+      "podRef": {"name": "dda-agent-abcde", "namespace": "default", "uid": "2"},
+      "containers": [{"name": "agent", "cpu": {"usageNanoCores": 1}}]
+    },
+    {
+      "podRef": {"name": "nginx", "namespace": "datadog", "uid": "3"},
+      "containers": [{"name": "nginx", "cpu": {"usageNanoCores": 1}}]
+    }
+  ]
+}`
+
+func Test_kubeletSummary_containersUsage(t *testing.T) {
+	summary := kubeletSummary{}
+	require.NoError(t, json.Unmarshal([]byte(kubeletSummaryJSON), &summary))
+
+	usage := summary.containersUsage("datadog", map[string]struct{}{"dda-agent-abcde": {}})
+	require.Len(t, usage, 1)
+	containers := usage["dda-agent-abcde"]
+	require.Len(t, containers, 3)
+
+	cpu := containers["agent"][corev1.ResourceCPU]
+	memory := containers["agent"][corev1.ResourceMemory]
+	assert.Equal(t, int64(124), cpu.MilliValue())
+	assert.Equal(t, "200Mi", memory.String())
+
+	cpu = containers["trace-agent"][corev1.ResourceCPU]
+	assert.Equal(t, int64(2), cpu.MilliValue())
+	assert.NotContains(t, containers["trace-agent"], corev1.ResourceMemory)
+
+	assert.Empty(t, containers["process-agent"])
+}
+
+// newKubeletRESTClient returns a REST client serving the stats summaries of the nodes, and an error for the other nodes.
+func newKubeletRESTClient(summaries map[string]string) *restfake.RESTClient {
+	return &restfake.RESTClient{
+		NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+		Client: restfake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			for nodeName, summary := range summaries {
+				if strings.HasSuffix(req.URL.Path, "/nodes/"+nodeName+"/proxy/stats/summary") {
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(summary))}, nil
+				}
+			}
+			return &http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+		}),
+	}
+}
+
+func Test_readKubeletUsage(t *testing.T) {
+	newPod := func(name, nodeName string) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "datadog", Name: name}, Spec: corev1.PodSpec{NodeName: nodeName}}
+	}
+	pods := []corev1.Pod{
+		newPod("dda-agent-abcde", "node-1"),
+		newPod("dda-agent-fghij", "node-2"),
+		newPod("dda-agent-pending", ""),
+	}
+	restClient := newKubeletRESTClient(map[string]string{"node-1": kubeletSummaryJSON})
+
+	usage, err := readKubeletUsage(context.TODO(), restClient, "datadog", pods)
+
+	// The usage read from the other kubelets is returned along with the error
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to get the stats summary of node node-2")
+	require.Len(t, usage, 1)
+	assert.Contains(t, usage, "dda-agent-abcde")
+}
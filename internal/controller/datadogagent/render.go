@@ -0,0 +1,288 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	edsdatadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/defaults"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+const (
+	// DefaultRenderKubernetesVersion is the Kubernetes version assumed when rendering a DatadogAgent offline
+	DefaultRenderKubernetesVersion = "v1.32.0"
+
+	// renderedClusterAgentTokenPlaceholder replaces the Cluster Agent token generated by the operator,
+	// to keep the rendering deterministic
+	renderedClusterAgentTokenPlaceholder = "generated-by-the-operator"
+)
+
+// RenderOptions contains the options used to render a DatadogAgent.
+type RenderOptions struct {
+	// ReconcilerOptions are the operator options the rendering should reproduce.
+	// DatadogAgentInternal, DatadogAgentProfile and operator metrics are not supported and are ignored.
+	ReconcilerOptions ReconcilerOptions
+	// PlatformInfo describes the targeted cluster.
+	// If not set, NewRenderPlatformInfo(DefaultRenderKubernetesVersion) is used.
+	PlatformInfo *kubernetes.PlatformInfo
+	// Logger is used to log the reconcile steps. Logs are discarded if not set.
+	Logger *logr.Logger
+	// Reader reads the other DatadogAgents and the nodes of the cluster, used to compute the node partition,
+	// and the DatadogChecks and their namespaces when ReconcilerOptions.DatadogCheckEnabled is set.
+	// Nothing is written with it. If not set, the DatadogAgent is rendered as the only one of an empty cluster.
+	Reader client.Reader
+}
+
+// NewRenderPlatformInfo returns a PlatformInfo describing a cluster running the given Kubernetes version
+// and only serving the built-in Kubernetes APIs.
+func NewRenderPlatformInfo(kubernetesVersion string) kubernetes.PlatformInfo {
+	return kubernetes.NewPlatformInfoFromVersionMaps(
+		&version.Info{GitVersion: kubernetesVersion},
+		map[string]string{"PodDisruptionBudget": "policy/v1"},
+		map[string]string{},
+	)
+}
+
+// Render runs the DatadogAgent reconcile logic against an in-memory client, and returns the objects
+// the operator would create for this DatadogAgent: workloads and dependencies (RBAC, ConfigMaps, Services...).
+// Objects are sorted by kind, namespace and name. The Cluster Agent token generated by the operator is read from
+// the DatadogAgent status, and replaced by a placeholder if the status doesn't contain one.
+func Render(ctx context.Context, dda *v2alpha1.DatadogAgent, opts RenderOptions) ([]client.Object, error) {
+	res, err := render(ctx, dda, opts)
+	if err != nil {
+		return nil, err
+	}
+	return res.objects(), nil
+}
+
+// renderResult contains the objects rendered for a DatadogAgent, and the context they were rendered with
+type renderResult struct {
+	scheme            *runtime.Scheme
+	platformInfo      kubernetes.PlatformInfo
+	reconcilerOptions ReconcilerOptions
+	// dda is the rendered DatadogAgent, with defaults applied
+	dda *v2alpha1.DatadogAgent
+
+	workloads    []client.Object
+	dependencies map[kubernetes.ObjectKind][]client.Object
+}
+
+func render(ctx context.Context, dda *v2alpha1.DatadogAgent, opts RenderOptions) (*renderResult, error) {
+	s, err := renderScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	logger := logr.Discard()
+	if opts.Logger != nil {
+		logger = *opts.Logger
+	}
+	platformInfo := NewRenderPlatformInfo(DefaultRenderKubernetesVersion)
+	if opts.PlatformInfo != nil {
+		platformInfo = *opts.PlatformInfo
+	}
+	reconcilerOptions := opts.ReconcilerOptions
+	reconcilerOptions.DatadogAgentInternalEnabled = false
+	reconcilerOptions.DatadogAgentProfileEnabled = false
+	reconcilerOptions.OperatorMetricsEnabled = false
+
+	instance := dda.DeepCopy()
+	instance.ResourceVersion = ""
+	if instance.Status.ClusterAgent == nil {
+		instance.Status.ClusterAgent = &v2alpha1.DeploymentStatus{}
+	}
+	if instance.Status.ClusterAgent.GeneratedToken == "" {
+		instance.Status.ClusterAgent.GeneratedToken = renderedClusterAgentTokenPlaceholder
+	}
+	clientBuilder := fake.NewClientBuilder().
+		WithScheme(s).
+		WithStatusSubresource(&v2alpha1.DatadogAgent{}).
+		WithObjects(instance)
+	if opts.Reader != nil {
+		clusterObjects, err := readClusterObjects(ctx, opts.Reader, s, instance, reconcilerOptions)
+		if err != nil {
+			return nil, err
+		}
+		clientBuilder = clientBuilder.WithObjects(clusterObjects...)
+	}
+	fakeClient := clientBuilder.Build()
+
+	r := &Reconciler{
+		options:      reconcilerOptions,
+		client:       fakeClient,
+		platformInfo: platformInfo,
+		scheme:       s,
+		log:          logger,
+		recorder:     &record.FakeRecorder{},
+	}
+
+	if err = v2alpha1.ValidateDatadogAgent(instance); err != nil {
+		return nil, err
+	}
+	defaults.DefaultDatadogAgentSpec(&instance.Spec)
+	if _, err = r.reconcileInstanceV2(ctx, logger, instance); err != nil {
+		return nil, fmt.Errorf("unable to render DatadogAgent %s/%s: %w", dda.Namespace, dda.Name, err)
+	}
+
+	res := &renderResult{
+		scheme:            s,
+		platformInfo:      platformInfo,
+		reconcilerOptions: reconcilerOptions,
+		dda:               instance,
+		dependencies:      map[kubernetes.ObjectKind][]client.Object{},
+	}
+	workloadLists := []client.ObjectList{
+		&appsv1.DaemonSetList{},
+		&appsv1.DeploymentList{},
+	}
+	if reconcilerOptions.ExtendedDaemonsetOptions.Enabled {
+		workloadLists = append(workloadLists, &edsdatadoghqv1alpha1.ExtendedDaemonSetList{})
+	}
+	for _, objList := range workloadLists {
+		objs, err := listRenderedObjects(ctx, fakeClient, s, objList)
+		if err != nil {
+			return nil, err
+		}
+		res.workloads = append(res.workloads, objs...)
+	}
+	for _, kind := range platformInfo.GetAgentResourcesKind(reconcilerOptions.SupportCilium) {
+		objs, err := listRenderedObjects(ctx, fakeClient, s, kubernetes.ObjectListFromKind(kind, platformInfo))
+		if err != nil {
+			return nil, err
+		}
+		res.dependencies[kind] = objs
+	}
+	return res, nil
+}
+
+// objects returns all the rendered objects, sorted by kind, namespace and name
+func (res *renderResult) objects() []client.Object {
+	objs := append([]client.Object{}, res.workloads...)
+	for _, kind := range res.platformInfo.GetAgentResourcesKind(res.reconcilerOptions.SupportCilium) {
+		objs = append(objs, res.dependencies[kind]...)
+	}
+	sortObjects(objs)
+	return objs
+}
+
+// readClusterObjects returns the objects of the cluster read by the reconcile loop besides the DatadogAgent:
+// the other DatadogAgents, the nodes and, if enabled, the DatadogChecks and the namespaces.
+func readClusterObjects(ctx context.Context, reader client.Reader, s *runtime.Scheme, dda *v2alpha1.DatadogAgent, reconcilerOptions ReconcilerOptions) ([]client.Object, error) {
+	objectLists := []client.ObjectList{
+		&v2alpha1.DatadogAgentList{},
+		&corev1.NodeList{},
+	}
+	if reconcilerOptions.DatadogCheckEnabled {
+		objectLists = append(objectLists, &datadoghqv1alpha1.DatadogCheckList{}, &corev1.NamespaceList{})
+	}
+
+	var objs []client.Object
+	for _, objList := range objectLists {
+		if err := reader.List(ctx, objList); err != nil {
+			return nil, fmt.Errorf("unable to read the cluster objects: %w", err)
+		}
+		items, err := extractObjects(s, objList)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range items {
+			if _, isDDA := obj.(*v2alpha1.DatadogAgent); isDDA && client.ObjectKeyFromObject(obj) == client.ObjectKeyFromObject(dda) {
+				continue
+			}
+			obj.SetResourceVersion("")
+			objs = append(objs, obj)
+		}
+	}
+	return objs, nil
+}
+
+// listRenderedObjects returns the objects of a given type created in the in-memory client
+func listRenderedObjects(ctx context.Context, c client.Client, s *runtime.Scheme, objList client.ObjectList) ([]client.Object, error) {
+	if err := c.List(ctx, objList); err != nil {
+		return nil, err
+	}
+	objs, err := extractObjects(s, objList)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range objs {
+		// Fields set by the in-memory client are not part of the rendered objects
+		obj.SetResourceVersion("")
+		obj.SetManagedFields(nil)
+	}
+	return objs, nil
+}
+
+// extractObjects returns the items of a list, with their GroupVersionKind set
+func extractObjects(s *runtime.Scheme, objList client.ObjectList) ([]client.Object, error) {
+	items, err := meta.ExtractList(objList)
+	if err != nil {
+		return nil, err
+	}
+
+	objs := make([]client.Object, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok {
+			continue
+		}
+		gvk, err := apiutil.GVKForObject(obj, s)
+		if err != nil {
+			return nil, err
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func sortObjects(objs []client.Object) {
+	sort.SliceStable(objs, func(i, j int) bool {
+		kindI, kindJ := objs[i].GetObjectKind().GroupVersionKind().Kind, objs[j].GetObjectKind().GroupVersionKind().Kind
+		if kindI != kindJ {
+			return kindI < kindJ
+		}
+		if objs[i].GetNamespace() != objs[j].GetNamespace() {
+			return objs[i].GetNamespace() < objs[j].GetNamespace()
+		}
+		return objs[i].GetName() < objs[j].GetName()
+	})
+}
+
+func renderScheme() (*runtime.Scheme, error) {
+	s := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		clientgoscheme.AddToScheme,
+		apiregistrationv1.AddToScheme,
+		edsdatadoghqv1alpha1.AddToScheme,
+		datadoghqv1alpha1.AddToScheme,
+		v2alpha1.AddToScheme,
+	} {
+		if err := addToScheme(s); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,156 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/pkg/testutils"
+)
+
+func renderedKeys(objs []client.Object) []string {
+	keys := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		keys = append(keys, obj.GetObjectKind().GroupVersionKind().Kind+"/"+obj.GetName())
+	}
+	return keys
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name        string
+		dda         *v2alpha1.DatadogAgent
+		opts        RenderOptions
+		wantErr     bool
+		wantObjects []string
+		notObjects  []string
+	}{
+		{
+			name: "default DatadogAgent",
+			dda:  testutils.NewInitializedDatadogAgentBuilder("bar", "foo").Build(),
+			wantObjects: []string{
+				"DaemonSet/foo-agent",
+				"Deployment/foo-cluster-agent",
+				"ServiceAccount/foo-agent",
+				"ServiceAccount/foo-cluster-agent",
+				"Service/foo-cluster-agent",
+			},
+			notObjects: []string{
+				"DatadogAgent/foo",
+				"Deployment/foo-cluster-checks-runner",
+			},
+		},
+		{
+			name: "cluster checks runner enabled",
+			dda: testutils.NewInitializedDatadogAgentBuilder("bar", "foo").
+				WithClusterChecksEnabled(true).
+				WithClusterChecksUseCLCEnabled(true).
+				Build(),
+			wantObjects: []string{
+				"DaemonSet/foo-agent",
+				"Deployment/foo-cluster-agent",
+				"Deployment/foo-cluster-checks-runner",
+			},
+		},
+		{
+			name:    "invalid DatadogAgent",
+			dda:     testutils.NewDatadogAgentBuilder().Build(),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs, err := Render(context.TODO(), tt.dda, tt.opts)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			keys := renderedKeys(objs)
+			for _, want := range tt.wantObjects {
+				assert.Contains(t, keys, want)
+			}
+			for _, notWant := range tt.notObjects {
+				assert.NotContains(t, keys, notWant)
+			}
+			for _, obj := range objs {
+				assert.Empty(t, obj.GetResourceVersion())
+			}
+		})
+	}
+}
+
+func TestRenderIsDeterministic(t *testing.T) {
+	dda := testutils.NewInitializedDatadogAgentBuilder("bar", "foo").Build()
+
+	first, err := Render(context.TODO(), dda, RenderOptions{})
+	require.NoError(t, err)
+	second, err := Render(context.TODO(), dda, RenderOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, renderedKeys(first), renderedKeys(second))
+	assert.Equal(t, first, second)
+	for _, obj := range first {
+		if ds, ok := obj.(*appsv1.DaemonSet); ok {
+			assert.NotEmpty(t, ds.Spec.Template.Spec.Containers)
+		}
+	}
+}
+
+func TestRenderWithResourceRecommendations(t *testing.T) {
+	dda := testutils.NewInitializedDatadogAgentBuilder("bar", "foo").
+		WithResourceRecommendations(true, true).
+		Build()
+
+	// Without a status, no recommendation is applied
+	objs, err := Render(context.TODO(), dda, RenderOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, renderedKeys(objs), "DaemonSet/foo-agent")
+
+	// The recommendations applied in the status are rendered
+	requests := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("120m"),
+		corev1.ResourceMemory: resource.MustParse("256Mi"),
+	}
+	dda.Status.ResourceRecommendations = &v2alpha1.ResourceRecommendationsStatus{
+		DaemonSets: []v2alpha1.DaemonSetResourceRecommendation{
+			{
+				Name: "foo-agent",
+				Applied: []v2alpha1.ContainerResourceRecommendation{
+					{
+						Name:     string(apicommon.CoreAgentContainerName),
+						Requests: requests,
+					},
+				},
+			},
+		},
+	}
+	objs, err = Render(context.TODO(), dda, RenderOptions{})
+	require.NoError(t, err)
+	var agentDS *appsv1.DaemonSet
+	for _, obj := range objs {
+		if ds, ok := obj.(*appsv1.DaemonSet); ok && ds.Name == "foo-agent" {
+			agentDS = ds
+		}
+	}
+	require.NotNil(t, agentDS)
+	for _, container := range agentDS.Spec.Template.Spec.Containers {
+		if container.Name == string(apicommon.CoreAgentContainerName) {
+			assert.Equal(t, requests, container.Resources.Requests)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/component"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/recommendation"
+)
+
+// updateResourceRecommendationsStatus reports the resources recommended for the containers of each node Agent DaemonSet
+// from the usage sampled by the collector, and updates the applied recommendations when they are applied.
+// A sampling error is logged and the previous status is kept, it does not fail the reconcile.
+// The previous status is also kept without a collector, when the DatadogAgent is rendered offline.
+func (r *Reconciler) updateResourceRecommendationsStatus(logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus, now metav1.Time) {
+	if !recommendation.IsEnabled(&dda.Spec) {
+		newStatus.ResourceRecommendations = nil
+		return
+	}
+
+	previous := dda.Status.ResourceRecommendations
+	if r.recommendations == nil {
+		newStatus.ResourceRecommendations = previous
+		return
+	}
+	var lastUpdate *metav1.Time
+	if previous != nil {
+		lastUpdate = previous.LastUpdate
+	}
+	newStatus.ResourceRecommendations = observeStatus(logger, previous, isObservedStatusFresh(lastUpdate, now), "Unable to read the usage of the node Agent containers",
+		func() (*datadoghqv2alpha1.ResourceRecommendationsStatus, error) {
+			recommendations, err := r.recommendations.Recommendations(client.ObjectKeyFromObject(dda))
+			if err != nil || recommendations == nil {
+				return previous, err
+			}
+			status := &datadoghqv2alpha1.ResourceRecommendationsStatus{
+				LastUpdate: &metav1.Time{Time: recommendations.LastSample},
+				Source:     recommendations.Source,
+				DaemonSets: recommendations.DaemonSets,
+			}
+			if recommendation.IsApplyEnabled(&dda.Spec) {
+				recommendation.UpdateApplied(status, previous, recommendations.FirstSamples, now)
+			}
+			return status, nil
+		})
+}
+
+// resourceRecommendationsOverride returns the node Agent override applying the recommended resources of the DaemonSet
+// to its containers without resources in componentOverrides, or nil when the recommendations are not applied.
+// The DaemonSet name is defaultName, unless one of componentOverrides renames it.
+func resourceRecommendationsOverride(dda *datadoghqv2alpha1.DatadogAgent, status *datadoghqv2alpha1.DatadogAgentStatus, defaultName string,
+	componentOverrides []*datadoghqv2alpha1.DatadogAgentComponentOverride,
+) *datadoghqv2alpha1.DatadogAgentComponentOverride {
+	if status == nil || !recommendation.IsApplyEnabled(&dda.Spec) {
+		return nil
+	}
+	dsName := defaultName
+	for _, componentOverride := range componentOverrides {
+		if componentOverride.Name != nil && *componentOverride.Name != "" {
+			dsName = *componentOverride.Name
+		}
+	}
+	return recommendation.Override(status.ResourceRecommendations, dsName, componentOverrides)
+}
+
+// applyResourceRecommendationsToDDAIs sets the recommended resources of each DaemonSet in the node Agent override of its
+// DatadogAgentInternal, for the containers without resources in this override.
+func applyResourceRecommendationsToDDAIs(dda *datadoghqv2alpha1.DatadogAgent, status *datadoghqv2alpha1.DatadogAgentStatus, ddais []*datadoghqv1alpha1.DatadogAgentInternal) {
+	if !recommendation.IsApplyEnabled(&dda.Spec) {
+		return
+	}
+	for _, ddai := range ddais {
+		nodeAgentOverride := ddai.Spec.Override[datadoghqv2alpha1.NodeAgentComponentName]
+		dsName := component.GetDaemonSetNameFromDatadogAgent(ddai, &ddai.Spec)
+		recommendationsOverride := recommendation.Override(status.ResourceRecommendations, dsName, []*datadoghqv2alpha1.DatadogAgentComponentOverride{nodeAgentOverride})
+		if recommendationsOverride == nil {
+			continue
+		}
+
+		// The overrides may be shared with the DatadogAgent and the other DatadogAgentInternals
+		if nodeAgentOverride == nil {
+			nodeAgentOverride = &datadoghqv2alpha1.DatadogAgentComponentOverride{}
+		} else {
+			nodeAgentOverride = nodeAgentOverride.DeepCopy()
+		}
+		if nodeAgentOverride.Containers == nil {
+			nodeAgentOverride.Containers = map[apicommon.AgentContainerName]*datadoghqv2alpha1.DatadogAgentGenericContainer{}
+		}
+		for name, container := range recommendationsOverride.Containers {
+			if existing, ok := nodeAgentOverride.Containers[name]; ok && existing != nil {
+				existing.Resources = container.Resources
+				continue
+			}
+			nodeAgentOverride.Containers[name] = container
+		}
+		if ddai.Spec.Override == nil {
+			ddai.Spec.Override = map[datadoghqv2alpha1.ComponentName]*datadoghqv2alpha1.DatadogAgentComponentOverride{}
+		}
+		ddai.Spec.Override[datadoghqv2alpha1.NodeAgentComponentName] = nodeAgentOverride
+	}
+}
@@ -0,0 +1,201 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	restfake "k8s.io/client-go/rest/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/recommendation"
+	agenttestutils "github.com/DataDog/datadog-operator/internal/controller/datadogagent/testutils"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	"github.com/DataDog/datadog-operator/pkg/testutils"
+)
+
+const resourceRecommendationsTestSummary = `{
+  "pods": [
+    {
+      "podRef": {"name": "foo-agent-abcde", "namespace": "foo"},
+      "containers": [{"name": "agent", "cpu": {"usageNanoCores": 100000000}, "memory": {"workingSetBytes": 209715200}}]
+    }
+  ]
+}`
+
+func Test_updateResourceRecommendationsStatus(t *testing.T) {
+	sch := agenttestutils.TestScheme()
+
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	agentPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      "foo-agent-abcde",
+			Labels: map[string]string{
+				apicommon.AgentDeploymentNameLabelKey:      "foo",
+				apicommon.AgentDeploymentComponentLabelKey: "agent",
+			},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: "foo-agent"}},
+		},
+		Spec: corev1.PodSpec{NodeName: "node-1"},
+	}
+	restClient := &restfake.RESTClient{
+		NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+		Client: restfake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasSuffix(req.URL.Path, "/nodes/node-1/proxy/stats/summary") {
+				return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(resourceRecommendationsTestSummary))}, nil
+		}),
+	}
+	sampledDDA := testutils.NewInitializedDatadogAgentBuilder(testNamespace, "foo").WithResourceRecommendations(true, false).Build()
+	fakeClient := fake.NewClientBuilder().WithScheme(sch).WithObjects(agentPod, sampledDDA).Build()
+	r, err := NewReconciler(ReconcilerOptions{}, fakeClient, fakeClient, restClient, kubernetes.PlatformInfo{}, sch, logr.Discard(), nil, nil)
+	require.NoError(t, err)
+
+	// The collector samples the usage as soon as it starts
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	go func() {
+		_ = r.ResourceRecommendationsCollector().Start(ctx)
+	}()
+	require.Eventually(t, func() bool {
+		recommendations, _ := r.ResourceRecommendationsCollector().Recommendations(client.ObjectKeyFromObject(sampledDDA))
+		return recommendations != nil
+	}, 10*time.Second, 10*time.Millisecond)
+
+	t.Run("usage is read from the collector", func(t *testing.T) {
+		dda := testutils.NewInitializedDatadogAgentBuilder(testNamespace, "foo").WithResourceRecommendations(true, true).Build()
+		status := &v2alpha1.DatadogAgentStatus{}
+
+		r.updateResourceRecommendationsStatus(logr.Discard(), dda, status, now)
+
+		require.NotNil(t, status.ResourceRecommendations)
+		require.NotNil(t, status.ResourceRecommendations.LastUpdate)
+		assert.Equal(t, recommendation.SourceKubelet, status.ResourceRecommendations.Source)
+		require.Len(t, status.ResourceRecommendations.DaemonSets, 1)
+		ds := status.ResourceRecommendations.DaemonSets[0]
+		assert.Equal(t, "foo-agent", ds.Name)
+		assert.Equal(t, int32(1), ds.Pods)
+		require.Len(t, ds.Containers, 1)
+		cpu := ds.Containers[0].Requests[corev1.ResourceCPU]
+		memory := ds.Containers[0].Limits[corev1.ResourceMemory]
+		assert.Equal(t, "115m", cpu.String())
+		assert.Equal(t, "250Mi", memory.String())
+
+		// The usage has not been sampled for long enough to apply the recommendations
+		assert.Nil(t, ds.Applied)
+	})
+
+	t.Run("recent status is kept", func(t *testing.T) {
+		dda := testutils.NewInitializedDatadogAgentBuilder(testNamespace, "foo").WithResourceRecommendations(true, false).Build()
+		lastUpdate := metav1.NewTime(now.Add(-time.Minute))
+		dda.Status.ResourceRecommendations = &v2alpha1.ResourceRecommendationsStatus{LastUpdate: &lastUpdate}
+		status := &v2alpha1.DatadogAgentStatus{}
+
+		r.updateResourceRecommendationsStatus(logr.Discard(), dda, status, now)
+
+		assert.Equal(t, dda.Status.ResourceRecommendations, status.ResourceRecommendations)
+	})
+
+	t.Run("previous status is kept before the first sample", func(t *testing.T) {
+		dda := testutils.NewInitializedDatadogAgentBuilder(testNamespace, "bar").WithResourceRecommendations(true, false).Build()
+		lastUpdate := metav1.NewTime(now.Add(-time.Hour))
+		dda.Status.ResourceRecommendations = &v2alpha1.ResourceRecommendationsStatus{LastUpdate: &lastUpdate}
+		status := &v2alpha1.DatadogAgentStatus{}
+
+		r.updateResourceRecommendationsStatus(logr.Discard(), dda, status, now)
+
+		assert.Equal(t, dda.Status.ResourceRecommendations, status.ResourceRecommendations)
+	})
+
+	t.Run("recommendations disabled", func(t *testing.T) {
+		dda := testutils.NewInitializedDatadogAgentBuilder(testNamespace, "foo").WithResourceRecommendations(false, false).Build()
+		status := &v2alpha1.DatadogAgentStatus{ResourceRecommendations: &v2alpha1.ResourceRecommendationsStatus{}}
+
+		r.updateResourceRecommendationsStatus(logr.Discard(), dda, status, now)
+
+		assert.Nil(t, status.ResourceRecommendations)
+	})
+}
+
+func Test_applyResourceRecommendationsToDDAIs(t *testing.T) {
+	status := &v2alpha1.DatadogAgentStatus{
+		ResourceRecommendations: &v2alpha1.ResourceRecommendationsStatus{
+			DaemonSets: []v2alpha1.DaemonSetResourceRecommendation{
+				{
+					Name: "foo-agent",
+					Pods: 2,
+					Applied: []v2alpha1.ContainerResourceRecommendation{
+						{Name: "agent", Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("115m")}},
+						{Name: "trace-agent", Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m")}},
+					},
+				},
+			},
+		},
+	}
+	sharedOverride := &v2alpha1.DatadogAgentComponentOverride{
+		Containers: map[apicommon.AgentContainerName]*v2alpha1.DatadogAgentGenericContainer{
+			apicommon.CoreAgentContainerName:  {LogLevel: apiutils.NewStringPointer("debug")},
+			apicommon.TraceAgentContainerName: {Resources: &corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}}},
+		},
+	}
+	newDDAI := func(name string) *v1alpha1.DatadogAgentInternal {
+		return &v1alpha1.DatadogAgentInternal{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name},
+			Spec: v2alpha1.DatadogAgentSpec{
+				Override: map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride{
+					v2alpha1.NodeAgentComponentName: sharedOverride,
+				},
+			},
+		}
+	}
+
+	t.Run("recommendations are applied to the containers without resources", func(t *testing.T) {
+		ddai := newDDAI("foo")
+		profileDDAI := newDDAI("foo-profile-gpu")
+
+		applyResourceRecommendationsToDDAIs(testutils.NewInitializedDatadogAgentBuilder(testNamespace, "foo").WithResourceRecommendations(true, true).Build(), status, []*v1alpha1.DatadogAgentInternal{ddai, profileDDAI})
+
+		containers := ddai.Spec.Override[v2alpha1.NodeAgentComponentName].Containers
+		require.NotNil(t, containers[apicommon.CoreAgentContainerName].Resources)
+		cpu := containers[apicommon.CoreAgentContainerName].Resources.Requests[corev1.ResourceCPU]
+		assert.Equal(t, "115m", cpu.String())
+		assert.Equal(t, "debug", *containers[apicommon.CoreAgentContainerName].LogLevel)
+		cpu = containers[apicommon.TraceAgentContainerName].Resources.Requests[corev1.ResourceCPU]
+		assert.Equal(t, "1", cpu.String())
+
+		// The profile DaemonSet has no recommendation, and the shared override is not modified
+		assert.Same(t, sharedOverride, profileDDAI.Spec.Override[v2alpha1.NodeAgentComponentName])
+		assert.Nil(t, sharedOverride.Containers[apicommon.CoreAgentContainerName].Resources)
+	})
+
+	t.Run("recommendations are not applied", func(t *testing.T) {
+		ddai := newDDAI("foo")
+
+		applyResourceRecommendationsToDDAIs(testutils.NewInitializedDatadogAgentBuilder(testNamespace, "foo").WithResourceRecommendations(true, false).Build(), status, []*v1alpha1.DatadogAgentInternal{ddai})
+
+		assert.Same(t, sharedOverride, ddai.Spec.Override[v2alpha1.NodeAgentComponentName])
+	})
+}
@@ -0,0 +1,194 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	edsv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/global"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
+	"github.com/DataDog/datadog-operator/pkg/condition"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	securityprofilesoperator "github.com/DataDog/datadog-operator/pkg/securityprofilesoperator/v1beta1"
+)
+
+const (
+	seccompProfileDriftReason        = "AgentVersionDrift"
+	noSeccompProfileDriftReason      = "NoAgentVersionDrift"
+	seccompProfileNotInstalledReason = "SeccompProfileNotInstalled"
+	unknownAgentVersionReason        = "UnknownAgentVersion"
+	// securityProfilesOperatorNotSupportedReason is set when the SeccompProfile API is not served by the cluster
+	securityProfilesOperatorNotSupportedReason = "SecurityProfilesOperatorNotSupported"
+)
+
+// seccompProfileDriftObservation is the observed state of the SeccompProfileDrift condition
+type seccompProfileDriftObservation struct {
+	status  metav1.ConditionStatus
+	reason  string
+	message string
+}
+
+// updateSeccompProfileDriftCondition reports in the SeccompProfileDrift condition whether the SeccompProfile generated for
+// the Security Profiles Operator is installed, and whether it was written for the Agent version run by the node Agents.
+// A read error is logged and the previous condition is kept, it does not fail the reconcile.
+func (r *Reconciler) updateSeccompProfileDriftCondition(ctx context.Context, logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus, now metav1.Time) {
+	if !global.IsSystemProbeSecurityProfilesOperatorEnabled(&dda.Spec) {
+		condition.DeleteDatadogAgentStatusCondition(newStatus, common.SeccompProfileDriftConditionType)
+		return
+	}
+	if !global.UseSystemProbeSecurityProfilesOperator(&dda.Spec, &r.platformInfo) {
+		condition.UpdateDatadogAgentStatusConditions(newStatus, now, common.SeccompProfileDriftConditionType, metav1.ConditionUnknown, securityProfilesOperatorNotSupportedReason,
+			fmt.Sprintf("the %s API of the Security Profiles Operator is not served by the cluster, the seccomp-setup init container copies the profile instead", securityprofilesoperator.GroupVersionSeccompProfileKind()), false)
+		return
+	}
+
+	observation := observeStatus(logger, nil, false, "Unable to observe the SeccompProfile drift", func() (*seccompProfileDriftObservation, error) {
+		return r.observeSeccompProfileDrift(ctx, dda)
+	})
+	if observation == nil {
+		return
+	}
+	condition.UpdateDatadogAgentStatusConditions(newStatus, now, common.SeccompProfileDriftConditionType, observation.status, observation.reason, observation.message, false)
+}
+
+// observeSeccompProfileDrift reads the SeccompProfile and compares it with the Agent version of the system-probe
+// containers of the node Agent workloads, which include the workloads of the profiles and of the DatadogAgentInternals.
+func (r *Reconciler) observeSeccompProfileDrift(ctx context.Context, dda *datadoghqv2alpha1.DatadogAgent) (*seccompProfileDriftObservation, error) {
+	// The SeccompProfiles are not in the cache
+	name := common.GetDefaultSeccompProfileName(dda)
+	obj := securityprofilesoperator.EmptyUnstructuredSeccompProfile()
+	if err := r.uncachedReader().Get(ctx, types.NamespacedName{Namespace: dda.Namespace, Name: name}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return &seccompProfileDriftObservation{
+				status:  metav1.ConditionUnknown,
+				reason:  seccompProfileNotInstalledReason,
+				message: fmt.Sprintf("the SeccompProfile %s/%s does not exist, it is created when system-probe is enabled", dda.Namespace, name),
+			}, nil
+		}
+		return nil, err
+	}
+
+	var profile securityprofilesoperator.SeccompProfile
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), &profile); err != nil {
+		return nil, fmt.Errorf("unable to convert the SeccompProfile %s/%s: %w", dda.Namespace, name, err)
+	}
+
+	agentVersions, err := r.getSystemProbeAgentVersions(ctx, dda)
+	if err != nil {
+		return nil, err
+	}
+	return seccompProfileDrift(&profile, agentVersions), nil
+}
+
+// getSystemProbeAgentVersions returns the Agent version of the system-probe container of the node Agent workloads, keyed by workload name
+func (r *Reconciler) getSystemProbeAgentVersions(ctx context.Context, dda *datadoghqv2alpha1.DatadogAgent) (map[string]string, error) {
+	listOptions := []client.ListOption{
+		client.InNamespace(dda.Namespace),
+		client.MatchingLabels{
+			apicommon.AgentDeploymentComponentLabelKey: constants.DefaultAgentResourceSuffix,
+			kubernetes.AppKubernetesPartOfLabelKey:     object.NewPartOfLabelValue(dda).String(),
+		},
+	}
+	podTemplates := map[string]*corev1.PodTemplateSpec{}
+
+	daemonSetList := appsv1.DaemonSetList{}
+	if err := r.client.List(ctx, &daemonSetList, listOptions...); err != nil {
+		return nil, err
+	}
+	for i := range daemonSetList.Items {
+		podTemplates[daemonSetList.Items[i].Name] = &daemonSetList.Items[i].Spec.Template
+	}
+	if r.options.ExtendedDaemonsetOptions.Enabled {
+		edsList := edsv1alpha1.ExtendedDaemonSetList{}
+		if err := r.client.List(ctx, &edsList, listOptions...); err != nil {
+			return nil, err
+		}
+		for i := range edsList.Items {
+			podTemplates[edsList.Items[i].Name] = &edsList.Items[i].Spec.Template
+		}
+	}
+
+	agentVersions := map[string]string{}
+	for workloadName, podTemplate := range podTemplates {
+		for _, container := range podTemplate.Spec.Containers {
+			if container.Name == string(apicommon.SystemProbeContainerName) {
+				agentVersions[workloadName] = common.GetAgentVersionFromImage(datadoghqv2alpha1.AgentImageConfig{Name: container.Image})
+			}
+		}
+	}
+	return agentVersions, nil
+}
+
+// seccompProfileDrift compares the Agent version a SeccompProfile was written for with the Agent versions run by the
+// node Agent workloads. The profile is considered in drift when the major or minor versions differ for a workload.
+func seccompProfileDrift(profile *securityprofilesoperator.SeccompProfile, agentVersions map[string]string) *seccompProfileDriftObservation {
+	if profile.Status.Status != securityprofilesoperator.ProfileStateInstalled {
+		return &seccompProfileDriftObservation{
+			status:  metav1.ConditionUnknown,
+			reason:  seccompProfileNotInstalledReason,
+			message: fmt.Sprintf("the SeccompProfile %s/%s is not installed on the nodes, state: %q", profile.Namespace, profile.Name, profile.Status.Status),
+		}
+	}
+	if len(agentVersions) == 0 {
+		return &seccompProfileDriftObservation{
+			status:  metav1.ConditionUnknown,
+			reason:  unknownAgentVersionReason,
+			message: fmt.Sprintf("no node Agent runs system-probe with the SeccompProfile %s/%s", profile.Namespace, profile.Name),
+		}
+	}
+
+	profileVersion := profile.Annotations[common.SeccompProfileAgentVersionAnnotationKey]
+	profileSemver, profileErr := semver.NewVersion(profileVersion)
+	var drifts []string
+	for _, workloadName := range slices.Sorted(maps.Keys(agentVersions)) {
+		agentVersion := agentVersions[workloadName]
+		agentSemver, agentErr := semver.NewVersion(agentVersion)
+		if profileErr != nil || agentErr != nil {
+			return &seccompProfileDriftObservation{
+				status: metav1.ConditionUnknown,
+				reason: unknownAgentVersionReason,
+				message: fmt.Sprintf("unable to compare the Agent version %q of %s with the version %q of the SeccompProfile %s/%s",
+					agentVersion, workloadName, profileVersion, profile.Namespace, profile.Name),
+			}
+		}
+		if profileSemver.Major() != agentSemver.Major() || profileSemver.Minor() != agentSemver.Minor() {
+			drifts = append(drifts, fmt.Sprintf("%s runs the Agent %s", workloadName, agentVersion))
+		}
+	}
+
+	if len(drifts) > 0 {
+		return &seccompProfileDriftObservation{
+			status: metav1.ConditionTrue,
+			reason: seccompProfileDriftReason,
+			message: fmt.Sprintf("the SeccompProfile %s/%s was generated for the Agent %s but %s",
+				profile.Namespace, profile.Name, profileVersion, strings.Join(drifts, ", ")),
+		}
+	}
+	return &seccompProfileDriftObservation{
+		status:  metav1.ConditionFalse,
+		reason:  noSeccompProfileDriftReason,
+		message: fmt.Sprintf("the SeccompProfile %s/%s matches the Agent version of the node Agents", profile.Namespace, profile.Name),
+	}
+}
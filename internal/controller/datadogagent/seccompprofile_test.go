@@ -0,0 +1,137 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	securityprofilesoperator "github.com/DataDog/datadog-operator/pkg/securityprofilesoperator/v1beta1"
+)
+
+func Test_seccompProfileDrift(t *testing.T) {
+	newProfile := func(state securityprofilesoperator.ProfileState, version string) *securityprofilesoperator.SeccompProfile {
+		return &securityprofilesoperator.SeccompProfile{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   testNamespace,
+				Name:        "foo-system-probe-seccomp",
+				Annotations: map[string]string{common.SeccompProfileAgentVersionAnnotationKey: version},
+			},
+			Status: securityprofilesoperator.SeccompProfileStatus{
+				Status: state,
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		profile       *securityprofilesoperator.SeccompProfile
+		agentVersions map[string]string
+		wantStatus    metav1.ConditionStatus
+		wantReason    string
+	}{
+		{
+			name:          "profile not installed",
+			profile:       newProfile("InProgress", "7.67.0"),
+			agentVersions: map[string]string{"foo-agent": "7.67.0"},
+			wantStatus:    metav1.ConditionUnknown,
+			wantReason:    seccompProfileNotInstalledReason,
+		},
+		{
+			name:       "no node Agent running system-probe",
+			profile:    newProfile(securityprofilesoperator.ProfileStateInstalled, "7.67.0"),
+			wantStatus: metav1.ConditionUnknown,
+			wantReason: unknownAgentVersionReason,
+		},
+		{
+			name:          "same minor version",
+			profile:       newProfile(securityprofilesoperator.ProfileStateInstalled, "7.67.0"),
+			agentVersions: map[string]string{"foo-agent": "7.67.1-jmx"},
+			wantStatus:    metav1.ConditionFalse,
+			wantReason:    noSeccompProfileDriftReason,
+		},
+		{
+			name:          "different minor version",
+			profile:       newProfile(securityprofilesoperator.ProfileStateInstalled, "7.67.0"),
+			agentVersions: map[string]string{"foo-agent": "7.70.0"},
+			wantStatus:    metav1.ConditionTrue,
+			wantReason:    seccompProfileDriftReason,
+		},
+		{
+			name:          "different minor version in a profile workload",
+			profile:       newProfile(securityprofilesoperator.ProfileStateInstalled, "7.67.0"),
+			agentVersions: map[string]string{"foo-agent": "7.67.0", "datadog-agent-with-profile-bar-profile": "7.70.0"},
+			wantStatus:    metav1.ConditionTrue,
+			wantReason:    seccompProfileDriftReason,
+		},
+		{
+			name:          "agent version is not a semver",
+			profile:       newProfile(securityprofilesoperator.ProfileStateInstalled, "7.67.0"),
+			agentVersions: map[string]string{"foo-agent": "latest"},
+			wantStatus:    metav1.ConditionUnknown,
+			wantReason:    unknownAgentVersionReason,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observation := seccompProfileDrift(tt.profile, tt.agentVersions)
+			assert.Equal(t, tt.wantStatus, observation.status)
+			assert.Equal(t, tt.wantReason, observation.reason)
+		})
+	}
+}
+
+func Test_getSystemProbeAgentVersions(t *testing.T) {
+	dda := &v2alpha1.DatadogAgent{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "foo"}}
+	newDaemonSet := func(name string, labels map[string]string, containers ...corev1.Container) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name, Labels: labels},
+			Spec: appsv1.DaemonSetSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: containers}},
+			},
+		}
+	}
+	agentLabels := map[string]string{
+		apicommon.AgentDeploymentComponentLabelKey: constants.DefaultAgentResourceSuffix,
+		kubernetes.AppKubernetesPartOfLabelKey:     object.NewPartOfLabelValue(dda).String(),
+	}
+	systemProbe := func(image string) corev1.Container {
+		return corev1.Container{Name: string(apicommon.SystemProbeContainerName), Image: image}
+	}
+	coreAgent := corev1.Container{Name: string(apicommon.CoreAgentContainerName), Image: "gcr.io/datadoghq/agent:7.71.0"}
+
+	r := &Reconciler{
+		client: fake.NewClientBuilder().WithObjects(
+			newDaemonSet("foo-agent", agentLabels, coreAgent, systemProbe("gcr.io/datadoghq/agent:7.70.1")),
+			newDaemonSet("datadog-agent-with-profile-foo-bar", agentLabels, coreAgent, systemProbe("gcr.io/datadoghq/agent:7.68.0-jmx")),
+			newDaemonSet("foo-agent-without-system-probe", agentLabels, coreAgent),
+			newDaemonSet("other-agent", map[string]string{
+				apicommon.AgentDeploymentComponentLabelKey: constants.DefaultAgentResourceSuffix,
+			}, systemProbe("gcr.io/datadoghq/agent:7.60.0")),
+		).Build(),
+	}
+
+	agentVersions, err := r.getSystemProbeAgentVersions(context.TODO(), dda)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"foo-agent":                          "7.70.1",
+		"datadog-agent-with-profile-foo-bar": "7.68.0",
+	}, agentVersions)
+}
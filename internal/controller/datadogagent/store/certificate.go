@@ -0,0 +1,140 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/datadog-operator/pkg/certificate"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+// previewedCertificate replaces the certificates the operator would issue when the certificates are previewed
+const previewedCertificate = "issued-by-the-operator"
+
+// prepareCertificates sets the certificate of the secrets of the store copied from another secret or issued by the
+// operator, then the caBundle of the webhook configurations injected from these secrets.
+// A certificate issued by the operator is kept while it is valid, it is only issued again when it needs to be renewed.
+// When issue is false, the certificates to issue are replaced by a placeholder instead.
+func (ds *Store) prepareCertificates(ctx context.Context, k8sClient client.Client, now time.Time, issue bool) []error {
+	var errs []error
+	for _, obj := range ds.deps[kubernetes.SecretsKind] {
+		secret, ok := obj.(*v1.Secret)
+		if !ok {
+			continue
+		}
+		if source, found := secret.Annotations[certificate.CopyFromAnnotationKey]; found {
+			if err := copyCertificate(ctx, k8sClient, secret, source); err != nil {
+				errs = append(errs, err)
+			}
+		} else if dnsNames, found := secret.Annotations[certificate.IssueForAnnotationKey]; found {
+			if err := issueCertificate(ctx, k8sClient, secret, strings.Split(dnsNames, ","), now, issue); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	for _, kind := range []kubernetes.ObjectKind{kubernetes.MutatingWebhookConfigurationsKind, kubernetes.ValidatingWebhookConfigurationsKind} {
+		for _, obj := range ds.deps[kind] {
+			secretID, found := obj.GetAnnotations()[certificate.InjectCAFromSecretAnnotationKey]
+			if !found {
+				continue
+			}
+			secret, found := ds.deps[kubernetes.SecretsKind][secretID]
+			if !found {
+				errs = append(errs, fmt.Errorf("the secret %s injected in the webhook configuration %s is not managed by the operator", secretID, obj.GetName()))
+				continue
+			}
+			setCABundles(obj, secret.(*v1.Secret).Data[certificate.CertificateKey])
+		}
+	}
+	return errs
+}
+
+// copyCertificate copies the certificate of the kubernetes.io/tls secret source to secret, with the keys read by the
+// Cluster Agent. The current certificate of secret is kept until source exists.
+func copyCertificate(ctx context.Context, k8sClient client.Client, secret *v1.Secret, source string) error {
+	sourceSecret := &v1.Secret{}
+	err := k8sClient.Get(ctx, types.NamespacedName{Namespace: secret.Namespace, Name: source}, sourceSecret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil {
+		secret.Data = map[string][]byte{
+			certificate.CertificateKey: sourceSecret.Data[v1.TLSCertKey],
+			certificate.PrivateKeyKey:  sourceSecret.Data[v1.TLSPrivateKeyKey],
+		}
+		return nil
+	}
+	return keepCurrentCertificate(ctx, k8sClient, secret)
+}
+
+// issueCertificate sets the current certificate of secret if it is still valid for dnsNames, or a new one.
+// The new certificate is only a placeholder when issue is false.
+func issueCertificate(ctx context.Context, k8sClient client.Client, secret *v1.Secret, dnsNames []string, now time.Time, issue bool) error {
+	current := &v1.Secret{}
+	err := k8sClient.Get(ctx, types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}, current)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil && certificate.IsValid(current.Data[certificate.CertificateKey], dnsNames, now) {
+		secret.Data = current.Data
+		return nil
+	}
+	if !issue {
+		secret.Data = map[string][]byte{
+			certificate.CertificateKey: []byte(previewedCertificate),
+			certificate.PrivateKeyKey:  []byte(previewedCertificate),
+		}
+		return nil
+	}
+
+	certPEM, keyPEM, err := certificate.NewSelfSigned(dnsNames, now)
+	if err != nil {
+		return fmt.Errorf("unable to issue the certificate of the secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+	secret.Data = map[string][]byte{
+		certificate.CertificateKey: certPEM,
+		certificate.PrivateKeyKey:  keyPEM,
+	}
+	return nil
+}
+
+func keepCurrentCertificate(ctx context.Context, k8sClient client.Client, secret *v1.Secret) error {
+	current := &v1.Secret{}
+	err := k8sClient.Get(ctx, types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}, current)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	secret.Data = current.Data
+	return nil
+}
+
+// setCABundles sets the caBundle of all the webhooks of a webhook configuration.
+func setCABundles(obj client.Object, caBundle []byte) {
+	switch webhookConfig := obj.(type) {
+	case *admissionregistrationv1.MutatingWebhookConfiguration:
+		for i := range webhookConfig.Webhooks {
+			webhookConfig.Webhooks[i].ClientConfig.CABundle = caBundle
+		}
+	case *admissionregistrationv1.ValidatingWebhookConfiguration:
+		for i := range webhookConfig.Webhooks {
+			webhookConfig.Webhooks[i].ClientConfig.CABundle = caBundle
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/DataDog/datadog-operator/pkg/certificate"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+func Test_prepareCertificates(t *testing.T) {
+	now := time.Now()
+	dnsNames := []string{"datadog-admission-controller.bar.svc"}
+	currentCert, currentKey, err := certificate.NewSelfSigned(dnsNames, now)
+	assert.NoError(t, err)
+
+	newSecret := func(name string, annotations map[string]string, data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "bar",
+				Name:        name,
+				Annotations: annotations,
+			},
+			Data: data,
+		}
+	}
+	newWebhookConfig := func(secretID string) *admissionregistrationv1.MutatingWebhookConfiguration {
+		return &admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "datadog-webhook",
+				Annotations: map[string]string{certificate.InjectCAFromSecretAnnotationKey: secretID},
+			},
+			Webhooks: []admissionregistrationv1.MutatingWebhook{{Name: "datadog.webhook.agent.config"}},
+		}
+	}
+	copyFrom := map[string]string{certificate.CopyFromAnnotationKey: "foo-tls"}
+	issueFor := map[string]string{certificate.IssueForAnnotationKey: "datadog-admission-controller.bar.svc"}
+	currentData := map[string][]byte{certificate.CertificateKey: currentCert, certificate.PrivateKeyKey: currentKey}
+
+	tests := []struct {
+		name          string
+		secret        *corev1.Secret
+		webhookConfig *admissionregistrationv1.MutatingWebhookConfiguration
+		existing      []client.Object
+		preview       bool
+		wantErr       bool
+		wantData      func(t *testing.T, data map[string][]byte)
+	}{
+		{
+			name:   "certificate copied from the cert-manager secret",
+			secret: newSecret("foo-cert", copyFrom, nil),
+			existing: []client.Object{
+				newSecret("foo-tls", nil, map[string][]byte{corev1.TLSCertKey: []byte("cert"), corev1.TLSPrivateKeyKey: []byte("key")}),
+			},
+			wantData: func(t *testing.T, data map[string][]byte) {
+				assert.Equal(t, map[string][]byte{certificate.CertificateKey: []byte("cert"), certificate.PrivateKeyKey: []byte("key")}, data)
+			},
+		},
+		{
+			name:     "current certificate kept until the cert-manager secret exists",
+			secret:   newSecret("foo-cert", copyFrom, nil),
+			existing: []client.Object{newSecret("foo-cert", nil, currentData)},
+			wantData: func(t *testing.T, data map[string][]byte) {
+				assert.Equal(t, currentData, data)
+			},
+		},
+		{
+			name:          "certificate issued by the operator",
+			secret:        newSecret("foo-cert", issueFor, nil),
+			webhookConfig: newWebhookConfig("bar/foo-cert"),
+			wantData: func(t *testing.T, data map[string][]byte) {
+				assert.True(t, certificate.IsValid(data[certificate.CertificateKey], dnsNames, now))
+				assert.NotEqual(t, currentCert, data[certificate.CertificateKey])
+			},
+		},
+		{
+			name:          "valid certificate issued by the operator kept",
+			secret:        newSecret("foo-cert", issueFor, nil),
+			webhookConfig: newWebhookConfig("bar/foo-cert"),
+			existing:      []client.Object{newSecret("foo-cert", nil, currentData)},
+			wantData: func(t *testing.T, data map[string][]byte) {
+				assert.Equal(t, currentData, data)
+			},
+		},
+		{
+			name:          "certificate previewed without issuing it",
+			secret:        newSecret("foo-cert", issueFor, nil),
+			webhookConfig: newWebhookConfig("bar/foo-cert"),
+			preview:       true,
+			wantData: func(t *testing.T, data map[string][]byte) {
+				assert.Equal(t, map[string][]byte{certificate.CertificateKey: []byte(previewedCertificate), certificate.PrivateKeyKey: []byte(previewedCertificate)}, data)
+			},
+		},
+		{
+			name:          "valid certificate kept when previewed",
+			secret:        newSecret("foo-cert", issueFor, nil),
+			webhookConfig: newWebhookConfig("bar/foo-cert"),
+			existing:      []client.Object{newSecret("foo-cert", nil, currentData)},
+			preview:       true,
+			wantData: func(t *testing.T, data map[string][]byte) {
+				assert.Equal(t, currentData, data)
+			},
+		},
+		{
+			name:          "CA injected from a secret not managed by the operator",
+			secret:        newSecret("foo-cert", issueFor, nil),
+			webhookConfig: newWebhookConfig("bar/other"),
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := NewStore(nil, nil)
+			assert.NoError(t, ds.AddOrUpdate(kubernetes.SecretsKind, tt.secret))
+			if tt.webhookConfig != nil {
+				assert.NoError(t, ds.AddOrUpdate(kubernetes.MutatingWebhookConfigurationsKind, tt.webhookConfig))
+			}
+			k8sClient := fake.NewClientBuilder().WithObjects(tt.existing...).Build()
+
+			errs := ds.prepareCertificates(context.TODO(), k8sClient, now, !tt.preview)
+			if tt.wantErr {
+				assert.NotEmpty(t, errs)
+				return
+			}
+			assert.Empty(t, errs)
+			tt.wantData(t, tt.secret.Data)
+			if tt.webhookConfig != nil {
+				assert.Equal(t, tt.secret.Data[certificate.CertificateKey], tt.webhookConfig.Webhooks[0].ClientConfig.CABundle)
+			}
+		})
+	}
+}
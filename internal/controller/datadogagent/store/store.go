@@ -10,8 +10,10 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
@@ -24,6 +26,7 @@ import (
 
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
+	certmanagerv1 "github.com/DataDog/datadog-operator/pkg/certmanager/v1"
 	"github.com/DataDog/datadog-operator/pkg/equality"
 	"github.com/DataDog/datadog-operator/pkg/kubernetes"
 )
@@ -191,14 +194,92 @@ func (ds *Store) Delete(kind kubernetes.ObjectKind, namespace string, name strin
 	return false
 }
 
+// Changes lists the changes the Store would make in the api-server.
+type Changes struct {
+	// ToCreate contains the objects Apply would create
+	ToCreate []Change
+	// ToUpdate contains the objects Apply would update
+	ToUpdate []Change
+	// ToDelete contains the objects Cleanup would delete
+	ToDelete []Change
+}
+
+// Change describes a change on a single object.
+type Change struct {
+	Kind kubernetes.ObjectKind
+	// Object is the object from the Store, or the object to delete
+	Object client.Object
+	// Current is the object in the api-server, nil for creations
+	Current client.Object
+}
+
+// PrepareCertificates sets the certificates of the secrets of the store, and the caBundle of the webhook configurations
+// injected from them. Apply calls it.
+func (ds *Store) PrepareCertificates(ctx context.Context, k8sClient client.Client) []error {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+
+	return ds.prepareCertificates(ctx, k8sClient, time.Now(), true)
+}
+
+// PreviewCertificates is PrepareCertificates without issuing any certificate: the current certificates are kept while
+// they are valid, and the ones the operator would issue are replaced by a placeholder. Changes does not set the
+// certificates, PreviewCertificates must be called before it to preview these objects.
+func (ds *Store) PreviewCertificates(ctx context.Context, k8sClient client.Client) []error {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+
+	return ds.prepareCertificates(ctx, k8sClient, time.Now(), false)
+}
+
+// Changes returns the objects Apply and Cleanup would create, update and delete, without applying them
+func (ds *Store) Changes(ctx context.Context, k8sClient client.Client) (Changes, []error) {
+	ds.mutex.RLock()
+	defer ds.mutex.RUnlock()
+
+	var changes Changes
+	var errs []error
+	changes.ToCreate, changes.ToUpdate, errs = ds.changesToApply(ctx, k8sClient)
+	toDelete, cleanupErrs := ds.changesToCleanup(ctx, k8sClient)
+	changes.ToDelete = toDelete
+	errs = append(errs, cleanupErrs...)
+	return changes, errs
+}
+
 // Apply use to create/update resources in the api-server
 func (ds *Store) Apply(ctx context.Context, k8sClient client.Client) []error {
+	errs := ds.PrepareCertificates(ctx, k8sClient)
+
 	ds.mutex.RLock()
 	defer ds.mutex.RUnlock()
 
+	objsToCreate, objsToUpdate, applyErrs := ds.changesToApply(ctx, k8sClient)
+	errs = append(errs, applyErrs...)
+
+	ds.logger.V(2).Info("store.store objsToCreate", "nb", len(objsToCreate))
+	for _, change := range objsToCreate {
+		obj := change.Object
+		if err := k8sClient.Create(ctx, obj); err != nil {
+			ds.logger.Error(err, "store.store Create", "obj.namespace", obj.GetNamespace(), "obj.name", obj.GetName())
+			errs = append(errs, err)
+		}
+	}
+
+	ds.logger.V(2).Info("store.store objsToUpdate", "nb", len(objsToUpdate))
+	for _, change := range objsToUpdate {
+		obj := change.Object
+		if err := k8sClient.Update(ctx, obj); err != nil {
+			ds.logger.Error(err, "store.store Update", "obj.namespace", obj.GetNamespace(), "obj.name", obj.GetName())
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func (ds *Store) changesToApply(ctx context.Context, k8sClient client.Client) ([]Change, []Change, []error) {
 	var errs []error
-	var objsToCreate []client.Object
-	var objsToUpdate []client.Object
+	var objsToCreate []Change
+	var objsToUpdate []Change
 	for kind := range ds.deps {
 		for objID, objStore := range ds.deps[kind] {
 			objNSName := buildObjectKey(objID)
@@ -206,7 +287,7 @@ func (ds *Store) Apply(ctx context.Context, k8sClient client.Client) []error {
 			err := k8sClient.Get(ctx, objNSName, objAPIServer)
 			if err != nil && apierrors.IsNotFound(err) {
 				ds.logger.V(2).Info("store.store Add object to create", "obj.namespace", objStore.GetNamespace(), "obj.name", objStore.GetName(), "obj.kind", kind)
-				objsToCreate = append(objsToCreate, objStore)
+				objsToCreate = append(objsToCreate, Change{Kind: kind, Object: objStore})
 				continue
 			} else if err != nil {
 				errs = append(errs, err)
@@ -219,35 +300,26 @@ func (ds *Store) Apply(ctx context.Context, k8sClient client.Client) []error {
 				objStore.(*v1.Service).Spec.ClusterIPs = objAPIServer.(*v1.Service).Spec.ClusterIPs
 				objStore.SetResourceVersion(objAPIServer.GetResourceVersion())
 			}
-			// The APIServiceKind and CiliumNetworkPoliciesKind resource version must be set.
-			if kind == kubernetes.APIServiceKind || kind == kubernetes.CiliumNetworkPoliciesKind {
+			// The APIServiceKind, network policies, cert-manager and seccomp profile resources resource version must be set.
+			if kind == kubernetes.APIServiceKind || kind == kubernetes.CiliumNetworkPoliciesKind ||
+				kind == kubernetes.CalicoGlobalNetworkPoliciesKind || kind == kubernetes.AdminNetworkPoliciesKind ||
+				kind == kubernetes.CertManagerCertificatesKind || kind == kubernetes.CertManagerIssuersKind ||
+				kind == kubernetes.SeccompProfilesKind {
 				objStore.SetResourceVersion(objAPIServer.GetResourceVersion())
 			}
+			// The caBundle of the webhook configurations can be injected by cert-manager, it must be kept.
+			if kind == kubernetes.MutatingWebhookConfigurationsKind || kind == kubernetes.ValidatingWebhookConfigurationsKind {
+				keepInjectedCABundles(objStore, objAPIServer)
+			}
 
 			if !equality.IsEqualObject(kind, objStore, objAPIServer) {
 				ds.logger.V(2).Info("store.store Add object to update", "obj.namespace", objStore.GetNamespace(), "obj.name", objStore.GetName(), "obj.kind", kind)
-				objsToUpdate = append(objsToUpdate, objStore)
+				objsToUpdate = append(objsToUpdate, Change{Kind: kind, Object: objStore, Current: objAPIServer})
 				continue
 			}
 		}
 	}
-
-	ds.logger.V(2).Info("store.store objsToCreate", "nb", len(objsToCreate))
-	for _, obj := range objsToCreate {
-		if err := k8sClient.Create(ctx, obj); err != nil {
-			ds.logger.Error(err, "store.store Create", "obj.namespace", obj.GetNamespace(), "obj.name", obj.GetName())
-			errs = append(errs, err)
-		}
-	}
-
-	ds.logger.V(2).Info("store.store objsToUpdate", "nb", len(objsToUpdate))
-	for _, obj := range objsToUpdate {
-		if err := k8sClient.Update(ctx, obj); err != nil {
-			ds.logger.Error(err, "store.store Update", "obj.namespace", obj.GetNamespace(), "obj.name", obj.GetName())
-			errs = append(errs, err)
-		}
-	}
-	return errs
+	return objsToCreate, objsToUpdate, errs
 }
 
 // Cleanup use to cleanup resources that are not needed anymore
@@ -255,7 +327,18 @@ func (ds *Store) Cleanup(ctx context.Context, k8sClient client.Client) []error {
 	ds.mutex.RLock()
 	defer ds.mutex.RUnlock()
 
+	changes, errs := ds.changesToCleanup(ctx, k8sClient)
+	objsToDelete := make([]client.Object, 0, len(changes))
+	for _, change := range changes {
+		objsToDelete = append(objsToDelete, change.Object)
+	}
+
+	return append(errs, deleteObjects(ctx, k8sClient, objsToDelete)...)
+}
+
+func (ds *Store) changesToCleanup(ctx context.Context, k8sClient client.Client) ([]Change, []error) {
 	var errs []error
+	var objsToDelete []Change
 
 	requirementLabel, _ := labels.NewRequirement(OperatorStoreLabelKey, selection.Exists, nil)
 	listOptions := &client.ListOptions{
@@ -268,15 +351,15 @@ func (ds *Store) Cleanup(ctx context.Context, k8sClient client.Client) []error {
 			continue
 		}
 
-		objsToDelete, err := ds.listObjectToDelete(objList, ds.deps[kind])
+		kindObjsToDelete, err := ds.listObjectToDelete(kind, objList, ds.deps[kind])
 		if err != nil {
 			errs = append(errs, err)
 			continue
 		}
-		errs = append(errs, deleteObjects(ctx, k8sClient, objsToDelete)...)
+		objsToDelete = append(objsToDelete, kindObjsToDelete...)
 	}
 
-	return errs
+	return objsToDelete, errs
 }
 
 // GetPlatformInfo returns api-resources info
@@ -331,13 +414,13 @@ func (ds *Store) DeleteAll(ctx context.Context, k8sClient client.Client) []error
 	return deleteObjects(ctx, k8sClient, objsToDelete)
 }
 
-func (ds *Store) listObjectToDelete(objList client.ObjectList, cacheObjects map[string]client.Object) ([]client.Object, error) {
+func (ds *Store) listObjectToDelete(kind kubernetes.ObjectKind, objList client.ObjectList, cacheObjects map[string]client.Object) ([]Change, error) {
 	items, err := apimeta.ExtractList(objList)
 	if err != nil {
 		return nil, err
 	}
 
-	var objsToDelete []client.Object
+	var objsToDelete []Change
 	for _, objAPIServer := range items {
 		objMeta, _ := apimeta.Accessor(objAPIServer)
 
@@ -360,7 +443,8 @@ func (ds *Store) listObjectToDelete(objList client.ObjectList, cacheObjects map[
 						},
 					}
 					partialObj.TypeMeta.SetGroupVersionKind(objAPIServer.GetObjectKind().GroupVersionKind())
-					objsToDelete = append(objsToDelete, partialObj)
+					current, _ := objAPIServer.(client.Object)
+					objsToDelete = append(objsToDelete, Change{Kind: kind, Object: partialObj, Current: current})
 				}
 			}
 		}
@@ -413,6 +497,14 @@ func shouldSetOwnerReference(kind kubernetes.ObjectKind, objNamespace, ownerName
 		return false
 	case kubernetes.APIServiceKind:
 		return false
+	case kubernetes.MutatingWebhookConfigurationsKind:
+		return false
+	case kubernetes.ValidatingWebhookConfigurationsKind:
+		return false
+	case kubernetes.CalicoGlobalNetworkPoliciesKind:
+		return false
+	case kubernetes.AdminNetworkPoliciesKind:
+		return false
 	}
 
 	// Owner-reference should not be added to namespaced resources in a different namespace than the owner
@@ -422,3 +514,38 @@ func shouldSetOwnerReference(kind kubernetes.ObjectKind, objNamespace, ownerName
 
 	return true
 }
+
+// keepInjectedCABundles copies the caBundle of the webhooks of the current webhook configuration to the webhooks
+// of the webhook configuration in the store, if it is injected by cert-manager.
+func keepInjectedCABundles(objStore, objAPIServer client.Object) {
+	if _, found := objStore.GetAnnotations()[certmanagerv1.InjectCAFromAnnotationKey]; !found {
+		return
+	}
+
+	switch webhookConfig := objStore.(type) {
+	case *admissionregistrationv1.MutatingWebhookConfiguration:
+		current, ok := objAPIServer.(*admissionregistrationv1.MutatingWebhookConfiguration)
+		if !ok {
+			return
+		}
+		for i := range webhookConfig.Webhooks {
+			for _, currentWebhook := range current.Webhooks {
+				if currentWebhook.Name == webhookConfig.Webhooks[i].Name && len(webhookConfig.Webhooks[i].ClientConfig.CABundle) == 0 {
+					webhookConfig.Webhooks[i].ClientConfig.CABundle = currentWebhook.ClientConfig.CABundle
+				}
+			}
+		}
+	case *admissionregistrationv1.ValidatingWebhookConfiguration:
+		current, ok := objAPIServer.(*admissionregistrationv1.ValidatingWebhookConfiguration)
+		if !ok {
+			return
+		}
+		for i := range webhookConfig.Webhooks {
+			for _, currentWebhook := range current.Webhooks {
+				if currentWebhook.Name == webhookConfig.Webhooks[i].Name && len(webhookConfig.Webhooks[i].ClientConfig.CABundle) == 0 {
+					webhookConfig.Webhooks[i].ClientConfig.CABundle = currentWebhook.ClientConfig.CABundle
+				}
+			}
+		}
+	}
+}
@@ -12,8 +12,11 @@ import (
 
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	testutils "github.com/DataDog/datadog-operator/internal/controller/datadogagent/testutils"
+	"github.com/DataDog/datadog-operator/pkg/certificate"
+	certmanagerv1 "github.com/DataDog/datadog-operator/pkg/certmanager/v1"
 	"github.com/DataDog/datadog-operator/pkg/kubernetes"
 	assert "github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -506,6 +509,83 @@ func TestStore_Cleanup(t *testing.T) {
 	}
 }
 
+func TestStore_Changes(t *testing.T) {
+	partOfLabels := map[string]string{
+		OperatorStoreLabelKey:                  "true",
+		kubernetes.AppKubernetesPartOfLabelKey: "namespace--test-dda--test",
+	}
+	newConfigMap := func(name string, data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ConfigMap",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "bar",
+				Name:      name,
+				Labels:    partOfLabels,
+			},
+			Data: data,
+		}
+	}
+
+	k8sClient := fake.NewClientBuilder().WithObjects(
+		newConfigMap("unchanged", nil),
+		newConfigMap("updated", map[string]string{"data1": "value1"}),
+		newConfigMap("deleted", nil),
+	).Build()
+
+	ds := &Store{
+		deps: map[kubernetes.ObjectKind]map[string]client.Object{
+			kubernetes.ConfigMapKind: {
+				"bar/unchanged": newConfigMap("unchanged", nil),
+				"bar/updated":   newConfigMap("updated", map[string]string{"data1": "value2"}),
+				"bar/created":   newConfigMap("created", nil),
+			},
+			kubernetes.SecretsKind: {
+				"bar/issued": &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "bar",
+						Name:        "issued",
+						Annotations: map[string]string{certificate.IssueForAnnotationKey: "datadog-admission-controller.bar.svc"},
+					},
+				},
+			},
+		},
+		logger: logf.Log.WithName(t.Name()),
+		owner: &metav1.ObjectMeta{
+			Name:      "dda-test",
+			Namespace: "namespace-test",
+		},
+	}
+
+	changes, errs := ds.Changes(context.TODO(), k8sClient)
+	assert.Empty(t, errs)
+
+	assert.Len(t, changes.ToCreate, 2)
+	created := map[string]client.Object{}
+	for _, change := range changes.ToCreate {
+		assert.Nil(t, change.Current)
+		created[change.Object.GetName()] = change.Object
+	}
+	assert.Contains(t, created, "created")
+	// Changes doesn't prepare the certificates
+	assert.Nil(t, created["issued"].(*corev1.Secret).Data)
+
+	assert.Len(t, changes.ToUpdate, 1)
+	assert.Equal(t, "updated", changes.ToUpdate[0].Object.GetName())
+	assert.Equal(t, "value1", changes.ToUpdate[0].Current.(*corev1.ConfigMap).Data["data1"])
+
+	assert.Len(t, changes.ToDelete, 1)
+	assert.Equal(t, kubernetes.ConfigMapKind, changes.ToDelete[0].Kind)
+	assert.Equal(t, "deleted", changes.ToDelete[0].Object.GetName())
+
+	// Changes doesn't modify the api-server
+	cmList := &corev1.ConfigMapList{}
+	assert.NoError(t, k8sClient.List(context.TODO(), cmList))
+	assert.Len(t, cmList.Items, 3)
+}
+
 func TestStore_GetOrCreate(t *testing.T) {
 	dummyConfigMap1 := &corev1.ConfigMap{
 		TypeMeta: metav1.TypeMeta{
@@ -743,3 +823,29 @@ func TestStore_DeleteAll(t *testing.T) {
 		})
 	}
 }
+
+func Test_keepInjectedCABundles(t *testing.T) {
+	newWebhookConfig := func(annotations map[string]string, caBundle []byte) *admissionregistrationv1.MutatingWebhookConfiguration {
+		return &admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "datadog-webhook",
+				Annotations: annotations,
+			},
+			Webhooks: []admissionregistrationv1.MutatingWebhook{
+				{
+					Name:         "datadog.webhook.agent.config",
+					ClientConfig: admissionregistrationv1.WebhookClientConfig{CABundle: caBundle},
+				},
+			},
+		}
+	}
+	injectAnnotations := map[string]string{certmanagerv1.InjectCAFromAnnotationKey: "foo/bar"}
+
+	objStore := newWebhookConfig(injectAnnotations, nil)
+	keepInjectedCABundles(objStore, newWebhookConfig(injectAnnotations, []byte("ca")))
+	assert.Equal(t, []byte("ca"), objStore.Webhooks[0].ClientConfig.CABundle)
+
+	objStore = newWebhookConfig(nil, nil)
+	keepInjectedCABundles(objStore, newWebhookConfig(nil, []byte("ca")))
+	assert.Empty(t, objStore.Webhooks[0].ClientConfig.CABundle)
+}
@@ -19,6 +19,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
@@ -28,10 +29,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogcheck"
 	"github.com/DataDog/datadog-operator/internal/controller/metrics"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
 	"github.com/DataDog/datadog-operator/pkg/kubernetes"
@@ -40,6 +44,8 @@ import (
 // DatadogAgentReconciler reconciles a DatadogAgent object.
 type DatadogAgentReconciler struct {
 	client.Client
+	APIReader    client.Reader
+	RESTClient   rest.Interface
 	PlatformInfo kubernetes.PlatformInfo
 	Log          logr.Logger
 	Scheme       *runtime.Scheme
@@ -95,6 +101,21 @@ type DatadogAgentReconciler struct {
 // Use CiliumNetworkPolicy
 // +kubebuilder:rbac:groups=cilium.io,resources=ciliumnetworkpolicies,verbs=get;list;watch;create;update;patch;delete
 
+// Use Calico GlobalNetworkPolicy
+// +kubebuilder:rbac:groups=projectcalico.org,resources=globalnetworkpolicies,verbs=get;list;watch;create;update;patch;delete;deletecollection
+
+// Use AdminNetworkPolicy
+// +kubebuilder:rbac:groups=policy.networking.k8s.io,resources=adminnetworkpolicies,verbs=get;list;watch;create;update;patch;delete;deletecollection
+
+// Use Security Profiles Operator SeccompProfile
+// +kubebuilder:rbac:groups=security-profiles-operator.x-k8s.io,resources=seccompprofiles,verbs=get;list;watch;create;update;patch;delete
+
+// Read the node Agent containers usage for the resource recommendations
+// +kubebuilder:rbac:groups=metrics.k8s.io,resources=pods,verbs=get;list
+
+// Use cert-manager
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates;issuers,verbs=get;list;watch;create;update;patch;delete
+
 // OpenShift
 // +kubebuilder:rbac:groups=quota.openshift.io,resources=clusterresourcequotas,verbs=get;list
 // +kubebuilder:rbac:groups=security.openshift.io,resources=securitycontextconstraints,resourceNames=restricted,verbs=use
@@ -116,6 +137,7 @@ type DatadogAgentReconciler struct {
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=list;watch
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch;delete
@@ -177,6 +199,9 @@ type DatadogAgentReconciler struct {
 // Profiles
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=list;watch;patch
 
+// Feature status
+// +kubebuilder:rbac:groups=node.k8s.io,resources=runtimeclasses,verbs=get;list;watch
+
 // Reconcile loop for DatadogAgent.
 func (r *DatadogAgentReconciler) Reconcile(ctx context.Context, dda *v2alpha1.DatadogAgent) (ctrl.Result, error) {
 	return r.internal.Reconcile(ctx, dda)
@@ -213,12 +238,28 @@ func (r *DatadogAgentReconciler) SetupWithManager(mgr ctrl.Manager, metricForwar
 			))
 	}
 
-	// Watch nodes and reconcile all DatadogAgents for node creation, node deletion, and node label change events
-	if r.Options.DatadogAgentProfileEnabled || r.Options.IntrospectionEnabled {
+	if r.Options.DatadogCheckEnabled {
 		builder.Watches(
-			&corev1.Node{},
-			handler.EnqueueRequestsFromMapFunc(r.enqueueRequestsForAllDDAs()),
-			ctrlbuilder.WithPredicates(r.enqueueIfNodeLabelsChange()),
+			&v1alpha1.DatadogCheck{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueRequestsForDatadogCheckDDAs()),
+		)
+	}
+
+	// Watch nodes and reconcile all DatadogAgents for node creation, node deletion, and node label change events,
+	// so that the node partition between DatadogAgents labels the new shared nodes. Only the node names and labels are cached.
+	builder.Watches(
+		&corev1.Node{},
+		handler.EnqueueRequestsFromMapFunc(r.enqueueRequestsForAllDDAs()),
+		ctrlbuilder.WithPredicates(r.enqueueIfNodeLabelsChange()),
+	)
+
+	// Watch the pods of the Datadog components, so that the staged rollout waves and the automatic rollback
+	// progress as soon as the pods become ready. The pod cache is scoped to these pods.
+	if r.Options.AgentRolloutEnabled {
+		builder.Watches(
+			&corev1.Pod{},
+			handler.EnqueueRequestsFromMapFunc(enqueueOwningDatadogAgent),
+			ctrlbuilder.WithPredicates(enqueueIfPodStatusChanges()),
 		)
 	}
 
@@ -255,17 +296,18 @@ func (r *DatadogAgentReconciler) SetupWithManager(mgr ctrl.Manager, metricForwar
 	}
 
 	or := reconcile.AsReconciler[*v2alpha1.DatadogAgent](r.Client, r)
-	if err := builder.For(&v2alpha1.DatadogAgent{}, builderOptions...).WithEventFilter(predicate.GenerationChangedPredicate{}).Complete(or); err != nil {
+	if err := builder.For(&v2alpha1.DatadogAgent{}, builderOptions...).WithEventFilter(predicate.Or(predicate.GenerationChangedPredicate{}, isNodeOrPodEvent(), isPauseAnnotationsChange())).Complete(or); err != nil {
 		return err
 	}
 
-	internal, err := datadogagent.NewReconciler(r.Options, r.Client, r.PlatformInfo, r.Scheme, r.Log, r.Recorder, metricForwardersMgr)
+	internal, err := datadogagent.NewReconciler(r.Options, r.Client, r.APIReader, r.RESTClient, r.PlatformInfo, r.Scheme, r.Log, r.Recorder, metricForwardersMgr)
 	if err != nil {
 		return err
 	}
 	r.internal = internal
 
-	return nil
+	// The usage of the node Agent containers is sampled outside of the reconcile loop
+	return mgr.Add(internal.ResourceRecommendationsCollector())
 }
 
 func enqueueIfOwnedByDatadogAgent(ctx context.Context, obj client.Object) []reconcile.Request {
@@ -281,6 +323,74 @@ func enqueueIfOwnedByDatadogAgent(ctx context.Context, obj client.Object) []reco
 	return []reconcile.Request{{NamespacedName: owner}}
 }
 
+// isNodeOrPodEvent lets the node and pod events through the generation filter:
+// the generation does not change with the node labels nor with the pod status.
+func isNodeOrPodEvent() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		switch obj.(type) {
+		case *corev1.Node, *corev1.Pod:
+			return true
+		default:
+			return false
+		}
+	})
+}
+
+// isPauseAnnotationsChange lets the updates of the pause-reconcile annotations through the generation filter:
+// the generation does not change with the annotations.
+func isPauseAnnotationsChange() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			oldAnnotations, newAnnotations := e.ObjectOld.GetAnnotations(), e.ObjectNew.GetAnnotations()
+			for _, key := range []string{common.PauseReconcileAnnotationKey, common.PauseReconcileUntilAnnotationKey} {
+				if oldAnnotations[key] != newAnnotations[key] {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// enqueueOwningDatadogAgent enqueues the DatadogAgent of a pod, set in the "agent.datadoghq.com/name" label.
+func enqueueOwningDatadogAgent(ctx context.Context, obj client.Object) []reconcile.Request {
+	name := obj.GetLabels()[apicommon.AgentDeploymentNameLabelKey]
+	if name == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: name}}}
+}
+
+// enqueueIfPodStatusChanges filters the pod updates on the fields kept in the pod cache and used by the staged
+// rollout and the automatic rollback: the node, the deletion, the readiness and the restart counts.
+func enqueueIfPodStatusChanges() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPod, okOld := e.ObjectOld.(*corev1.Pod)
+			newPod, okNew := e.ObjectNew.(*corev1.Pod)
+			if !okOld || !okNew {
+				return false
+			}
+			return oldPod.Spec.NodeName != newPod.Spec.NodeName ||
+				(oldPod.DeletionTimestamp == nil) != (newPod.DeletionTimestamp == nil) ||
+				!reflect.DeepEqual(oldPod.Status.Conditions, newPod.Status.Conditions) ||
+				!reflect.DeepEqual(oldPod.Status.ContainerStatuses, newPod.Status.ContainerStatuses)
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return true
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+	}
+}
+
 func (r *DatadogAgentReconciler) enqueueIfNodeLabelsChange() predicate.Funcs {
 	return predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
@@ -295,6 +405,39 @@ func (r *DatadogAgentReconciler) enqueueIfNodeLabelsChange() predicate.Funcs {
 	}
 }
 
+// enqueueRequestsForDatadogCheckDDAs enqueues the DatadogAgents that may be selected by a DatadogCheck, without reading its namespace.
+// On update, both the old and the new DatadogCheck are mapped, so DatadogAgents no longer selected are enqueued too.
+func (r *DatadogAgentReconciler) enqueueRequestsForDatadogCheckDDAs() handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var requests []reconcile.Request
+
+		check, ok := obj.(*v1alpha1.DatadogCheck)
+		if !ok {
+			return requests
+		}
+
+		ddaList := v2alpha1.DatadogAgentList{}
+		if err := r.List(ctx, &ddaList); err != nil {
+			return requests
+		}
+
+		for i := range ddaList.Items {
+			dda := &ddaList.Items[i]
+			if !datadogcheck.MaySelectDatadogAgent(check, dda, &dda.Spec) {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: dda.Namespace,
+					Name:      dda.Name,
+				},
+			})
+		}
+
+		return requests
+	}
+}
+
 func (r *DatadogAgentReconciler) enqueueRequestsForAllDDAs() handler.MapFunc {
 	return func(ctx context.Context, obj client.Object) []reconcile.Request {
 		var requests []reconcile.Request
@@ -29,6 +29,7 @@ import (
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/clusterchecks"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/cspm"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/cws"
+	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/datadogcheck"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/dogstatsd"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/dummy"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/ebpfcheck"
@@ -44,6 +45,7 @@ import (
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/npm"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/oomkill"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/orchestratorexplorer"
+	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/otelagentgateway"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/otelcollector"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/otlp"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/processdiscovery"
@@ -64,12 +66,14 @@ type ReconcilerOptions struct {
 	ExtendedDaemonsetOptions componentagent.ExtendedDaemonsetOptions
 	SupportCilium            bool
 	OperatorMetricsEnabled   bool
+	DatadogCheckEnabled      bool
 }
 
 // Reconciler is the internal reconciler for Datadog Agent
 type Reconciler struct {
 	options      ReconcilerOptions
 	client       client.Client
+	apiReader    client.Reader
 	platformInfo kubernetes.PlatformInfo
 	scheme       *runtime.Scheme
 	log          logr.Logger
@@ -78,12 +82,13 @@ type Reconciler struct {
 }
 
 // NewReconciler returns a reconciler for DatadogAgent
-func NewReconciler(options ReconcilerOptions, client client.Client, platformInfo kubernetes.PlatformInfo,
+func NewReconciler(options ReconcilerOptions, client client.Client, apiReader client.Reader, platformInfo kubernetes.PlatformInfo,
 	scheme *runtime.Scheme, log logr.Logger, recorder record.EventRecorder, metricForwardersMgr datadog.MetricsForwardersManager,
 ) (*Reconciler, error) {
 	return &Reconciler{
 		options:      options,
 		client:       client,
+		apiReader:    apiReader,
 		platformInfo: platformInfo,
 		scheme:       scheme,
 		log:          log,
@@ -92,6 +97,14 @@ func NewReconciler(options ReconcilerOptions, client client.Client, platformInfo
 	}, nil
 }
 
+// uncachedReader returns the reader of the API server, for the objects which are not in the cache.
+func (r *Reconciler) uncachedReader() client.Reader {
+	if r.apiReader != nil {
+		return r.apiReader
+	}
+	return r.client
+}
+
 // Reconcile is similar to reconciler.Reconcile interface, but taking a context
 func (r *Reconciler) Reconcile(ctx context.Context, ddai *v1alpha1.DatadogAgentInternal) (reconcile.Result, error) {
 	var resp reconcile.Result
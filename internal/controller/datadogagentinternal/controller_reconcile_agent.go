@@ -83,11 +83,14 @@ func (r *Reconciler) reconcileV2Agent(logger logr.Logger, requiredComponents fea
 			if apiutils.BoolValue(componentOverride.Disabled) {
 				disabledByOverride = true
 			}
-			override.PodTemplateSpec(logger, podManagers, componentOverride, datadoghqv2alpha1.NodeAgentComponentName, ddaiCopy.Name)
+			if err := override.PodTemplateSpec(logger, podManagers, componentOverride, datadoghqv2alpha1.NodeAgentComponentName, ddaiCopy.Name); err != nil {
+				return reconcile.Result{}, err
+			}
 			override.ExtendedDaemonSet(eds, componentOverride)
 		}
 
 		experimental.ApplyExperimentalOverrides(logger, ddaiCopy, podManagers)
+		global.ApplySecurityProfile(podManagers, &ddaiCopy.Spec)
 
 		if disabledByOverride {
 			if agentEnabled {
@@ -140,11 +143,14 @@ func (r *Reconciler) reconcileV2Agent(logger logr.Logger, requiredComponents fea
 		if apiutils.BoolValue(componentOverride.Disabled) {
 			disabledByOverride = true
 		}
-		override.PodTemplateSpec(logger, podManagers, componentOverride, datadoghqv2alpha1.NodeAgentComponentName, ddaiCopy.Name)
+		if err := override.PodTemplateSpec(logger, podManagers, componentOverride, datadoghqv2alpha1.NodeAgentComponentName, ddaiCopy.Name); err != nil {
+			return reconcile.Result{}, err
+		}
 		override.DaemonSet(daemonset, componentOverride)
 	}
 
 	experimental.ApplyExperimentalOverrides(logger, ddaiCopy, podManagers)
+	global.ApplySecurityProfile(podManagers, &ddaiCopy.Spec)
 
 	if disabledByOverride {
 		if agentEnabled {
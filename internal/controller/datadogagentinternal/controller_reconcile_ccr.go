@@ -83,13 +83,17 @@ func (r *Reconciler) reconcileV2ClusterChecksRunner(logger logr.Logger, required
 			// Delete CCR
 			return r.cleanupV2ClusterChecksRunner(deploymentLogger, ddai, deployment, newStatus)
 		}
-		override.PodTemplateSpec(logger, podManagers, componentOverride, datadoghqv2alpha1.ClusterChecksRunnerComponentName, ddai.Name)
+		if err := override.PodTemplateSpec(logger, podManagers, componentOverride, datadoghqv2alpha1.ClusterChecksRunnerComponentName, ddai.Name); err != nil {
+			return reconcile.Result{}, err
+		}
 		override.Deployment(deployment, componentOverride)
 	} else if !ccrEnabled {
 		return r.cleanupV2ClusterChecksRunner(deploymentLogger, ddai, deployment, newStatus)
 	}
 
-	return r.createOrUpdateDeployment(deploymentLogger, ddai, deployment, newStatus, updateStatusV2WithClusterChecksRunner)
+	global.ApplySecurityProfile(podManagers, &ddai.Spec)
+
+	return r.createOrUpdateDeployment(deploymentLogger, ddai, datadoghqv2alpha1.ClusterChecksRunnerComponentName, deployment, newStatus, updateStatusV2WithClusterChecksRunner)
 }
 
 func updateStatusV2WithClusterChecksRunner(deployment *appsv1.Deployment, newStatus *datadoghqv1alpha1.DatadogAgentInternalStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string) {
@@ -19,6 +19,7 @@ import (
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/defaults"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogcheck"
 	"github.com/DataDog/datadog-operator/pkg/condition"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils"
 	pkgutils "github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
@@ -53,7 +54,17 @@ func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger
 	newStatus := instance.Status.DeepCopy()
 	now := metav1.NewTime(time.Now())
 
-	configuredFeatures, enabledFeatures, requiredComponents := feature.BuildFeatures(instance, &instance.Spec, instance.Status.RemoteConfigConfiguration, reconcilerOptionsToFeatureOptions(&r.options, r.log))
+	featureOptions := reconcilerOptionsToFeatureOptions(&r.options, r.log)
+	if r.options.DatadogCheckEnabled {
+		checks, err := datadogcheck.ListValidChecks(ctx, r.client, instance, &instance.Spec)
+		if err != nil {
+			return r.updateStatusIfNeededV2(logger, instance, newStatus, result, err, now)
+		}
+		featureOptions.DatadogChecks = checks
+	}
+
+	configuredFeatures, enabledFeatures, requiredComponents, blockedFeatures := feature.BuildFeatures(instance, &instance.Spec, instance.Status.RemoteConfigConfiguration, featureOptions)
+	newStatus.Features = feature.NewStatuses(configuredFeatures, enabledFeatures, blockedFeatures)
 	// update list of enabled features for metrics forwarder
 	r.updateMetricsForwardersFeatures(instance, enabledFeatures)
 
@@ -67,7 +78,9 @@ func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger
 		if err = r.manageGlobalDependencies(logger, instance, resourceManagers, requiredComponents); err != nil {
 			return r.updateStatusIfNeededV2(logger, instance, newStatus, reconcile.Result{}, err, now)
 		}
-		if err = r.manageFeatureDependencies(logger, enabledFeatures, resourceManagers); err != nil {
+		err = r.manageFeatureDependencies(logger, enabledFeatures, resourceManagers, newStatus.Features)
+		feature.UpdateDegradedStatuses(ctx, r.uncachedReader(), enabledFeatures, newStatus.Features)
+		if err != nil {
 			return r.updateStatusIfNeededV2(logger, instance, newStatus, reconcile.Result{}, err, now)
 		}
 		if err = r.overrideDependencies(logger, resourceManagers, instance); err != nil {
@@ -99,6 +112,14 @@ func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger
 	// Update the status to set ClusterChecksRunnerReconcileConditionType to successful
 	condition.UpdateDatadogAgentInternalStatusConditions(newStatus, now, common.ClusterChecksRunnerReconcileConditionType, metav1.ConditionTrue, "reconcile_succeed", "reconcile succeed", false)
 
+	// 2.d. OTel Agent Gateway
+	result, err = r.reconcileV2OtelAgentGateway(logger, requiredComponents, append(configuredFeatures, enabledFeatures...), instance, resourceManagers, newStatus)
+	if utils.ShouldReturn(result, err) {
+		return r.updateStatusIfNeededV2(logger, instance, newStatus, result, err, now)
+	}
+	// Update the status to set OtelAgentGatewayReconcileConditionType to successful
+	condition.UpdateDatadogAgentInternalStatusConditions(newStatus, now, common.OtelAgentGatewayReconcileConditionType, metav1.ConditionTrue, "reconcile_succeed", "reconcile succeed", false)
+
 	// 3. Cleanup extraneous resources.
 	if err = r.cleanupExtraneousResources(ctx, logger, instance, newStatus, resourceManagers); err != nil {
 		logger.Error(err, "Error cleaning up extraneous resources")
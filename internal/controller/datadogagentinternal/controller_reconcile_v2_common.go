@@ -21,6 +21,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
 	"github.com/DataDog/datadog-operator/pkg/agentprofile"
 	"github.com/DataDog/datadog-operator/pkg/condition"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
@@ -38,7 +40,7 @@ type updateDepStatusComponentFunc func(deployment *appsv1.Deployment, newStatus
 type updateDSStatusComponentFunc func(daemonsetName string, daemonset *appsv1.DaemonSet, newStatus *datadoghqv1alpha1.DatadogAgentInternalStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string)
 type updateEDSStatusComponentFunc func(eds *edsv1alpha1.ExtendedDaemonSet, newStatus *datadoghqv1alpha1.DatadogAgentInternalStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string)
 
-func (r *Reconciler) createOrUpdateDeployment(parentLogger logr.Logger, ddai *datadoghqv1alpha1.DatadogAgentInternal, deployment *appsv1.Deployment, newStatus *datadoghqv1alpha1.DatadogAgentInternalStatus, updateStatusFunc updateDepStatusComponentFunc) (reconcile.Result, error) {
+func (r *Reconciler) createOrUpdateDeployment(parentLogger logr.Logger, ddai *datadoghqv1alpha1.DatadogAgentInternal, component datadoghqv2alpha1.ComponentName, deployment *appsv1.Deployment, newStatus *datadoghqv1alpha1.DatadogAgentInternalStatus, updateStatusFunc updateDepStatusComponentFunc) (reconcile.Result, error) {
 	logger := parentLogger.WithValues("deployment.Namespace", deployment.Namespace, "deployment.Name", deployment.Name)
 
 	var result reconcile.Result
@@ -97,6 +99,12 @@ func (r *Reconciler) createOrUpdateDeployment(parentLogger logr.Logger, ddai *da
 		}
 		// check if same hash
 		needUpdate := !comparison.IsSameSpecMD5Hash(hash, currentDeployment.GetAnnotations())
+		if needUpdate && common.IsReconcilePaused(logger, ddai.Annotations, component) {
+			logger.Info("Deployment updates are paused", "annotation", common.PauseReconcileAnnotationKey)
+			now := metav1.NewTime(time.Now())
+			updateStatusFunc(currentDeployment, newStatus, now, metav1.ConditionTrue, "DeploymentUpdatePaused", "Deployment updates are paused")
+			return reconcile.Result{}, nil
+		}
 		if !needUpdate {
 			// no need to update hasn't changed
 			now := metav1.NewTime(time.Now())
@@ -235,6 +243,11 @@ func (r *Reconciler) createOrUpdateDaemonset(parentLogger logr.Logger, ddai *dat
 
 		// check if same hash
 		needUpdate := !comparison.IsSameSpecMD5Hash(hash, currentDaemonset.GetAnnotations()) || currentDaemonsetPodTemplateLabelHash != daemonsetPodTemplateLabelHash
+		if needUpdate && common.IsReconcilePaused(logger, ddai.Annotations, datadoghqv2alpha1.NodeAgentComponentName) {
+			logger.Info("Daemonset updates are paused", "annotation", common.PauseReconcileAnnotationKey)
+			updateStatusFunc(currentDaemonset.Name, currentDaemonset, newStatus, now, metav1.ConditionTrue, "DaemonSetUpdatePaused", "Daemonset updates are paused")
+			return reconcile.Result{}, nil
+		}
 		if !needUpdate {
 			// Even if the DaemonSet is still the same, its status might have
 			// changed (for example, the number of pods ready). This call is
@@ -351,6 +364,12 @@ func (r *Reconciler) createOrUpdateExtendedDaemonset(parentLogger logr.Logger, d
 
 		// check if same hash
 		needUpdate := !comparison.IsSameSpecMD5Hash(hash, currentEDS.GetAnnotations())
+		if needUpdate && common.IsReconcilePaused(logger, ddai.Annotations, datadoghqv2alpha1.NodeAgentComponentName) {
+			logger.Info("ExtendedDaemonSet updates are paused", "annotation", common.PauseReconcileAnnotationKey)
+			now := metav1.NewTime(time.Now())
+			updateStatusFunc(currentEDS, newStatus, now, metav1.ConditionTrue, "ExtendedDaemonSetUpdatePaused", "ExtendedDaemonSet updates are paused")
+			return reconcile.Result{}, nil
+		}
 		if !needUpdate {
 			// Even if the EDS is still the same, its status might have
 			// changed (for example, the number of pods ready). This call is
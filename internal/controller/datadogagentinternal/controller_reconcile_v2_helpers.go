@@ -47,6 +47,9 @@ func (r *Reconciler) manageGlobalDependencies(logger logr.Logger, ddai *datadogh
 	if err := global.ApplyGlobalComponentDependencies(logger, ddai.GetObjectMeta(), &ddai.Spec, nil, resourceManagers, datadoghqv2alpha1.ClusterChecksRunnerComponentName, requiredComponents.ClusterChecksRunner, true); len(err) > 0 {
 		errs = append(errs, err...)
 	}
+	if err := global.ApplyGlobalComponentDependencies(logger, ddai.GetObjectMeta(), &ddai.Spec, nil, resourceManagers, datadoghqv2alpha1.OtelAgentGatewayComponentName, requiredComponents.OtelAgentGateway, true); len(err) > 0 {
+		errs = append(errs, err...)
+	}
 
 	if len(errs) > 0 {
 		return errors.NewAggregate(errs)
@@ -55,11 +58,13 @@ func (r *Reconciler) manageGlobalDependencies(logger logr.Logger, ddai *datadogh
 }
 
 // manageFeatureDependencies iterates over features to set up dependencies.
-func (r *Reconciler) manageFeatureDependencies(logger logr.Logger, features []feature.Feature, resourceManagers feature.ResourceManagers) error {
+// The features failing to manage their dependencies are marked as degraded in featureStatuses.
+func (r *Reconciler) manageFeatureDependencies(logger logr.Logger, features []feature.Feature, resourceManagers feature.ResourceManagers, featureStatuses map[string]datadoghqv2alpha1.FeatureStatus) error {
 	var errs []error
 	for _, feat := range features {
 		logger.V(1).Info("Managing dependencies", "featureID", feat.ID())
 		if err := feat.ManageDependencies(resourceManagers); err != nil {
+			feature.SetDegraded(featureStatuses, feat.ID(), feature.DependenciesErrorReason, err.Error())
 			errs = append(errs, err)
 		}
 	}
@@ -85,7 +90,7 @@ func (r *Reconciler) overrideDependencies(logger logr.Logger, resourceManagers f
 // cleanupExtraneousResources groups the cleanup calls for old components.
 func (r *Reconciler) cleanupExtraneousResources(ctx context.Context, logger logr.Logger, instance *datadoghqv1alpha1.DatadogAgentInternal, newStatus *datadoghqv1alpha1.DatadogAgentInternalStatus, resourceManagers feature.ResourceManagers) error {
 	var errs []error
-	// Cleanup old DaemonSets, DCA and CCR deployments.
+	// Cleanup old DaemonSets, DCA, CCR and OTel Agent Gateway deployments.
 
 	// TODO: re-enable once labels are updated to use DDAI name
 	// if err := r.cleanupExtraneousDaemonSets(ctx, logger, instance, newStatus); err != nil {
@@ -100,6 +105,10 @@ func (r *Reconciler) cleanupExtraneousResources(ctx context.Context, logger logr
 		errs = append(errs, err)
 		logger.Error(err, "Error cleaning up old CCR Deployments")
 	}
+	if err := r.cleanupOldOtelAgentGatewayDeployments(ctx, logger, instance, newStatus); err != nil {
+		errs = append(errs, err)
+		logger.Error(err, "Error cleaning up old OTel Agent Gateway Deployments")
+	}
 	if len(errs) > 0 {
 		return errors.NewAggregate(errs)
 	}
@@ -24,6 +24,7 @@ type dummyFeature struct {
 	ManageNodeAgentError            error
 	ManageSingleContainerAgentError error
 	ManageClusterChecksRunnerError  error
+	ManageOtelAgentGatewayError     error
 }
 
 // ID returns the dummy feature's ID.
@@ -61,6 +62,11 @@ func (df *dummyFeature) ManageClusterChecksRunner(managers feature.PodTemplateMa
 	return df.ManageClusterChecksRunnerError
 }
 
+// ManageOtelAgentGateway returns a predefined error (or nil for success).
+func (df *dummyFeature) ManageOtelAgentGateway(managers feature.PodTemplateManagers) error {
+	return df.ManageOtelAgentGatewayError
+}
+
 // Test_setupDependencies verifies that store and resource managers are initialized.
 func Test_setupDependencies(t *testing.T) {
 	// Create a dummy DatadogAgent instance.
@@ -97,11 +103,11 @@ func Test_manageFeatureDependencies(t *testing.T) {
 
 	r := &Reconciler{}
 	// Test when all features succeed.
-	err := r.manageFeatureDependencies(dummyLogger, []feature.Feature{f1}, dummyResMgrs)
+	err := r.manageFeatureDependencies(dummyLogger, []feature.Feature{f1}, dummyResMgrs, nil)
 	require.NoError(t, err)
 
 	// Test with one failing feature.
-	err = r.manageFeatureDependencies(dummyLogger, []feature.Feature{f1, f2}, dummyResMgrs)
+	err = r.manageFeatureDependencies(dummyLogger, []feature.Feature{f1, f2}, dummyResMgrs, nil)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "fail dependency")
 }
@@ -142,6 +142,22 @@ func (r *Reconciler) cleanUpClusterLevelResources(ddai client.Object) error {
 	if err := deleteObjectsForResource(r.client, ddai, kubernetes.ObjectFromKind(kubernetes.APIServiceKind, r.platformInfo)); err != nil {
 		return err
 	}
+	if err := deleteObjectsForResource(r.client, ddai, kubernetes.ObjectFromKind(kubernetes.MutatingWebhookConfigurationsKind, r.platformInfo)); err != nil {
+		return err
+	}
+	if err := deleteObjectsForResource(r.client, ddai, kubernetes.ObjectFromKind(kubernetes.ValidatingWebhookConfigurationsKind, r.platformInfo)); err != nil {
+		return err
+	}
+	if r.platformInfo.IsCalicoGlobalNetworkPolicySupported() {
+		if err := deleteObjectsForResource(r.client, ddai, kubernetes.ObjectFromKind(kubernetes.CalicoGlobalNetworkPoliciesKind, r.platformInfo)); err != nil {
+			return err
+		}
+	}
+	if r.platformInfo.IsAdminNetworkPolicySupported() {
+		if err := deleteObjectsForResource(r.client, ddai, kubernetes.ObjectFromKind(kubernetes.AdminNetworkPoliciesKind, r.platformInfo)); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -17,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
@@ -30,6 +31,7 @@ import (
 	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagentinternal"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogcheck"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
 	"github.com/DataDog/datadog-operator/pkg/kubernetes"
 )
@@ -37,6 +39,7 @@ import (
 // DatadogAgentInternalReconciler reconciles a DatadogAgentInternal object.
 type DatadogAgentInternalReconciler struct {
 	client.Client
+	APIReader    client.Reader
 	PlatformInfo kubernetes.PlatformInfo
 	Log          logr.Logger
 	Scheme       *runtime.Scheme
@@ -92,6 +95,18 @@ type DatadogAgentInternalReconciler struct {
 // Use CiliumNetworkPolicy
 // +kubebuilder:rbac:groups=cilium.io,resources=ciliumnetworkpolicies,verbs=get;list;watch;create;update;patch;delete
 
+// Use Calico GlobalNetworkPolicy
+// +kubebuilder:rbac:groups=projectcalico.org,resources=globalnetworkpolicies,verbs=get;list;watch;create;update;patch;delete;deletecollection
+
+// Use AdminNetworkPolicy
+// +kubebuilder:rbac:groups=policy.networking.k8s.io,resources=adminnetworkpolicies,verbs=get;list;watch;create;update;patch;delete;deletecollection
+
+// Use Security Profiles Operator SeccompProfile
+// +kubebuilder:rbac:groups=security-profiles-operator.x-k8s.io,resources=seccompprofiles,verbs=get;list;watch;create;update;patch;delete
+
+// Use cert-manager
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates;issuers,verbs=get;list;watch;create;update;patch;delete
+
 // OpenShift
 // +kubebuilder:rbac:groups=quota.openshift.io,resources=clusterresourcequotas,verbs=get;list
 // +kubebuilder:rbac:groups=security.openshift.io,resources=securitycontextconstraints,resourceNames=restricted,verbs=use
@@ -193,6 +208,13 @@ func (r *DatadogAgentInternalReconciler) SetupWithManager(mgr ctrl.Manager, metr
 		Owns(r.PlatformInfo.CreatePDBObject()).
 		Owns(&networkingv1.NetworkPolicy{})
 
+	if r.Options.DatadogCheckEnabled {
+		builder.Watches(
+			&v1alpha1.DatadogCheck{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueRequestsForDatadogCheckDDAIs()),
+		)
+	}
+
 	// DatadogAgent is namespaced whereas ClusterRole and ClusterRoleBinding are
 	// cluster-scoped. That means that DatadogAgent cannot be their owner, and
 	// we cannot use .Owns().
@@ -226,11 +248,11 @@ func (r *DatadogAgentInternalReconciler) SetupWithManager(mgr ctrl.Manager, metr
 	}
 
 	or := reconcile.AsReconciler[*v1alpha1.DatadogAgentInternal](r.Client, r)
-	if err := builder.For(&datadoghqv1alpha1.DatadogAgentInternal{}, builderOptions...).WithEventFilter(predicate.GenerationChangedPredicate{}).Complete(or); err != nil {
+	if err := builder.For(&datadoghqv1alpha1.DatadogAgentInternal{}, builderOptions...).WithEventFilter(predicate.Or(predicate.GenerationChangedPredicate{}, isPauseAnnotationsChange())).Complete(or); err != nil {
 		return err
 	}
 
-	internal, err := datadogagentinternal.NewReconciler(r.Options, r.Client, r.PlatformInfo, r.Scheme, r.Log, r.Recorder, metricForwardersMgr)
+	internal, err := datadogagentinternal.NewReconciler(r.Options, r.Client, r.APIReader, r.PlatformInfo, r.Scheme, r.Log, r.Recorder, metricForwardersMgr)
 	if err != nil {
 		return err
 	}
@@ -251,3 +273,36 @@ func enqueueIfOwnedByDatadogAgentInternal(ctx context.Context, obj client.Object
 
 	return []reconcile.Request{{NamespacedName: owner}}
 }
+
+// enqueueRequestsForDatadogCheckDDAIs enqueues the DatadogAgentInternals that may be selected by a DatadogCheck, without reading its namespace.
+// On update, both the old and the new DatadogCheck are mapped, so DatadogAgentInternals no longer selected are enqueued too.
+func (r *DatadogAgentInternalReconciler) enqueueRequestsForDatadogCheckDDAIs() handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var requests []reconcile.Request
+
+		check, ok := obj.(*v1alpha1.DatadogCheck)
+		if !ok {
+			return requests
+		}
+
+		ddaiList := v1alpha1.DatadogAgentInternalList{}
+		if err := r.List(ctx, &ddaiList); err != nil {
+			return requests
+		}
+
+		for i := range ddaiList.Items {
+			ddai := &ddaiList.Items[i]
+			if !datadogcheck.MaySelectDatadogAgent(check, ddai, &ddai.Spec) {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: ddai.Namespace,
+					Name:      ddai.Name,
+				},
+			})
+		}
+
+		return requests
+	}
+}
@@ -0,0 +1,114 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogcheck
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+// ListValidChecks returns the valid DatadogChecks configured in the DatadogAgent (or DatadogAgentInternal) owner
+// of spec, sorted by namespace and name. The metadata of the namespaces of the DatadogChecks of other namespaces
+// are read to match them with the namespace selector of the owner.
+func ListValidChecks(ctx context.Context, c client.Reader, owner metav1.Object, spec *v2alpha1.DatadogAgentSpec) ([]v1alpha1.DatadogCheck, error) {
+	checkList := &v1alpha1.DatadogCheckList{}
+	if err := c.List(ctx, checkList); err != nil {
+		return nil, err
+	}
+
+	namespaces := map[string]*metav1.PartialObjectMetadata{}
+	checks := make([]v1alpha1.DatadogCheck, 0, len(checkList.Items))
+	for _, check := range checkList.Items {
+		if check.DeletionTimestamp != nil {
+			continue
+		}
+		if v1alpha1.IsValidDatadogCheck(&check.Spec) != nil {
+			continue
+		}
+		if !MaySelectDatadogAgent(&check, owner, spec) {
+			continue
+		}
+		namespace, found := namespaces[check.Namespace]
+		if !found && check.Namespace != owner.GetNamespace() {
+			namespace = &metav1.PartialObjectMetadata{}
+			namespace.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Namespace"))
+			if err := c.Get(ctx, client.ObjectKey{Name: check.Namespace}, namespace); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return nil, err
+				}
+				namespace = nil
+			}
+			namespaces[check.Namespace] = namespace
+		}
+		if !SelectsDatadogAgent(&check, namespace, owner, spec) {
+			continue
+		}
+		checks = append(checks, check)
+	}
+
+	sort.Slice(checks, func(i, j int) bool {
+		if checks[i].Namespace != checks[j].Namespace {
+			return checks[i].Namespace < checks[j].Namespace
+		}
+		return checks[i].Name < checks[j].Name
+	})
+
+	return checks, nil
+}
+
+// SelectsDatadogAgent returns whether the DatadogCheck is configured in the DatadogAgent (or DatadogAgentInternal) owner
+// of spec. A DatadogCheck of another namespace is only configured when its namespace, whose metadata is passed,
+// matches the DatadogChecks namespace selector of the owner.
+func SelectsDatadogAgent(check *v1alpha1.DatadogCheck, namespace *metav1.PartialObjectMetadata, owner metav1.Object, spec *v2alpha1.DatadogAgentSpec) bool {
+	if !MaySelectDatadogAgent(check, owner, spec) {
+		return false
+	}
+	if check.Namespace == owner.GetNamespace() {
+		return true
+	}
+	if namespace == nil {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(namespaceSelector(spec))
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(namespace.GetLabels()))
+}
+
+// MaySelectDatadogAgent returns whether the DatadogCheck can be configured in the DatadogAgent (or DatadogAgentInternal)
+// owner of spec, without matching the labels of its namespace: the owner must be in the namespace of the check and
+// match its agent selector if set, or opt in to the DatadogChecks of other namespaces and match its agent selector.
+func MaySelectDatadogAgent(check *v1alpha1.DatadogCheck, owner metav1.Object, spec *v2alpha1.DatadogAgentSpec) bool {
+	if check.Namespace != owner.GetNamespace() && (namespaceSelector(spec) == nil || check.Spec.AgentSelector == nil) {
+		return false
+	}
+	if check.Spec.AgentSelector == nil {
+		return true
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(check.Spec.AgentSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(owner.GetLabels()))
+}
+
+func namespaceSelector(spec *v2alpha1.DatadogAgentSpec) *metav1.LabelSelector {
+	if spec == nil || spec.Features == nil || spec.Features.DatadogChecks == nil {
+		return nil
+	}
+	return spec.Features.DatadogChecks.NamespaceSelector
+}
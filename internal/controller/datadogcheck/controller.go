@@ -0,0 +1,92 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogcheck
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/condition"
+)
+
+const (
+	defaultErrRequeuePeriod = 5 * time.Second
+
+	validReason   = "ValidSpec"
+	invalidReason = "InvalidSpec"
+)
+
+// Reconciler reconciles the status of a DatadogCheck object.
+// The checks are configured in the Agents by the DatadogAgent reconcilers.
+type Reconciler struct {
+	client   client.Client
+	scheme   *runtime.Scheme
+	log      logr.Logger
+	recorder record.EventRecorder
+}
+
+// NewReconciler returns a new Reconciler object
+func NewReconciler(client client.Client, scheme *runtime.Scheme, log logr.Logger, recorder record.EventRecorder) *Reconciler {
+	return &Reconciler{
+		client:   client,
+		scheme:   scheme,
+		log:      log,
+		recorder: recorder,
+	}
+}
+
+// Reconcile validates a DatadogCheck and reports the result in its status.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (ctrl.Result, error) {
+	logger := r.log.WithValues("datadogcheck", req.NamespacedName)
+	logger.V(1).Info("Reconciling DatadogCheck")
+	now := metav1.NewTime(time.Now())
+
+	instance := &v1alpha1.DatadogCheck{}
+	if err := r.client.Get(ctx, req.NamespacedName, instance); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	newStatus := instance.Status.DeepCopy()
+	if err := v1alpha1.IsValidDatadogCheck(&instance.Spec); err != nil {
+		logger.Info("Invalid DatadogCheck spec", "error", err.Error())
+		newStatus.Valid = metav1.ConditionFalse
+		condition.UpdateStatusConditions(&newStatus.Conditions, now, v1alpha1.DatadogCheckConditionTypeValid, metav1.ConditionFalse, invalidReason, err.Error())
+	} else {
+		newStatus.Valid = metav1.ConditionTrue
+		condition.UpdateStatusConditions(&newStatus.Conditions, now, v1alpha1.DatadogCheckConditionTypeValid, metav1.ConditionTrue, validReason, "DatadogCheck is valid")
+	}
+
+	return r.updateStatusIfNeeded(ctx, logger, instance, newStatus)
+}
+
+func (r *Reconciler) updateStatusIfNeeded(ctx context.Context, logger logr.Logger, instance *v1alpha1.DatadogCheck, status *v1alpha1.DatadogCheckStatus) (ctrl.Result, error) {
+	if !apiequality.Semantic.DeepEqual(&instance.Status, status) {
+		instance.Status = *status
+		if err := r.client.Status().Update(ctx, instance); err != nil {
+			if apierrors.IsConflict(err) {
+				logger.Error(err, "unable to update DatadogCheck status due to update conflict")
+				return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, nil
+			}
+			logger.Error(err, "unable to update DatadogCheck status")
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,161 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogcheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+func newCheck(namespace, name, checkName string) *v1alpha1.DatadogCheck {
+	return &v1alpha1.DatadogCheck{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: v1alpha1.DatadogCheckSpec{
+			Name:      checkName,
+			Instances: []apiextensionsv1.JSON{{Raw: []byte(`{"url": "http://example.com"}`)}},
+		},
+	}
+}
+
+func newFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, v1alpha1.AddToScheme(s))
+	return fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).WithStatusSubresource(&v1alpha1.DatadogCheck{}).Build()
+}
+
+func TestReconciler_Reconcile(t *testing.T) {
+	tests := []struct {
+		name       string
+		check      *v1alpha1.DatadogCheck
+		wantValid  metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:       "valid DatadogCheck",
+			check:      newCheck("app", "web", "http_check"),
+			wantValid:  metav1.ConditionTrue,
+			wantReason: validReason,
+		},
+		{
+			name:       "invalid DatadogCheck",
+			check:      newCheck("app", "web", "HTTP check"),
+			wantValid:  metav1.ConditionFalse,
+			wantReason: invalidReason,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeClient(t, tt.check)
+			r := NewReconciler(c, c.Scheme(), zap.New(zap.UseDevMode(true)), record.NewFakeRecorder(10))
+			key := types.NamespacedName{Namespace: tt.check.Namespace, Name: tt.check.Name}
+
+			_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: key})
+			require.NoError(t, err)
+
+			got := &v1alpha1.DatadogCheck{}
+			require.NoError(t, c.Get(context.TODO(), key, got))
+			assert.Equal(t, tt.wantValid, got.Status.Valid)
+			cond := meta.FindStatusCondition(got.Status.Conditions, v1alpha1.DatadogCheckConditionTypeValid)
+			require.NotNil(t, cond)
+			assert.Equal(t, tt.wantValid, cond.Status)
+			assert.Equal(t, tt.wantReason, cond.Reason)
+		})
+	}
+
+	t.Run("DatadogCheck not found", func(t *testing.T) {
+		c := newFakeClient(t)
+		r := NewReconciler(c, c.Scheme(), zap.New(zap.UseDevMode(true)), record.NewFakeRecorder(10))
+
+		result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "app", Name: "web"}})
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, result)
+	})
+}
+
+func TestListValidChecks(t *testing.T) {
+	selected := newCheck("app2", "web", "http_check")
+	selected.Spec.AgentSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"team": "web"}}
+	notSelected := newCheck("app2", "cache", "redisdb")
+	notSelected.Spec.AgentSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"team": "cache"}}
+	otherNamespace := newCheck("app3", "web", "http_check")
+	otherNamespace.Spec.AgentSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"team": "web"}}
+	emptySelector := newCheck("app2", "empty", "http_check")
+	emptySelector.Spec.AgentSelector = &metav1.LabelSelector{}
+
+	objs := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app2", Labels: map[string]string{"datadog-checks": "true"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app3"}},
+		selected,
+		notSelected,
+		otherNamespace,
+		emptySelector,
+		newCheck("datadog", "web", "http_check"),
+		newCheck("datadog", "cache", "redisdb"),
+		newCheck("datadog", "invalid", "Redis DB"),
+		newCheck("app1", "web", "http_check"),
+	}
+
+	tests := []struct {
+		name              string
+		namespaceSelector *metav1.LabelSelector
+		want              []string
+	}{
+		{
+			name: "no namespace selector",
+			want: []string{"datadog/cache", "datadog/web"},
+		},
+		{
+			name:              "namespace selector",
+			namespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"datadog-checks": "true"}},
+			want:              []string{"app2/web", "datadog/cache", "datadog/web"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeClient(t, objs...)
+			dda := &v2alpha1.DatadogAgent{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "datadog", Name: "datadog", Labels: map[string]string{"team": "web"}},
+				Spec: v2alpha1.DatadogAgentSpec{
+					Features: &v2alpha1.DatadogFeatures{
+						DatadogChecks: &v2alpha1.DatadogChecksFeatureConfig{NamespaceSelector: tt.namespaceSelector},
+					},
+				},
+			}
+			checks, err := ListValidChecks(context.TODO(), c, dda, &dda.Spec)
+			require.NoError(t, err)
+
+			var got []string
+			for _, check := range checks {
+				got = append(got, check.Namespace+"/"+check.Name)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
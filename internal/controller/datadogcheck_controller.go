@@ -0,0 +1,49 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package controller
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	ddcheck "github.com/DataDog/datadog-operator/internal/controller/datadogcheck"
+)
+
+// DatadogCheckReconciler reconciles a DatadogCheck object
+type DatadogCheckReconciler struct {
+	Client   client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	internal *ddcheck.Reconciler
+}
+
+// +kubebuilder:rbac:groups=datadoghq.com,resources=datadogchecks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=datadoghq.com,resources=datadogchecks/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=datadoghq.com,resources=datadogchecks/finalizers,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile loop for DatadogCheck.
+func (r *DatadogCheckReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return r.internal.Reconcile(ctx, req)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DatadogCheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.internal = ddcheck.NewReconciler(r.Client, r.Scheme, r.Log, r.Recorder)
+
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.DatadogCheck{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{})
+
+	return builder.Complete(r)
+}
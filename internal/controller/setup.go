@@ -12,6 +12,7 @@ import (
 	"github.com/go-logr/logr"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/discovery"
+	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -34,6 +35,7 @@ const (
 	profileControllerName         = "DatadogAgentProfile"
 	dashboardControllerName       = "DatadogDashboard"
 	genericResourceControllerName = "DatadogGenericResource"
+	checkControllerName           = "DatadogCheck"
 )
 
 // SetupOptions defines options for setting up controllers to ease testing
@@ -49,9 +51,11 @@ type SetupOptions struct {
 	V2APIEnabled                  bool
 	IntrospectionEnabled          bool
 	DatadogAgentProfileEnabled    bool
+	AgentRolloutEnabled           bool
 	OtelAgentEnabled              bool
 	DatadogDashboardEnabled       bool
 	DatadogGenericResourceEnabled bool
+	DatadogCheckEnabled           bool
 }
 
 // ExtendedDaemonsetOptions defines ExtendedDaemonset options
@@ -80,6 +84,7 @@ var controllerStarters = map[string]starterFunc{
 	profileControllerName:         startDatadogAgentProfiles,
 	dashboardControllerName:       startDatadogDashboard,
 	genericResourceControllerName: startDatadogGenericResource,
+	checkControllerName:           startDatadogCheck,
 }
 
 // SetupControllers starts all controllers (also used by e2e tests)
@@ -143,8 +148,16 @@ func startDatadogAgent(logger logr.Logger, mgr manager.Manager, pInfo kubernetes
 		return nil
 	}
 
+	// Used to query the kubelets through the APIServer, the config is copied as clients might modify it
+	kubeClient, err := clientset.NewForConfig(rest.CopyConfig(mgr.GetConfig()))
+	if err != nil {
+		return fmt.Errorf("unable to get kubernetes client: %w", err)
+	}
+
 	return (&DatadogAgentReconciler{
 		Client:       mgr.GetClient(),
+		APIReader:    mgr.GetAPIReader(),
+		RESTClient:   kubeClient.CoreV1().RESTClient(),
 		PlatformInfo: pInfo,
 		Log:          ctrl.Log.WithName("controllers").WithName(agentControllerName),
 		Scheme:       mgr.GetScheme(),
@@ -167,7 +180,9 @@ func startDatadogAgent(logger logr.Logger, mgr manager.Manager, pInfo kubernetes
 			OperatorMetricsEnabled:      options.OperatorMetricsEnabled,
 			IntrospectionEnabled:        options.IntrospectionEnabled,
 			DatadogAgentProfileEnabled:  options.DatadogAgentProfileEnabled,
+			AgentRolloutEnabled:         options.AgentRolloutEnabled,
 			DatadogAgentInternalEnabled: options.DatadogAgentInternalEnabled,
+			DatadogCheckEnabled:         options.DatadogCheckEnabled,
 		},
 	}).SetupWithManager(mgr, metricForwardersMgr)
 }
@@ -180,6 +195,7 @@ func startDatadogAgentInternal(logger logr.Logger, mgr manager.Manager, pInfo ku
 
 	return (&DatadogAgentInternalReconciler{
 		Client:       mgr.GetClient(),
+		APIReader:    mgr.GetAPIReader(),
 		PlatformInfo: pInfo,
 		Log:          ctrl.Log.WithName("controllers").WithName(agentInternalControllerName),
 		Scheme:       mgr.GetScheme(),
@@ -200,6 +216,7 @@ func startDatadogAgentInternal(logger logr.Logger, mgr manager.Manager, pInfo ku
 			},
 			SupportCilium:          options.SupportCilium,
 			OperatorMetricsEnabled: options.OperatorMetricsEnabled,
+			DatadogCheckEnabled:    options.DatadogCheckEnabled,
 		},
 	}).SetupWithManager(mgr, metricForwardersMgr)
 }
@@ -301,3 +318,17 @@ func startDatadogAgentProfiles(logger logr.Logger, mgr manager.Manager, pInfo ku
 		Recorder: mgr.GetEventRecorderFor(profileControllerName),
 	}).SetupWithManager(mgr)
 }
+
+func startDatadogCheck(logger logr.Logger, mgr manager.Manager, pInfo kubernetes.PlatformInfo, options SetupOptions, metricForwardersMgr datadog.MetricsForwardersManager) error {
+	if !options.DatadogCheckEnabled {
+		logger.Info("Feature disabled, not starting the controller", "controller", checkControllerName)
+		return nil
+	}
+
+	return (&DatadogCheckReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName(checkControllerName),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor(checkControllerName),
+	}).SetupWithManager(mgr)
+}
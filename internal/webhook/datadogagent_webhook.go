@@ -0,0 +1,253 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/defaults"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/pkg/agentrollout"
+
+	// Use to register features
+	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent"
+)
+
+// +kubebuilder:webhook:path=/mutate-datadoghq-com-v2alpha1-datadogagent,mutating=true,failurePolicy=fail,sideEffects=None,groups=datadoghq.com,resources=datadogagents,verbs=create;update,versions=v2alpha1,name=mdatadogagent-v2alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-datadoghq-com-v2alpha1-datadogagent,mutating=false,failurePolicy=fail,sideEffects=None,groups=datadoghq.com,resources=datadogagents,verbs=create;update,versions=v2alpha1,name=vdatadogagent-v2alpha1.kb.io,admissionReviewVersions=v1
+
+// knownComponentNames contains the component names accepted in the DatadogAgent `override` field
+var knownComponentNames = map[v2alpha1.ComponentName]struct{}{
+	v2alpha1.NodeAgentComponentName:           {},
+	v2alpha1.ClusterAgentComponentName:        {},
+	v2alpha1.ClusterChecksRunnerComponentName: {},
+	v2alpha1.OtelAgentGatewayComponentName:    {},
+}
+
+// datadogAgentValidator validates DatadogAgent resources at admission time
+type datadogAgentValidator struct {
+	log                         logr.Logger
+	datadogAgentInternalEnabled bool
+}
+
+var _ admission.CustomValidator = &datadogAgentValidator{}
+
+// datadogAgentDefaulter writes the defaults applied by the operator in DatadogAgent resources at admission time
+type datadogAgentDefaulter struct{}
+
+var _ admission.CustomDefaulter = &datadogAgentDefaulter{}
+
+// setupDatadogAgentWebhookWithManager registers the DatadogAgent webhooks.
+// The conversion webhook is served on `/convert` as long as a version other than the hub is registered in the manager scheme.
+func setupDatadogAgentWebhookWithManager(logger logr.Logger, mgr ctrl.Manager, options Options) error {
+	builder := ctrl.NewWebhookManagedBy(mgr).For(&v2alpha1.DatadogAgent{})
+	if options.ValidationEnabled {
+		builder = builder.WithValidator(&datadogAgentValidator{
+			log:                         logger.WithName("datadogagent"),
+			datadogAgentInternalEnabled: options.DatadogAgentInternalEnabled,
+		})
+	}
+	if options.DefaultingEnabled {
+		builder = builder.WithDefaulter(&datadogAgentDefaulter{})
+	}
+	return builder.Complete()
+}
+
+// ValidateCreate validates a DatadogAgent on creation
+func (v *datadogAgentValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(obj)
+}
+
+// ValidateUpdate validates a DatadogAgent on update.
+// Only the errors introduced by the update are reported, so that a DatadogAgent accepted before a validation rule
+// was added can still be edited and deleted as long as its invalid fields are left unchanged.
+// The warnings are reported for the whole updated spec.
+func (v *datadogAgentValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldDDA, ok := oldObj.(*v2alpha1.DatadogAgent)
+	if !ok {
+		return nil, fmt.Errorf("expected a DatadogAgent but got a %T", oldObj)
+	}
+	newDDA, ok := newObj.(*v2alpha1.DatadogAgent)
+	if !ok {
+		return nil, fmt.Errorf("expected a DatadogAgent but got a %T", newObj)
+	}
+
+	// Finalizers must be removable whatever the spec
+	if newDDA.DeletionTimestamp != nil {
+		return nil, nil
+	}
+
+	if equality.Semantic.DeepEqual(oldDDA.Spec, newDDA.Spec) {
+		return nil, utilerrors.NewAggregate(newErrors(metadataErrors(oldDDA), metadataErrors(newDDA)))
+	}
+	_, oldErrs := v.validationResults(oldDDA)
+	warnings, errs := v.validationResults(newDDA)
+	return warnings, utilerrors.NewAggregate(newErrors(oldErrs, errs))
+}
+
+// ValidateDelete does nothing, deletion is always allowed
+func (v *datadogAgentValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// Default sets the default values of a DatadogAgent
+func (d *datadogAgentDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	dda, ok := obj.(*v2alpha1.DatadogAgent)
+	if !ok {
+		return fmt.Errorf("expected a DatadogAgent but got a %T", obj)
+	}
+	DefaultDatadogAgent(dda)
+	return nil
+}
+
+func (v *datadogAgentValidator) validate(obj runtime.Object) (admission.Warnings, error) {
+	dda, ok := obj.(*v2alpha1.DatadogAgent)
+	if !ok {
+		return nil, fmt.Errorf("expected a DatadogAgent but got a %T", obj)
+	}
+	warnings, errs := v.validationResults(dda)
+	return warnings, utilerrors.NewAggregate(errs)
+}
+
+// validationResults adds to the DatadogAgent validation the checks of the options not supported by the operator configuration
+func (v *datadogAgentValidator) validationResults(dda *v2alpha1.DatadogAgent) (admission.Warnings, []error) {
+	warnings, errs := validationResults(v.log, dda)
+	if v.datadogAgentInternalEnabled && dda.Spec.Global != nil && agentrollout.IsRollbackEnabled(dda.Spec.Global.AutoRollback) {
+		errs = append(errs, errors.New("spec.global.autoRollback is not supported when the DatadogAgentInternal controller is enabled"))
+	}
+	return warnings, errs
+}
+
+// validationResults returns the warnings and the errors of the DatadogAgent validation.
+// On top of v2alpha1.ValidateDatadogAgent, which the reconcile loop runs, it rejects specs with conflicting feature
+// configurations by running the same feature configuration logic as the DatadogAgent controller.
+// The warnings report a configuration which is accepted but likely a mistake.
+func validationResults(logger logr.Logger, dda *v2alpha1.DatadogAgent) (admission.Warnings, []error) {
+	errs := metadataErrors(dda)
+	if err := v2alpha1.ValidateDatadogAgent(dda); err != nil {
+		errs = append(errs, err)
+	}
+
+	names := make([]string, 0, len(dda.Spec.Override))
+	for name := range dda.Spec.Override {
+		if _, found := knownComponentNames[name]; !found {
+			names = append(names, string(name))
+		}
+	}
+	// Sort the names so that the same errors are reported for the same spec
+	sort.Strings(names)
+	for _, name := range names {
+		errs = append(errs, fmt.Errorf("unknown component %q in spec.override", name))
+	}
+
+	// Features are configured from the defaulted spec, like in the reconcile loop
+	spec := dda.Spec.DeepCopy()
+	defaults.DefaultDatadogAgentSpec(spec)
+	warnings, err := feature.ValidateFeatures(dda, spec, dda.Status.RemoteConfigConfiguration, &feature.Options{Logger: logger})
+	if err != nil {
+		var agg utilerrors.Aggregate
+		if errors.As(err, &agg) {
+			errs = append(errs, agg.Errors()...)
+		} else {
+			errs = append(errs, err)
+		}
+	}
+
+	return warnings, errs
+}
+
+// metadataErrors validates the annotations of a DatadogAgent.
+func metadataErrors(dda *v2alpha1.DatadogAgent) []error {
+	// The deadline is not checked, an expired pause is valid
+	if _, err := common.ParseReconcilePause(dda.Annotations, time.Time{}); err != nil {
+		return []error{err}
+	}
+	return nil
+}
+
+// newErrors returns the errors of the updated object which were not already reported for the previous version.
+func newErrors(oldErrs, errs []error) []error {
+	known := make(map[string]struct{}, len(oldErrs))
+	for _, err := range oldErrs {
+		known[err.Error()] = struct{}{}
+	}
+	var result []error
+	for _, err := range errs {
+		if _, found := known[err.Error()]; !found {
+			result = append(result, err)
+		}
+	}
+	return result
+}
+
+// DefaultDatadogAgent sets in the DatadogAgent spec the default values applied by the operator during the reconcile loop.
+// The values which depend on the operator version or are derived from other fields are left unset, so that they keep
+// following the operator upgrades and the changes of the fields they are derived from:
+// the image tags, the registry derived from the site, and the `enabled` flags.
+func DefaultDatadogAgent(dda *v2alpha1.DatadogAgent) {
+	original := dda.Spec.DeepCopy()
+
+	defaults.DefaultDatadogAgentSpec(&dda.Spec)
+
+	if original.Global == nil || original.Global.Registry == nil {
+		dda.Spec.Global.Registry = nil
+	}
+	fipsTagSet := original.Global != nil && original.Global.FIPS != nil && original.Global.FIPS.Image != nil && original.Global.FIPS.Image.Tag != ""
+	if !fipsTagSet && dda.Spec.Global.FIPS.Image != nil {
+		dda.Spec.Global.FIPS.Image.Tag = ""
+	}
+	unsetDefaultedEnabled(reflect.ValueOf(original).Elem(), reflect.ValueOf(&dda.Spec).Elem())
+}
+
+var boolPointerType = reflect.TypeOf((*bool)(nil))
+
+// unsetDefaultedEnabled unsets the `enabled` fields of the defaulted struct which are not set in the original one.
+// The structs left empty by the defaulting are removed. The original value is invalid if the struct did not exist.
+func unsetDefaultedEnabled(original, defaulted reflect.Value) {
+	for i := 0; i < defaulted.NumField(); i++ {
+		field := defaulted.Field(i)
+		if !field.CanSet() || field.Kind() != reflect.Ptr || field.IsNil() {
+			continue
+		}
+		var originalField reflect.Value
+		if original.IsValid() && !original.Field(i).IsNil() {
+			originalField = original.Field(i)
+		}
+
+		if defaulted.Type().Field(i).Name == "Enabled" && field.Type() == boolPointerType {
+			if !originalField.IsValid() {
+				field.Set(reflect.Zero(field.Type()))
+			}
+			continue
+		}
+		if field.Elem().Kind() != reflect.Struct {
+			continue
+		}
+
+		var originalElem reflect.Value
+		if originalField.IsValid() {
+			originalElem = originalField.Elem()
+		}
+		unsetDefaultedEnabled(originalElem, field.Elem())
+		if !originalField.IsValid() && field.Elem().IsZero() {
+			field.Set(reflect.Zero(field.Type()))
+		}
+	}
+}
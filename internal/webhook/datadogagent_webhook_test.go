@@ -0,0 +1,480 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/pkg/testutils"
+)
+
+func Test_validationResults(t *testing.T) {
+	tests := []struct {
+		name    string
+		dda     *v2alpha1.DatadogAgent
+		wantErr []string
+	}{
+		{
+			name: "valid DatadogAgent",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithAPMEnabled(true).
+				WithAPMHostPortEnabled(true, nil).
+				WithDogstatsdHostPortEnabled(true).
+				Build(),
+		},
+		{
+			name:    "missing credentials",
+			dda:     testutils.NewDatadogAgentBuilder().Build(),
+			wantErr: []string{"credentials not configured"},
+		},
+		{
+			name: "unknown override component",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithComponentOverride("nodeagent", v2alpha1.DatadogAgentComponentOverride{}).
+				Build(),
+			wantErr: []string{`unknown component "nodeagent" in spec.override`},
+		},
+		{
+			name: "invalid pause-reconcile annotations",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithAnnotations(map[string]string{
+					"agent.datadoghq.com/pause-reconcile":       "nodeAgent",
+					"agent.datadoghq.com/pause-reconcile-until": "tomorrow",
+				}).
+				Build(),
+			wantErr: []string{"invalid annotation agent.datadoghq.com/pause-reconcile-until"},
+		},
+		{
+			name: "SSI with enabled and disabled namespaces",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithAdmissionControllerEnabled(true).
+				WithAPMEnabled(true).
+				WithAPMSingleStepInstrumentationEnabled(true, []string{"foo"}, []string{"bar"}, nil, false, "", nil).
+				Build(),
+			wantErr: []string{"feature apm: instrumentation.enabledNamespaces and instrumentation.disabledNamespaces cannot be set together"},
+		},
+		{
+			name: "cert-manager and operator-issued certificates",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithAdmissionControllerEnabled(true).
+				WithAdmissionControllerCertManager(nil).
+				WithAdmissionControllerOperatorIssuedCertificate().
+				Build(),
+			wantErr: []string{"features.admissionController.certManager and features.admissionController.operatorIssuedCertificate cannot be enabled together"},
+		},
+		{
+			name: "invalid node selector",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithNodeSelector(&metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: metav1.LabelSelectorOpIn}},
+				}).
+				Build(),
+			wantErr: []string{"invalid global.nodeSelector"},
+		},
+		{
+			name: "valid network policy extra rules",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithNetworkPolicy(&v2alpha1.NetworkPolicyConfig{
+					Create: apiutils.NewBoolPointer(true),
+					Flavor: v2alpha1.NetworkPolicyFlavorCilium,
+					ExtraIngress: map[v2alpha1.ComponentName][]v2alpha1.NetworkPolicyRule{
+						v2alpha1.NodeAgentComponentName: {{
+							Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 8125}},
+							Peers: []v2alpha1.NetworkPolicyRulePeer{
+								{CIDR: "10.0.0.0/16"},
+								{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "apm"}}},
+							},
+						}},
+					},
+				}).
+				Build(),
+		},
+		{
+			name: "network policy extra rules with the calico flavor",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithNetworkPolicy(&v2alpha1.NetworkPolicyConfig{
+					Create: apiutils.NewBoolPointer(true),
+					Flavor: v2alpha1.NetworkPolicyFlavorCalico,
+					ExtraEgress: map[v2alpha1.ComponentName][]v2alpha1.NetworkPolicyRule{
+						v2alpha1.NodeAgentComponentName: {{}},
+					},
+				}).
+				Build(),
+			wantErr: []string{"global.networkPolicy.extraIngress and global.networkPolicy.extraEgress are not supported by the calico flavor"},
+		},
+		{
+			name: "network policy extra rules of an unknown component",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithNetworkPolicy(&v2alpha1.NetworkPolicyConfig{
+					ExtraEgress: map[v2alpha1.ComponentName][]v2alpha1.NetworkPolicyRule{
+						"agent": {{}},
+					},
+				}).
+				Build(),
+			wantErr: []string{`invalid global.networkPolicy.extraEgress component "agent"`},
+		},
+		{
+			name: "network policy extra rule with an empty peer",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithNetworkPolicy(&v2alpha1.NetworkPolicyConfig{
+					ExtraIngress: map[v2alpha1.ComponentName][]v2alpha1.NetworkPolicyRule{
+						v2alpha1.ClusterAgentComponentName: {{Peers: []v2alpha1.NetworkPolicyRulePeer{{}}}},
+					},
+				}).
+				Build(),
+			wantErr: []string{"invalid global.networkPolicy.extraIngress.clusterAgent[0].peers[0]: one of cidr, podSelector or namespaceSelector must be set"},
+		},
+		{
+			name: "network policy extra rule with a CIDR and selectors",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithNetworkPolicy(&v2alpha1.NetworkPolicyConfig{
+					ExtraIngress: map[v2alpha1.ComponentName][]v2alpha1.NetworkPolicyRule{
+						v2alpha1.ClusterAgentComponentName: {{Peers: []v2alpha1.NetworkPolicyRulePeer{{
+							CIDR:        "10.0.0.0/16",
+							PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+						}}}},
+					},
+				}).
+				Build(),
+			wantErr: []string{"invalid global.networkPolicy.extraIngress.clusterAgent[0].peers[0]: cidr cannot be combined with podSelector or namespaceSelector"},
+		},
+		{
+			name: "network policy extra rule with an invalid CIDR",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithNetworkPolicy(&v2alpha1.NetworkPolicyConfig{
+					ExtraIngress: map[v2alpha1.ComponentName][]v2alpha1.NetworkPolicyRule{
+						v2alpha1.ClusterAgentComponentName: {{Peers: []v2alpha1.NetworkPolicyRulePeer{{CIDR: "10.0.0.0"}}}},
+					},
+				}).
+				Build(),
+			wantErr: []string{`invalid global.networkPolicy.extraIngress.clusterAgent[0].peers[0] cidr "10.0.0.0"`},
+		},
+		{
+			name: "valid container filters",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithGlobalContainerFilters(&v2alpha1.ContainerFiltersConfig{
+					Global: &v2alpha1.ContainerFilterRules{
+						Exclude: &v2alpha1.ContainerFilter{Namespaces: []string{"^kube-system$"}},
+					},
+					Logs: &v2alpha1.ContainerFilterRules{
+						Include: &v2alpha1.ContainerFilter{Images: []string{"^nginx$"}},
+					},
+				}).
+				Build(),
+		},
+		{
+			name: "invalid regular expression in container filters",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithGlobalContainerFilters(&v2alpha1.ContainerFiltersConfig{
+					Global: &v2alpha1.ContainerFilterRules{
+						Exclude: &v2alpha1.ContainerFilter{Namespaces: []string{"^kube-system("}},
+					},
+				}).
+				Build(),
+			wantErr: []string{"invalid global.containerFilters.global.exclude.namespaces value"},
+		},
+		{
+			name: "whitespace in container filters",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithGlobalContainerFilters(&v2alpha1.ContainerFiltersConfig{
+					Metrics: &v2alpha1.ContainerFilterRules{
+						Include: &v2alpha1.ContainerFilter{Names: []string{"agent sidecar"}},
+					},
+				}).
+				Build(),
+			wantErr: []string{"invalid global.containerFilters.metrics.include.names value"},
+		},
+		{
+			name: "valid log collection container filters",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithLogCollectionEnabled(true).
+				WithLogCollectionContainerFilters(
+					&v2alpha1.ContainerFilter{Images: []string{"^nginx$"}},
+					&v2alpha1.ContainerFilter{Namespaces: []string{"^kube-system$"}, Names: []string{"^istio-.*"}},
+				).
+				Build(),
+		},
+		{
+			name: "invalid regular expression in log collection container include",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithLogCollectionEnabled(true).
+				WithLogCollectionContainerFilters(&v2alpha1.ContainerFilter{Images: []string{"nginx[0-9"}}, nil).
+				Build(),
+			wantErr: []string{"invalid features.logCollection.containerInclude.images value"},
+		},
+		{
+			name: "whitespace in log collection container exclude",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithLogCollectionEnabled(true).
+				WithLogCollectionContainerFilters(nil, &v2alpha1.ContainerFilter{Namespaces: []string{"kube-system kube-public"}}).
+				Build(),
+			wantErr: []string{"invalid features.logCollection.containerExclude.namespaces value"},
+		},
+		{
+			name: "multi_line log collection processing rule",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithLogCollectionEnabled(true).
+				WithLogCollectionProcessingRules([]v2alpha1.LogProcessingRule{
+					{Name: "new_log_start_with_date", Type: "multi_line", Pattern: `\d{4}-(0?[1-9]|1[012])-(0?[1-9]|[12][0-9]|3[01])`},
+				}).
+				Build(),
+			wantErr: []string{`invalid features.logCollection.processingRules[0].type "multi_line"`},
+		},
+		{
+			name: "log collection processing rule without pattern",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithLogCollectionEnabled(true).
+				WithLogCollectionProcessingRules([]v2alpha1.LogProcessingRule{
+					{Name: "exclude_healthchecks", Type: v2alpha1.LogProcessingRuleExcludeAtMatch},
+				}).
+				Build(),
+			wantErr: []string{"invalid features.logCollection.processingRules[0]: pattern is required"},
+		},
+		{
+			name: "invalid regular expression in log collection processing rule",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithLogCollectionEnabled(true).
+				WithLogCollectionProcessingRules([]v2alpha1.LogProcessingRule{
+					{Name: "exclude_healthchecks", Type: v2alpha1.LogProcessingRuleExcludeAtMatch, Pattern: "GET /health"},
+					{Name: "mask_tokens", Type: v2alpha1.LogProcessingRuleMaskSequences, Pattern: "token=[a-z"},
+				}).
+				Build(),
+			wantErr: []string{"invalid features.logCollection.processingRules[1].pattern"},
+		},
+		{
+			name: "APM and Dogstatsd host ports collide",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithAPMEnabled(true).
+				WithAPMHostPortEnabled(true, apiutils.NewInt32Pointer(8125)).
+				WithDogstatsdHostPortEnabled(true).
+				Build(),
+			wantErr: []string{"host port 8125 is used by both apm/traceport and dogstatsd/dogstatsdport"},
+		},
+		{
+			name: "OTLP host ports collide",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithOTLPGRPCSettings(true, true, 4317, "0.0.0.0:4317").
+				WithOTLPHTTPSettings(true, true, 4317, "0.0.0.0:4318").
+				Build(),
+			wantErr: []string{"host port 4317 is used by both otlp/otlpgrpcport and otlp/otlphttpport"},
+		},
+		{
+			name: "invalid OTLP endpoint",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithOTLPHTTPSettings(true, false, 0, "0.0.0.0").
+				Build(),
+			wantErr: []string{"feature otlp: failed to extract port from OTLP/HTTP endpoint"},
+		},
+		{
+			name: "single container strategy with privileged features",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithSingleContainerStrategy(true).
+				WithNPMEnabled(true).
+				Build(),
+			wantErr: []string{`container strategy "single" cannot be used with features requiring privileged containers: system-probe`},
+		},
+		{
+			name: "single container strategy with unprivileged features",
+			dda: testutils.NewDatadogAgentBuilder().
+				WithCredentials("api-key", "app-key").
+				WithSingleContainerStrategy(true).
+				WithAPMEnabled(true).
+				Build(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := validationResults(zap.New(zap.UseDevMode(true)), tt.dda)
+			err := utilerrors.NewAggregate(errs)
+			if len(tt.wantErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, wantErr := range tt.wantErr {
+				assert.Contains(t, err.Error(), wantErr)
+			}
+		})
+	}
+}
+
+func TestDatadogAgentValidator(t *testing.T) {
+	validator := &datadogAgentValidator{log: zap.New(zap.UseDevMode(true))}
+	valid := testutils.NewDatadogAgentBuilder().WithCredentials("api-key", "app-key").Build()
+	invalid := testutils.NewDatadogAgentBuilder().Build()
+
+	_, err := validator.ValidateCreate(context.TODO(), valid)
+	assert.NoError(t, err)
+	_, err = validator.ValidateCreate(context.TODO(), invalid)
+	assert.Error(t, err)
+	_, err = validator.ValidateUpdate(context.TODO(), valid, invalid)
+	assert.Error(t, err)
+	_, err = validator.ValidateDelete(context.TODO(), invalid)
+	assert.NoError(t, err)
+	_, err = validator.ValidateCreate(context.TODO(), &corev1.Pod{})
+	assert.Error(t, err)
+}
+
+func TestDatadogAgentValidatorAutoRollback(t *testing.T) {
+	dda := testutils.NewDatadogAgentBuilder().WithCredentials("api-key", "app-key").Build()
+	dda.Spec.Global.AutoRollback = &v2alpha1.AutoRollbackConfig{Enabled: apiutils.NewBoolPointer(true)}
+
+	validator := &datadogAgentValidator{log: zap.New(zap.UseDevMode(true))}
+	_, err := validator.ValidateCreate(context.TODO(), dda)
+	assert.NoError(t, err)
+
+	validator.datadogAgentInternalEnabled = true
+	_, err = validator.ValidateCreate(context.TODO(), dda)
+	assert.ErrorContains(t, err, "spec.global.autoRollback is not supported")
+
+	dda.Spec.Global.AutoRollback.Enabled = apiutils.NewBoolPointer(false)
+	_, err = validator.ValidateCreate(context.TODO(), dda)
+	assert.NoError(t, err)
+}
+
+func TestDatadogAgentValidatorWarnings(t *testing.T) {
+	validator := &datadogAgentValidator{log: zap.New(zap.UseDevMode(true))}
+	dda := testutils.NewDatadogAgentBuilder().
+		WithCredentials("api-key", "app-key").
+		WithPrometheusScrapeEnabled(true).
+		WithPrometheusScrapeAdditionalConfigs("- configuration:\n  - timeout: 5").
+		Build()
+	wantWarnings := admission.Warnings{`feature prometheus_scrape: additionalConfigs[0]: unknown field "configuration" is ignored by the Agent`}
+
+	warnings, err := validator.ValidateCreate(context.TODO(), dda)
+	assert.NoError(t, err)
+	assert.Equal(t, wantWarnings, warnings)
+
+	updated := dda.DeepCopy()
+	updated.Spec.Global.ClusterName = apiutils.NewStringPointer("cluster")
+	warnings, err = validator.ValidateUpdate(context.TODO(), dda, updated)
+	assert.NoError(t, err)
+	assert.Equal(t, wantWarnings, warnings)
+}
+
+func TestDatadogAgentValidatorUpdate(t *testing.T) {
+	validator := &datadogAgentValidator{log: zap.New(zap.UseDevMode(true))}
+	// The unknown component stands for a rule added after the creation of the DatadogAgent
+	invalid := testutils.NewDatadogAgentBuilder().
+		WithCredentials("api-key", "app-key").
+		WithComponentOverride("nodeagent", v2alpha1.DatadogAgentComponentOverride{}).
+		Build()
+
+	t.Run("deleted DatadogAgent", func(t *testing.T) {
+		deleted := invalid.DeepCopy()
+		deleted.DeletionTimestamp = &metav1.Time{}
+		deleted.Finalizers = nil
+		_, err := validator.ValidateUpdate(context.TODO(), invalid, deleted)
+		assert.NoError(t, err)
+	})
+
+	t.Run("metadata-only update", func(t *testing.T) {
+		updated := invalid.DeepCopy()
+		updated.Labels = map[string]string{"team": "containers"}
+		_, err := validator.ValidateUpdate(context.TODO(), invalid, updated)
+		assert.NoError(t, err)
+
+		updated.Annotations = map[string]string{
+			"agent.datadoghq.com/pause-reconcile":       "nodeAgent",
+			"agent.datadoghq.com/pause-reconcile-until": "tomorrow",
+		}
+		_, err = validator.ValidateUpdate(context.TODO(), invalid, updated)
+		assert.ErrorContains(t, err, "invalid annotation agent.datadoghq.com/pause-reconcile-until")
+	})
+
+	t.Run("invalid field left unchanged", func(t *testing.T) {
+		updated := invalid.DeepCopy()
+		updated.Spec.Features = &v2alpha1.DatadogFeatures{APM: &v2alpha1.APMFeatureConfig{Enabled: apiutils.NewBoolPointer(true)}}
+		_, err := validator.ValidateUpdate(context.TODO(), invalid, updated)
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid field introduced", func(t *testing.T) {
+		updated := invalid.DeepCopy()
+		updated.Spec.Override["clusteragent"] = &v2alpha1.DatadogAgentComponentOverride{}
+		_, err := validator.ValidateUpdate(context.TODO(), invalid, updated)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown component "clusteragent" in spec.override`)
+		assert.NotContains(t, err.Error(), `"nodeagent"`)
+	})
+}
+
+func TestDefaultDatadogAgent(t *testing.T) {
+	dda := testutils.NewDatadogAgentBuilder().
+		WithCredentials("api-key", "app-key").
+		WithAPMEnabled(true).
+		WithDogstatsdHostPortEnabled(true).
+		WithFIPS(v2alpha1.FIPSConfig{Enabled: apiutils.NewBoolPointer(true)}).
+		Build()
+	DefaultDatadogAgent(dda)
+
+	assert.Equal(t, int32(8125), *dda.Spec.Features.Dogstatsd.HostPortConfig.Port)
+	assert.Equal(t, "/var/run/datadog/apm.socket", *dda.Spec.Features.APM.UnixDomainSocketConfig.Path)
+	assert.Equal(t, "fips-proxy", dda.Spec.Global.FIPS.Image.Name)
+	assert.Empty(t, dda.Spec.Global.FIPS.Image.Tag, "the default image tag must not be written in the spec")
+	assert.Nil(t, dda.Spec.Global.Registry, "the registry derived from the site must not be written in the spec")
+	assert.True(t, *dda.Spec.Features.APM.Enabled)
+	assert.Nil(t, dda.Spec.Features.APM.UnixDomainSocketConfig.Enabled, "the default enabled flags must not be written in the spec")
+	assert.Nil(t, dda.Spec.Features.LogCollection, "the features left empty must not be written in the spec")
+	assert.Nil(t, dda.Spec.Features.OrchestratorExplorer.Enabled)
+	assert.True(t, *dda.Spec.Features.OrchestratorExplorer.ScrubContainers)
+
+	// Defaulting is idempotent
+	defaulted := dda.DeepCopy()
+	DefaultDatadogAgent(defaulted)
+	assert.Equal(t, dda, defaulted)
+
+	// Tags set by users are kept
+	dda.Spec.Global.FIPS.Image.Tag = "1.0.0"
+	DefaultDatadogAgent(dda)
+	assert.Equal(t, "1.0.0", dda.Spec.Global.FIPS.Image.Tag)
+}
+
+func TestDatadogAgentDefaulter(t *testing.T) {
+	defaulter := &datadogAgentDefaulter{}
+	dda := testutils.NewDatadogAgentBuilder().WithCredentials("api-key", "app-key").Build()
+
+	require.NoError(t, defaulter.Default(context.TODO(), dda))
+	assert.Equal(t, "datadoghq.com", *dda.Spec.Global.Site)
+	assert.Error(t, defaulter.Default(context.TODO(), &corev1.Pod{}))
+}
@@ -0,0 +1,39 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package webhook contains the admission and conversion webhooks served by the operator manager.
+package webhook
+
+import (
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Options defines the webhooks registered in the manager webhook server
+type Options struct {
+	// ValidationEnabled enables the validating admission webhook for DatadogAgent resources
+	ValidationEnabled bool
+	// DefaultingEnabled enables the mutating admission webhook writing the defaults in DatadogAgent resources
+	DefaultingEnabled bool
+	// ConversionEnabled enables the conversion webhook between the DatadogAgent API versions
+	ConversionEnabled bool
+	// DatadogAgentInternalEnabled is set when DatadogAgents are reconciled through DatadogAgentInternal resources,
+	// it rejects the options not supported in this mode
+	DatadogAgentInternalEnabled bool
+}
+
+// SetupWebhooks registers the webhooks in the manager webhook server.
+func SetupWebhooks(logger logr.Logger, mgr ctrl.Manager, options Options) error {
+	if !options.ValidationEnabled && !options.DefaultingEnabled && !options.ConversionEnabled {
+		return nil
+	}
+
+	if err := setupDatadogAgentWebhookWithManager(logger, mgr, options); err != nil {
+		return err
+	}
+	logger.Info("Webhook registered", "webhook", "DatadogAgent", "validation", options.ValidationEnabled, "defaulting", options.DefaultingEnabled, "conversion", options.ConversionEnabled)
+
+	return nil
+}
@@ -0,0 +1,40 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package adminnetworkpolicy
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GroupVersion is the admin network policy API group version
+var GroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1alpha1"}
+
+// GroupVersionAdminNetworkPolicyListKind return the schema.GroupVersionKind for AdminNetworkPolicyList
+func GroupVersionAdminNetworkPolicyListKind() schema.GroupVersionKind {
+	return GroupVersion.WithKind("AdminNetworkPolicyList")
+}
+
+// GroupVersionAdminNetworkPolicyKind return the schema.GroupVersionKind for AdminNetworkPolicy
+func GroupVersionAdminNetworkPolicyKind() schema.GroupVersionKind {
+	return GroupVersion.WithKind(AdminNetworkPolicyKind)
+}
+
+// EmptyUnstructuredAdminNetworkPolicyList return a new unstructured.UnstructuredList for AdminNetworkPolicy
+func EmptyUnstructuredAdminNetworkPolicyList() *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(GroupVersionAdminNetworkPolicyListKind())
+
+	return list
+}
+
+// EmptyUnstructuredAdminNetworkPolicy return a new unstructured.Unstructured for AdminNetworkPolicy
+func EmptyUnstructuredAdminNetworkPolicy() *unstructured.Unstructured {
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(GroupVersionAdminNetworkPolicyKind())
+
+	return policy
+}
@@ -0,0 +1,96 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package adminnetworkpolicy
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// GroupName is the Kubernetes network policy API group
+	GroupName = "policy.networking.k8s.io"
+	// AdminNetworkPolicyKind is the kind of an admin network policy
+	AdminNetworkPolicyKind = "AdminNetworkPolicy"
+)
+
+// RuleAction is an admin network policy rule action
+type RuleAction string
+
+const (
+	// RuleActionAllow allows the traffic matched by a rule, regardless of the NetworkPolicies
+	RuleActionAllow RuleAction = "Allow"
+)
+
+// AdminNetworkPolicy is a cluster-wide network policy evaluated before the NetworkPolicies
+type AdminNetworkPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AdminNetworkPolicySpec `json:"spec,omitempty"`
+}
+
+// AdminNetworkPolicySpec is an admin network policy spec
+type AdminNetworkPolicySpec struct {
+	Priority int32         `json:"priority"`
+	Subject  Subject       `json:"subject"`
+	Ingress  []IngressRule `json:"ingress,omitempty"`
+	Egress   []EgressRule  `json:"egress,omitempty"`
+}
+
+// Subject selects the pods an admin network policy applies to
+type Subject struct {
+	Namespaces *metav1.LabelSelector `json:"namespaces,omitempty"`
+	Pods       *NamespacedPod        `json:"pods,omitempty"`
+}
+
+// NamespacedPod selects pods by namespace and pod labels
+type NamespacedPod struct {
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+	PodSelector       metav1.LabelSelector `json:"podSelector"`
+}
+
+// IngressRule is an admin network policy ingress rule
+type IngressRule struct {
+	Name   string        `json:"name,omitempty"`
+	Action RuleAction    `json:"action"`
+	From   []IngressPeer `json:"from"`
+	Ports  []Port        `json:"ports,omitempty"`
+}
+
+// IngressPeer is the source of an admin network policy ingress rule
+type IngressPeer struct {
+	Namespaces *metav1.LabelSelector `json:"namespaces,omitempty"`
+	Pods       *NamespacedPod        `json:"pods,omitempty"`
+}
+
+// EgressRule is an admin network policy egress rule
+type EgressRule struct {
+	Name   string       `json:"name,omitempty"`
+	Action RuleAction   `json:"action"`
+	To     []EgressPeer `json:"to"`
+	Ports  []Port       `json:"ports,omitempty"`
+}
+
+// EgressPeer is the destination of an admin network policy egress rule
+type EgressPeer struct {
+	Namespaces  *metav1.LabelSelector `json:"namespaces,omitempty"`
+	Pods        *NamespacedPod        `json:"pods,omitempty"`
+	Nodes       *metav1.LabelSelector `json:"nodes,omitempty"`
+	Networks    []string              `json:"networks,omitempty"`
+	DomainNames []string              `json:"domainNames,omitempty"`
+}
+
+// Port is an admin network policy port
+type Port struct {
+	PortNumber *PortNumber `json:"portNumber,omitempty"`
+}
+
+// PortNumber is a port number and protocol
+type PortNumber struct {
+	Protocol corev1.Protocol `json:"protocol"`
+	Port     int32           `json:"port"`
+}
@@ -0,0 +1,60 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package agentrollout
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+)
+
+const (
+	// DefaultRollbackFailureThreshold is the default number of failing updated pods triggering a rollback
+	DefaultRollbackFailureThreshold = "50%"
+	// DefaultRollbackWindow is the default duration for an updated pod to become ready
+	DefaultRollbackWindow = 5 * time.Minute
+)
+
+// IsRollbackEnabled returns true if the automatic rollback is enabled
+func IsRollbackEnabled(config *v2alpha1.AutoRollbackConfig) bool {
+	return config != nil && apiutils.BoolValue(config.Enabled)
+}
+
+// ShouldRollback returns true if enough updated pods fail readiness to roll back the component.
+// An updated pod fails readiness when it is not ready once the rollback window has elapsed since its creation.
+// It also returns the number of failing pods.
+func ShouldRollback(config *v2alpha1.AutoRollbackConfig, updatedPods []corev1.Pod, now time.Time) (bool, int, error) {
+	window := DefaultRollbackWindow
+	if config.Window != nil {
+		window = config.Window.Duration
+	}
+	threshold := apiutils.NewIntOrStringPointer(DefaultRollbackFailureThreshold)
+	if config.FailureThreshold != nil {
+		threshold = config.FailureThreshold
+	}
+
+	failing := 0
+	for i := range updatedPods {
+		pod := &updatedPods[i]
+		if pod.DeletionTimestamp == nil && !isReady(pod) && now.Sub(pod.CreationTimestamp.Time) > window {
+			failing++
+		}
+	}
+	if failing == 0 {
+		return false, 0, nil
+	}
+
+	maxFailing, err := intstr.GetScaledValueFromIntOrPercent(threshold, len(updatedPods), true)
+	if err != nil {
+		return false, failing, fmt.Errorf("invalid autoRollback.failureThreshold: %w", err)
+	}
+	return failing >= max(maxFailing, 1), failing, nil
+}
@@ -0,0 +1,92 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package agentrollout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+)
+
+func TestShouldRollback(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name         string
+		config       *v2alpha1.AutoRollbackConfig
+		pods         []testPod
+		wantRollback bool
+		wantFailing  int
+	}{
+		{
+			name:   "all updated pods ready",
+			config: &v2alpha1.AutoRollbackConfig{Enabled: apiutils.NewBoolPointer(true)},
+			pods: []testPod{
+				{node: "node1", ready: true, age: time.Hour},
+				{node: "node2", ready: true, age: time.Hour},
+			},
+		},
+		{
+			name:   "pods not ready within the window",
+			config: &v2alpha1.AutoRollbackConfig{Enabled: apiutils.NewBoolPointer(true)},
+			pods: []testPod{
+				{node: "node1", ready: false, age: time.Minute},
+				{node: "node2", ready: false, age: time.Minute},
+			},
+		},
+		{
+			name:   "half of the updated pods failing, default threshold",
+			config: &v2alpha1.AutoRollbackConfig{Enabled: apiutils.NewBoolPointer(true)},
+			pods: []testPod{
+				{node: "node1", ready: false, age: time.Hour},
+				{node: "node2", ready: true, age: time.Hour},
+			},
+			wantRollback: true,
+			wantFailing:  1,
+		},
+		{
+			name:   "below the default threshold",
+			config: &v2alpha1.AutoRollbackConfig{Enabled: apiutils.NewBoolPointer(true)},
+			pods: []testPod{
+				{node: "node1", ready: false, age: time.Hour},
+				{node: "node2", ready: true, age: time.Hour},
+				{node: "node3", ready: true, age: time.Hour},
+				{node: "node4", ready: true, age: time.Hour},
+			},
+			wantFailing: 1,
+		},
+		{
+			name: "absolute threshold and custom window",
+			config: &v2alpha1.AutoRollbackConfig{
+				Enabled:          apiutils.NewBoolPointer(true),
+				FailureThreshold: apiutils.NewIntOrStringPointer("1"),
+				Window:           &metav1.Duration{Duration: 30 * time.Second},
+			},
+			pods: []testPod{
+				{node: "node1", ready: false, age: time.Minute},
+				{node: "node2", ready: true, age: time.Minute},
+				{node: "node3", ready: true, age: time.Minute},
+			},
+			wantRollback: true,
+			wantFailing:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rollback, failing, err := ShouldRollback(tt.config, newPods(now, tt.pods...), now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRollback, rollback)
+			assert.Equal(t, tt.wantFailing, failing)
+		})
+	}
+}
@@ -0,0 +1,259 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package agentrollout
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+)
+
+const (
+	// DefaultMaxUnavailable is the default maximum number of unavailable Agent pods during a staged rollout
+	DefaultMaxUnavailable = "10%"
+	// DefaultMaxRestarts is the default maximum number of restarts of an updated Agent pod
+	DefaultMaxRestarts int32 = 2
+	// DefaultProgressDeadline is the default maximum duration for an updated Agent pod to become ready
+	DefaultProgressDeadline = 10 * time.Minute
+
+	// lastWaveName is the name of the wave containing the nodes not selected by any configured wave
+	lastWaveName = "default"
+)
+
+// Plan is the result of a staged rollout step
+type Plan struct {
+	// Status is the status of the rollout
+	Status v2alpha1.RolloutStatus
+	// PodsToDelete are the outdated pods to delete in this step
+	PodsToDelete []corev1.Pod
+}
+
+type wave struct {
+	name  string
+	pause *metav1.Duration
+	pods  []corev1.Pod
+}
+
+// IsEnabled returns true if the staged rollout is enabled
+func IsEnabled(config *v2alpha1.StagedRolloutConfig) bool {
+	return config != nil && apiutils.BoolValue(config.Enabled)
+}
+
+// ComputePlan computes the next step of the staged rollout of a DaemonSet.
+// The pods are the pods of the DaemonSet, the nodes are used to match the node selectors of the waves,
+// and revision is the `controller-revision-hash` of the up to date pods.
+// The previous status is used to enforce the pause between waves.
+func ComputePlan(config *v2alpha1.StagedRolloutConfig, pods []corev1.Pod, nodes map[string]*corev1.Node, revision string, previous *v2alpha1.RolloutStatus, now time.Time) (*Plan, error) {
+	waves, err := assignWaves(config.Waves, pods, nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{
+		Status: v2alpha1.RolloutStatus{
+			Revision:  revision,
+			TotalPods: int32(len(pods)),
+		},
+	}
+	if previous != nil && previous.Revision == revision {
+		plan.Status.LastWaveCompletionTime = previous.LastWaveCompletionTime
+	}
+
+	unavailable := 0
+	for i := range pods {
+		if isUpdated(&pods[i], revision) {
+			plan.Status.UpdatedPods++
+		}
+		if !isReady(&pods[i]) {
+			unavailable++
+		}
+	}
+
+	completed := 0
+	for completed < len(waves) && isWaveCompleted(waves[completed], revision) {
+		completed++
+	}
+	plan.Status.CompletedWaves = int32(completed)
+	if previous == nil || previous.Revision != revision || int32(completed) > previous.CompletedWaves {
+		if completed > 0 {
+			plan.Status.LastWaveCompletionTime = &metav1.Time{Time: now}
+		}
+	}
+
+	if completed == len(waves) {
+		plan.Status.State = v2alpha1.RolloutStateCompleted
+		plan.Status.Message = "all the Agent pods are up to date"
+		return plan, nil
+	}
+	plan.Status.CurrentWave = waves[completed].name
+
+	if message := checkHealth(config, pods, revision, now); message != "" {
+		plan.Status.State = v2alpha1.RolloutStatePaused
+		plan.Status.Message = message
+		return plan, nil
+	}
+
+	plan.Status.State = v2alpha1.RolloutStateInProgress
+	if completed > 0 && waves[completed-1].pause != nil && plan.Status.LastWaveCompletionTime != nil {
+		if end := plan.Status.LastWaveCompletionTime.Add(waves[completed-1].pause.Duration); now.Before(end) {
+			plan.Status.Message = fmt.Sprintf("waiting until %s to start wave %s", end.UTC().Format(time.RFC3339), waves[completed].name)
+			return plan, nil
+		}
+	}
+
+	maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(maxUnavailableValue(config), len(pods), true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stagedRollout.maxUnavailable: %w", err)
+	}
+	maxUnavailable = max(maxUnavailable, 1)
+
+	// Deleting an outdated pod that is not ready does not reduce the availability
+	budget := maxUnavailable - unavailable
+	for _, pod := range waves[completed].pods {
+		if isUpdated(&pod, revision) || pod.DeletionTimestamp != nil {
+			continue
+		}
+		if isReady(&pod) {
+			if budget <= 0 {
+				continue
+			}
+			budget--
+		}
+		plan.PodsToDelete = append(plan.PodsToDelete, pod)
+	}
+	plan.Status.Message = fmt.Sprintf("rolling out wave %s", waves[completed].name)
+
+	return plan, nil
+}
+
+// assignWaves assigns each pod to a wave. The pods are sorted by node name to keep the waves stable.
+func assignWaves(configs []v2alpha1.RolloutWave, pods []corev1.Pod, nodes map[string]*corev1.Node) ([]wave, error) {
+	remaining := make([]corev1.Pod, len(pods))
+	copy(remaining, pods)
+	sort.Slice(remaining, func(i, j int) bool {
+		if remaining[i].Spec.NodeName != remaining[j].Spec.NodeName {
+			return remaining[i].Spec.NodeName < remaining[j].Spec.NodeName
+		}
+		return remaining[i].Name < remaining[j].Name
+	})
+
+	waves := make([]wave, 0, len(configs)+1)
+	for _, config := range configs {
+		selector := labels.Everything()
+		if config.NodeSelector != nil {
+			var err error
+			if selector, err = metav1.LabelSelectorAsSelector(config.NodeSelector); err != nil {
+				return nil, fmt.Errorf("invalid node selector in wave %s: %w", config.Name, err)
+			}
+		}
+		limit := len(remaining)
+		if config.MaxNodes != nil {
+			var err error
+			if limit, err = intstr.GetScaledValueFromIntOrPercent(config.MaxNodes, len(pods), true); err != nil {
+				return nil, fmt.Errorf("invalid maxNodes in wave %s: %w", config.Name, err)
+			}
+		}
+
+		w := wave{name: config.Name, pause: config.Pause}
+		var rest []corev1.Pod
+		for _, pod := range remaining {
+			if len(w.pods) < limit && selector.Matches(nodeLabels(nodes, pod.Spec.NodeName)) {
+				w.pods = append(w.pods, pod)
+			} else {
+				rest = append(rest, pod)
+			}
+		}
+		remaining = rest
+		waves = append(waves, w)
+	}
+	if len(remaining) > 0 || len(waves) == 0 {
+		waves = append(waves, wave{name: lastWaveName, pods: remaining})
+	}
+
+	return waves, nil
+}
+
+// checkHealth returns a message describing the first updated pod failing the health checks
+func checkHealth(config *v2alpha1.StagedRolloutConfig, pods []corev1.Pod, revision string, now time.Time) string {
+	maxRestarts := DefaultMaxRestarts
+	if config.MaxRestarts != nil {
+		maxRestarts = *config.MaxRestarts
+	}
+	progressDeadline := DefaultProgressDeadline
+	if config.ProgressDeadline != nil {
+		progressDeadline = config.ProgressDeadline.Duration
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+		if !isUpdated(pod, revision) {
+			continue
+		}
+		if restarts := restartCount(pod); restarts > maxRestarts {
+			return fmt.Sprintf("updated pod %s restarted %d times", pod.Name, restarts)
+		}
+		if !isReady(pod) && now.Sub(pod.CreationTimestamp.Time) > progressDeadline {
+			return fmt.Sprintf("updated pod %s not ready after %s", pod.Name, progressDeadline)
+		}
+	}
+	return ""
+}
+
+func isWaveCompleted(w wave, revision string) bool {
+	for i := range w.pods {
+		if !isUpdated(&w.pods[i], revision) || !isReady(&w.pods[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isUpdated(pod *corev1.Pod, revision string) bool {
+	return pod.Labels[appsv1.DefaultDaemonSetUniqueLabelKey] == revision
+}
+
+func isReady(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil {
+		return false
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func restartCount(pod *corev1.Pod) int32 {
+	var restarts int32
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += status.RestartCount
+	}
+	return restarts
+}
+
+func nodeLabels(nodes map[string]*corev1.Node, nodeName string) labels.Set {
+	if node, found := nodes[nodeName]; found {
+		return node.Labels
+	}
+	return nil
+}
+
+func maxUnavailableValue(config *v2alpha1.StagedRolloutConfig) *intstr.IntOrString {
+	if config.MaxUnavailable != nil {
+		return config.MaxUnavailable
+	}
+	return apiutils.NewIntOrStringPointer(DefaultMaxUnavailable)
+}
@@ -0,0 +1,273 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package agentrollout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+)
+
+const (
+	oldRevision = "old"
+	newRevision = "new"
+)
+
+type testPod struct {
+	node     string
+	revision string
+	ready    bool
+	restarts int32
+	age      time.Duration
+}
+
+func newPods(now time.Time, specs ...testPod) []corev1.Pod {
+	pods := make([]corev1.Pod, 0, len(specs))
+	for _, spec := range specs {
+		readyStatus := corev1.ConditionFalse
+		if spec.ready {
+			readyStatus = corev1.ConditionTrue
+		}
+		pods = append(pods, corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "agent-" + spec.node,
+				Labels:            map[string]string{appsv1.DefaultDaemonSetUniqueLabelKey: spec.revision},
+				CreationTimestamp: metav1.NewTime(now.Add(-spec.age)),
+			},
+			Spec: corev1.PodSpec{NodeName: spec.node},
+			Status: corev1.PodStatus{
+				Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "agent", RestartCount: spec.restarts}},
+			},
+		})
+	}
+	return pods
+}
+
+func newNodes(nodeLabels map[string]map[string]string) map[string]*corev1.Node {
+	nodes := map[string]*corev1.Node{}
+	for name, l := range nodeLabels {
+		nodes[name] = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: l}}
+	}
+	return nodes
+}
+
+func podNames(pods []corev1.Pod) []string {
+	var names []string
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	return names
+}
+
+func TestComputePlan(t *testing.T) {
+	now := time.Now()
+	nodes := newNodes(map[string]map[string]string{
+		"node1": {"canary": "true"},
+		"node2": nil,
+		"node3": nil,
+		"node4": nil,
+	})
+	canaryWave := v2alpha1.RolloutWave{
+		Name:         "canary",
+		NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+		Pause:        &metav1.Duration{Duration: 10 * time.Minute},
+	}
+
+	tests := []struct {
+		name        string
+		config      *v2alpha1.StagedRolloutConfig
+		pods        []corev1.Pod
+		previous    *v2alpha1.RolloutStatus
+		wantDeleted []string
+		wantState   v2alpha1.RolloutState
+		wantWave    string
+		wantUpdated int32
+	}{
+		{
+			name:   "all pods up to date",
+			config: &v2alpha1.StagedRolloutConfig{Enabled: apiutils.NewBoolPointer(true)},
+			pods: newPods(now,
+				testPod{node: "node1", revision: newRevision, ready: true},
+				testPod{node: "node2", revision: newRevision, ready: true},
+			),
+			wantState:   v2alpha1.RolloutStateCompleted,
+			wantUpdated: 2,
+		},
+		{
+			name: "single wave, limited by maxUnavailable",
+			config: &v2alpha1.StagedRolloutConfig{
+				Enabled:        apiutils.NewBoolPointer(true),
+				MaxUnavailable: apiutils.NewIntOrStringPointer("2"),
+			},
+			pods: newPods(now,
+				testPod{node: "node1", revision: oldRevision, ready: true},
+				testPod{node: "node2", revision: oldRevision, ready: true},
+				testPod{node: "node3", revision: oldRevision, ready: true},
+				testPod{node: "node4", revision: oldRevision, ready: true},
+			),
+			wantDeleted: []string{"agent-node1", "agent-node2"},
+			wantState:   v2alpha1.RolloutStateInProgress,
+			wantWave:    "default",
+		},
+		{
+			name: "outdated pods not ready do not use the unavailability budget",
+			config: &v2alpha1.StagedRolloutConfig{
+				Enabled:        apiutils.NewBoolPointer(true),
+				MaxUnavailable: apiutils.NewIntOrStringPointer("1"),
+			},
+			pods: newPods(now,
+				testPod{node: "node1", revision: oldRevision, ready: false},
+				testPod{node: "node2", revision: oldRevision, ready: true},
+			),
+			wantDeleted: []string{"agent-node1"},
+			wantState:   v2alpha1.RolloutStateInProgress,
+			wantWave:    "default",
+		},
+		{
+			name: "canary wave by node label",
+			config: &v2alpha1.StagedRolloutConfig{
+				Enabled:        apiutils.NewBoolPointer(true),
+				Waves:          []v2alpha1.RolloutWave{canaryWave},
+				MaxUnavailable: apiutils.NewIntOrStringPointer("50%"),
+			},
+			pods: newPods(now,
+				testPod{node: "node1", revision: oldRevision, ready: true},
+				testPod{node: "node2", revision: oldRevision, ready: true},
+				testPod{node: "node3", revision: oldRevision, ready: true},
+			),
+			wantDeleted: []string{"agent-node1"},
+			wantState:   v2alpha1.RolloutStateInProgress,
+			wantWave:    "canary",
+		},
+		{
+			name: "pause after the canary wave",
+			config: &v2alpha1.StagedRolloutConfig{
+				Enabled: apiutils.NewBoolPointer(true),
+				Waves:   []v2alpha1.RolloutWave{canaryWave},
+			},
+			pods: newPods(now,
+				testPod{node: "node1", revision: newRevision, ready: true},
+				testPod{node: "node2", revision: oldRevision, ready: true},
+			),
+			previous: &v2alpha1.RolloutStatus{
+				Revision:               newRevision,
+				CompletedWaves:         1,
+				LastWaveCompletionTime: &metav1.Time{Time: now.Add(-5 * time.Minute)},
+			},
+			wantState:   v2alpha1.RolloutStateInProgress,
+			wantWave:    "default",
+			wantUpdated: 1,
+		},
+		{
+			name: "next wave after the pause",
+			config: &v2alpha1.StagedRolloutConfig{
+				Enabled: apiutils.NewBoolPointer(true),
+				Waves:   []v2alpha1.RolloutWave{canaryWave},
+			},
+			pods: newPods(now,
+				testPod{node: "node1", revision: newRevision, ready: true},
+				testPod{node: "node2", revision: oldRevision, ready: true},
+			),
+			previous: &v2alpha1.RolloutStatus{
+				Revision:               newRevision,
+				CompletedWaves:         1,
+				LastWaveCompletionTime: &metav1.Time{Time: now.Add(-15 * time.Minute)},
+			},
+			wantDeleted: []string{"agent-node2"},
+			wantState:   v2alpha1.RolloutStateInProgress,
+			wantWave:    "default",
+			wantUpdated: 1,
+		},
+		{
+			name: "wave by percentage",
+			config: &v2alpha1.StagedRolloutConfig{
+				Enabled:        apiutils.NewBoolPointer(true),
+				Waves:          []v2alpha1.RolloutWave{{Name: "first", MaxNodes: &intstr.IntOrString{Type: intstr.String, StrVal: "25%"}}},
+				MaxUnavailable: apiutils.NewIntOrStringPointer("100%"),
+			},
+			pods: newPods(now,
+				testPod{node: "node1", revision: oldRevision, ready: true},
+				testPod{node: "node2", revision: oldRevision, ready: true},
+				testPod{node: "node3", revision: oldRevision, ready: true},
+				testPod{node: "node4", revision: oldRevision, ready: true},
+			),
+			wantDeleted: []string{"agent-node1"},
+			wantState:   v2alpha1.RolloutStateInProgress,
+			wantWave:    "first",
+		},
+		{
+			name: "paused on restarts",
+			config: &v2alpha1.StagedRolloutConfig{
+				Enabled:     apiutils.NewBoolPointer(true),
+				MaxRestarts: apiutils.NewInt32Pointer(1),
+			},
+			pods: newPods(now,
+				testPod{node: "node1", revision: newRevision, ready: true, restarts: 2},
+				testPod{node: "node2", revision: oldRevision, ready: true},
+			),
+			wantState:   v2alpha1.RolloutStatePaused,
+			wantWave:    "default",
+			wantUpdated: 1,
+		},
+		{
+			name:   "paused on progress deadline",
+			config: &v2alpha1.StagedRolloutConfig{Enabled: apiutils.NewBoolPointer(true)},
+			pods: newPods(now,
+				testPod{node: "node1", revision: newRevision, ready: false, age: time.Hour},
+				testPod{node: "node2", revision: oldRevision, ready: true},
+			),
+			wantState:   v2alpha1.RolloutStatePaused,
+			wantWave:    "default",
+			wantUpdated: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := ComputePlan(tt.config, tt.pods, nodes, newRevision, tt.previous, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDeleted, podNames(plan.PodsToDelete))
+			assert.Equal(t, tt.wantState, plan.Status.State)
+			assert.Equal(t, tt.wantWave, plan.Status.CurrentWave)
+			assert.Equal(t, tt.wantUpdated, plan.Status.UpdatedPods)
+			assert.Equal(t, int32(len(tt.pods)), plan.Status.TotalPods)
+		})
+	}
+}
+
+func TestComputePlan_WaveCompletionTime(t *testing.T) {
+	now := time.Now()
+	config := &v2alpha1.StagedRolloutConfig{
+		Enabled: apiutils.NewBoolPointer(true),
+		Waves:   []v2alpha1.RolloutWave{{Name: "first", MaxNodes: &intstr.IntOrString{Type: intstr.Int, IntVal: 1}}},
+	}
+	pods := newPods(now,
+		testPod{node: "node1", revision: newRevision, ready: true},
+		testPod{node: "node2", revision: oldRevision, ready: true},
+	)
+
+	plan, err := ComputePlan(config, pods, nil, newRevision, &v2alpha1.RolloutStatus{Revision: newRevision}, now)
+	require.NoError(t, err)
+	require.NotNil(t, plan.Status.LastWaveCompletionTime)
+	assert.Equal(t, now, plan.Status.LastWaveCompletionTime.Time)
+	assert.Equal(t, int32(1), plan.Status.CompletedWaves)
+
+	// The completion time is kept while the number of completed waves does not change
+	later := now.Add(time.Minute)
+	next, err := ComputePlan(config, pods, nil, newRevision, &plan.Status, later)
+	require.NoError(t, err)
+	assert.Equal(t, now, next.Status.LastWaveCompletionTime.Time)
+}
@@ -0,0 +1,40 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package calico
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GroupVersion is the Calico API group version
+var GroupVersion = schema.GroupVersion{Group: GroupName, Version: "v3"}
+
+// GroupVersionGlobalNetworkPolicyListKind return the schema.GroupVersionKind for GlobalNetworkPolicyList
+func GroupVersionGlobalNetworkPolicyListKind() schema.GroupVersionKind {
+	return GroupVersion.WithKind("GlobalNetworkPolicyList")
+}
+
+// GroupVersionGlobalNetworkPolicyKind return the schema.GroupVersionKind for GlobalNetworkPolicy
+func GroupVersionGlobalNetworkPolicyKind() schema.GroupVersionKind {
+	return GroupVersion.WithKind(GlobalNetworkPolicyKind)
+}
+
+// EmptyUnstructuredGlobalNetworkPolicyList return a new unstructured.UnstructuredList for GlobalNetworkPolicy
+func EmptyUnstructuredGlobalNetworkPolicyList() *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(GroupVersionGlobalNetworkPolicyListKind())
+
+	return list
+}
+
+// EmptyUnstructuredGlobalNetworkPolicy return a new unstructured.Unstructured for GlobalNetworkPolicy
+func EmptyUnstructuredGlobalNetworkPolicy() *unstructured.Unstructured {
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(GroupVersionGlobalNetworkPolicyKind())
+
+	return policy
+}
@@ -0,0 +1,85 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package calico
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// GroupName is the Calico API group
+	GroupName = "projectcalico.org"
+	// GlobalNetworkPolicyKind is the kind of a Calico global network policy
+	GlobalNetworkPolicyKind = "GlobalNetworkPolicy"
+	// NamespaceLabelKey is the label set by Calico on workload endpoints with the namespace of the pod
+	NamespaceLabelKey = "projectcalico.org/namespace"
+)
+
+// PolicyType is a Calico policy type
+type PolicyType string
+
+const (
+	// PolicyTypeIngress refers to the ingress rules of a policy
+	PolicyTypeIngress PolicyType = "Ingress"
+	// PolicyTypeEgress refers to the egress rules of a policy
+	PolicyTypeEgress PolicyType = "Egress"
+)
+
+// Action is a Calico rule action
+type Action string
+
+const (
+	// ActionAllow allows the traffic matched by a rule
+	ActionAllow Action = "Allow"
+)
+
+// Protocol is a Calico network protocol
+type Protocol string
+
+const (
+	// ProtocolTCP refers to the TCP network protocol
+	ProtocolTCP Protocol = "TCP"
+	// ProtocolUDP refers to the UDP network protocol
+	ProtocolUDP Protocol = "UDP"
+)
+
+// GlobalNetworkPolicy is a Calico global network policy
+type GlobalNetworkPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GlobalNetworkPolicySpec `json:"spec,omitempty"`
+}
+
+// GlobalNetworkPolicySpec is a Calico global network policy spec
+type GlobalNetworkPolicySpec struct {
+	Selector string       `json:"selector,omitempty"`
+	Types    []PolicyType `json:"types,omitempty"`
+	Ingress  []Rule       `json:"ingress,omitempty"`
+	Egress   []Rule       `json:"egress,omitempty"`
+}
+
+// Rule is a Calico policy rule
+type Rule struct {
+	Action      Action     `json:"action"`
+	Protocol    *Protocol  `json:"protocol,omitempty"`
+	Source      EntityRule `json:"source,omitempty"`
+	Destination EntityRule `json:"destination,omitempty"`
+}
+
+// EntityRule is a Calico rule source or destination
+type EntityRule struct {
+	Nets     []string      `json:"nets,omitempty"`
+	Selector string        `json:"selector,omitempty"`
+	Ports    []int32       `json:"ports,omitempty"`
+	Services *ServiceMatch `json:"services,omitempty"`
+}
+
+// ServiceMatch is a Calico selector of the endpoints of a Kubernetes service
+type ServiceMatch struct {
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
@@ -0,0 +1,101 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package certificate
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+)
+
+const (
+	// CertificateKey is the key of the PEM encoded certificate in the secret read by the Cluster Agent Admission Controller
+	CertificateKey = "cert.pem"
+	// PrivateKeyKey is the key of the PEM encoded private key in the secret read by the Cluster Agent Admission Controller
+	PrivateKeyKey = "key.pem"
+
+	// IssueForAnnotationKey is set on a secret whose certificate is issued by the operator,
+	// with the comma-separated DNS names of the certificate
+	IssueForAnnotationKey = "operator.datadoghq.com/issue-certificate-for"
+	// CopyFromAnnotationKey is set on a secret whose certificate is copied from the kubernetes.io/tls secret
+	// of the same namespace named by the annotation, such as a secret written by cert-manager
+	CopyFromAnnotationKey = "operator.datadoghq.com/copy-certificate-from"
+	// InjectCAFromSecretAnnotationKey is set on a webhook configuration whose caBundle is the certificate of a secret
+	// issued by the operator, formatted as `<namespace>/<secret name>`
+	InjectCAFromSecretAnnotationKey = "operator.datadoghq.com/inject-ca-from-secret"
+
+	// Validity is the validity of the certificates issued by the operator
+	Validity = 365 * 24 * time.Hour
+	// RenewBefore is the remaining validity below which a certificate is issued again. It is longer than the 30 days
+	// before the expiration at which the Cluster Agent renews the certificate itself.
+	RenewBefore = 60 * 24 * time.Hour
+
+	rsaKeySize = 2048
+)
+
+// NewSelfSigned returns a PEM encoded self-signed certificate for dnsNames, valid from now, and its private key.
+// The certificate is its own CA, so it is also the caBundle of the webhooks it serves.
+func NewSelfSigned(dnsNames []string, now time.Time) ([]byte, []byte, error) {
+	if len(dnsNames) == 0 {
+		return nil, nil, fmt.Errorf("a certificate requires at least one DNS name")
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, rsaKeySize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to generate the private key: %w", err)
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to generate the serial number: %w", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:              dnsNames,
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(Validity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create the certificate: %w", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return certPEM, keyPEM, nil
+}
+
+// IsValid returns true if certPEM is a certificate for exactly dnsNames, which does not need to be renewed at now.
+func IsValid(certPEM []byte, dnsNames []string, now time.Time) bool {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return false
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false
+	}
+	if now.Before(cert.NotBefore) || now.Add(RenewBefore).After(cert.NotAfter) {
+		return false
+	}
+
+	certDNSNames := slices.Clone(cert.DNSNames)
+	expected := slices.Clone(dnsNames)
+	slices.Sort(certDNSNames)
+	slices.Sort(expected)
+	return slices.Equal(certDNSNames, expected)
+}
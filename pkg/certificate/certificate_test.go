@@ -0,0 +1,78 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package certificate
+
+import (
+	"crypto/tls"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSelfSigned(t *testing.T) {
+	now := time.Now()
+	dnsNames := []string{"datadog-admission-controller.datadog.svc", "datadog-admission-controller.datadog.svc.cluster.local"}
+
+	certPEM, keyPEM, err := NewSelfSigned(dnsNames, now)
+	require.NoError(t, err)
+
+	_, err = tls.X509KeyPair(certPEM, keyPEM)
+	assert.NoError(t, err)
+
+	_, _, err = NewSelfSigned(nil, now)
+	assert.Error(t, err)
+}
+
+func TestIsValid(t *testing.T) {
+	now := time.Now()
+	dnsNames := []string{"foo.bar.svc", "foo.bar.svc.cluster.local"}
+	certPEM, _, err := NewSelfSigned(dnsNames, now)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		certPEM  []byte
+		dnsNames []string
+		now      time.Time
+		want     bool
+	}{
+		{
+			name:     "valid certificate",
+			certPEM:  certPEM,
+			dnsNames: []string{"foo.bar.svc.cluster.local", "foo.bar.svc"},
+			now:      now,
+			want:     true,
+		},
+		{
+			name:     "different DNS names",
+			certPEM:  certPEM,
+			dnsNames: []string{"baz.bar.svc", "baz.bar.svc.cluster.local"},
+			now:      now,
+			want:     false,
+		},
+		{
+			name:     "certificate to renew",
+			certPEM:  certPEM,
+			dnsNames: dnsNames,
+			now:      now.Add(Validity - RenewBefore + time.Hour),
+			want:     false,
+		},
+		{
+			name:     "invalid certificate",
+			certPEM:  []byte("not a certificate"),
+			dnsNames: dnsNames,
+			now:      now,
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsValid(tt.certPEM, tt.dnsNames, tt.now))
+		})
+	}
+}
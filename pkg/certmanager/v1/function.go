@@ -0,0 +1,66 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package certmanager
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GroupVersion is the cert-manager API group version
+var GroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1"}
+
+// GroupVersionCertificateListKind return the schema.GroupVersionKind for CertificateList
+func GroupVersionCertificateListKind() schema.GroupVersionKind {
+	return GroupVersion.WithKind("CertificateList")
+}
+
+// GroupVersionCertificateKind return the schema.GroupVersionKind for Certificate
+func GroupVersionCertificateKind() schema.GroupVersionKind {
+	return GroupVersion.WithKind("Certificate")
+}
+
+// GroupVersionIssuerListKind return the schema.GroupVersionKind for IssuerList
+func GroupVersionIssuerListKind() schema.GroupVersionKind {
+	return GroupVersion.WithKind("IssuerList")
+}
+
+// GroupVersionIssuerKind return the schema.GroupVersionKind for Issuer
+func GroupVersionIssuerKind() schema.GroupVersionKind {
+	return GroupVersion.WithKind(IssuerKind)
+}
+
+// EmptyUnstructuredCertificateList return a new unstructured.UnstructuredList for Certificate
+func EmptyUnstructuredCertificateList() *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(GroupVersionCertificateListKind())
+
+	return list
+}
+
+// EmptyUnstructuredCertificate return a new unstructured.Unstructured for Certificate
+func EmptyUnstructuredCertificate() *unstructured.Unstructured {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(GroupVersionCertificateKind())
+
+	return certificate
+}
+
+// EmptyUnstructuredIssuerList return a new unstructured.UnstructuredList for Issuer
+func EmptyUnstructuredIssuerList() *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(GroupVersionIssuerListKind())
+
+	return list
+}
+
+// EmptyUnstructuredIssuer return a new unstructured.Unstructured for Issuer
+func EmptyUnstructuredIssuer() *unstructured.Unstructured {
+	issuer := &unstructured.Unstructured{}
+	issuer.SetGroupVersionKind(GroupVersionIssuerKind())
+
+	return issuer
+}
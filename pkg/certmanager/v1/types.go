@@ -0,0 +1,62 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package certmanager
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// GroupName is the cert-manager API group
+	GroupName = "cert-manager.io"
+	// IssuerKind is the kind of a namespaced cert-manager issuer
+	IssuerKind = "Issuer"
+	// ClusterIssuerKind is the kind of a cluster-wide cert-manager issuer
+	ClusterIssuerKind = "ClusterIssuer"
+	// InjectCAFromAnnotationKey is the annotation used by the cert-manager CA injector to set the caBundle
+	// of a webhook configuration from a Certificate, formatted as `<namespace>/<certificate name>`
+	InjectCAFromAnnotationKey = "cert-manager.io/inject-ca-from"
+)
+
+// Certificate is a cert-manager certificate
+type Certificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CertificateSpec `json:"spec,omitempty"`
+}
+
+// CertificateSpec is a cert-manager certificate spec
+type CertificateSpec struct {
+	DNSNames    []string         `json:"dnsNames,omitempty"`
+	SecretName  string           `json:"secretName"`
+	IssuerRef   ObjectReference  `json:"issuerRef"`
+	Duration    *metav1.Duration `json:"duration,omitempty"`
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// ObjectReference is a reference to a cert-manager issuer
+type ObjectReference struct {
+	Name  string `json:"name"`
+	Kind  string `json:"kind,omitempty"`
+	Group string `json:"group,omitempty"`
+}
+
+// Issuer is a cert-manager issuer
+type Issuer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IssuerSpec `json:"spec,omitempty"`
+}
+
+// IssuerSpec is a cert-manager issuer spec. Only self-signed issuers are supported.
+type IssuerSpec struct {
+	SelfSigned *SelfSignedIssuer `json:"selfSigned,omitempty"`
+}
+
+// SelfSignedIssuer configures an issuer to self-sign certificates
+type SelfSignedIssuer struct{}
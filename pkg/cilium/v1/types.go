@@ -33,6 +33,8 @@ const (
 	EntityWorld Entity = "world"
 	// EntityKubeApiServer is a Kube Api Server
 	EntityKubeApiServer Entity = "kube-apiserver"
+	// EntityAll is any endpoint, inside or outside of the cluster
+	EntityAll Entity = "all"
 )
 
 // NetworkPolicy is a Cilium network policy
@@ -53,6 +55,7 @@ type NetworkPolicySpec struct {
 
 // IngressRule is a Cilium ingress rule
 type IngressRule struct {
+	FromCIDR      []string               `json:"fromCIDR,omitempty"`
 	FromEndpoints []metav1.LabelSelector `json:"fromEndpoints,omitempty"`
 	FromEntities  []Entity               `json:"fromEntities,omitempty"`
 	ToPorts       []PortRule             `json:"toPorts,omitempty"`
@@ -389,11 +389,48 @@ func UpdateCombinedDaemonSetStatus(dsStatus []*v2alpha1.DaemonSetStatus) *v2alph
 		}
 		combinedStatus.State = getCombinedState(combinedStatus.State, status.State)
 		combinedStatus.Status = fmt.Sprintf("%v (%d/%d/%d)", combinedStatus.State, combinedStatus.Desired, combinedStatus.Ready, combinedStatus.UpToDate)
+		combinedStatus.Rollout = combineRolloutStatus(combinedStatus.Rollout, status.Rollout)
 	}
 
 	return &combinedStatus
 }
 
+// combineRolloutStatus combines the staged rollout status of multiple DaemonSets.
+// The least advanced rollout is reported, and the pods are summed.
+func combineRolloutStatus(current, other *v2alpha1.RolloutStatus) *v2alpha1.RolloutStatus {
+	if other == nil {
+		return current
+	}
+	if current == nil {
+		return other.DeepCopy()
+	}
+	combined := current.DeepCopy()
+	combined.UpdatedPods += other.UpdatedPods
+	combined.TotalPods += other.TotalPods
+	if rolloutStateRank(other.State) < rolloutStateRank(current.State) {
+		combined.State = other.State
+		combined.CurrentWave = other.CurrentWave
+		combined.CompletedWaves = other.CompletedWaves
+		combined.LastWaveCompletionTime = other.LastWaveCompletionTime
+		combined.Message = other.Message
+	}
+	if combined.Revision != other.Revision {
+		combined.Revision = ""
+	}
+	return combined
+}
+
+func rolloutStateRank(state v2alpha1.RolloutState) int {
+	switch state {
+	case v2alpha1.RolloutStatePaused:
+		return 1
+	case v2alpha1.RolloutStateInProgress:
+		return 2
+	default:
+		return 3
+	}
+}
+
 func getCombinedState(currentState, newState string) string {
 	currentNum := assignNumeralState(currentState)
 	newNum := assignNumeralState(newState)
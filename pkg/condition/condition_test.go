@@ -119,3 +119,38 @@ func TestUpdateWhenDSNil(t *testing.T) {
 	dsStatus = UpdateDaemonSetStatus("ds", ds, dsStatus, &metav1.Time{Time: time.Now()})
 	assert.Equal(t, 1, len(dsStatus))
 }
+
+func TestUpdateCombinedDaemonSetStatusRollout(t *testing.T) {
+	dsStatus := []*v2alpha1.DaemonSetStatus{
+		{
+			DaemonsetName: "ds-1",
+			Rollout: &v2alpha1.RolloutStatus{
+				State:       v2alpha1.RolloutStateCompleted,
+				Revision:    "rev-1",
+				UpdatedPods: 3,
+				TotalPods:   3,
+			},
+		},
+		{
+			DaemonsetName: "ds-2",
+			Rollout: &v2alpha1.RolloutStatus{
+				State:       v2alpha1.RolloutStatePaused,
+				Revision:    "rev-2",
+				CurrentWave: "canary",
+				UpdatedPods: 1,
+				TotalPods:   2,
+				Message:     "updated pod agent-xyz restarted 3 times",
+			},
+		},
+	}
+
+	combined := UpdateCombinedDaemonSetStatus(dsStatus)
+	assert.NotNil(t, combined.Rollout)
+	assert.Equal(t, v2alpha1.RolloutStatePaused, combined.Rollout.State)
+	assert.Equal(t, "canary", combined.Rollout.CurrentWave)
+	assert.Equal(t, int32(4), combined.Rollout.UpdatedPods)
+	assert.Equal(t, int32(5), combined.Rollout.TotalPods)
+	assert.Equal(t, "", combined.Rollout.Revision)
+	// The DaemonSet statuses are not modified
+	assert.Equal(t, int32(3), dsStatus[0].Rollout.UpdatedPods)
+}
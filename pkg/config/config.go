@@ -15,6 +15,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -30,6 +31,8 @@ import (
 const (
 	// AgentWatchNamespaceEnvVar is a comma-separated list of namespaces watched by the DatadogAgent controller.
 	agentWatchNamespaceEnvVar = "DD_AGENT_WATCH_NAMESPACE"
+	// CheckWatchNamespaceEnvVar is a comma-separated list of namespaces watched for DatadogCheck resources.
+	checkWatchNamespaceEnvVar = "DD_CHECK_WATCH_NAMESPACE"
 	// DashboardWatchNamespaceEnvVar is a comma-separated list of namespaces watched by the DatadogDashboard controller.
 	dashboardWatchNamespaceEnvVar = "DD_DASHBOARD_WATCH_NAMESPACE"
 	// GenericResourceWatchNamespaceEnvVar is a comma-separated list of namespaces watched by the DatadogGenericResource controller.
@@ -47,6 +50,7 @@ const (
 
 var (
 	agentObj           = &datadoghqv2alpha1.DatadogAgent{}
+	checkObj           = &datadoghqv1alpha1.DatadogCheck{}
 	dashboardObj       = &datadoghqv1alpha1.DatadogDashboard{}
 	genericResourceObj = &datadoghqv1alpha1.DatadogGenericResource{}
 	monitorObj         = &datadoghqv1alpha1.DatadogMonitor{}
@@ -64,8 +68,10 @@ type WatchOptions struct {
 	DatadogSLOEnabled             bool
 	DatadogAgentProfileEnabled    bool
 	IntrospectionEnabled          bool
+	AgentRolloutEnabled           bool
 	DatadogDashboardEnabled       bool
 	DatadogGenericResourceEnabled bool
+	DatadogCheckEnabled           bool
 }
 
 // CacheOptions function configures Controller Runtime cache options on a resource level (supported in v0.16+).
@@ -81,6 +87,14 @@ func CacheOptions(logger logr.Logger, opts WatchOptions) cache.Options {
 		}
 	}
 
+	if opts.DatadogCheckEnabled {
+		checkNamespaces := getWatchNamespacesFromEnv(logger, checkWatchNamespaceEnvVar)
+		logger.Info("DatadogCheck Enabled", "watching namespaces", maps.Keys(checkNamespaces))
+		byObject[checkObj] = cache.ByObject{
+			Namespaces: checkNamespaces,
+		}
+	}
+
 	if opts.DatadogDashboardEnabled {
 		dashboardNamespaces := getWatchNamespacesFromEnv(logger, dashboardWatchNamespaceEnvVar)
 		logger.Info("DatadogDashboard Enabled", "watching namespaces", maps.Keys(dashboardNamespaces))
@@ -119,20 +133,40 @@ func CacheOptions(logger logr.Logger, opts WatchOptions) cache.Options {
 		byObject[profileObj] = cache.ByObject{
 			Namespaces: agentProfileNamespaces,
 		}
+	}
 
-		// It is very important to reduce memory usage when profiles are used.
+	agentRolloutEnabled := opts.DatadogAgentEnabled && opts.AgentRolloutEnabled
+	if opts.DatadogAgentProfileEnabled || agentRolloutEnabled {
+		// It is very important to reduce memory usage, the pod cache is only scoped to the Datadog components.
 		// For the profiles feature we need to list the agent pods, but we're only
-		// interested in the node name and the labels. This function removes all the
-		// rest of fields to reduce memory usage.
+		// interested in the node name and the labels. The staged rollout of the
+		// Agent DaemonSet also needs the owner, the readiness and the restart counts,
+		// and the automatic rollback needs them for the Cluster Agent, Cluster
+		// Checks Runner and OTel Agent Gateway pods too.
+		// This function removes all the rest of fields to reduce memory usage.
 		// Pods are watched in DatadogAgent namespace(s) since that's where Agent pods are running.
 		agentNamespaces := getWatchNamespacesFromEnv(logger, agentWatchNamespaceEnvVar)
-		logger.Info("DatadogAgentProfile Enabled", "watching Pods in namespaces", maps.Keys(agentNamespaces))
+		logger.Info("DatadogAgent Enabled", "watching Pods in namespaces", maps.Keys(agentNamespaces))
+		podSelector := labels.SelectorFromSet(map[string]string{
+			common.AgentDeploymentComponentLabelKey: constants.DefaultAgentResourceSuffix,
+		})
+		if agentRolloutEnabled {
+			componentRequirement, err := labels.NewRequirement(common.AgentDeploymentComponentLabelKey, selection.In, []string{
+				constants.DefaultAgentResourceSuffix,
+				constants.DefaultClusterAgentResourceSuffix,
+				constants.DefaultClusterChecksRunnerResourceSuffix,
+				constants.DefaultOtelAgentGatewayResourceSuffix,
+			})
+			if err != nil {
+				logger.Error(err, "Unable to watch the Cluster Agent, Cluster Checks Runner and OTel Agent Gateway pods")
+			} else {
+				podSelector = labels.NewSelector().Add(*componentRequirement)
+			}
+		}
 		byObject[podObj] = cache.ByObject{
 			Namespaces: agentNamespaces,
 
-			Label: labels.SelectorFromSet(map[string]string{
-				common.AgentDeploymentComponentLabelKey: constants.DefaultAgentResourceSuffix,
-			}),
+			Label: podSelector,
 
 			Transform: func(obj interface{}) (interface{}, error) {
 				pod := obj.(*corev1.Pod)
@@ -140,22 +174,34 @@ func CacheOptions(logger logr.Logger, opts WatchOptions) cache.Options {
 				newPod := &corev1.Pod{
 					TypeMeta: pod.TypeMeta,
 					ObjectMeta: v1.ObjectMeta{
-						Namespace: pod.Namespace,
-						Name:      pod.Name,
-						Labels:    pod.Labels,
+						Namespace:         pod.Namespace,
+						Name:              pod.Name,
+						UID:               pod.UID,
+						Labels:            pod.Labels,
+						OwnerReferences:   pod.OwnerReferences,
+						CreationTimestamp: pod.CreationTimestamp,
+						DeletionTimestamp: pod.DeletionTimestamp,
 					},
 					Spec: corev1.PodSpec{
 						NodeName: pod.Spec.NodeName,
 					},
 				}
+				for _, cond := range pod.Status.Conditions {
+					if cond.Type == corev1.PodReady {
+						newPod.Status.Conditions = append(newPod.Status.Conditions, corev1.PodCondition{Type: cond.Type, Status: cond.Status})
+					}
+				}
+				for _, status := range pod.Status.ContainerStatuses {
+					newPod.Status.ContainerStatuses = append(newPod.Status.ContainerStatuses, corev1.ContainerStatus{Name: status.Name, RestartCount: status.RestartCount})
+				}
 
 				return newPod, nil
 			},
 		}
 	}
 
-	if opts.DatadogAgentProfileEnabled || opts.IntrospectionEnabled {
-		// Also for the profiles feature, we need to list the nodes, but we're only
+	if opts.DatadogAgentEnabled || opts.DatadogAgentProfileEnabled || opts.IntrospectionEnabled {
+		// The profiles, the node partition and the staged rollout need to list the nodes, but we're only
 		// interested in the node name and the labels.
 		// Note that if in the future we need to list or get pods or nodes and use other
 		// fields we'll need to modify this function.
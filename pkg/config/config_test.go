@@ -38,6 +38,7 @@ func Test_CacheConfig(t *testing.T) {
 				DatadogAgentProfileEnabled:    true,
 				DatadogDashboardEnabled:       true,
 				DatadogGenericResourceEnabled: true,
+				DatadogCheckEnabled:           true,
 			},
 
 			envConfig: map[string]string{
@@ -48,12 +49,14 @@ func Test_CacheConfig(t *testing.T) {
 				profileWatchNamespaceEnvVar:         "profileNs",
 				dashboardWatchNamespaceEnvVar:       "dashboardNs",
 				genericResourceWatchNamespaceEnvVar: "genericNs",
+				checkWatchNamespaceEnvVar:           "checkNs1,checkNs2",
 			},
 
 			wantDefaultNamepsace: objectConfig{configured: true, namespaces: []string{"agentNs"}},
 
 			wantObjectConfig: map[client.Object]objectConfig{
 				agentObj:           {configured: true, namespaces: []string{"agentNs"}},
+				checkObj:           {configured: true, namespaces: []string{"checkNs1", "checkNs2"}},
 				dashboardObj:       {configured: true, namespaces: []string{"dashboardNs"}},
 				genericResourceObj: {configured: true, namespaces: []string{"genericNs"}},
 				monitorObj:         {configured: true, namespaces: []string{"monitorNs", "monitorNs2"}},
@@ -117,7 +120,7 @@ func Test_CacheConfig(t *testing.T) {
 			},
 		},
 		{
-			name: "Only Agent enabled; Monitor enabled without namespace config. Other CRDs, Pods, Nodes not configured",
+			name: "Only Agent enabled; Monitor enabled without namespace config; Node uses nil namespace. Other CRDs, Pods not configured",
 
 			watchOptions: WatchOptions{
 				DatadogAgentEnabled:   true,
@@ -140,7 +143,7 @@ func Test_CacheConfig(t *testing.T) {
 				sloObj:             {configured: false},
 				profileObj:         {configured: false},
 				podObj:             {configured: false},
-				nodeObj:            {configured: false},
+				nodeObj:            {configured: true, namespaces: nil},
 			},
 		},
 		{
@@ -171,6 +174,33 @@ func Test_CacheConfig(t *testing.T) {
 				nodeObj:            {configured: true, namespaces: nil},
 			},
 		},
+		{
+			name: "DAP disabled, Agent rollout enabled; Pods use Agent namespace; Node uses nil namespace; Profiles are not configured",
+
+			watchOptions: WatchOptions{
+				DatadogAgentEnabled: true,
+				AgentRolloutEnabled: true,
+			},
+
+			envConfig: map[string]string{
+				watchNamespaceEnvVar:        "datadog",
+				agentWatchNamespaceEnvVar:   "agentNs1,agentNs2",
+				profileWatchNamespaceEnvVar: "profileNs",
+			},
+
+			// Expected
+			wantDefaultNamepsace: objectConfig{configured: true, namespaces: []string{"agentNs1", "agentNs2"}},
+			wantObjectConfig: map[client.Object]objectConfig{
+				agentObj:           {configured: true, namespaces: []string{"agentNs1", "agentNs2"}},
+				dashboardObj:       {configured: false},
+				genericResourceObj: {configured: false},
+				monitorObj:         {configured: false},
+				sloObj:             {configured: false},
+				profileObj:         {configured: false},
+				podObj:             {configured: true, namespaces: []string{"agentNs1", "agentNs2"}},
+				nodeObj:            {configured: true, namespaces: nil},
+			},
+		},
 	}
 
 	logger := logf.Log.WithName(t.Name())
@@ -7,6 +7,7 @@ package constants
 
 import (
 	"fmt"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -35,6 +36,8 @@ func GetServiceAccountByComponent(objName string, ddaSpec *v2alpha1.DatadogAgent
 		return GetAgentServiceAccount(objName, ddaSpec)
 	case v2alpha1.ClusterChecksRunnerComponentName:
 		return GetClusterChecksRunnerServiceAccount(objName, ddaSpec)
+	case v2alpha1.OtelAgentGatewayComponentName:
+		return GetOtelAgentGatewayServiceAccount(objName, ddaSpec)
 	default:
 		return ""
 	}
@@ -67,6 +70,15 @@ func GetClusterChecksRunnerServiceAccount(objName string, ddaSpec *v2alpha1.Data
 	return saDefault
 }
 
+// GetOtelAgentGatewayServiceAccount return the otel-agent-gateway service account name
+func GetOtelAgentGatewayServiceAccount(objName string, ddaSpec *v2alpha1.DatadogAgentSpec) string {
+	saDefault := fmt.Sprintf("%s-%s", objName, DefaultOtelAgentGatewayResourceSuffix)
+	if ddaSpec.Override[v2alpha1.OtelAgentGatewayComponentName] != nil && ddaSpec.Override[v2alpha1.OtelAgentGatewayComponentName].ServiceAccountName != nil {
+		return *ddaSpec.Override[v2alpha1.OtelAgentGatewayComponentName].ServiceAccountName
+	}
+	return saDefault
+}
+
 // IsHostNetworkEnabled returns whether the pod should use the host's network namespace
 func IsHostNetworkEnabled(ddaSpec *v2alpha1.DatadogAgentSpec, component v2alpha1.ComponentName) bool {
 	if ddaSpec.Override != nil {
@@ -106,6 +118,37 @@ func IsNetworkPolicyEnabled(ddaSpec *v2alpha1.DatadogAgentSpec) (bool, v2alpha1.
 	return false, ""
 }
 
+// IsRestrictedSecurityProfile returns whether the agents are restricted to the Pod Security Standard `restricted`
+func IsRestrictedSecurityProfile(ddaSpec *v2alpha1.DatadogAgentSpec) bool {
+	return ddaSpec.Global != nil && ddaSpec.Global.SecurityProfile != nil && *ddaSpec.Global.SecurityProfile == v2alpha1.RestrictedSecurityProfile
+}
+
+// defaultDisabledNamespaces are the namespaces the Cluster Agent never instruments, in addition to its own namespace.
+var defaultDisabledNamespaces = []string{"kube-system"}
+
+// InstrumentationNamespaces are the namespace rules the Cluster Agent applies before the targets.
+type InstrumentationNamespaces struct {
+	// Enabled are the namespaces the instrumentation is restricted to. The Cluster Agent rejects them with targets.
+	Enabled []string
+	// Disabled are the namespaces never instrumented.
+	Disabled []string
+}
+
+// GetInstrumentationNamespaces returns the namespace rules of the Single Step Instrumentation of a DatadogAgent deployed in
+// agentNamespace. Like the Cluster Agent, kube-system and the namespace of the Agent are always disabled.
+func GetInstrumentationNamespaces(ddaSpec *v2alpha1.DatadogAgentSpec, agentNamespace string) InstrumentationNamespaces {
+	namespaces := InstrumentationNamespaces{
+		Disabled: append(slices.Clone(defaultDisabledNamespaces), agentNamespace),
+	}
+	if ddaSpec.Features == nil || ddaSpec.Features.APM == nil || ddaSpec.Features.APM.SingleStepInstrumentation == nil {
+		return namespaces
+	}
+	ssi := ddaSpec.Features.APM.SingleStepInstrumentation
+	namespaces.Enabled = ssi.EnabledNamespaces
+	namespaces.Disabled = append(namespaces.Disabled, ssi.DisabledNamespaces...)
+	return namespaces
+}
+
 // GetDefaultLivenessProbe creates a defaulted LivenessProbe
 func GetDefaultLivenessProbe() *corev1.Probe {
 	livenessProbe := &corev1.Probe{
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -59,3 +61,19 @@ func TestServiceAccountNameOverride(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetInstrumentationNamespaces(t *testing.T) {
+	ddaSpec := &v2alpha1.DatadogAgentSpec{
+		Features: &v2alpha1.DatadogFeatures{
+			APM: &v2alpha1.APMFeatureConfig{
+				SingleStepInstrumentation: &v2alpha1.SingleStepInstrumentation{
+					EnabledNamespaces:  []string{"billing"},
+					DisabledNamespaces: []string{"monitoring"},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, InstrumentationNamespaces{Disabled: []string{"kube-system", "datadog"}}, GetInstrumentationNamespaces(&v2alpha1.DatadogAgentSpec{}, "datadog"))
+	assert.Equal(t, InstrumentationNamespaces{Enabled: []string{"billing"}, Disabled: []string{"kube-system", "datadog", "monitoring"}}, GetInstrumentationNamespaces(ddaSpec, "datadog"))
+}
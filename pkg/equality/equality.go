@@ -57,6 +57,9 @@ func IsEqualObject(kind kubernetes.ObjectKind, a, b client.Object) bool {
 		return IsEqualNetworkPolicies(a, b)
 	case kubernetes.CiliumNetworkPoliciesKind:
 		return IsEqualCiliumNetworkPolicies(a, b)
+	case kubernetes.CertManagerCertificatesKind, kubernetes.CertManagerIssuersKind,
+		kubernetes.CalicoGlobalNetworkPoliciesKind, kubernetes.AdminNetworkPoliciesKind, kubernetes.SeccompProfilesKind:
+		return isEqualUnstructuredSpec(a, b)
 	default:
 		return false
 	}
@@ -246,3 +249,20 @@ func IsEqualOperatorAnnotations(a, b metav1.Object) bool {
 func IsEqualOperatorLabels(a, b metav1.Object) bool {
 	return apiequality.Semantic.DeepEqual(a.GetLabels(), b.GetLabels())
 }
+
+// isEqualUnstructuredSpec return true if the spec of the two objects are equal. It is used for the custom resources
+// of other projects: cert-manager Certificates and Issuers, Calico GlobalNetworkPolicies, AdminNetworkPolicies and
+// Security Profiles Operator SeccompProfiles.
+func isEqualUnstructuredSpec(objA, objB client.Object) bool {
+	unstructuredA, errA := runtime.DefaultUnstructuredConverter.ToUnstructured(objA)
+	if errA != nil {
+		return false
+	}
+
+	unstructuredB, errB := runtime.DefaultUnstructuredConverter.ToUnstructured(objB)
+	if errB != nil {
+		return false
+	}
+
+	return apiequality.Semantic.DeepEqual(unstructuredA["spec"], unstructuredB["spec"])
+}
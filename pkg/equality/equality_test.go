@@ -11,7 +11,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
 )
 
 func TestIsEqualOperatorAnnotations(t *testing.T) {
@@ -252,3 +255,25 @@ func TestIsEqualSecrets(t *testing.T) {
 		})
 	}
 }
+
+func TestIsEqualObject_unstructuredSpec(t *testing.T) {
+	newObject := func(spec, status map[string]interface{}) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec, "status": status}}
+		obj.SetName("foo")
+		return obj
+	}
+	kinds := []kubernetes.ObjectKind{
+		kubernetes.CertManagerCertificatesKind,
+		kubernetes.CertManagerIssuersKind,
+		kubernetes.CalicoGlobalNetworkPoliciesKind,
+		kubernetes.AdminNetworkPoliciesKind,
+		kubernetes.SeccompProfilesKind,
+	}
+	for _, kind := range kinds {
+		t.Run(string(kind), func(t *testing.T) {
+			a := newObject(map[string]interface{}{"foo": "bar"}, map[string]interface{}{"ready": true})
+			assert.True(t, IsEqualObject(kind, a, newObject(map[string]interface{}{"foo": "bar"}, nil)))
+			assert.False(t, IsEqualObject(kind, a, newObject(map[string]interface{}{"foo": "baz"}, nil)))
+		})
+	}
+}
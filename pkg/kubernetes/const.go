@@ -29,8 +29,16 @@ const (
 type ObjectKind string
 
 const (
+	// AdminNetworkPoliciesKind is the AdminNetworkPolicies resource kind
+	AdminNetworkPoliciesKind = "adminnetworkpolicies"
 	// APIServiceKind is the APIService resource kind
 	APIServiceKind = "apiservices"
+	// CalicoGlobalNetworkPoliciesKind is the Calico GlobalNetworkPolicies resource kind
+	CalicoGlobalNetworkPoliciesKind = "globalnetworkpolicies"
+	// CertManagerCertificatesKind is the cert-manager Certificates resource kind
+	CertManagerCertificatesKind = "certificates"
+	// CertManagerIssuersKind is the cert-manager Issuers resource kind
+	CertManagerIssuersKind = "issuers"
 	// CiliumNetworkPoliciesKind is the CiliumNetworkPolicies resource kind
 	CiliumNetworkPoliciesKind = "ciliumnetworkpolicies"
 	// ClusterRolesKind is the ClusterRoles resource kind
@@ -53,6 +61,8 @@ const (
 	RolesKind = "roles"
 	// SecretsKind is the Secrets resource kind
 	SecretsKind = "secrets"
+	// SeccompProfilesKind is the Security Profiles Operator SeccompProfiles resource kind
+	SeccompProfilesKind = "seccompprofiles"
 	// ServiceAccountsKind is the ServiceAccounts resource kind
 	ServiceAccountsKind = "serviceaccounts"
 	// ServicesKind is the Services resource kind
@@ -62,7 +72,7 @@ const (
 )
 
 // getResourcesKind return the list of all possible ObjectKind supported as DatadogAgent dependencies
-func getResourcesKind(withCiliumResources bool) []ObjectKind {
+func getResourcesKind(withCiliumResources, withCertManagerResources bool) []ObjectKind {
 	resources := []ObjectKind{
 		APIServiceKind,
 		ClusterRolesKind,
@@ -83,6 +93,10 @@ func getResourcesKind(withCiliumResources bool) []ObjectKind {
 		resources = append(resources, CiliumNetworkPoliciesKind)
 	}
 
+	if withCertManagerResources {
+		resources = append(resources, CertManagerCertificatesKind, CertManagerIssuersKind)
+	}
+
 	return resources
 }
 
@@ -13,7 +13,11 @@ import (
 	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	adminnetworkpolicyv1alpha1 "github.com/DataDog/datadog-operator/pkg/adminnetworkpolicy/v1alpha1"
+	calicov3 "github.com/DataDog/datadog-operator/pkg/calico/v3"
+	certmanagerv1 "github.com/DataDog/datadog-operator/pkg/certmanager/v1"
 	ciliumv1 "github.com/DataDog/datadog-operator/pkg/cilium/v1"
+	securityprofilesoperatorv1beta1 "github.com/DataDog/datadog-operator/pkg/securityprofilesoperator/v1beta1"
 )
 
 // ObjectListFromKind returns the corresponding object list from a kind
@@ -47,6 +51,16 @@ func ObjectListFromKind(kind ObjectKind, platformInfo PlatformInfo) client.Objec
 		return &networkingv1.NetworkPolicyList{}
 	case CiliumNetworkPoliciesKind:
 		return ciliumv1.EmptyCiliumUnstructuredListPolicy()
+	case CertManagerCertificatesKind:
+		return certmanagerv1.EmptyUnstructuredCertificateList()
+	case CertManagerIssuersKind:
+		return certmanagerv1.EmptyUnstructuredIssuerList()
+	case CalicoGlobalNetworkPoliciesKind:
+		return calicov3.EmptyUnstructuredGlobalNetworkPolicyList()
+	case AdminNetworkPoliciesKind:
+		return adminnetworkpolicyv1alpha1.EmptyUnstructuredAdminNetworkPolicyList()
+	case SeccompProfilesKind:
+		return securityprofilesoperatorv1beta1.EmptyUnstructuredSeccompProfileList()
 	}
 
 	return nil
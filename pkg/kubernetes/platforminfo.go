@@ -4,14 +4,22 @@ import (
 	policyv1 "k8s.io/api/policy/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/version"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	adminnetworkpolicyv1alpha1 "github.com/DataDog/datadog-operator/pkg/adminnetworkpolicy/v1alpha1"
+	calicov3 "github.com/DataDog/datadog-operator/pkg/calico/v3"
+	certmanagerv1 "github.com/DataDog/datadog-operator/pkg/certmanager/v1"
+	securityprofilesoperatorv1beta1 "github.com/DataDog/datadog-operator/pkg/securityprofilesoperator/v1beta1"
 )
 
 type PlatformInfo struct {
 	versionInfo          *version.Info
 	apiPreferredVersions map[string]string
 	apiOtherVersions     map[string]string
+	// apiGroupVersions are all the group versions serving a kind, as several API groups can define the same kind
+	apiGroupVersions map[string]map[string]struct{}
 }
 
 func NewPlatformInfo(versionInfo *version.Info, groups []*v1.APIGroup, resources []*v1.APIResourceList) PlatformInfo {
@@ -46,19 +54,39 @@ func NewPlatformInfo(versionInfo *version.Info, groups []*v1.APIGroup, resources
 		}
 	}
 
-	return NewPlatformInfoFromVersionMaps(
+	platformInfo := NewPlatformInfoFromVersionMaps(
 		versionInfo,
 		apiPreferredVersions,
 		apiOtherVersions,
 	)
+	for _, list := range resources {
+		for j := range list.APIResources {
+			platformInfo.addGroupVersion(list.APIResources[j].Kind, list.GroupVersion)
+		}
+	}
+	return platformInfo
 }
 
 func NewPlatformInfoFromVersionMaps(versionInfo *version.Info, apiPreferredVersions, apiOtherVersions map[string]string) PlatformInfo {
-	return PlatformInfo{
+	platformInfo := PlatformInfo{
 		versionInfo:          versionInfo,
 		apiPreferredVersions: apiPreferredVersions,
 		apiOtherVersions:     apiOtherVersions,
+		apiGroupVersions:     map[string]map[string]struct{}{},
 	}
+	for _, versions := range []map[string]string{apiPreferredVersions, apiOtherVersions} {
+		for kind, groupVersion := range versions {
+			platformInfo.addGroupVersion(kind, groupVersion)
+		}
+	}
+	return platformInfo
+}
+
+func (platformInfo *PlatformInfo) addGroupVersion(kind, groupVersion string) {
+	if _, found := platformInfo.apiGroupVersions[kind]; !found {
+		platformInfo.apiGroupVersions[kind] = map[string]struct{}{}
+	}
+	platformInfo.apiGroupVersions[kind][groupVersion] = struct{}{}
 }
 
 func (platformInfo *PlatformInfo) UseV1Beta1PDB() bool {
@@ -83,7 +111,40 @@ func (platformInfo *PlatformInfo) CreatePDBObjectList() client.ObjectList {
 }
 
 func (platformInfo *PlatformInfo) GetAgentResourcesKind(withCiliumResources bool) []ObjectKind {
-	return getResourcesKind(withCiliumResources)
+	resources := getResourcesKind(withCiliumResources, platformInfo.IsCertManagerSupported())
+
+	if platformInfo.IsCalicoGlobalNetworkPolicySupported() {
+		resources = append(resources, CalicoGlobalNetworkPoliciesKind)
+	}
+
+	if platformInfo.IsAdminNetworkPolicySupported() {
+		resources = append(resources, AdminNetworkPoliciesKind)
+	}
+
+	if platformInfo.IsResourceVersionSupported(securityprofilesoperatorv1beta1.SeccompProfileKind, securityprofilesoperatorv1beta1.GroupVersion) {
+		resources = append(resources, SeccompProfilesKind)
+	}
+
+	return resources
+}
+
+// IsCalicoGlobalNetworkPolicySupported returns true if the Calico GlobalNetworkPolicy resource version is supported by the server
+func (platformInfo *PlatformInfo) IsCalicoGlobalNetworkPolicySupported() bool {
+	return platformInfo.IsResourceVersionSupported(calicov3.GlobalNetworkPolicyKind, calicov3.GroupVersion)
+}
+
+// IsAdminNetworkPolicySupported returns true if the AdminNetworkPolicy resource version is supported by the server
+func (platformInfo *PlatformInfo) IsAdminNetworkPolicySupported() bool {
+	return platformInfo.IsResourceVersionSupported(adminnetworkpolicyv1alpha1.AdminNetworkPolicyKind, adminnetworkpolicyv1alpha1.GroupVersion)
+}
+
+// IsCertManagerSupported returns true if the cert-manager Certificate and Issuer resources are supported by the server
+func (platformInfo *PlatformInfo) IsCertManagerSupported() bool {
+	if platformInfo == nil {
+		return false
+	}
+	return platformInfo.IsResourceVersionSupported("Certificate", certmanagerv1.GroupVersion) &&
+		platformInfo.IsResourceVersionSupported(certmanagerv1.IssuerKind, certmanagerv1.GroupVersion)
 }
 
 // IsResourceSupported returns true if a Kubernetes resource is supported by the server
@@ -100,6 +161,17 @@ func (platformInfo *PlatformInfo) IsResourceSupported(resource string) bool {
 	return false
 }
 
+// IsResourceVersionSupported returns true if a Kubernetes resource of the API group version is supported by the server.
+// Unlike IsResourceSupported, a kind defined by another API group, such as the Calico CRDs of crd.projectcalico.org
+// for the resources of projectcalico.org served by the Calico API server, is not supported.
+func (platformInfo *PlatformInfo) IsResourceVersionSupported(kind string, groupVersion schema.GroupVersion) bool {
+	if platformInfo == nil {
+		return false
+	}
+	_, found := platformInfo.apiGroupVersions[kind][groupVersion.String()]
+	return found
+}
+
 func (platformInfo *PlatformInfo) GetApiVersions(name string) (preferred string, other string) {
 	preferred = platformInfo.apiPreferredVersions[name]
 	other = platformInfo.apiOtherVersions[name]
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func Test_createPlatformInfoFromAPIObjects(t *testing.T) {
@@ -125,6 +126,112 @@ func Test_getPDBFlag(t *testing.T) {
 	}
 }
 
+func Test_IsCertManagerSupported(t *testing.T) {
+	tests := []struct {
+		name      string
+		preferred map[string]string
+		other     map[string]string
+		supported bool
+	}{
+		{
+			name: "cert-manager installed",
+			preferred: map[string]string{
+				"Certificate": "cert-manager.io/v1",
+				"Issuer":      "cert-manager.io/v1",
+			},
+			other:     map[string]string{},
+			supported: true,
+		},
+		{
+			name: "Certificate of another API group",
+			preferred: map[string]string{
+				"Certificate": "acme.example.com/v1",
+			},
+			other:     map[string]string{},
+			supported: false,
+		},
+		{
+			name:      "cert-manager not installed",
+			preferred: map[string]string{},
+			other:     map[string]string{},
+			supported: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			platformInfo := NewPlatformInfoFromVersionMaps(nil, tt.preferred, tt.other)
+			assert.Equal(t, tt.supported, platformInfo.IsCertManagerSupported())
+			assert.Equal(t, tt.supported, containsObjectKind(platformInfo.GetAgentResourcesKind(false), CertManagerCertificatesKind))
+		})
+	}
+}
+
+func Test_GetAgentResourcesKind_NetworkPolicies(t *testing.T) {
+	tests := []struct {
+		name       string
+		preferred  map[string]string
+		withCalico bool
+		withANP    bool
+	}{
+		{
+			name:      "no network policy extension",
+			preferred: map[string]string{},
+		},
+		{
+			name: "Calico and AdminNetworkPolicy supported",
+			preferred: map[string]string{
+				"GlobalNetworkPolicy": "projectcalico.org/v3",
+				"AdminNetworkPolicy":  "policy.networking.k8s.io/v1alpha1",
+			},
+			withCalico: true,
+			withANP:    true,
+		},
+		{
+			name: "kinds of other API groups",
+			preferred: map[string]string{
+				"GlobalNetworkPolicy": "crd.projectcalico.org/v1",
+				"AdminNetworkPolicy":  "policy.networking.k8s.io/v1alpha2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			platformInfo := NewPlatformInfoFromVersionMaps(nil, tt.preferred, map[string]string{})
+			kinds := platformInfo.GetAgentResourcesKind(false)
+			assert.Equal(t, tt.withCalico, containsObjectKind(kinds, CalicoGlobalNetworkPoliciesKind))
+			assert.Equal(t, tt.withANP, containsObjectKind(kinds, AdminNetworkPoliciesKind))
+		})
+	}
+}
+
+func Test_IsResourceVersionSupported(t *testing.T) {
+	newGroup := func(groupVersion string) *v1.APIGroup {
+		return &v1.APIGroup{PreferredVersion: v1.GroupVersionForDiscovery{GroupVersion: groupVersion}}
+	}
+	newResources := func(groupVersion string) *v1.APIResourceList {
+		return &v1.APIResourceList{
+			GroupVersion: groupVersion,
+			APIResources: []v1.APIResource{{Kind: "GlobalNetworkPolicy"}},
+		}
+	}
+
+	// The Calico CRDs and the Calico API server both define GlobalNetworkPolicy, in different API groups
+	platformInfo := NewPlatformInfo(nil,
+		[]*v1.APIGroup{newGroup("crd.projectcalico.org/v1"), newGroup("projectcalico.org/v3")},
+		[]*v1.APIResourceList{newResources("projectcalico.org/v3"), newResources("crd.projectcalico.org/v1")},
+	)
+	assert.True(t, platformInfo.IsResourceVersionSupported("GlobalNetworkPolicy", schema.GroupVersion{Group: "projectcalico.org", Version: "v3"}))
+
+	platformInfo = NewPlatformInfo(nil,
+		[]*v1.APIGroup{newGroup("crd.projectcalico.org/v1")},
+		[]*v1.APIResourceList{newResources("crd.projectcalico.org/v1")},
+	)
+	assert.True(t, platformInfo.IsResourceSupported("GlobalNetworkPolicy"))
+	assert.False(t, platformInfo.IsResourceVersionSupported("GlobalNetworkPolicy", schema.GroupVersion{Group: "projectcalico.org", Version: "v3"}))
+}
+
 func Test_getDatadogAgentVersions(t *testing.T) {
 	tests := []struct {
 		name            string
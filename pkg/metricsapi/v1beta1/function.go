@@ -0,0 +1,27 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package metricsapi
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GroupVersion is the metrics API group version
+var GroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1beta1"}
+
+// GroupVersionPodMetricsListKind return the schema.GroupVersionKind for PodMetricsList
+func GroupVersionPodMetricsListKind() schema.GroupVersionKind {
+	return GroupVersion.WithKind("PodMetricsList")
+}
+
+// EmptyUnstructuredPodMetricsList return a new unstructured.UnstructuredList for PodMetrics
+func EmptyUnstructuredPodMetricsList() *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(GroupVersionPodMetricsListKind())
+
+	return list
+}
@@ -8,15 +8,20 @@ package common
 import (
 	"fmt"
 
+	edsdatadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 
 	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2beta1"
+	pkgkubernetes "github.com/DataDog/datadog-operator/pkg/kubernetes"
 )
 
 // NewClient returns a new controller-runtime client instance
@@ -46,6 +51,19 @@ func NewClient(clientConfig clientcmd.ClientConfig) (client.Client, error) {
 		return nil, fmt.Errorf("unable register DatadogAgent apis: %w", err)
 	}
 
+	if err = v2beta1.AddToScheme(scheme.Scheme); err != nil {
+		return nil, fmt.Errorf("unable register DatadogAgent apis: %w", err)
+	}
+
+	// Register the APIs of the objects managed by the operator
+	if err = apiregistrationv1.AddToScheme(scheme.Scheme); err != nil {
+		return nil, fmt.Errorf("unable register APIService apis: %w", err)
+	}
+
+	if err = edsdatadoghqv1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		return nil, fmt.Errorf("unable register ExtendedDaemonSet apis: %w", err)
+	}
+
 	// Create the Client for Read/Write operations.
 	var newClient client.Client
 	newClient, err = client.New(restConfig, client.Options{Scheme: scheme.Scheme, Mapper: mapper})
@@ -70,3 +88,18 @@ func NewClientset(clientConfig clientcmd.ClientConfig) (*kubernetes.Clientset, e
 
 	return clientset, nil
 }
+
+// NewPlatformInfo returns the PlatformInfo of the cluster, as the operator computes it
+func NewPlatformInfo(discoveryClient discovery.DiscoveryInterface) (pkgkubernetes.PlatformInfo, error) {
+	versionInfo, err := discoveryClient.ServerVersion()
+	if err != nil {
+		return pkgkubernetes.PlatformInfo{}, fmt.Errorf("unable to get APIServer version: %w", err)
+	}
+
+	groups, resources, err := discoveryClient.ServerGroupsAndResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return pkgkubernetes.PlatformInfo{}, fmt.Errorf("unable to get API resource versions: %w", err)
+	}
+
+	return pkgkubernetes.NewPlatformInfo(versionInfo, groups, resources), nil
+}
@@ -0,0 +1,78 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package common
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2beta1"
+)
+
+// ReadDatadogAgentManifest reads a v2alpha1 or v2beta1 DatadogAgent from a YAML or JSON manifest.
+// v2beta1 DatadogAgents are converted to v2alpha1, the version used by the operator.
+// The namespace is set to defaultNamespace if the manifest does not define one.
+func ReadDatadogAgentManifest(path, defaultNamespace string) (*v2alpha1.DatadogAgent, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", path, err)
+	}
+
+	typeMeta := metav1.TypeMeta{}
+	if err = yaml.Unmarshal(data, &typeMeta); err != nil {
+		return nil, fmt.Errorf("unable to parse DatadogAgent from %s: %w", path, err)
+	}
+	if typeMeta.Kind != "DatadogAgent" {
+		return nil, fmt.Errorf("%s must contain a DatadogAgent, got %s %s", path, typeMeta.APIVersion, typeMeta.Kind)
+	}
+
+	dda := &v2alpha1.DatadogAgent{}
+	switch typeMeta.APIVersion {
+	case v2alpha1.GroupVersion.String():
+		if err = yaml.UnmarshalStrict(data, dda); err != nil {
+			return nil, fmt.Errorf("unable to parse DatadogAgent from %s: %w", path, err)
+		}
+	case v2beta1.GroupVersion.String():
+		betaDDA := &v2beta1.DatadogAgent{}
+		if err = yaml.UnmarshalStrict(data, betaDDA); err != nil {
+			return nil, fmt.Errorf("unable to parse DatadogAgent from %s: %w", path, err)
+		}
+		if err = betaDDA.ConvertTo(dda); err != nil {
+			return nil, fmt.Errorf("unable to convert DatadogAgent from %s: %w", path, err)
+		}
+		dda.TypeMeta = metav1.TypeMeta{APIVersion: v2alpha1.GroupVersion.String(), Kind: typeMeta.Kind}
+	default:
+		return nil, fmt.Errorf("%s must contain a %s or %s DatadogAgent, got %s %s", path, v2alpha1.GroupVersion.String(), v2beta1.GroupVersion.String(), typeMeta.APIVersion, typeMeta.Kind)
+	}
+
+	if dda.Name == "" {
+		return nil, fmt.Errorf("the DatadogAgent in %s must have a name", path)
+	}
+	if dda.Namespace == "" {
+		dda.Namespace = defaultNamespace
+	}
+	return dda, nil
+}
+
+// PrintObjectsYAML prints objects as a multi-document YAML stream.
+func PrintObjectsYAML(out io.Writer, objs []client.Object) error {
+	for _, obj := range objs {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("unable to marshal %s %s/%s: %w", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetNamespace(), obj.GetName(), err)
+		}
+		if _, err = fmt.Fprintf(out, "---\n%s", data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
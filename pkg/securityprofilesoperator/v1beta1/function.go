@@ -0,0 +1,40 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package securityprofilesoperator
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GroupVersion is the Security Profiles Operator API group version
+var GroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1beta1"}
+
+// GroupVersionSeccompProfileListKind return the schema.GroupVersionKind for SeccompProfileList
+func GroupVersionSeccompProfileListKind() schema.GroupVersionKind {
+	return GroupVersion.WithKind("SeccompProfileList")
+}
+
+// GroupVersionSeccompProfileKind return the schema.GroupVersionKind for SeccompProfile
+func GroupVersionSeccompProfileKind() schema.GroupVersionKind {
+	return GroupVersion.WithKind(SeccompProfileKind)
+}
+
+// EmptyUnstructuredSeccompProfileList return a new unstructured.UnstructuredList for SeccompProfile
+func EmptyUnstructuredSeccompProfileList() *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(GroupVersionSeccompProfileListKind())
+
+	return list
+}
+
+// EmptyUnstructuredSeccompProfile return a new unstructured.Unstructured for SeccompProfile
+func EmptyUnstructuredSeccompProfile() *unstructured.Unstructured {
+	profile := &unstructured.Unstructured{}
+	profile.SetGroupVersionKind(GroupVersionSeccompProfileKind())
+
+	return profile
+}
@@ -0,0 +1,72 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package securityprofilesoperator
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// GroupName is the Security Profiles Operator API group
+	GroupName = "security-profiles-operator.x-k8s.io"
+	// SeccompProfileKind is the kind of a Security Profiles Operator seccomp profile
+	SeccompProfileKind = "SeccompProfile"
+	// localhostProfileRoot is the directory, relative to the kubelet seccomp root, where the profiles are installed
+	localhostProfileRoot = "operator"
+)
+
+// ProfileState is the state of a profile on the nodes
+type ProfileState string
+
+const (
+	// ProfileStateInstalled means that the profile is installed on all the nodes
+	ProfileStateInstalled ProfileState = "Installed"
+)
+
+// SeccompProfile is a Security Profiles Operator seccomp profile
+type SeccompProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SeccompProfileSpec   `json:"spec,omitempty"`
+	Status SeccompProfileStatus `json:"status,omitempty"`
+}
+
+// SeccompProfileSpec is a Security Profiles Operator seccomp profile spec
+type SeccompProfileSpec struct {
+	DefaultAction string    `json:"defaultAction"`
+	Architectures []string  `json:"architectures,omitempty"`
+	Syscalls      []Syscall `json:"syscalls,omitempty"`
+}
+
+// Syscall is a list of syscalls and the action applied to them
+type Syscall struct {
+	Names  []string `json:"names"`
+	Action string   `json:"action"`
+	Args   []Arg    `json:"args,omitempty"`
+}
+
+// Arg is a condition on a syscall argument
+type Arg struct {
+	Index    uint   `json:"index"`
+	Value    uint64 `json:"value,omitempty"`
+	ValueTwo uint64 `json:"valueTwo,omitempty"`
+	Op       string `json:"op"`
+}
+
+// SeccompProfileStatus is a Security Profiles Operator seccomp profile status
+type SeccompProfileStatus struct {
+	Status           ProfileState `json:"status,omitempty"`
+	LocalhostProfile string       `json:"localhostProfile,omitempty"`
+}
+
+// LocalhostProfile returns the path of an installed profile, relative to the kubelet seccomp root.
+// It is the value of the `localhostProfile` field of the containers using the profile.
+func LocalhostProfile(namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s.json", localhostProfileRoot, namespace, name)
+}
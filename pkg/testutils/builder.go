@@ -95,12 +95,18 @@ func (builder *DatadogAgentBuilder) initDogstatsd() {
 
 func (builder *DatadogAgentBuilder) WithDogstatsdHostPortEnabled(enabled bool) *DatadogAgentBuilder {
 	builder.initDogstatsd()
+	if builder.datadogAgent.Spec.Features.Dogstatsd.HostPortConfig == nil {
+		builder.datadogAgent.Spec.Features.Dogstatsd.HostPortConfig = &v2alpha1.HostPortConfig{}
+	}
 	builder.datadogAgent.Spec.Features.Dogstatsd.HostPortConfig.Enabled = apiutils.NewBoolPointer(enabled)
 	return builder
 }
 
 func (builder *DatadogAgentBuilder) WithDogstatsdHostPortConfig(port int32) *DatadogAgentBuilder {
 	builder.initDogstatsd()
+	if builder.datadogAgent.Spec.Features.Dogstatsd.HostPortConfig == nil {
+		builder.datadogAgent.Spec.Features.Dogstatsd.HostPortConfig = &v2alpha1.HostPortConfig{}
+	}
 	builder.datadogAgent.Spec.Features.Dogstatsd.HostPortConfig.Port = apiutils.NewInt32Pointer(port)
 	return builder
 }
@@ -273,6 +279,23 @@ func (builder *DatadogAgentBuilder) WithAdmissionControllerRegistry(name string)
 	return builder
 }
 
+func (builder *DatadogAgentBuilder) WithAdmissionControllerCertManager(issuerRef *v2alpha1.CertManagerIssuerReference) *DatadogAgentBuilder {
+	builder.initAdmissionController()
+	builder.datadogAgent.Spec.Features.AdmissionController.CertManager = &v2alpha1.AdmissionControllerCertManagerConfig{
+		Enabled:   apiutils.NewBoolPointer(true),
+		IssuerRef: issuerRef,
+	}
+	return builder
+}
+
+func (builder *DatadogAgentBuilder) WithAdmissionControllerOperatorIssuedCertificate() *DatadogAgentBuilder {
+	builder.initAdmissionController()
+	builder.datadogAgent.Spec.Features.AdmissionController.OperatorIssuedCertificate = &v2alpha1.AdmissionControllerOperatorIssuedCertificateConfig{
+		Enabled: apiutils.NewBoolPointer(true),
+	}
+	return builder
+}
+
 // sidecar Injection
 func (builder *DatadogAgentBuilder) WithSidecarInjectionEnabled(enabled bool) *DatadogAgentBuilder {
 	// builder.initAdmissionController()
@@ -477,6 +500,46 @@ func (builder *DatadogAgentBuilder) WithOTelCollectorPorts(grpcPort int32, httpP
 	return builder
 }
 
+// OTel Agent Gateway
+func (builder *DatadogAgentBuilder) initOtelAgentGateway() {
+	if builder.datadogAgent.Spec.Features.OtelAgentGateway == nil {
+		builder.datadogAgent.Spec.Features.OtelAgentGateway = &v2alpha1.OtelAgentGatewayFeatureConfig{}
+	}
+}
+
+func (builder *DatadogAgentBuilder) WithOtelAgentGatewayEnabled(enabled bool) *DatadogAgentBuilder {
+	builder.initOtelAgentGateway()
+	builder.datadogAgent.Spec.Features.OtelAgentGateway.Enabled = apiutils.NewBoolPointer(enabled)
+	return builder
+}
+
+func (builder *DatadogAgentBuilder) WithOtelAgentGatewayConfigMap() *DatadogAgentBuilder {
+	builder.initOtelAgentGateway()
+	builder.datadogAgent.Spec.Features.OtelAgentGateway.Conf = &v2alpha1.CustomConfig{
+		ConfigMap: &v2alpha1.ConfigMapConfig{
+			Name: "user-provided-config-map",
+		},
+	}
+	return builder
+}
+
+func (builder *DatadogAgentBuilder) WithOtelAgentGatewayPorts(grpcPort int32, httpPort int32) *DatadogAgentBuilder {
+	builder.initOtelAgentGateway()
+	builder.datadogAgent.Spec.Features.OtelAgentGateway.Ports = []*corev1.ContainerPort{
+		{
+			Name:          "otel-grpc",
+			ContainerPort: grpcPort,
+			Protocol:      corev1.ProtocolTCP,
+		},
+		{
+			Name:          "otel-http",
+			ContainerPort: httpPort,
+			Protocol:      corev1.ProtocolTCP,
+		},
+	}
+	return builder
+}
+
 // Log Collection
 func (builder *DatadogAgentBuilder) initLogCollection() {
 	if builder.datadogAgent.Spec.Features.LogCollection == nil {
@@ -517,6 +580,19 @@ func (builder *DatadogAgentBuilder) WithLogCollectionPaths(podLogs, containerLog
 	return builder
 }
 
+func (builder *DatadogAgentBuilder) WithLogCollectionProcessingRules(rules []v2alpha1.LogProcessingRule) *DatadogAgentBuilder {
+	builder.initLogCollection()
+	builder.datadogAgent.Spec.Features.LogCollection.ProcessingRules = rules
+	return builder
+}
+
+func (builder *DatadogAgentBuilder) WithLogCollectionContainerFilters(include, exclude *v2alpha1.ContainerFilter) *DatadogAgentBuilder {
+	builder.initLogCollection()
+	builder.datadogAgent.Spec.Features.LogCollection.ContainerInclude = include
+	builder.datadogAgent.Spec.Features.LogCollection.ContainerExclude = exclude
+	return builder
+}
+
 // Event Collection
 func (builder *DatadogAgentBuilder) initEventCollection() {
 	if builder.datadogAgent.Spec.Features.EventCollection == nil {
@@ -574,6 +650,12 @@ func (builder *DatadogAgentBuilder) WithKSMCustomConf(customData string) *Datado
 	return builder
 }
 
+func (builder *DatadogAgentBuilder) WithKSMNamespaces(namespaces []string) *DatadogAgentBuilder {
+	builder.initKSM()
+	builder.datadogAgent.Spec.Features.KubeStateMetricsCore.Namespaces = namespaces
+	return builder
+}
+
 // Orchestrator Explorer
 
 func (builder *DatadogAgentBuilder) initOE() {
@@ -672,6 +754,12 @@ func (builder *DatadogAgentBuilder) WithPrometheusScrapeVersion(version int) *Da
 	return builder
 }
 
+func (builder *DatadogAgentBuilder) WithPrometheusScrapeRules(rules []v2alpha1.PrometheusScrapeRule) *DatadogAgentBuilder {
+	builder.initPrometheusScrape()
+	builder.datadogAgent.Spec.Features.PrometheusScrape.Rules = rules
+	return builder
+}
+
 // APM
 
 func (builder *DatadogAgentBuilder) initAPM() {
@@ -926,6 +1014,42 @@ func (builder *DatadogAgentBuilder) WithSingleContainerStrategy(enabled bool) *D
 	return builder
 }
 
+func (builder *DatadogAgentBuilder) WithSecurityProfile(profile v2alpha1.SecurityProfileType) *DatadogAgentBuilder {
+	builder.datadogAgent.Spec.Global.SecurityProfile = &profile
+	return builder
+}
+
+// Global ResourceRecommendations
+
+func (builder *DatadogAgentBuilder) WithResourceRecommendations(enabled, apply bool) *DatadogAgentBuilder {
+	builder.datadogAgent.Spec.Global.ResourceRecommendations = &v2alpha1.ResourceRecommendationsConfig{
+		Enabled: apiutils.NewBoolPointer(enabled),
+		Apply:   apiutils.NewBoolPointer(apply),
+	}
+	return builder
+}
+
+// Global NodeSelector
+
+func (builder *DatadogAgentBuilder) WithNodeSelector(selector *metav1.LabelSelector) *DatadogAgentBuilder {
+	builder.datadogAgent.Spec.Global.NodeSelector = selector
+	return builder
+}
+
+// Global Container Filters
+
+func (builder *DatadogAgentBuilder) WithGlobalContainerFilters(filters *v2alpha1.ContainerFiltersConfig) *DatadogAgentBuilder {
+	builder.datadogAgent.Spec.Global.ContainerFilters = filters
+	return builder
+}
+
+// Global Network Policy
+
+func (builder *DatadogAgentBuilder) WithNetworkPolicy(networkPolicy *v2alpha1.NetworkPolicyConfig) *DatadogAgentBuilder {
+	builder.datadogAgent.Spec.Global.NetworkPolicy = networkPolicy
+	return builder
+}
+
 // Global Credentials
 
 func (builder *DatadogAgentBuilder) WithCredentials(apiKey, appKey string) *DatadogAgentBuilder {